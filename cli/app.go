@@ -166,6 +166,23 @@ const (
 	tunnelFlagLocalPort       = "local-port"
 	tunnelFlagDestinationPort = "destination-port"
 
+	configHistoryFlagRevision = "revision"
+
+	resourceGraphFlagFormat           = "format"
+	resourceGraphFlagDependentsOf     = "dependents-of"
+	resourceGraphFlagDependenciesOf   = "dependencies-of"
+	resourceGraphFlagWhyNotConfigured = "why-not-configured"
+
+	auditLogFlagResource = "resource"
+	auditLogFlagMethod   = "method"
+	auditLogFlagEntity   = "entity"
+	auditLogFlagSince    = "since"
+
+	operationsFlagID = "id"
+
+	jobsFlagJob    = "job"
+	jobsFlagFailed = "failed"
+
 	organizationFlagSupportEmail = "support-email"
 	organizationBillingAddress   = "address"
 	organizationFlagLogoPath     = "logo-path"
@@ -3076,6 +3093,206 @@ Note: There is no progress meter while copying is in progress.
 							}...),
 							Action: createActionCommandWithT[robotsPartTunnelArgs](RobotsPartTunnelAction),
 						},
+						{
+							Name:            "config-history",
+							Usage:           "work with the configs recently applied by a machine part",
+							UsageText:       createUsageText("machines part config-history", nil, false, true),
+							HideHelpCommand: true,
+							Commands: []*cli.Command{
+								{
+									Name:  "list",
+									Usage: "list the configs recently applied by a machine part",
+									Description: `
+List the configs most recently applied by a machine part, newest first, along with their revision,
+when they were applied and their outcome. In order to use this command, the machine must have a
+valid shell type service.
+`,
+									UsageText: createUsageText("machines part config-history list", []string{generalFlagPart}, true, false),
+									Flags:     commonPartFlags,
+									Action:    createActionCommandWithT[machinesPartConfigHistoryArgs](MachinesPartConfigHistoryListAction),
+								},
+								{
+									Name:  "rollback",
+									Usage: "roll back a machine part to a config it previously applied",
+									Description: `
+Update the machine part's config in the cloud to a config the machine previously applied, as listed by
+'viam machines part config-history list'. The stored config is fully resolved, so the contents of any
+fragments it used are written into the part config. In order to use this command, the machine must have
+a valid shell type service.
+
+Example:
+  viam machines part config-history rollback --part=<part-id> --revision=<revision>`,
+									UsageText: createUsageText(
+										"machines part config-history rollback",
+										[]string{generalFlagPart, configHistoryFlagRevision}, true, false),
+									Flags: append(commonPartFlags, &cli.StringFlag{
+										Name:     configHistoryFlagRevision,
+										Required: true,
+										Usage:    "revision of the config to roll back to",
+									}),
+									Action: createActionCommandWithT[machinesPartConfigHistoryRollbackArgs](
+										MachinesPartConfigHistoryRollbackAction),
+								},
+							},
+						},
+						{
+							Name:  "resource-graph",
+							Usage: "show or query the resource dependency graph of a machine part",
+							Description: `
+Show the resources of a machine part along with their state, errors, owning module and the resources
+they depend on. The graph can be rendered as a text tree, Graphviz DOT or a Mermaid flowchart, or
+queried for the resources that depend on a resource and for why a resource is not configured. In order
+to use this command, the machine must have a valid shell type service.
+
+Examples:
+  viam machines part resource-graph --part=<part-id> --format=mermaid
+  viam machines part resource-graph --part=<part-id> --dependents-of=my-board
+  viam machines part resource-graph --part=<part-id> --why-not-configured=my-arm`,
+							UsageText: createUsageText("machines part resource-graph", []string{generalFlagPart}, true, false),
+							Flags: append(commonPartFlags, []cli.Flag{
+								&cli.StringFlag{
+									Name: resourceGraphFlagFormat,
+									Usage: formatAcceptedValues("output format",
+										resourceGraphFormatText, resourceGraphFormatDOT, resourceGraphFormatMermaid, resourceGraphFormatJSON),
+									Value: resourceGraphFormatText,
+								},
+								&cli.StringFlag{
+									Name:  resourceGraphFlagDependentsOf,
+									Usage: "list the resources that depend on this resource, directly or transitively",
+								},
+								&cli.StringFlag{
+									Name:  resourceGraphFlagDependenciesOf,
+									Usage: "list the resources this resource depends on, directly or transitively",
+								},
+								&cli.StringFlag{
+									Name:  resourceGraphFlagWhyNotConfigured,
+									Usage: "explain why this resource is not configured",
+								},
+							}...),
+							Action: createActionCommandWithT[machinesPartResourceGraphArgs](MachinesPartResourceGraphAction),
+						},
+						{
+							Name:  "audit-log",
+							Usage: "show the mutating API calls recorded in the audit log of a machine part",
+							Description: `
+Show the mutating API calls recorded by a machine part with an audit config, oldest first, along with
+the resource they targeted, the caller and the result. In order to use this command, the machine must
+have a valid shell type service.
+
+Examples:
+  viam machines part audit-log --part=<part-id> --since=1h
+  viam machines part audit-log --part=<part-id> --resource=my-arm --method=MoveToPosition`,
+							UsageText: createUsageText("machines part audit-log", []string{generalFlagPart}, true, false),
+							Flags: append(commonPartFlags, []cli.Flag{
+								&cli.StringFlag{
+									Name:  auditLogFlagResource,
+									Usage: "only show calls to resources matching this glob pattern",
+								},
+								&cli.StringFlag{
+									Name:  auditLogFlagMethod,
+									Usage: "only show calls to methods matching this glob pattern",
+								},
+								&cli.StringFlag{
+									Name:  auditLogFlagEntity,
+									Usage: "only show calls made by entities matching this glob pattern",
+								},
+								&cli.StringFlag{
+									Name:  auditLogFlagSince,
+									Usage: "only show calls made after this time, as an RFC3339 timestamp or a duration before now (e.g. 1h)",
+								},
+							}...),
+							Action: createActionCommandWithT[machinesPartAuditLogArgs](MachinesPartAuditLogAction),
+						},
+						{
+							Name:            "operations",
+							Usage:           "work with the operations running on a machine part",
+							UsageText:       createUsageText("machines part operations", nil, false, true),
+							HideHelpCommand: true,
+							Commands: []*cli.Command{
+								{
+									Name:      "list",
+									Usage:     "list the operations running on a machine part",
+									UsageText: createUsageText("machines part operations list", []string{generalFlagPart}, true, false),
+									Flags:     commonPartFlags,
+									Action:    createActionCommandWithT[machinesPartOperationsArgs](MachinesPartOperationsListAction),
+								},
+								{
+									Name:  "watch",
+									Usage: "watch an operation running on a machine part until it completes",
+									UsageText: createUsageText(
+										"machines part operations watch", []string{generalFlagPart, operationsFlagID}, true, false),
+									Flags: append(commonPartFlags, &cli.StringFlag{
+										Name:     operationsFlagID,
+										Required: true,
+										Usage:    "ID of the operation to watch",
+									}),
+									Action: createActionCommandWithT[machinesPartOperationArgs](MachinesPartOperationsWatchAction),
+								},
+								{
+									Name:      "history",
+									Usage:     "list the operations that recently completed on a machine part",
+									UsageText: createUsageText("machines part operations history", []string{generalFlagPart}, true, false),
+									Flags:     commonPartFlags,
+									Action:    createActionCommandWithT[machinesPartOperationsArgs](MachinesPartOperationsHistoryAction),
+								},
+								{
+									Name:  "cancel",
+									Usage: "cancel an operation running on a machine part",
+									UsageText: createUsageText(
+										"machines part operations cancel", []string{generalFlagPart, operationsFlagID}, true, false),
+									Flags: append(commonPartFlags, &cli.StringFlag{
+										Name:     operationsFlagID,
+										Required: true,
+										Usage:    "ID of the operation to cancel",
+									}),
+									Action: createActionCommandWithT[machinesPartOperationArgs](MachinesPartOperationsCancelAction),
+								},
+							},
+						},
+						{
+							Name:            "jobs",
+							Usage:           "work with the jobs configured on a machine part",
+							UsageText:       createUsageText("machines part jobs", nil, false, true),
+							HideHelpCommand: true,
+							Commands: []*cli.Command{
+								{
+									Name:  "history",
+									Usage: "show the recent runs of the jobs on a machine part",
+									Description: `
+Show the recent runs of the jobs on a machine part, newest first, along with what triggered them and
+their result. History is kept across restarts. In order to use this command, the machine must have a
+valid shell type service.
+
+Examples:
+  viam machines part jobs history --part=<part-id>
+  viam machines part jobs history --part=<part-id> --job=nightly-calibration --failed`,
+									UsageText: createUsageText("machines part jobs history", []string{generalFlagPart}, true, false),
+									Flags: append(commonPartFlags, []cli.Flag{
+										&cli.StringFlag{
+											Name:  jobsFlagJob,
+											Usage: "only show runs of this job",
+										},
+										&cli.BoolFlag{
+											Name:  jobsFlagFailed,
+											Usage: "only show failed runs",
+										},
+									}...),
+									Action: createActionCommandWithT[machinesPartJobsHistoryArgs](MachinesPartJobsHistoryAction),
+								},
+								{
+									Name:  "run",
+									Usage: "run a job on a machine part now, outside of its schedule",
+									UsageText: createUsageText(
+										"machines part jobs run", []string{generalFlagPart, jobsFlagJob}, true, false),
+									Flags: append(commonPartFlags, &cli.StringFlag{
+										Name:     jobsFlagJob,
+										Required: true,
+										Usage:    "name of the job to run",
+									}),
+									Action: createActionCommandWithT[machinesPartJobsRunArgs](MachinesPartJobsRunAction),
+								},
+							},
+						},
 						{
 							Name: "motion",
 							Commands: []*cli.Command{
@@ -3117,6 +3334,29 @@ Note: There is no progress meter while copying is in progress.
 									}...),
 									Action: createActionCommandWithT[motionSetPoseArgs](motionSetPoseAction),
 								},
+								{
+									Name:  "export-plan",
+									Usage: "plan a move like set-pose, without moving, and export an animation of the plan",
+									Flags: append(commonPartFlags, []cli.Flag{
+										&cli.StringFlag{
+											Name:     "component",
+											Required: true,
+										},
+										&cli.StringFlag{
+											Name:     "output",
+											Required: true,
+											Usage:    "file to write the plan to, a .gltf scene or a self contained .html page",
+										},
+										&cli.Float64SliceFlag{Name: "x"},
+										&cli.Float64SliceFlag{Name: "y"},
+										&cli.Float64SliceFlag{Name: "z"},
+										&cli.Float64SliceFlag{Name: "ox"},
+										&cli.Float64SliceFlag{Name: "oy"},
+										&cli.Float64SliceFlag{Name: "oz"},
+										&cli.Float64SliceFlag{Name: "theta"},
+									}...),
+									Action: createActionCommandWithT[motionExportPlanArgs](motionExportPlanAction),
+								},
 							},
 						},
 						{
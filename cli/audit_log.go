@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"go.viam.com/rdk/config"
+)
+
+type machinesPartAuditLogArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+	Resource     string
+	Method       string
+	Entity       string
+	Since        string
+}
+
+// MachinesPartAuditLogAction is the corresponding Action for 'machines part audit-log'.
+func MachinesPartAuditLogAction(ctx context.Context, cmd *cli.Command, args machinesPartAuditLogArgs) error {
+	client, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	logger := globalArgs.createLogger()
+
+	since, err := parseAuditLogSince(args.Since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	part, err := client.robotPart(ctx, args.Organization, args.Location, args.Machine, args.Part)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := client.copyViamPathFromMachine(
+		ctx, args.Organization, args.Location, args.Machine, args.Part, path.Join("audit", part.Id), globalArgs.Debug, logger)
+	if err != nil {
+		return errors.Wrap(err, "could not copy audit log from machine part")
+	}
+	//nolint: errcheck
+	defer os.RemoveAll(tmp)
+	entries, err := readAuditLogDir(tmp)
+	if err != nil {
+		return err
+	}
+
+	entries, err = filterAuditEntries(entries, args, since)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		printf(cmd.Root().Writer, "No audited calls found")
+		return nil
+	}
+	for _, entry := range entries {
+		printf(cmd.Root().Writer, "%s", formatAuditEntry(entry))
+	}
+	return nil
+}
+
+// parseAuditLogSince parses the --since flag as either an RFC3339 timestamp or a duration
+// before now. An empty value returns the zero time.
+func parseAuditLogSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil {
+		return time.Time{}, errors.Errorf("--%s must be an RFC3339 timestamp or a duration, got %q", auditLogFlagSince, since)
+	}
+	return now.Add(-d), nil
+}
+
+// readAuditLogDir reads the entries of every audit log file, current and rotated, within dir
+// and returns them oldest first.
+func readAuditLogDir(dir string) ([]config.AuditEntry, error) {
+	ext := filepath.Ext(config.AuditLogFileName)
+	prefix := strings.TrimSuffix(config.AuditLogFileName, ext)
+
+	var entries []config.AuditEntry
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasPrefix(d.Name(), prefix) || filepath.Ext(d.Name()) != ext {
+			return nil
+		}
+		//nolint:gosec
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		//nolint: errcheck
+		defer f.Close()
+		fileEntries, err := config.ReadAuditLog(f)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read audit log file %s", d.Name())
+		}
+		entries = append(entries, fileEntries...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// filterAuditEntries returns the entries matching the resource, method and entity glob
+// patterns of args that were made at or after since.
+func filterAuditEntries(entries []config.AuditEntry, args machinesPartAuditLogArgs, since time.Time) ([]config.AuditEntry, error) {
+	for _, pattern := range []string{args.Resource, args.Method, args.Entity} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+	}
+	matches := func(pattern, value string) bool {
+		if pattern == "" {
+			return true
+		}
+		matched, _ := path.Match(pattern, value)
+		return matched
+	}
+
+	var filtered []config.AuditEntry
+	for _, entry := range entries {
+		method := entry.Method[strings.LastIndex(entry.Method, "/")+1:]
+		if entry.Time.Before(since) ||
+			!matches(args.Resource, entry.Resource) ||
+			!matches(args.Method, method) ||
+			!matches(args.Entity, entry.Entity) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered, nil
+}
+
+func formatAuditEntry(entry config.AuditEntry) string {
+	fields := []string{
+		entry.Time.Format(time.RFC3339),
+		entry.Method,
+		entry.Resource,
+		entry.Entity,
+		entry.Peer,
+		entry.Code,
+		(time.Duration(entry.DurationMs) * time.Millisecond).String(),
+	}
+	line := strings.Join(fields, "\t")
+	if entry.Error != "" {
+		line += "\t" + entry.Error
+	}
+	return line
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestFilterAuditEntries(t *testing.T) {
+	now := time.Now()
+	entries := []config.AuditEntry{
+		{Time: now.Add(-2 * time.Hour), Method: "/viam.component.arm.v1.ArmService/MoveToPosition", Resource: "arm1", Entity: "alice"},
+		{Time: now.Add(-time.Minute), Method: "/viam.component.arm.v1.ArmService/Stop", Resource: "arm1", Entity: "bob"},
+		{Time: now.Add(-time.Minute), Method: "/viam.robot.v1.RobotService/StopAll", Entity: "alice"},
+	}
+
+	filtered, err := filterAuditEntries(entries, machinesPartAuditLogArgs{Resource: "arm*"}, time.Time{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered, test.ShouldResemble, entries[:2])
+
+	filtered, err = filterAuditEntries(entries, machinesPartAuditLogArgs{Method: "Stop*", Entity: "alice"}, time.Time{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered, test.ShouldResemble, entries[2:])
+
+	since, err := parseAuditLogSince("1h", now)
+	test.That(t, err, test.ShouldBeNil)
+	filtered, err = filterAuditEntries(entries, machinesPartAuditLogArgs{}, since)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, filtered, test.ShouldResemble, entries[1:])
+
+	_, err = filterAuditEntries(entries, machinesPartAuditLogArgs{Method: "["}, time.Time{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = parseAuditLogSince("yesterday", now)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	apppb "go.viam.com/api/app/v1"
+	"go.viam.com/utils/protoutils"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+type machinesPartConfigHistoryArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+}
+
+type machinesPartConfigHistoryRollbackArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+	Revision     string
+}
+
+// MachinesPartConfigHistoryListAction is the corresponding Action for 'machines part config-history list'.
+func MachinesPartConfigHistoryListAction(ctx context.Context, cmd *cli.Command, args machinesPartConfigHistoryArgs) error {
+	client, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	logger := globalArgs.createLogger()
+
+	_, entries, err := client.machinesPartConfigHistory(ctx, args, globalArgs.Debug, logger)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		printf(cmd.Root().Writer, "No config history found")
+		return nil
+	}
+	for _, entry := range entries {
+		line := entry.AppliedAt.Format(time.RFC3339) + "\t" + entry.Revision + "\t" + string(entry.Outcome)
+		if entry.Error != "" {
+			line += "\t" + entry.Error
+		}
+		printf(cmd.Root().Writer, "%s", line)
+	}
+	return nil
+}
+
+// MachinesPartConfigHistoryRollbackAction is the corresponding Action for 'machines part config-history rollback'.
+func MachinesPartConfigHistoryRollbackAction(
+	ctx context.Context,
+	cmd *cli.Command,
+	args machinesPartConfigHistoryRollbackArgs,
+) error {
+	client, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	logger := globalArgs.createLogger()
+
+	part, entries, err := client.machinesPartConfigHistory(ctx, machinesPartConfigHistoryArgs{
+		Organization: args.Organization,
+		Location:     args.Location,
+		Machine:      args.Machine,
+		Part:         args.Part,
+	}, globalArgs.Debug, logger)
+	if err != nil {
+		return err
+	}
+
+	var entry *config.HistoryEntry
+	for idx := range entries {
+		if entries[idx].Revision == args.Revision {
+			entry = &entries[idx]
+			break
+		}
+	}
+	if entry == nil {
+		return errors.Errorf("no config with revision %q found in the config history of part %s", args.Revision, part.Name)
+	}
+	if entry.Outcome != config.HistoryOutcomeApplied {
+		warningf(cmd.Root().ErrWriter, "config with revision %q has outcome %q", entry.Revision, entry.Outcome)
+	}
+
+	conf, err := rollbackRobotConfig(*entry)
+	if err != nil {
+		return err
+	}
+	pbConf, err := protoutils.StructToStructPb(conf)
+	if err != nil {
+		return err
+	}
+	req := apppb.UpdateRobotPartRequest{Id: part.Id, Name: part.Name, RobotConfig: pbConf}
+	if _, err := client.client.UpdateRobotPart(ctx, &req); err != nil {
+		return err
+	}
+
+	printf(cmd.Root().Writer, "successfully rolled back part %s to config revision %s", part.Name, entry.Revision)
+	return nil
+}
+
+// machinesPartConfigHistory copies the config history file from the machine part and
+// returns its entries from newest to oldest.
+func (c *viamClient) machinesPartConfigHistory(
+	ctx context.Context,
+	args machinesPartConfigHistoryArgs,
+	debug bool,
+	logger logging.Logger,
+) (*apppb.RobotPart, []config.HistoryEntry, error) {
+	part, err := c.robotPart(ctx, args.Organization, args.Location, args.Machine, args.Part)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := c.copyViamFileFromMachine(
+		ctx, args.Organization, args.Location, args.Machine, args.Part, config.HistoryFileName(part.Id), debug, logger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not copy config history from machine part")
+	}
+	entries, err := config.ReadHistory(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	return part, entries, nil
+}
+
+// rollbackRobotConfig returns the robot config to write to the cloud for a config history entry.
+// Fields that are not part of a robot part's config in the cloud are removed.
+func rollbackRobotConfig(entry config.HistoryEntry) (map[string]any, error) {
+	if len(entry.Config) == 0 {
+		return nil, errors.Errorf("config history entry for revision %q has no stored config", entry.Revision)
+	}
+	var conf map[string]any
+	if err := json.Unmarshal(entry.Config, &conf); err != nil {
+		return nil, errors.Wrap(err, "cannot parse stored config")
+	}
+	delete(conf, "cloud")
+	delete(conf, "revision")
+	return conf, nil
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestRollbackRobotConfig(t *testing.T) {
+	entry := config.HistoryEntry{
+		Revision: "rev1",
+		Config: []byte(`{
+			"cloud": {"id": "part", "secret": "shh"},
+			"revision": "rev1",
+			"components": [{"name": "arm1", "api": "rdk:component:arm", "model": "rdk:builtin:fake"}]
+		}`),
+	}
+	conf, err := rollbackRobotConfig(entry)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conf, test.ShouldNotContainKey, "cloud")
+	test.That(t, conf, test.ShouldNotContainKey, "revision")
+	test.That(t, conf["components"], test.ShouldHaveLength, 1)
+
+	_, err = rollbackRobotConfig(config.HistoryEntry{Revision: "rev2"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no stored config")
+}
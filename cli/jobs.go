@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/robot/client"
+)
+
+type machinesPartJobsHistoryArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+	Job          string
+	Failed       bool
+}
+
+type machinesPartJobsRunArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+	Job          string
+}
+
+// MachinesPartJobsHistoryAction is the corresponding Action for 'machines part jobs history'.
+func MachinesPartJobsHistoryAction(ctx context.Context, cmd *cli.Command, args machinesPartJobsHistoryArgs) error {
+	viamClient, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	logger := globalArgs.createLogger()
+
+	part, err := viamClient.robotPart(ctx, args.Organization, args.Location, args.Machine, args.Part)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := viamClient.copyViamPathFromMachine(
+		ctx, args.Organization, args.Location, args.Machine, args.Part, path.Join("jobs", part.Id), globalArgs.Debug, logger)
+	if err != nil {
+		return errors.Wrap(err, "could not copy job history from machine part")
+	}
+	//nolint: errcheck
+	defer os.RemoveAll(tmp)
+	history, err := readJobHistoryDir(tmp)
+	if err != nil {
+		return err
+	}
+
+	runs := filterJobRuns(history, args.Job, args.Failed)
+	if len(runs) == 0 {
+		printf(cmd.Root().Writer, "No job runs found")
+		return nil
+	}
+	for _, run := range runs {
+		printf(cmd.Root().Writer, "%s", formatJobRun(run))
+	}
+	return nil
+}
+
+// MachinesPartJobsRunAction is the corresponding Action for 'machines part jobs run'.
+func MachinesPartJobsRunAction(ctx context.Context, cmd *cli.Command, args machinesPartJobsRunArgs) error {
+	return withMachinePartRobotClient(ctx, cmd, args.Organization, args.Location, args.Machine, args.Part,
+		func(robotClient *client.RobotClient) error {
+			if err := robotClient.RunJob(ctx, args.Job); err != nil {
+				return err
+			}
+			printf(cmd.Root().Writer, "started job %s; see 'viam machines part jobs history' for its result", args.Job)
+			return nil
+		})
+}
+
+// readJobHistoryDir reads the persisted job history file within dir.
+func readJobHistoryDir(dir string) (map[string][]config.JobRun, error) {
+	var history map[string][]config.JobRun
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != config.JobHistoryFileName {
+			return nil
+		}
+		//nolint:gosec
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		//nolint: errcheck
+		defer f.Close()
+		history, err = config.ReadJobHistory(f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// filterJobRuns returns the runs of the named job, or of every job if job is empty, newest
+// first. If failed is set, only failed runs are returned.
+func filterJobRuns(history map[string][]config.JobRun, job string, failed bool) []config.JobRun {
+	var runs []config.JobRun
+	for name, jobRuns := range history {
+		if job != "" && name != job {
+			continue
+		}
+		for _, run := range jobRuns {
+			if failed && run.Succeeded() {
+				continue
+			}
+			runs = append(runs, run)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Start.After(runs[j].Start)
+	})
+	return runs
+}
+
+func formatJobRun(run config.JobRun) string {
+	result := "succeeded"
+	if !run.Succeeded() {
+		result = "failed"
+	}
+	fields := []string{
+		run.Start.Format(time.RFC3339),
+		run.Job,
+		run.Trigger,
+		result,
+		run.End.Sub(run.Start).Round(time.Millisecond).String(),
+	}
+	if run.Attempts > 1 {
+		fields = append(fields, fmt.Sprintf("attempts=%d", run.Attempts))
+	}
+	if run.Error != "" {
+		fields = append(fields, run.Error)
+	} else if run.Result != "" {
+		fields = append(fields, run.Result)
+	}
+	return strings.Join(fields, "\t")
+}
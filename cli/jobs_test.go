@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestFilterJobRuns(t *testing.T) {
+	now := time.Now()
+	calibrate := []config.JobRun{
+		{Job: "calibrate", Start: now.Add(-2 * time.Hour), End: now.Add(-2 * time.Hour), Error: "not settled"},
+		{Job: "calibrate", Start: now.Add(-time.Hour), End: now.Add(-time.Hour)},
+	}
+	report := []config.JobRun{
+		{Job: "report", Start: now.Add(-90 * time.Minute), End: now.Add(-90 * time.Minute)},
+	}
+	history := map[string][]config.JobRun{"calibrate": calibrate, "report": report}
+
+	test.That(t, filterJobRuns(history, "", false), test.ShouldResemble,
+		[]config.JobRun{calibrate[1], report[0], calibrate[0]})
+	test.That(t, filterJobRuns(history, "calibrate", false), test.ShouldResemble,
+		[]config.JobRun{calibrate[1], calibrate[0]})
+	test.That(t, filterJobRuns(history, "", true), test.ShouldResemble, []config.JobRun{calibrate[0]})
+	test.That(t, filterJobRuns(history, "missing", false), test.ShouldBeEmpty)
+}
+
+func TestFormatJobRun(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := config.JobRun{
+		Job:      "calibrate",
+		Trigger:  config.JobTriggerSchedule,
+		Start:    start,
+		End:      start.Add(1500 * time.Millisecond),
+		Attempts: 2,
+		Result:   `{"offset":1.5}`,
+	}
+	test.That(t, formatJobRun(run), test.ShouldEqual,
+		"2025-01-02T03:04:05Z\tcalibrate\tschedule\tsucceeded\t1.5s\tattempts=2\t{\"offset\":1.5}")
+
+	run.Attempts = 1
+	run.Error = "not settled"
+	test.That(t, formatJobRun(run), test.ShouldEqual,
+		"2025-01-02T03:04:05Z\tcalibrate\tschedule\tfailed\t1.5s\tnot settled")
+}
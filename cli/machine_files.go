@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/logging"
+)
+
+var viamHomeDir = path.Join("~", ".viam")
+
+// errShellServiceRequired is returned by the commands that read the state a machine keeps in its
+// Viam home directory, such as its config history, job history, audit log and resource graph,
+// when the machine part has no shell service to copy those files with.
+var errShellServiceRequired = errors.New(
+	"this command copies files from the machine part through its shell service; add a shell service to the part's config to use it")
+
+// copyViamPathFromMachine copies a file or directory, given relative to the Viam home directory
+// of a machine part, into a new temporary directory and returns the path of that directory.
+// The caller must remove it. Files are copied through the machine part's shell service.
+func (c *viamClient) copyViamPathFromMachine(
+	ctx context.Context,
+	orgStr, locStr, robotStr, partStr, relPath string,
+	debug bool,
+	logger logging.Logger,
+) (string, error) {
+	tmp, err := os.MkdirTemp("", "viamfiles")
+	if err != nil {
+		return "", err
+	}
+
+	// Intentional use of path instead of filepath: Windows understands both / and
+	// \ as path separators, and we don't want a cli running on Windows to send
+	// a path using \ to a *NIX machine.
+	src := path.Join(viamHomeDir, relPath)
+	if err := c.copyFilesFromMachine(
+		ctx, orgStr, locStr, robotStr, partStr, debug, true, false, []string{src}, tmp, logger,
+	); err != nil {
+		//nolint: errcheck
+		os.RemoveAll(tmp)
+		if errors.Is(err, errNoShellService) {
+			return "", errShellServiceRequired
+		}
+		return "", err
+	}
+	return tmp, nil
+}
+
+// copyViamFileFromMachine copies a file from the Viam home directory of a machine part and
+// returns its contents.
+func (c *viamClient) copyViamFileFromMachine(
+	ctx context.Context,
+	orgStr, locStr, robotStr, partStr, fileName string,
+	debug bool,
+	logger logging.Logger,
+) ([]byte, error) {
+	tmp, err := c.copyViamPathFromMachine(ctx, orgStr, locStr, robotStr, partStr, fileName, debug, logger)
+	if err != nil {
+		return nil, err
+	}
+	//nolint: errcheck
+	defer os.RemoveAll(tmp)
+
+	//nolint:gosec
+	return os.ReadFile(filepath.Join(tmp, fileName))
+}
@@ -3,15 +3,24 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/urfave/cli/v3"
 	"go.viam.com/utils"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/services/motion"
 	"go.viam.com/rdk/spatialmath"
 )
 
+// motionDoPlanCommand is the DoCommand of the builtin motion service which plans a move without
+// executing it.
+const motionDoPlanCommand = "plan"
+
 func prettyString(p spatialmath.Pose) string {
 	o := p.Orientation().OrientationVectorDegrees()
 	f := ""
@@ -213,44 +222,153 @@ func motionSetPoseAction(ctx context.Context, cmd *cli.Command, args motionSetPo
 
 	printf(cmd.Root().Writer, "start pose %v", pose)
 
+	pose = overridePose(pose, args.X, args.Y, args.Z, args.Ox, args.Oy, args.Oz, args.Theta)
+
+	printf(cmd.Root().Writer, "going to pose %v", pose)
+
+	req := motion.MoveReq{
+		ComponentName: args.Component,
+		Destination:   pose,
+	}
+	_, err = myMotion.Move(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// overridePose returns a pose with any of its coordinates replaced by the first value of the
+// corresponding flag, if given.
+func overridePose(pose *referenceframe.PoseInFrame, x, y, z, ox, oy, oz, theta []float64) *referenceframe.PoseInFrame {
 	pt := pose.Pose().Point()
 	o := pose.Pose().Orientation().OrientationVectorDegrees()
 
-	if len(args.X) > 0 {
-		pt.X = args.X[0]
+	if len(x) > 0 {
+		pt.X = x[0]
 	}
-	if len(args.Y) > 0 {
-		pt.Y = args.Y[0]
+	if len(y) > 0 {
+		pt.Y = y[0]
 	}
-	if len(args.Z) > 0 {
-		pt.Z = args.Z[0]
+	if len(z) > 0 {
+		pt.Z = z[0]
 	}
 
-	if len(args.Ox) > 0 {
-		o.OX = args.Ox[0]
+	if len(ox) > 0 {
+		o.OX = ox[0]
 	}
-	if len(args.Oy) > 0 {
-		o.OY = args.Oy[0]
+	if len(oy) > 0 {
+		o.OY = oy[0]
 	}
-	if len(args.Oz) > 0 {
-		o.OZ = args.Oz[0]
+	if len(oz) > 0 {
+		o.OZ = oz[0]
 	}
-	if len(args.Theta) > 0 {
-		o.Theta = args.Theta[0]
+	if len(theta) > 0 {
+		o.Theta = theta[0]
 	}
 
-	pose = referenceframe.NewPoseInFrame(pose.Parent(), spatialmath.NewPose(pt, o))
+	return referenceframe.NewPoseInFrame(pose.Parent(), spatialmath.NewPose(pt, o))
+}
 
-	printf(cmd.Root().Writer, "going to pose %v", pose)
+type motionExportPlanArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+
+	Component string
+	Output    string
+
+	X, Y, Z, Ox, Oy, Oz, Theta []float64
+}
+
+// motionExportPlanAction plans the move set-pose would make, without making it, and writes an
+// animation of the plan to a glTF or HTML file depending on the extension of the output.
+func motionExportPlanAction(ctx context.Context, cmd *cli.Command, args motionExportPlanArgs) error {
+	export := motionplan.ExportSceneGLTF
+	switch ext := filepath.Ext(args.Output); ext {
+	case ".gltf":
+	case ".html":
+		export = motionplan.ExportSceneHTML
+	default:
+		return fmt.Errorf("cannot export a plan to a %q file, only .gltf or .html", ext)
+	}
+
+	client, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	dialCtx, fqdn, rpcOpts, err := client.prepareDial(ctx, args.Organization, args.Location, args.Machine, args.Part, globalArgs.Debug)
+	if err != nil {
+		return err
+	}
+
+	logger := globalArgs.createLogger()
+
+	robotClient, err := client.connectToRobot(dialCtx, fqdn, rpcOpts, globalArgs.Debug, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		utils.UncheckedError(robotClient.Close(ctx))
+	}()
+
+	myMotion, err := motion.FromProvider(robotClient, "builtin")
+	if err != nil || myMotion == nil {
+		return fmt.Errorf("no motion: %w", err)
+	}
+
+	pose, err := myMotion.GetPose(ctx, args.Component, "world", nil, nil)
+	if err != nil {
+		return err
+	}
+	pose = overridePose(pose, args.X, args.Y, args.Z, args.Ox, args.Oy, args.Oz, args.Theta)
+	printf(cmd.Root().Writer, "planning to pose %v", pose)
 
 	req := motion.MoveReq{
 		ComponentName: args.Component,
 		Destination:   pose,
 	}
-	_, err = myMotion.Move(ctx, req)
+	reqProto, err := req.ToProto(myMotion.Name().Name)
+	if err != nil {
+		return err
+	}
+	reqJSON, err := protojson.Marshal(reqProto)
 	if err != nil {
 		return err
 	}
+	resp, err := myMotion.DoCommand(ctx, map[string]interface{}{motionDoPlanCommand: string(reqJSON)})
+	if err != nil {
+		return err
+	}
+	var trajectory motionplan.Trajectory
+	if err := mapstructure.Decode(resp[motionDoPlanCommand], &trajectory); err != nil {
+		return err
+	}
 
+	frameSystemConfig, err := robotClient.FrameSystemConfig(ctx)
+	if err != nil {
+		return err
+	}
+	frameSystem, err := referenceframe.NewFrameSystem(args.Part, frameSystemConfig.Parts, nil)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Clean(args.Output))
+	if err != nil {
+		return err
+	}
+	defer utils.UncheckedErrorFunc(file.Close)
+	if err := export(file, frameSystem, nil, trajectory, motionplan.SceneExportOptions{Title: args.Component}); err != nil {
+		return err
+	}
+	printf(cmd.Root().Writer, "wrote a plan of %d steps to %s", len(trajectory), args.Output)
 	return nil
 }
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	"go.viam.com/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/robot/client"
+)
+
+// operationsWatchInterval is how often 'machines part operations watch' checks on an operation.
+var operationsWatchInterval = time.Second
+
+type machinesPartOperationsArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+}
+
+type machinesPartOperationArgs struct {
+	Organization string
+	Location     string
+	Machine      string
+	Part         string
+	ID           string
+}
+
+// MachinesPartOperationsListAction is the corresponding Action for 'machines part operations list'.
+func MachinesPartOperationsListAction(ctx context.Context, cmd *cli.Command, args machinesPartOperationsArgs) error {
+	return withMachinePartRobotClient(ctx, cmd, args.Organization, args.Location, args.Machine, args.Part,
+		func(robotClient *client.RobotClient) error {
+			ops, err := robotClient.Operations(ctx)
+			if err != nil {
+				return err
+			}
+			if len(ops) == 0 {
+				printf(cmd.Root().Writer, "No running operations")
+				return nil
+			}
+			sort.Slice(ops, func(i, j int) bool { return ops[i].Started.Before(ops[j].Started) })
+			now := time.Now()
+			for _, op := range ops {
+				printf(cmd.Root().Writer, "%s", formatOperationSummary(op, now))
+			}
+			return nil
+		})
+}
+
+// MachinesPartOperationsWatchAction is the corresponding Action for 'machines part operations watch'.
+func MachinesPartOperationsWatchAction(ctx context.Context, cmd *cli.Command, args machinesPartOperationArgs) error {
+	return withMachinePartRobotClient(ctx, cmd, args.Organization, args.Location, args.Machine, args.Part,
+		func(robotClient *client.RobotClient) error {
+			for {
+				op, err := findOperation(ctx, robotClient, args.ID)
+				if err != nil {
+					return err
+				}
+				if op == nil {
+					return printCompletedOperation(ctx, cmd, robotClient, args.ID)
+				}
+				printf(cmd.Root().Writer, "%s", formatOperationSummary(*op, time.Now()))
+				if !utils.SelectContextOrWait(ctx, operationsWatchInterval) {
+					return ctx.Err()
+				}
+			}
+		})
+}
+
+// MachinesPartOperationsHistoryAction is the corresponding Action for 'machines part operations history'.
+func MachinesPartOperationsHistoryAction(ctx context.Context, cmd *cli.Command, args machinesPartOperationsArgs) error {
+	return withMachinePartRobotClient(ctx, cmd, args.Organization, args.Location, args.Machine, args.Part,
+		func(robotClient *client.RobotClient) error {
+			history, err := robotClient.OperationHistory(ctx)
+			if err != nil {
+				return err
+			}
+			if len(history) == 0 {
+				printf(cmd.Root().Writer, "No completed operations")
+				return nil
+			}
+			for _, op := range history {
+				printf(cmd.Root().Writer, "%s", formatCompletedOperation(op))
+			}
+			return nil
+		})
+}
+
+// MachinesPartOperationsCancelAction is the corresponding Action for 'machines part operations cancel'.
+func MachinesPartOperationsCancelAction(ctx context.Context, cmd *cli.Command, args machinesPartOperationArgs) error {
+	return withMachinePartRobotClient(ctx, cmd, args.Organization, args.Location, args.Machine, args.Part,
+		func(robotClient *client.RobotClient) error {
+			op, err := findOperation(ctx, robotClient, args.ID)
+			if err != nil {
+				return err
+			}
+			if op == nil {
+				return errors.Errorf("no running operation with id %s", args.ID)
+			}
+			if err := robotClient.CancelOperation(ctx, args.ID); err != nil {
+				return err
+			}
+			printf(cmd.Root().Writer, "cancelled operation %s (%s)", args.ID, op.Method)
+			return nil
+		})
+}
+
+// withMachinePartRobotClient connects to a machine part and calls f with a client for it.
+func withMachinePartRobotClient(
+	ctx context.Context,
+	cmd *cli.Command,
+	orgStr, locStr, robotStr, partStr string,
+	f func(robotClient *client.RobotClient) error,
+) error {
+	viamClient, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+
+	dialCtx, fqdn, rpcOpts, err := viamClient.prepareDial(ctx, orgStr, locStr, robotStr, partStr, globalArgs.Debug)
+	if err != nil {
+		return err
+	}
+
+	logger := globalArgs.createLogger()
+
+	robotClient, err := viamClient.connectToRobot(dialCtx, fqdn, rpcOpts, globalArgs.Debug, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		utils.UncheckedError(robotClient.Close(ctx))
+	}()
+	return f(robotClient)
+}
+
+// findOperation returns the running operation with the given ID, or nil if it is not running.
+func findOperation(ctx context.Context, robotClient *client.RobotClient, id string) (*operation.Summary, error) {
+	ops, err := robotClient.Operations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.ID.String() == id {
+			return &op, nil
+		}
+	}
+	return nil, nil
+}
+
+// printCompletedOperation prints how an operation that is no longer running ended, if the
+// machine still remembers it.
+func printCompletedOperation(ctx context.Context, cmd *cli.Command, robotClient *client.RobotClient, id string) error {
+	history, err := robotClient.OperationHistory(ctx)
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	for _, op := range history {
+		if op.ID.String() == id {
+			printf(cmd.Root().Writer, "%s", formatCompletedOperation(op))
+			return nil
+		}
+	}
+	printf(cmd.Root().Writer, "operation %s is not running", id)
+	return nil
+}
+
+func formatCompletedOperation(op operation.CompletedOperation) string {
+	fields := []string{
+		op.ID.String(),
+		op.Method,
+		op.Started.Format(time.RFC3339),
+		op.Duration().Round(time.Millisecond).String(),
+		string(op.Status),
+	}
+	if progress := op.Progress.String(); progress != "" {
+		fields = append(fields, progress)
+	}
+	if op.Error != "" {
+		fields = append(fields, op.Error)
+	}
+	return strings.Join(fields, "\t")
+}
+
+func formatOperationSummary(op operation.Summary, now time.Time) string {
+	fields := []string{
+		op.ID.String(),
+		op.Method,
+		op.Started.Format(time.RFC3339),
+		now.Sub(op.Started).Round(time.Millisecond).String(),
+	}
+	if progress := op.Progress.String(); progress != "" {
+		fields = append(fields, progress)
+	}
+	return strings.Join(fields, "\t")
+}
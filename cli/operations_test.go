@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/operation"
+)
+
+func TestFormatOperations(t *testing.T) {
+	id := uuid.MustParse("6f9619ff-8b86-d011-b42d-00c04fc964ff")
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	running := operation.Summary{
+		ID:       id,
+		Method:   "/viam.service.motion.v1.MotionService/Move",
+		Started:  start,
+		Progress: operation.Progress{Current: 3, Total: 7, Message: "executing"},
+	}
+	test.That(t, formatOperationSummary(running, start.Add(2*time.Second)), test.ShouldEqual,
+		id.String()+"\t/viam.service.motion.v1.MotionService/Move\t2025-01-02T03:04:05Z\t2s\texecuting 3 of 7")
+
+	completed := operation.CompletedOperation{
+		ID:       id,
+		Method:   "/viam.service.motion.v1.MotionService/Move",
+		Started:  start,
+		Ended:    start.Add(1500 * time.Millisecond),
+		Status:   operation.StatusFailed,
+		Error:    "collision",
+		Progress: operation.Progress{Current: 5, Total: 7},
+	}
+	test.That(t, formatCompletedOperation(completed), test.ShouldEqual,
+		id.String()+"\t/viam.service.motion.v1.MotionService/Move\t2025-01-02T03:04:05Z\t1.5s\tfailed\t5 of 7\tcollision")
+}
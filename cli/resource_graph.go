@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	resourceGraphFormatText    = "text"
+	resourceGraphFormatDOT     = "dot"
+	resourceGraphFormatMermaid = "mermaid"
+	resourceGraphFormatJSON    = "json"
+)
+
+type machinesPartResourceGraphArgs struct {
+	Organization     string
+	Location         string
+	Machine          string
+	Part             string
+	Format           string
+	DependentsOf     string
+	DependenciesOf   string
+	WhyNotConfigured string
+}
+
+// MachinesPartResourceGraphAction is the corresponding Action for 'machines part resource-graph'.
+func MachinesPartResourceGraphAction(ctx context.Context, cmd *cli.Command, args machinesPartResourceGraphArgs) error {
+	client, err := newViamClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	globalArgs, err := getGlobalArgs(cmd)
+	if err != nil {
+		return err
+	}
+	logger := globalArgs.createLogger()
+
+	part, err := client.robotPart(ctx, args.Organization, args.Location, args.Machine, args.Part)
+	if err != nil {
+		return err
+	}
+	data, err := client.copyViamFileFromMachine(
+		ctx, args.Organization, args.Location, args.Machine, args.Part, resource.GraphInfoFileName(part.Id), globalArgs.Debug, logger)
+	if err != nil {
+		return errors.Wrap(err, "could not copy resource graph from machine part")
+	}
+	var info resource.GraphInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return errors.Wrap(err, "cannot parse resource graph")
+	}
+
+	out, err := queryResourceGraph(info, args)
+	if err != nil {
+		return err
+	}
+	printf(cmd.Root().Writer, "%s", strings.TrimSuffix(out, "\n"))
+	return nil
+}
+
+// queryResourceGraph answers the query given by args, or renders the whole graph in the
+// requested format if there is no query.
+func queryResourceGraph(info resource.GraphInfo, args machinesPartResourceGraphArgs) (string, error) {
+	switch {
+	case args.DependentsOf != "":
+		names, err := info.Dependents(args.DependentsOf)
+		if err != nil {
+			return "", err
+		}
+		if len(names) == 0 {
+			return "no resources depend on " + args.DependentsOf, nil
+		}
+		return strings.Join(names, "\n"), nil
+	case args.DependenciesOf != "":
+		names, err := info.Dependencies(args.DependenciesOf)
+		if err != nil {
+			return "", err
+		}
+		if len(names) == 0 {
+			return args.DependenciesOf + " has no dependencies", nil
+		}
+		return strings.Join(names, "\n"), nil
+	case args.WhyNotConfigured != "":
+		reasons, err := info.WhyNotConfigured(args.WhyNotConfigured)
+		if err != nil {
+			return "", err
+		}
+		if len(reasons) == 0 {
+			return args.WhyNotConfigured + " is configured", nil
+		}
+		return strings.Join(reasons, "\n"), nil
+	}
+
+	switch args.Format {
+	case "", resourceGraphFormatText:
+		return info.Text(), nil
+	case resourceGraphFormatDOT:
+		return info.DOT(), nil
+	case resourceGraphFormatMermaid:
+		return info.Mermaid(), nil
+	case resourceGraphFormatJSON:
+		md, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(md), nil
+	default:
+		return "", errors.Errorf("unknown format %q; must be one of %s", args.Format,
+			strings.Join([]string{
+				resourceGraphFormatText, resourceGraphFormatDOT, resourceGraphFormatMermaid, resourceGraphFormatJSON,
+			}, ", "))
+	}
+}
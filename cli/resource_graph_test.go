@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/resource"
+)
+
+func TestQueryResourceGraph(t *testing.T) {
+	info := resource.GraphInfo{
+		Nodes: []resource.GraphNodeInfo{
+			{Name: "rdk:component:arm/arm1", API: "rdk:component:arm", State: "Configuring"},
+			{Name: "rdk:component:board/board1", API: "rdk:component:board", State: "Unhealthy", Error: "no pins"},
+		},
+		Edges: []resource.GraphEdgeInfo{{From: "rdk:component:arm/arm1", To: "rdk:component:board/board1"}},
+	}
+
+	out, err := queryResourceGraph(info, machinesPartResourceGraphArgs{DependentsOf: "board1"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out, test.ShouldEqual, "rdk:component:arm/arm1")
+
+	out, err = queryResourceGraph(info, machinesPartResourceGraphArgs{DependentsOf: "arm1"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out, test.ShouldEqual, "no resources depend on arm1")
+
+	out, err = queryResourceGraph(info, machinesPartResourceGraphArgs{WhyNotConfigured: "arm1"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out, test.ShouldContainSubstring, "rdk:component:board/board1 is unhealthy: no pins")
+
+	out, err = queryResourceGraph(info, machinesPartResourceGraphArgs{Format: resourceGraphFormatMermaid})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out, test.ShouldStartWith, "flowchart LR")
+
+	out, err = queryResourceGraph(info, machinesPartResourceGraphArgs{Format: resourceGraphFormatJSON})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, out, test.ShouldContainSubstring, `"from": "rdk:component:arm/arm1"`)
+
+	_, err = queryResourceGraph(info, machinesPartResourceGraphArgs{Format: "svg"})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = queryResourceGraph(info, machinesPartResourceGraphArgs{WhyNotConfigured: "gripper1"})
+	test.That(t, err, test.ShouldNotBeNil)
+}
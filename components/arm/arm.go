@@ -48,6 +48,13 @@ const SubtypeName = "arm"
 // API is a variable that identifies the component resource API.
 var API = resource.APINamespaceRDK.WithComponentType(SubtypeName)
 
+// DoGoToTimedInputs is the DoCommand by which an arm is sent timed inputs over gRPC, which has no
+// method for them. Its value is a list of maps with a "time_s" and the "inputs" and "velocities" of
+// the arm at that time, and its result is true if the arm followed them. Drivers that implement
+// framesystem.TimedInputEnabled are sent the inputs by the arm server; drivers in other languages may
+// handle the command themselves. An empty list only checks that the command is supported.
+const DoGoToTimedInputs = "go_to_timed_inputs"
+
 // Named is a helper for getting the named Arm's typed resource name.
 func Named(name string) resource.Name {
 	return resource.NewName(API, name)
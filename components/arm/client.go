@@ -15,6 +15,7 @@ import (
 	rprotoutils "go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
 	"go.viam.com/rdk/spatialmath"
 )
 
@@ -24,11 +25,16 @@ type client struct {
 	resource.TriviallyReconfigurable
 	resource.TriviallyCloseable
 	name   string
+	conn   rpc.ClientConn
 	client pb.ArmServiceClient
 	logger logging.Logger
 
 	mu    sync.Mutex
 	model referenceframe.Model
+	// timedInputsSupported is whether the remote arm handles DoGoToTimedInputs, once known. It
+	// is only known for timedInputsConn, as the remote may be different after a reconnect.
+	timedInputsSupported bool
+	timedInputsConn      rpc.ClientConn
 }
 
 // NewClientFromConn constructs a new Client from connection passed in.
@@ -43,6 +49,7 @@ func NewClientFromConn(
 	return &client{
 		Named:  name.PrependRemote(remoteName).AsNamed(),
 		name:   name.Name,
+		conn:   conn,
 		client: pbClient,
 		logger: logger,
 	}, nil
@@ -187,6 +194,10 @@ func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return err
 }
 
+func (c *client) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+	return rprotoutils.SafeStopFromResourceClient(ctx, c.client, c.name, extra)
+}
+
 func (c *client) Kinematics(ctx context.Context) (referenceframe.Model, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -214,6 +225,47 @@ func (c *client) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.
 	return c.MoveThroughJointPositions(ctx, inputSteps, nil, nil)
 }
 
+// GoToTimedInputs sends timed inputs to the remote arm with DoGoToTimedInputs, first checking once
+// per connection that it handles them.
+func (c *client) GoToTimedInputs(ctx context.Context, inputs []referenceframe.TimedInputs) error {
+	conn := c.currentConn()
+	c.mu.Lock()
+	known := c.timedInputsConn != nil && c.timedInputsConn == conn
+	supported := c.timedInputsSupported
+	c.mu.Unlock()
+	if !known {
+		resp, err := c.DoCommand(ctx, map[string]interface{}{DoGoToTimedInputs: []interface{}{}})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// an error is most likely the driver not knowing the command.
+		supported = err == nil && resp[DoGoToTimedInputs] == true
+		c.mu.Lock()
+		c.timedInputsSupported = supported
+		c.timedInputsConn = conn
+		c.mu.Unlock()
+	}
+	if !supported {
+		return framesystem.ErrTimedInputsUnsupported
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+	_, err := c.DoCommand(ctx, map[string]interface{}{DoGoToTimedInputs: timedInputsToCommand(inputs)})
+	return err
+}
+
+// currentConn returns the connection requests to the remote arm currently go over. Connections
+// to robots are swapped out underneath their clients when they reconnect.
+func (c *client) currentConn() rpc.ClientConn {
+	if reconnecting, ok := c.conn.(interface{ Conn() rpc.ClientConn }); ok {
+		if conn := reconnecting.Conn(); conn != nil {
+			return conn
+		}
+	}
+	return c.conn
+}
+
 func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	return rprotoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
 }
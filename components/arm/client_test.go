@@ -2,8 +2,10 @@ package arm_test
 
 import (
 	"context"
+	"errors"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/geo/r3"
 	robotpb "go.viam.com/api/robot/v1"
@@ -11,6 +13,7 @@ import (
 	"go.viam.com/utils/rpc"
 
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/arm/fake"
 	viamgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
@@ -245,3 +248,129 @@ func TestClient(t *testing.T) {
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
 }
+
+type timedInjectArm struct {
+	*inject.Arm
+	timedInputs []referenceframe.TimedInputs
+}
+
+func (a *timedInjectArm) GoToTimedInputs(ctx context.Context, inputs []referenceframe.TimedInputs) error {
+	a.timedInputs = inputs
+	return nil
+}
+
+func TestClientTimedInputs(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+
+	timedArm := &timedInjectArm{Arm: &inject.Arm{}}
+	untimedArm := &inject.Arm{}
+	var probes int
+	untimedArm.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		probes++
+		return nil, resource.ErrDoUnimplemented
+	}
+	armSvc, err := resource.NewAPIResourceCollection(arm.API, map[resource.Name]arm.Arm{
+		arm.Named(testArmName):  timedArm,
+		arm.Named(testArmName2): untimedArm,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	resourceAPI, ok, err := resource.LookupAPIRegistration[arm.Arm](arm.API)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, resourceAPI.RegisterRPCService(context.Background(), rpcServer, armSvc, logger), test.ShouldBeNil)
+
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer conn.Close()
+
+	inputs := []referenceframe.TimedInputs{
+		{Time: 0, Inputs: []referenceframe.Input{0, 0}, Velocities: []referenceframe.Input{0, 0}},
+		{Time: 10 * time.Millisecond, Inputs: []referenceframe.Input{0.1, 0.2}, Velocities: []referenceframe.Input{1, 2}},
+	}
+
+	client, err := arm.NewClientFromConn(context.Background(), conn, "", arm.Named(testArmName), logger)
+	test.That(t, err, test.ShouldBeNil)
+	timedClient, ok := client.(framesystem.TimedInputEnabled)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, timedClient.GoToTimedInputs(context.Background(), inputs), test.ShouldBeNil)
+	test.That(t, timedArm.timedInputs, test.ShouldResemble, inputs)
+
+	// clients of a robot share a connection which is replaced when they reconnect.
+	reconnecting := &viamgrpc.ReconfigurableClientConn{Logger: logger}
+	reconnecting.ReplaceConn(conn)
+	client2, err := arm.NewClientFromConn(context.Background(), reconnecting, "", arm.Named(testArmName2), logger)
+	test.That(t, err, test.ShouldBeNil)
+	err = client2.(framesystem.TimedInputEnabled).GoToTimedInputs(context.Background(), inputs)
+	test.That(t, errors.Is(err, framesystem.ErrTimedInputsUnsupported), test.ShouldBeTrue)
+	test.That(t, probes, test.ShouldEqual, 1)
+
+	// the arm is only asked whether it handles timed inputs once per connection.
+	err = client2.(framesystem.TimedInputEnabled).GoToTimedInputs(context.Background(), inputs)
+	test.That(t, errors.Is(err, framesystem.ErrTimedInputsUnsupported), test.ShouldBeTrue)
+	test.That(t, probes, test.ShouldEqual, 1)
+
+	conn2, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer conn2.Close()
+	reconnecting.ReplaceConn(conn2)
+	err = client2.(framesystem.TimedInputEnabled).GoToTimedInputs(context.Background(), inputs)
+	test.That(t, errors.Is(err, framesystem.ErrTimedInputsUnsupported), test.ShouldBeTrue)
+	test.That(t, probes, test.ShouldEqual, 2)
+}
+
+func TestClientSafeStop(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	rpcServer, err := rpc.NewServer(logger, rpc.WithUnauthenticated())
+	test.That(t, err, test.ShouldBeNil)
+
+	fakeArm, err := fake.NewArm(context.Background(), nil, resource.Config{
+		Name:                testArmName,
+		ConvertedAttributes: &fake.Config{ArmModel: "ur5e"},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fakeArm.MoveToJointPositions(context.Background(), []referenceframe.Input{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}, nil),
+		test.ShouldBeNil)
+
+	plainArm := &inject.Arm{}
+	plainArm.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		return nil, resource.ErrDoUnimplemented
+	}
+	armSvc, err := resource.NewAPIResourceCollection(arm.API, map[resource.Name]arm.Arm{
+		arm.Named(testArmName):  fakeArm,
+		arm.Named(testArmName2): plainArm,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	resourceAPI, ok, err := resource.LookupAPIRegistration[arm.Arm](arm.API)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, resourceAPI.RegisterRPCService(context.Background(), rpcServer, armSvc, logger), test.ShouldBeNil)
+
+	go rpcServer.Serve(listener)
+	defer rpcServer.Stop()
+
+	conn, err := viamgrpc.Dial(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer conn.Close()
+
+	// the fake arm parks itself.
+	client, err := arm.NewClientFromConn(context.Background(), conn, "", arm.Named(testArmName), logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, client.(resource.SafeStopper).SafeStop(context.Background(), nil), test.ShouldBeNil)
+	joints, err := fakeArm.JointPositions(context.Background(), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, joints, test.ShouldResemble, make([]referenceframe.Input, 6))
+
+	client2, err := arm.NewClientFromConn(context.Background(), conn, "", arm.Named(testArmName2), logger)
+	test.That(t, err, test.ShouldBeNil)
+	err = client2.(resource.SafeStopper).SafeStop(context.Background(), nil)
+	test.That(t, errors.Is(err, resource.ErrSafeStopUnsupported), test.ShouldBeTrue)
+}
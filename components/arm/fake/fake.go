@@ -227,6 +227,16 @@ func (a *Arm) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return nil
 }
 
+// SafeStop parks the fake arm by moving all of its joints to zero.
+func (a *Arm) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := range a.joints {
+		a.joints[i] = 0
+	}
+	return nil
+}
+
 // IsMoving is always false for a fake arm.
 func (a *Arm) IsMoving(ctx context.Context) (bool, error) {
 	return false, nil
@@ -255,6 +265,17 @@ func (a *Arm) GoToInputs(ctx context.Context, inputSteps ...[]referenceframe.Inp
 	return a.MoveThroughJointPositions(ctx, inputSteps, nil, nil)
 }
 
+// GoToTimedInputs moves the fake arm through the given inputs in turn, without waiting for their
+// times.
+func (a *Arm) GoToTimedInputs(ctx context.Context, inputs []referenceframe.TimedInputs) error {
+	for _, timed := range inputs {
+		if err := a.MoveToJointPositions(ctx, timed.Inputs, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close does nothing.
 func (a *Arm) Close(ctx context.Context) error {
 	a.mu.Lock()
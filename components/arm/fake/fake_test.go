@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"go.viam.com/test"
 
@@ -11,6 +12,7 @@ import (
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
 )
 
 func TestReconfigure(t *testing.T) {
@@ -114,6 +116,19 @@ func TestJointPositions(t *testing.T) {
 	inputs, err := arm.CurrentInputs(ctx)
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, sampleInputs, test.ShouldResemble, inputs)
+
+	// Round trip test for GoToTimedInputs -> CurrentInputs
+	timed, ok := arm.(framesystem.TimedInputEnabled)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, timed.GoToTimedInputs(ctx, nil), test.ShouldBeNil)
+	timedInputs := []referenceframe.TimedInputs{
+		{Time: 0, Inputs: sampleInputs},
+		{Time: time.Second, Inputs: samplePositions},
+	}
+	test.That(t, timed.GoToTimedInputs(ctx, timedInputs), test.ShouldBeNil)
+	inputs, err = arm.CurrentInputs(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs, test.ShouldResemble, samplePositions)
 }
 
 func TestGet3DModels(t *testing.T) {
@@ -1,8 +1,12 @@
 package arm
 
 import (
+	"fmt"
+	"time"
+
 	pb "go.viam.com/api/component/arm/v1"
 
+	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/utils"
 )
 
@@ -70,3 +74,69 @@ func (opts *MoveOptions) toProtobuf() *pb.MoveOptions {
 	}
 	return pbOpts
 }
+
+func timedInputsToCommand(inputs []referenceframe.TimedInputs) []interface{} {
+	floats := func(values []referenceframe.Input) []interface{} {
+		list := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			list = append(list, v)
+		}
+		return list
+	}
+	cmd := make([]interface{}, 0, len(inputs))
+	for _, in := range inputs {
+		cmd = append(cmd, map[string]interface{}{
+			"time_s":     in.Time.Seconds(),
+			"inputs":     floats(in.Inputs),
+			"velocities": floats(in.Velocities),
+		})
+	}
+	return cmd
+}
+
+func timedInputsFromCommand(cmd interface{}) ([]referenceframe.TimedInputs, error) {
+	list, ok := cmd.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list, not %T", DoGoToTimedInputs, cmd)
+	}
+	floats := func(value interface{}) ([]referenceframe.Input, error) {
+		values, ok := value.([]interface{})
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%s inputs must be lists of numbers, not %T", DoGoToTimedInputs, value)
+		}
+		inputs := make([]referenceframe.Input, 0, len(values))
+		for _, v := range values {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%s inputs must be lists of numbers, not %T", DoGoToTimedInputs, v)
+			}
+			inputs = append(inputs, f)
+		}
+		return inputs, nil
+	}
+	timed := make([]referenceframe.TimedInputs, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of maps, not of %T", DoGoToTimedInputs, item)
+		}
+		seconds, ok := m["time_s"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s is missing a time_s", DoGoToTimedInputs)
+		}
+		inputs, err := floats(m["inputs"])
+		if err != nil {
+			return nil, err
+		}
+		velocities, err := floats(m["velocities"])
+		if err != nil {
+			return nil, err
+		}
+		timed = append(timed, referenceframe.TimedInputs{
+			Time:       time.Duration(seconds * float64(time.Second)),
+			Inputs:     inputs,
+			Velocities: velocities,
+		})
+	}
+	return timed, nil
+}
@@ -3,16 +3,19 @@ package arm
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/arm/v1"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
 	"go.viam.com/rdk/spatialmath"
 )
 
@@ -242,9 +245,32 @@ func (s *serviceServer) DoCommand(ctx context.Context,
 	}
 
 	s.logger.Debugw("DoCommand", "res", req.Name, "req", req)
+	if timed, ok := arm.(framesystem.TimedInputEnabled); ok {
+		if cmd, ok := req.GetCommand().AsMap()[DoGoToTimedInputs]; ok {
+			return goToTimedInputs(ctx, timed, cmd)
+		}
+	}
 	return protoutils.DoFromResourceServer(ctx, arm, req)
 }
 
+// goToTimedInputs handles DoGoToTimedInputs for an arm that can follow timed inputs.
+func goToTimedInputs(ctx context.Context, arm framesystem.TimedInputEnabled, cmd interface{}) (*commonpb.DoCommandResponse, error) {
+	inputs, err := timedInputsFromCommand(cmd)
+	if err != nil {
+		return nil, err
+	}
+	err = arm.GoToTimedInputs(ctx, inputs)
+	if err != nil && !errors.Is(err, framesystem.ErrTimedInputsUnsupported) {
+		return nil, err
+	}
+	supported := err == nil
+	result, err := structpb.NewStruct(map[string]interface{}{DoGoToTimedInputs: supported})
+	if err != nil {
+		return nil, err
+	}
+	return &commonpb.DoCommandResponse{Result: result}, nil
+}
+
 // GetStatus returns the status of the arm.
 func (s *serviceServer) GetStatus(ctx context.Context, req *commonpb.GetStatusRequest) (*commonpb.GetStatusResponse, error) {
 	res, err := s.coll.Resource(req.GetName())
@@ -125,6 +125,10 @@ func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return nil
 }
 
+func (c *client) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+	return rprotoutils.SafeStopFromResourceClient(ctx, c.client, c.name, extra)
+}
+
 func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	return rprotoutils.DoFromResourceClient(ctx, c.client, c.name, cmd)
 }
@@ -8,6 +8,7 @@ import (
 
 	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/spatialmath"
@@ -118,6 +119,13 @@ func (g *Gantry) Lengths(ctx context.Context, extra map[string]interface{}) ([]f
 // Home runs the homing sequence of the gantry and returns true once completed.
 func (g *Gantry) Home(ctx context.Context, extra map[string]interface{}) (bool, error) {
 	g.logger.CInfo(ctx, "homing")
+	for axis := range g.lengthsMm {
+		operation.SetProgress(ctx, operation.Progress{
+			Current: axis + 1,
+			Total:   len(g.lengthsMm),
+			Message: fmt.Sprintf("homing axis %d", axis),
+		})
+	}
 	return true, nil
 }
 
@@ -81,6 +81,7 @@ func (s *serviceServer) Home(
 	if err != nil {
 		return nil, err
 	}
+	operation.SetProgress(ctx, operation.Progress{Message: "homing"})
 	homed, err := gantry.Home(ctx, req.Extra.AsMap())
 	if err != nil {
 		return &pb.HomeResponse{Homed: homed}, err
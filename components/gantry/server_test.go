@@ -12,6 +12,7 @@ import (
 
 	"go.viam.com/rdk/components/gantry"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/spatialmath"
@@ -211,10 +212,13 @@ func TestServer(t *testing.T) {
 
 		ext, err := protoutils.StructToStructPb(map[string]interface{}{"foo": 123, "bar": "234"})
 		test.That(t, err, test.ShouldBeNil)
-		resp, err := gantryServer.Home(context.Background(), &pb.HomeRequest{Name: testGantryName, Extra: ext})
+		opCtx, done := operation.NewManager(logging.NewTestLogger(t)).Create(context.Background(), "home", nil)
+		defer done()
+		resp, err := gantryServer.Home(opCtx, &pb.HomeRequest{Name: testGantryName, Extra: ext})
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, resp.Homed, test.ShouldBeTrue)
 		test.That(t, extra1, test.ShouldResemble, map[string]interface{}{"foo": 123., "bar": "234"})
+		test.That(t, operation.Get(opCtx).Progress(), test.ShouldResemble, operation.Progress{Message: "homing"})
 
 		resp, err = gantryServer.Home(context.Background(), &pb.HomeRequest{Name: failGantryName})
 		test.That(t, err, test.ShouldNotBeNil)
@@ -148,6 +148,10 @@ func (c *client) Stop(ctx context.Context, extra map[string]interface{}) error {
 	return err
 }
 
+func (c *client) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+	return rprotoutils.SafeStopFromResourceClient(ctx, c.client, c.name, extra)
+}
+
 func (c *client) IsPowered(ctx context.Context, extra map[string]interface{}) (bool, float64, error) {
 	ext, err := protoutils.StructToStructPb(extra)
 	if err != nil {
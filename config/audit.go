@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	// DefaultAuditMaxSizeMB is the size an audit log file may grow to before it is rotated
+	// when audit.max_size_mb is not set.
+	DefaultAuditMaxSizeMB = 10
+	// DefaultAuditMaxFiles is the number of rotated audit log files retained when
+	// audit.max_files is not set.
+	DefaultAuditMaxFiles = 10
+
+	// AuditLogFileName is the name of the audit log file within the audit directory. Rotated
+	// files are kept next to it.
+	AuditLogFileName = "audit.jsonl"
+)
+
+// AuditConfig configures the audit log of mutating calls made to the robot's gRPC API. The cloud
+// config cannot carry it, so it is always read from the local config file, even on cloud-managed
+// machines.
+type AuditConfig struct {
+	// APIs select which calls are audited. Each entry is a path.Match style glob matched
+	// against the resource API served by the gRPC service (e.g. "rdk:component:arm") or
+	// the fully qualified gRPC service name (e.g. "viam.robot.v1.RobotService"). If empty,
+	// every API is audited.
+	APIs []string `json:"apis,omitempty"`
+
+	// MaxSizeMB is the size in megabytes the audit log may grow to before it is rotated.
+	MaxSizeMB int `json:"max_size_mb,omitempty"`
+
+	// MaxFiles is the number of rotated audit log files to retain.
+	MaxFiles int `json:"max_files,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid. Sets defaults for unset fields.
+func (ac *AuditConfig) Validate(path string) error {
+	for idx, pattern := range ac.APIs {
+		if err := validateGlob(pattern); err != nil {
+			return resource.NewConfigValidationError(fmt.Sprintf("%s.apis.%d", path, idx), err)
+		}
+	}
+	if ac.MaxSizeMB < 0 {
+		return resource.NewConfigValidationError(path, errors.New("max_size_mb must be non-negative"))
+	}
+	if ac.MaxSizeMB == 0 {
+		ac.MaxSizeMB = DefaultAuditMaxSizeMB
+	}
+	if ac.MaxFiles < 0 {
+		return resource.NewConfigValidationError(path, errors.New("max_files must be non-negative"))
+	}
+	if ac.MaxFiles == 0 {
+		ac.MaxFiles = DefaultAuditMaxFiles
+	}
+	return nil
+}
+
+// Audits returns whether a call to the given method should be audited. api is the resource
+// API served by the service, if any. Only mutating methods are audited.
+func (ac *AuditConfig) Audits(api, service, method string) bool {
+	if !IsMutatingMethod(service, method) {
+		return false
+	}
+	if len(ac.APIs) == 0 {
+		return true
+	}
+	return (api != "" && anyGlobMatches(ac.APIs, api)) || anyGlobMatches(ac.APIs, service)
+}
+
+// readOnlyMethodPrefixes are the prefixes of gRPC methods that do not change the state of
+// the robot.
+var readOnlyMethodPrefixes = []string{"Get", "Is", "List", "Read", "Stream"}
+
+// readOnlyMethods are gRPC methods that do not change the state of the robot but do not
+// have a read-only prefix.
+var readOnlyMethods = map[string]struct{}{
+	"ResourceNames":        {},
+	"ResourceRPCSubtypes":  {},
+	"FrameSystemConfig":    {},
+	"TransformPose":        {},
+	"TransformPCD":         {},
+	"StartSession":         {},
+	"SendSessionHeartbeat": {},
+	"Log":                  {},
+}
+
+// IsMutatingMethod returns whether the given gRPC method may change the state of the robot
+// or its resources. Calls that are part of establishing a connection are never mutating.
+func IsMutatingMethod(service, method string) bool {
+	if strings.HasPrefix(service, "proto.rpc.") || strings.HasPrefix(service, "grpc.") {
+		return false
+	}
+	if _, ok := readOnlyMethods[method]; ok {
+		return false
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditDirectory returns the directory that the audit log for the given part ID is kept in
+// within the given Viam home directory.
+func AuditDirectory(homeDir, id string) string {
+	return filepath.Join(homeDir, "audit", id)
+}
+
+// AuditEntry is a record of a single audited call.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Resource is the name of the resource the call targeted, if any.
+	Resource string `json:"resource,omitempty"`
+
+	// Entity is the authenticated entity that made the call, if authentication is enabled.
+	Entity string `json:"entity,omitempty"`
+	// Peer is the network address of the caller.
+	Peer string `json:"peer,omitempty"`
+	// Client is the SDK type and version of the caller, if known.
+	Client    string `json:"client,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+
+	// Arguments is a summary of the request. Long requests are truncated.
+	Arguments string `json:"arguments,omitempty"`
+	// Code is the gRPC status code of the result and Error its message, if the call failed.
+	Code       string `json:"code"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ReadAuditLog reads newline delimited audit entries from an audit log file.
+func ReadAuditLog(r io.Reader) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(err, "cannot parse audit log line %d", line)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+func TestAuditConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		conf := config.AuditConfig{}
+		test.That(t, conf.Validate("audit"), test.ShouldBeNil)
+		test.That(t, conf.MaxSizeMB, test.ShouldEqual, config.DefaultAuditMaxSizeMB)
+		test.That(t, conf.MaxFiles, test.ShouldEqual, config.DefaultAuditMaxFiles)
+
+		conf = config.AuditConfig{APIs: []string{"["}}
+		err := conf.Validate("audit")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "audit.apis.0")
+
+		conf = config.AuditConfig{MaxFiles: -1}
+		test.That(t, conf.Validate("audit"), test.ShouldNotBeNil)
+	})
+
+	t.Run("audits", func(t *testing.T) {
+		all := config.AuditConfig{}
+		test.That(t, all.Audits("rdk:component:arm", "viam.component.arm.v1.ArmService", "MoveToPosition"), test.ShouldBeTrue)
+		test.That(t, all.Audits("rdk:component:arm", "viam.component.arm.v1.ArmService", "GetEndPosition"), test.ShouldBeFalse)
+		test.That(t, all.Audits("", "viam.robot.v1.RobotService", "ResourceNames"), test.ShouldBeFalse)
+		test.That(t, all.Audits("", "viam.robot.v1.RobotService", "StopAll"), test.ShouldBeTrue)
+		test.That(t, all.Audits("", "rdk.robot.v1.JobService", "RunJob"), test.ShouldBeTrue)
+		test.That(t, all.Audits("", "proto.rpc.v1.AuthService", "Authenticate"), test.ShouldBeFalse)
+
+		arms := config.AuditConfig{APIs: []string{"rdk:component:arm", "viam.robot.v1.*"}}
+		test.That(t, arms.Audits("rdk:component:arm", "viam.component.arm.v1.ArmService", "Stop"), test.ShouldBeTrue)
+		test.That(t, arms.Audits("rdk:component:motor", "viam.component.motor.v1.MotorService", "Stop"), test.ShouldBeFalse)
+		test.That(t, arms.Audits("", "viam.robot.v1.RobotService", "StopAll"), test.ShouldBeTrue)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		conf, err := config.FromReader(
+			t.Context(), "", bytes.NewReader([]byte(`{"audit": {"apis": ["rdk:component:*"]}}`)), logging.NewTestLogger(t), nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, conf.Audit, test.ShouldResemble, &config.AuditConfig{
+			APIs:      []string{"rdk:component:*"},
+			MaxSizeMB: config.DefaultAuditMaxSizeMB,
+			MaxFiles:  config.DefaultAuditMaxFiles,
+		})
+	})
+}
+
+func TestReadAuditLog(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	var buf bytes.Buffer
+	for _, entry := range []config.AuditEntry{
+		{Time: now, Method: "/viam.component.arm.v1.ArmService/Stop", Resource: "arm1", Code: "OK"},
+		{Time: now.Add(time.Second), Method: "/viam.robot.v1.RobotService/StopAll", Code: "PermissionDenied", Error: "denied"},
+	} {
+		line, err := json.Marshal(entry)
+		test.That(t, err, test.ShouldBeNil)
+		buf.Write(append(line, '\n'))
+	}
+
+	entries, err := config.ReadAuditLog(&buf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, entries, test.ShouldHaveLength, 2)
+	test.That(t, entries[0].Resource, test.ShouldEqual, "arm1")
+	test.That(t, entries[1].Error, test.ShouldEqual, "denied")
+
+	_, err = config.ReadAuditLog(bytes.NewBufferString("not json\n"))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "line 1")
+}
@@ -43,6 +43,9 @@ type Config struct {
 	MaintenanceConfig *MaintenanceConfig
 	Jobs              []JobConfig
 	Tracing           TracingConfig
+	ConfigHistory     *HistoryConfig
+	Audit             *AuditConfig
+	RateLimits        *RateLimitConfig
 
 	ConfigFilePath string
 
@@ -139,6 +142,9 @@ type configData struct {
 	DisableLogDeduplication bool                          `json:"disable_log_deduplication"`
 	Jobs                    []JobConfig                   `json:"jobs,omitempty"`
 	Tracing                 TracingConfig                 `json:"tracing,omitempty"`
+	ConfigHistory           *HistoryConfig                `json:"config_history,omitempty"`
+	Audit                   *AuditConfig                  `json:"audit,omitempty"`
+	RateLimits              *RateLimitConfig              `json:"rate_limits,omitempty"`
 }
 
 // AppValidationStatus refers to the.
@@ -170,6 +176,28 @@ func (c *Config) Ensure(fromCloud bool, logger logging.Logger) error {
 		return err
 	}
 
+	// Adds defaults for Size and RollbackAfter if not set.
+	if c.ConfigHistory != nil {
+		if err := c.ConfigHistory.Validate("config_history"); err != nil {
+			logger.Errorw("Config history config error; using defaults", "error", err.Error())
+			c.ConfigHistory = &HistoryConfig{Size: DefaultHistorySize, RollbackAfter: DefaultRollbackAfter}
+		}
+	}
+
+	// Adds defaults for MaxSizeMB and MaxFiles if not set.
+	if c.Audit != nil {
+		if err := c.Audit.Validate("audit"); err != nil {
+			return err
+		}
+	}
+
+	// Adds defaults for Burst if not set.
+	if c.RateLimits != nil {
+		if err := c.RateLimits.Validate("rate_limits"); err != nil {
+			return err
+		}
+	}
+
 	// Validate jobs, modules, remotes, packages, and processes, and log errors for lack of
 	// uniqueness within each category. Managers of each resource handle duplicates
 	// differently, and behavior is undefined.
@@ -321,6 +349,9 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	c.DisableLogDeduplication = conf.DisableLogDeduplication
 	c.Jobs = conf.Jobs
 	c.Tracing = conf.Tracing
+	c.ConfigHistory = conf.ConfigHistory
+	c.Audit = conf.Audit
+	c.RateLimits = conf.RateLimits
 
 	return nil
 }
@@ -353,6 +384,9 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		DisableLogDeduplication: c.DisableLogDeduplication,
 		Jobs:                    c.Jobs,
 		Tracing:                 c.Tracing,
+		ConfigHistory:           c.ConfigHistory,
+		Audit:                   c.Audit,
+		RateLimits:              c.RateLimits,
 	})
 }
 
@@ -378,8 +412,13 @@ func (c *Config) CopyOnlyPublicFields() (*Config, error) {
 // the current robot. All components of the remote robot who have Parent as "world" will be attached to the parent defined
 // in Frame, and with the given offset as well.
 type Remote struct {
-	Name                      string
-	Address                   string
+	Name    string
+	Address string
+	// Addresses are other addresses the remote can be reached through, such as a LAN IP, an
+	// mDNS name or a cloud FQDN. The connection fails over between Address and these and
+	// prefers whichever connects fastest. The cloud config has no field for them, so they only
+	// take effect for remotes read from a local config file.
+	Addresses                 []string
 	Frame                     *referenceframe.LinkConfig
 	Auth                      RemoteAuth
 	ManagedBy                 string
@@ -400,6 +439,7 @@ type Remote struct {
 type remoteData struct {
 	Name                      string                              `json:"name"`
 	Address                   string                              `json:"address"`
+	Addresses                 []string                            `json:"addresses,omitempty"`
 	Frame                     *referenceframe.LinkConfig          `json:"frame,omitempty"`
 	Auth                      RemoteAuth                          `json:"auth"`
 	ManagedBy                 string                              `json:"managed_by"`
@@ -432,6 +472,7 @@ func (conf *Remote) UnmarshalJSON(data []byte) error {
 	*conf = Remote{
 		Name:                      temp.Name,
 		Address:                   temp.Address,
+		Addresses:                 temp.Addresses,
 		Frame:                     temp.Frame,
 		Auth:                      temp.Auth,
 		ManagedBy:                 temp.ManagedBy,
@@ -462,6 +503,7 @@ func (conf Remote) MarshalJSON() ([]byte, error) {
 	temp := remoteData{
 		Name:                      conf.Name,
 		Address:                   conf.Address,
+		Addresses:                 conf.Addresses,
 		Prefix:                    conf.Prefix,
 		Frame:                     conf.Frame,
 		Auth:                      conf.Auth,
@@ -519,6 +561,11 @@ func (conf *Remote) validate(path string) error {
 	if conf.Address == "" {
 		return resource.NewConfigValidationFieldRequiredError(path, "address")
 	}
+	for idx, address := range conf.Addresses {
+		if address == "" {
+			return resource.NewConfigValidationFieldRequiredError(path, fmt.Sprintf("addresses.%d", idx))
+		}
+	}
 	if conf.Frame != nil {
 		if conf.Frame.Parent == "" {
 			return resource.NewConfigValidationFieldRequiredError(path, "frame.parent")
@@ -896,6 +943,16 @@ type AuthConfig struct {
 	Handlers           []AuthHandlerConfig `json:"handlers,omitempty"`
 	TLSAuthEntities    []string            `json:"tls_auth_entities,omitempty"`
 	ExternalAuthConfig *ExternalAuthConfig `json:"external_auth_config,omitempty"`
+
+	// Roles restrict which robot gRPC methods authenticated entities may call. If no roles
+	// are configured, authenticated entities may call any method. The cloud config cannot
+	// carry roles, so they are always read from the local config file, even on cloud-managed
+	// machines.
+	Roles []RoleConfig `json:"roles,omitempty"`
+	// DefaultRole is the role of authenticated entities that are not bound to any role. If
+	// unset, such entities may call any method. Like Roles, it is always read from the local
+	// config file.
+	DefaultRole string `json:"default_role,omitempty"`
 }
 
 // ExternalAuthConfig contains information needed to verify externally authenticated tokens.
@@ -997,7 +1054,19 @@ type AuthHandlerConfig struct {
 //					}
 //				}
 //			],
-//		    "external_auth_config": {}
+//		    "external_auth_config": {},
+//			"roles": [
+//				{
+//					"name": "operator",
+//					"entities": ["API_KEY_ID_2"],
+//					"allow": [
+//						{"service": "viam.robot.v1.RobotService", "methods": ["ResourceNames", "GetMachineStatus"]},
+//						{"api": "rdk:component:camera"},
+//						{"methods": ["Stop"]}
+//					],
+//					"deny": [{"api": "rdk:service:shell"}]
+//				}
+//			]
 //	}
 func (config *AuthConfig) Validate(path string) error {
 	seenTypes := make(map[string]struct{}, len(config.Handlers))
@@ -1016,7 +1085,7 @@ func (config *AuthConfig) Validate(path string) error {
 			return err
 		}
 	}
-	return nil
+	return config.validateRoles(path)
 }
 
 // Validate ensures all parts of the config are valid.
@@ -1298,6 +1367,9 @@ type JobConfig struct {
 }
 
 // JobConfigData is the job config data that gets marshaled/unmarshaled.
+//
+// Steps, Timeout, Retries, RetryBackoff, After and ConcurrencyPolicy have no counterpart in
+// the cloud config, so they only take effect for jobs read from a local config file.
 type JobConfigData struct {
 	Name             string              `json:"name"`
 	Schedule         string              `json:"schedule"`
@@ -1305,6 +1377,62 @@ type JobConfigData struct {
 	Method           string              `json:"method"`
 	Command          map[string]any      `json:"command,omitempty"`
 	LogConfiguration *resource.LogConfig `json:"log_configuration,omitempty"`
+
+	// Steps make this a scripted job that calls each step in order instead of calling Method
+	// on Resource.
+	Steps []JobStep `json:"steps,omitempty"`
+
+	// Timeout bounds each attempt of a run, as a duration string. Unset means no timeout.
+	Timeout string `json:"timeout,omitempty"`
+	// Retries is how many more times a failed run is attempted before it is recorded as failed.
+	Retries int `json:"retries,omitempty"`
+	// RetryBackoff is how long to wait before the first retry, as a duration string. The wait
+	// doubles for every further retry. Defaults to 1s.
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+	// After is the name of another job. This job runs every time that job succeeds, in
+	// addition to its own schedule, if any.
+	After string `json:"after,omitempty"`
+	// ConcurrencyPolicy decides what happens when the job is due while a previous run is still
+	// in progress. Defaults to skip for cron schedules and queue otherwise. It has no effect
+	// on continuous jobs.
+	ConcurrencyPolicy JobConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+}
+
+// JobConcurrencyPolicy decides what happens when a job is due while a previous run of it is
+// still in progress.
+type JobConcurrencyPolicy string
+
+const (
+	// JobConcurrencySkip skips the new run.
+	JobConcurrencySkip JobConcurrencyPolicy = "skip"
+	// JobConcurrencyQueue starts the new run once the previous one completes.
+	JobConcurrencyQueue JobConcurrencyPolicy = "queue"
+	// JobConcurrencyReplace cancels the previous run and starts the new one once it has stopped.
+	JobConcurrencyReplace JobConcurrencyPolicy = "replace"
+)
+
+// DefaultJobRetryBackoff is how long a failed job waits before its first retry by default.
+const DefaultJobRetryBackoff = time.Second
+
+// localOnlyField returns the json name of the first field that is set but cannot be
+// carried by the cloud config, or "" if there is none.
+func (jc *JobConfig) localOnlyField() string {
+	switch {
+	case len(jc.Steps) > 0:
+		return "steps"
+	case jc.Timeout != "":
+		return "timeout"
+	case jc.Retries != 0:
+		return "retries"
+	case jc.RetryBackoff != "":
+		return "retry_backoff"
+	case jc.After != "":
+		return "after"
+	case jc.ConcurrencyPolicy != "":
+		return "concurrency_policy"
+	default:
+		return ""
+	}
 }
 
 // MarshalJSON marshals out this config.
@@ -1330,15 +1458,46 @@ func (jc *JobConfig) Validate(path string) error {
 	if jc.Name == "" {
 		return resource.NewConfigValidationFieldRequiredError(path, "name")
 	}
-	if jc.Method == "" {
-		return resource.NewConfigValidationFieldRequiredError(path, "method")
-	}
-	if jc.Resource == "" {
-		return resource.NewConfigValidationFieldRequiredError(path, "resource")
+	if len(jc.Steps) != 0 {
+		if jc.Method != "" || jc.Resource != "" {
+			return resource.NewConfigValidationError(path, errors.New("a job with steps cannot also have a resource or method"))
+		}
+		if err := validateJobSteps(path, jc.Steps); err != nil {
+			return err
+		}
+	} else {
+		if jc.Method == "" {
+			return resource.NewConfigValidationFieldRequiredError(path, "method")
+		}
+		if jc.Resource == "" {
+			return resource.NewConfigValidationFieldRequiredError(path, "resource")
+		}
 	}
-	if jc.Schedule == "" {
+	if jc.Schedule == "" && jc.After == "" {
 		return resource.NewConfigValidationFieldRequiredError(path, "schedule")
 	}
+	if jc.After == jc.Name {
+		return resource.NewConfigValidationError(path, errors.New("a job cannot run after itself"))
+	}
+	if jc.Timeout != "" {
+		if timeout, err := time.ParseDuration(jc.Timeout); err != nil || timeout <= 0 {
+			return resource.NewConfigValidationError(path, errors.Errorf("timeout must be a positive duration, got %q", jc.Timeout))
+		}
+	}
+	if jc.Retries < 0 {
+		return resource.NewConfigValidationError(path, errors.New("retries must be non-negative"))
+	}
+	if jc.RetryBackoff != "" {
+		if backoff, err := time.ParseDuration(jc.RetryBackoff); err != nil || backoff < 0 {
+			return resource.NewConfigValidationError(path,
+				errors.Errorf("retry_backoff must be a non-negative duration, got %q", jc.RetryBackoff))
+		}
+	}
+	switch jc.ConcurrencyPolicy {
+	case "", JobConcurrencySkip, JobConcurrencyQueue, JobConcurrencyReplace:
+	default:
+		return resource.NewConfigValidationError(path, errors.Errorf("unknown concurrency_policy %q", jc.ConcurrencyPolicy))
+	}
 	// At this point, the schedule could still be invalid (not a golang duration string or a
 	// cron expression). Such errors will be caught later, when the job manager will try to
 	// schedule the job and parse this field. The error will be displayed to the user.
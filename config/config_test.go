@@ -251,6 +251,24 @@ func TestConfigEnsure(t *testing.T) {
 	}
 	test.That(t, invalidRemotes.Ensure(false, logger), test.ShouldBeNil)
 
+	logs.TakeAll() // clear logs
+	invalidRemotes.Remotes[0] = config.Remote{
+		Name:      "foo",
+		Address:   "bar",
+		Addresses: []string{"baz", ""},
+	}
+	test.That(t, invalidRemotes.Ensure(false, logger), test.ShouldBeNil)
+	remoteConfigErrorLogs = logs.FilterMessageSnippet("Remote config error")
+	test.That(t, remoteConfigErrorLogs.Len(), test.ShouldEqual, 1)
+	test.That(t, fmt.Sprint(remoteConfigErrorLogs.All()[0].ContextMap()), test.ShouldContainSubstring, "addresses.1")
+
+	invalidRemotes.Remotes[0] = config.Remote{
+		Name:      "foo",
+		Address:   "bar",
+		Addresses: []string{"baz"},
+	}
+	test.That(t, invalidRemotes.Ensure(false, logger), test.ShouldBeNil)
+
 	logs.TakeAll() // clear logs
 	invalidComponents := config.Config{
 		Components: []resource.Config{{}},
@@ -1269,6 +1287,72 @@ func TestJobsConfig(t *testing.T) {
 			},
 			shouldFailValidation: false,
 		},
+		{
+			config: config.JobConfig{
+				config.JobConfigData{
+					Name:              "after other job",
+					After:             "other",
+					Method:            "my_method",
+					Resource:          "my_resource",
+					Timeout:           "10m",
+					Retries:           3,
+					RetryBackoff:      "30s",
+					ConcurrencyPolicy: config.JobConcurrencyReplace,
+				},
+			},
+			shouldFailValidation: false,
+		},
+		{
+			config: config.JobConfig{
+				config.JobConfigData{
+					Name:     "my_name",
+					After:    "my_name",
+					Method:   "my_method",
+					Resource: "my_resource",
+				},
+			},
+			shouldFailValidation: true,
+			expRespErr:           "cannot run after itself",
+		},
+		{
+			config: config.JobConfig{
+				config.JobConfigData{
+					Name:     "my_name",
+					Schedule: "1m",
+					Method:   "my_method",
+					Resource: "my_resource",
+					Timeout:  "soon",
+				},
+			},
+			shouldFailValidation: true,
+			expRespErr:           "timeout must be a positive duration",
+		},
+		{
+			config: config.JobConfig{
+				config.JobConfigData{
+					Name:     "my_name",
+					Schedule: "1m",
+					Method:   "my_method",
+					Resource: "my_resource",
+					Retries:  -1,
+				},
+			},
+			shouldFailValidation: true,
+			expRespErr:           "retries must be non-negative",
+		},
+		{
+			config: config.JobConfig{
+				config.JobConfigData{
+					Name:              "my_name",
+					Schedule:          "1m",
+					Method:            "my_method",
+					Resource:          "my_resource",
+					ConcurrencyPolicy: "sometimes",
+				},
+			},
+			shouldFailValidation: true,
+			expRespErr:           `unknown concurrency_policy "sometimes"`,
+		},
 	}
 
 	for _, jt := range jobsTests {
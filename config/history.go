@@ -0,0 +1,315 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+	"go.viam.com/utils/artifact"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	// DefaultHistorySize is the number of applied configs retained when config_history.size is not set.
+	DefaultHistorySize = 5
+	// DefaultRollbackAfter is how long critical resources may be failing before the robot rolls
+	// back to the last-known-good config when config_history.rollback_after is not set.
+	DefaultRollbackAfter = time.Minute
+)
+
+// HistoryConfig configures how many applied configs a robot retains and when it should
+// automatically roll back to the last-known-good config. The cloud config cannot carry it, so
+// it is always read from the local config file, including on cloud-managed machines.
+type HistoryConfig struct {
+	// Size is the number of applied configs to retain on disk.
+	Size int
+
+	// CriticalResources are the names of resources that must be healthy for a newly applied
+	// config to be considered good. Automatic rollback is disabled if this is empty.
+	CriticalResources []string
+
+	// RollbackAfter is how long any critical resource may be failing before the robot rolls
+	// back to the last-known-good config.
+	RollbackAfter time.Duration
+}
+
+// Note: keep this in sync with HistoryConfig.
+type historyConfigData struct {
+	Size              int      `json:"size,omitempty"`
+	CriticalResources []string `json:"critical_resources,omitempty"`
+	RollbackAfter     string   `json:"rollback_after,omitempty"`
+}
+
+// UnmarshalJSON unmarshals JSON data into this config.
+func (hc *HistoryConfig) UnmarshalJSON(data []byte) error {
+	var temp historyConfigData
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	*hc = HistoryConfig{
+		Size:              temp.Size,
+		CriticalResources: temp.CriticalResources,
+	}
+	if temp.RollbackAfter != "" {
+		dur, err := time.ParseDuration(temp.RollbackAfter)
+		if err != nil {
+			return err
+		}
+		hc.RollbackAfter = dur
+	}
+	return nil
+}
+
+// MarshalJSON marshals out this config.
+func (hc HistoryConfig) MarshalJSON() ([]byte, error) {
+	temp := historyConfigData{
+		Size:              hc.Size,
+		CriticalResources: hc.CriticalResources,
+	}
+	if hc.RollbackAfter != 0 {
+		temp.RollbackAfter = hc.RollbackAfter.String()
+	}
+	return json.Marshal(temp)
+}
+
+// Validate ensures all parts of the config are valid. Sets defaults for unset fields.
+func (hc *HistoryConfig) Validate(path string) error {
+	if hc.Size < 0 {
+		return resource.NewConfigValidationError(path, errors.New("size must be non-negative"))
+	}
+	if hc.Size == 0 {
+		hc.Size = DefaultHistorySize
+	}
+	if hc.RollbackAfter < 0 {
+		return resource.NewConfigValidationError(path, errors.New("rollback_after must be non-negative"))
+	}
+	if hc.RollbackAfter == 0 {
+		hc.RollbackAfter = DefaultRollbackAfter
+	}
+	for idx, name := range hc.CriticalResources {
+		if name == "" {
+			return resource.NewConfigValidationFieldRequiredError(fmt.Sprintf("%s.critical_resources.%d", path, idx), "name")
+		}
+	}
+	return nil
+}
+
+// HistoryOutcome describes what happened to a config after the robot applied it.
+type HistoryOutcome string
+
+// The set of known config outcomes.
+const (
+	// HistoryOutcomePending means the config was applied and its critical resources are still
+	// being watched.
+	HistoryOutcomePending = HistoryOutcome("pending")
+	// HistoryOutcomeApplied means the config was applied and is considered good.
+	HistoryOutcomeApplied = HistoryOutcome("applied")
+	// HistoryOutcomeRolledBack means critical resources failed under the config and the robot
+	// rolled back to the last-known-good config.
+	HistoryOutcomeRolledBack = HistoryOutcome("rolled_back")
+	// HistoryOutcomeFailed means critical resources failed under the config but there was no
+	// known-good config to roll back to.
+	HistoryOutcomeFailed = HistoryOutcome("failed")
+	// HistoryOutcomeSuperseded means a newer config was applied before the config's critical
+	// resources were confirmed healthy.
+	HistoryOutcomeSuperseded = HistoryOutcome("superseded")
+)
+
+// A HistoryEntry records a config the robot applied along with its outcome.
+type HistoryEntry struct {
+	Revision  string          `json:"revision"`
+	AppliedAt time.Time       `json:"applied_at"`
+	Outcome   HistoryOutcome  `json:"outcome"`
+	Error     string          `json:"error,omitempty"`
+	Config    json.RawMessage `json:"config,omitempty"`
+}
+
+// NewHistoryEntry returns a pending entry for the given config. The stored config is the
+// unprocessed copy that would be cached for cloud configs, or the config itself otherwise.
+func NewHistoryEntry(cfg *Config, appliedAt time.Time) (HistoryEntry, error) {
+	raw := cfg.toCache
+	if raw == nil {
+		var err error
+		if raw, err = json.Marshal(cfg); err != nil {
+			return HistoryEntry{}, errors.Wrap(err, "error marshaling config for history")
+		}
+	}
+	return HistoryEntry{
+		Revision:  cfg.Revision,
+		AppliedAt: appliedAt,
+		Outcome:   HistoryOutcomePending,
+		Config:    raw,
+	}, nil
+}
+
+// RestoreConfig returns the config stored in the entry, processed the way the robot processes
+// configs it reads. The parts of a config that the history does not store, namely the cloud
+// credentials, the sections only read from the local config file and the settings that come
+// from command line flags, are taken from current.
+func (e HistoryEntry) RestoreConfig(current *Config, logger logging.Logger) (*Config, error) {
+	unprocessed := &Config{}
+	if err := json.Unmarshal(e.Config, unprocessed); err != nil {
+		return nil, errors.Wrapf(err, "cannot parse config %q from config history as json", e.Revision)
+	}
+	fromCloud := current.Cloud != nil
+	if fromCloud {
+		cloud := *current.Cloud
+		unprocessed.Cloud = &cloud
+	}
+	cfg, err := processConfig(unprocessed, fromCloud, logger)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot process config %q from config history", e.Revision)
+	}
+	if fromCloud {
+		mergeLocalConfig(cfg, current)
+		cfg.toCache = e.Config
+	}
+	cfg.ConfigFilePath = current.ConfigFilePath
+	cfg.AllowInsecureCreds = current.AllowInsecureCreds
+	cfg.UntrustedEnv = current.UntrustedEnv
+	cfg.FromCommand = current.FromCommand
+	if cfg.PackagePath == "" {
+		cfg.PackagePath = current.PackagePath
+	}
+	return cfg, nil
+}
+
+// HistoryFileName returns the name of the config history file for the given part ID.
+func HistoryFileName(id string) string {
+	return fmt.Sprintf("config_history_%s.json", id)
+}
+
+// HistoryFilePath returns the path of the config history file for the given part ID
+// within the given Viam home directory.
+func HistoryFilePath(homeDir, id string) string {
+	return filepath.Join(homeDir, HistoryFileName(id))
+}
+
+// History is a bounded, on-disk record of configs applied by a robot. Entries are
+// ordered from newest to oldest.
+type History struct {
+	mu      sync.Mutex
+	path    string
+	size    int
+	entries []HistoryEntry
+}
+
+// NewHistory returns a History that persists to the given path and retains at most size
+// entries. Existing entries are loaded from disk. If the existing file cannot be read, a
+// usable empty History is returned along with the error.
+func NewHistory(path string, size int) (*History, error) {
+	h := &History{path: path, size: size}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return h, err
+	}
+	defer utils.UncheckedErrorFunc(f.Close)
+
+	entries, err := ReadHistory(f)
+	if err != nil {
+		return h, err
+	}
+	h.entries = entries
+	h.truncate()
+	return h, nil
+}
+
+// ReadHistory reads config history entries as written by History.
+func ReadHistory(r io.Reader) ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, errors.Wrap(err, "cannot parse config history as json")
+	}
+	return entries, nil
+}
+
+// SetSize changes the number of retained entries, dropping the oldest ones if needed.
+func (h *History) SetSize(size int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if size == h.size {
+		return nil
+	}
+	h.size = size
+	if !h.truncate() {
+		return nil
+	}
+	return h.store()
+}
+
+// Add records a new entry as the newest one and persists the history.
+func (h *History) Add(entry HistoryEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append([]HistoryEntry{entry}, h.entries...)
+	h.truncate()
+	return h.store()
+}
+
+// UpdateLatest sets the outcome of the newest entry and persists the history.
+func (h *History) UpdateLatest(outcome HistoryOutcome, cause error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.entries) == 0 {
+		return errors.New("config history is empty")
+	}
+	h.entries[0].Outcome = outcome
+	h.entries[0].Error = ""
+	if cause != nil {
+		h.entries[0].Error = cause.Error()
+	}
+	return h.store()
+}
+
+// Latest returns the newest entry, if any.
+func (h *History) Latest() (HistoryEntry, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.entries) == 0 {
+		return HistoryEntry{}, false
+	}
+	return h.entries[0], true
+}
+
+// Entries returns a copy of all retained entries from newest to oldest.
+func (h *History) Entries() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := make([]HistoryEntry, len(h.entries))
+	copy(entries, h.entries)
+	return entries
+}
+
+// truncate drops the oldest entries beyond the configured size and reports whether any
+// were dropped. Must be called with mu held.
+func (h *History) truncate() bool {
+	if h.size <= 0 || len(h.entries) <= h.size {
+		return false
+	}
+	h.entries = h.entries[:h.size]
+	return true
+}
+
+// store persists the history. Must be called with mu held.
+func (h *History) store() error {
+	md, err := json.MarshalIndent(h.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+	return artifact.AtomicStore(h.path, bytes.NewReader(md), filepath.Base(h.path))
+}
@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+func TestHistoryConfigJSON(t *testing.T) {
+	var hc config.HistoryConfig
+	err := json.Unmarshal([]byte(`{"size": 3, "critical_resources": ["arm1"], "rollback_after": "30s"}`), &hc)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, hc, test.ShouldResemble, config.HistoryConfig{
+		Size:              3,
+		CriticalResources: []string{"arm1"},
+		RollbackAfter:     30 * time.Second,
+	})
+
+	md, err := json.Marshal(hc)
+	test.That(t, err, test.ShouldBeNil)
+	var roundTripped config.HistoryConfig
+	test.That(t, json.Unmarshal(md, &roundTripped), test.ShouldBeNil)
+	test.That(t, roundTripped, test.ShouldResemble, hc)
+
+	err = json.Unmarshal([]byte(`{"rollback_after": "soon"}`), &hc)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestHistoryConfigValidate(t *testing.T) {
+	hc := config.HistoryConfig{}
+	test.That(t, hc.Validate("config_history"), test.ShouldBeNil)
+	test.That(t, hc.Size, test.ShouldEqual, config.DefaultHistorySize)
+	test.That(t, hc.RollbackAfter, test.ShouldEqual, config.DefaultRollbackAfter)
+
+	hc = config.HistoryConfig{Size: -1}
+	test.That(t, hc.Validate("config_history"), test.ShouldNotBeNil)
+
+	hc = config.HistoryConfig{CriticalResources: []string{""}}
+	err := hc.Validate("config_history")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "config_history.critical_resources.0")
+
+	// an invalid config history falls back to the defaults rather than turning history off.
+	cfg := config.Config{ConfigHistory: &config.HistoryConfig{Size: -1}}
+	test.That(t, cfg.Ensure(false, logging.NewTestLogger(t)), test.ShouldBeNil)
+	test.That(t, cfg.ConfigHistory, test.ShouldResemble, &config.HistoryConfig{
+		Size:          config.DefaultHistorySize,
+		RollbackAfter: config.DefaultRollbackAfter,
+	})
+}
+
+func TestHistory(t *testing.T) {
+	path := config.HistoryFilePath(t.TempDir(), "part")
+	test.That(t, filepath.Base(path), test.ShouldEqual, "config_history_part.json")
+
+	h, err := config.NewHistory(path, 2)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, h.Entries(), test.ShouldBeEmpty)
+	_, ok := h.Latest()
+	test.That(t, ok, test.ShouldBeFalse)
+	test.That(t, h.UpdateLatest(config.HistoryOutcomeApplied, nil), test.ShouldNotBeNil)
+
+	for _, rev := range []string{"1", "2", "3"} {
+		entry, err := config.NewHistoryEntry(&config.Config{Revision: rev}, time.Now())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, entry.Outcome, test.ShouldEqual, config.HistoryOutcomePending)
+		test.That(t, h.Add(entry), test.ShouldBeNil)
+	}
+	test.That(t, h.UpdateLatest(config.HistoryOutcomeRolledBack, errors.New("arm1 failing")), test.ShouldBeNil)
+
+	entries := h.Entries()
+	test.That(t, entries, test.ShouldHaveLength, 2)
+	test.That(t, entries[0].Revision, test.ShouldEqual, "3")
+	test.That(t, entries[0].Outcome, test.ShouldEqual, config.HistoryOutcomeRolledBack)
+	test.That(t, entries[0].Error, test.ShouldEqual, "arm1 failing")
+	test.That(t, entries[1].Revision, test.ShouldEqual, "2")
+
+	var cfg config.Config
+	test.That(t, json.Unmarshal(entries[1].Config, &cfg), test.ShouldBeNil)
+	test.That(t, cfg.Revision, test.ShouldEqual, "2")
+
+	// history is reloaded from disk and truncated to the new size
+	h, err = config.NewHistory(path, 1)
+	test.That(t, err, test.ShouldBeNil)
+	entries = h.Entries()
+	test.That(t, entries, test.ShouldHaveLength, 1)
+	test.That(t, entries[0].Revision, test.ShouldEqual, "3")
+	test.That(t, entries[0].Outcome, test.ShouldEqual, config.HistoryOutcomeRolledBack)
+}
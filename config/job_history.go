@@ -0,0 +1,58 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JobHistoryFileName is the name of the file the job manager persists run history to.
+const JobHistoryFileName = "history.json"
+
+// Triggers of a job run.
+const (
+	JobTriggerSchedule = "schedule"
+	JobTriggerManual   = "manual"
+	// JobTriggerAfter is followed by the name of the job whose success triggered the run.
+	JobTriggerAfter = "after "
+)
+
+// JobHistoryDirectory returns the directory that job run history for the given part ID is
+// kept in within the given Viam home directory.
+func JobHistoryDirectory(homeDir, id string) string {
+	return filepath.Join(homeDir, "jobs", id)
+}
+
+// JobRun is a record of a single completed run of a job.
+type JobRun struct {
+	Job string `json:"job"`
+	// Trigger is what started the run: its schedule, a manual request, or the success of
+	// another job.
+	Trigger string    `json:"trigger"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	// Attempts is how many times the job was attempted, including retries.
+	Attempts int `json:"attempts"`
+	// Result is the response of the last attempt as JSON. Long responses are truncated.
+	Result string `json:"result,omitempty"`
+	// Error is the error of the last attempt, if the run failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Succeeded returns whether the run completed without error.
+func (run JobRun) Succeeded() bool {
+	return run.Error == ""
+}
+
+// ReadJobHistory reads persisted job run history, keyed by job name with the oldest run
+// first.
+func ReadJobHistory(r io.Reader) (map[string][]JobRun, error) {
+	var history map[string][]JobRun
+	if err := json.NewDecoder(r).Decode(&history); err != nil {
+		return nil, errors.Wrap(err, "cannot parse job history")
+	}
+	return history, nil
+}
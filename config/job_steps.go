@@ -0,0 +1,391 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// JobStepErrorPolicy decides what happens when a step of a scripted job fails.
+type JobStepErrorPolicy string
+
+const (
+	// JobStepErrorFail stops the job and fails the run. This is the default.
+	JobStepErrorFail JobStepErrorPolicy = "fail"
+	// JobStepErrorContinue moves on to the next step. The step's result is replaced by its
+	// error, see JobStepErrorKey, so later steps can react to it.
+	JobStepErrorContinue JobStepErrorPolicy = "continue"
+)
+
+// JobStepErrorKey is the key of the error message in the result of a step that failed with
+// the continue error policy.
+const JobStepErrorKey = "error"
+
+// JobStep is a single resource call made by a scripted job.
+type JobStep struct {
+	// Name identifies the step so that later steps can reference its result.
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	Method   string `json:"method"`
+	// Arguments are the fields of the request for gRPC methods, or the command for DoCommand.
+	// String values may reference results of earlier steps as ${step.field.subfield}. A value
+	// that is a single reference takes on the type of the referenced value; otherwise
+	// references are substituted as text.
+	Arguments map[string]any `json:"arguments,omitempty"`
+	// If is a condition on the results of earlier steps, such as
+	// "${read.readings.temperature} > 30". The step is skipped unless it holds. A condition
+	// without a comparison holds if its value is set and not false, zero or empty.
+	If string `json:"if,omitempty"`
+	// OnError decides what happens when the step fails. Defaults to fail.
+	OnError JobStepErrorPolicy `json:"on_error,omitempty"`
+}
+
+// Validate ensures all parts of the step are valid.
+func (step *JobStep) Validate(path string) error {
+	if step.Name == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "name")
+	}
+	if step.Resource == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "resource")
+	}
+	if step.Method == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "method")
+	}
+	if step.If != "" {
+		if _, err := ParseJobCondition(step.If); err != nil {
+			return resource.NewConfigValidationError(path, err)
+		}
+	}
+	switch step.OnError {
+	case "", JobStepErrorFail, JobStepErrorContinue:
+	default:
+		return resource.NewConfigValidationError(path, errors.Errorf("unknown on_error %q", step.OnError))
+	}
+	return nil
+}
+
+// validateJobSteps ensures every step is valid and that step names are unique.
+func validateJobSteps(path string, steps []JobStep) error {
+	seen := make(map[string]struct{}, len(steps))
+	for idx := range steps {
+		stepPath := fmt.Sprintf("%s.steps.%d", path, idx)
+		if err := steps[idx].Validate(stepPath); err != nil {
+			return err
+		}
+		if _, ok := seen[steps[idx].Name]; ok {
+			return resource.NewConfigValidationError(stepPath, errors.Errorf("duplicate step name %q", steps[idx].Name))
+		}
+		seen[steps[idx].Name] = struct{}{}
+	}
+	return nil
+}
+
+// JobStepResults are the results of the steps of a scripted job run so far, by step name.
+type JobStepResults map[string]map[string]any
+
+var jobReferenceRegex = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// Lookup returns the value a reference, such as "read.readings.temperature", points to. The
+// first part of the reference is a step name and the rest is a path into its result, where
+// numbers index into lists.
+func (results JobStepResults) Lookup(ref string) (any, bool) {
+	parts := strings.Split(ref, ".")
+	result, ok := results[parts[0]]
+	if !ok {
+		return nil, false
+	}
+	var value any = result
+	for _, part := range parts[1:] {
+		switch v := value.(type) {
+		case map[string]any:
+			if value, ok = v[part]; !ok {
+				return nil, false
+			}
+		case []any:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			value = v[idx]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// ResolveArguments returns a copy of arguments with references to results substituted,
+// recursing into maps and lists. It fails if a reference has no value.
+func (results JobStepResults) ResolveArguments(arguments map[string]any) (map[string]any, error) {
+	resolved, err := results.resolve(arguments)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == nil {
+		return nil, nil
+	}
+	return resolved.(map[string]any), nil
+}
+
+func (results JobStepResults) resolve(value any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if v == nil {
+			return nil, nil
+		}
+		resolved := make(map[string]any, len(v))
+		for key, elem := range v {
+			r, err := results.resolve(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = r
+		}
+		return resolved, nil
+	case []any:
+		resolved := make([]any, 0, len(v))
+		for _, elem := range v {
+			r, err := results.resolve(elem)
+			if err != nil {
+				return nil, err
+			}
+			resolved = append(resolved, r)
+		}
+		return resolved, nil
+	case string:
+		return results.resolveString(v)
+	default:
+		return value, nil
+	}
+}
+
+func (results JobStepResults) resolveString(s string) (any, error) {
+	matches := jobReferenceRegex.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+	// a lone reference keeps the type of the value it points to.
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(s) {
+		ref := s[matches[0][2]:matches[0][3]]
+		value, ok := results.Lookup(ref)
+		if !ok {
+			return nil, errors.Errorf("no result for reference ${%s}", ref)
+		}
+		return value, nil
+	}
+	var missing error
+	resolved := jobReferenceRegex.ReplaceAllStringFunc(s, func(match string) string {
+		ref := match[2 : len(match)-1]
+		value, ok := results.Lookup(ref)
+		if !ok {
+			missing = errors.Errorf("no result for reference ${%s}", ref)
+			return ""
+		}
+		return fmt.Sprint(value)
+	})
+	if missing != nil {
+		return nil, missing
+	}
+	return resolved, nil
+}
+
+// JobCondition is a parsed JobStep.If condition.
+type JobCondition struct {
+	left, op, right string
+}
+
+// jobConditionOperators are the supported comparisons. Longer operators come first so that
+// "<=" is not mistaken for "<".
+var jobConditionOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// ParseJobCondition parses a condition of the form "operand [operator operand]". Operands
+// are references such as ${step.field}, numbers, quoted strings, true or false.
+func ParseJobCondition(s string) (*JobCondition, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty condition")
+	}
+	inQuote, inRef := byte(0), false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inQuote != 0:
+			if c == inQuote {
+				inQuote = 0
+			}
+			continue
+		case inRef:
+			if c == '}' {
+				inRef = false
+			}
+			continue
+		case c == '"' || c == '\'':
+			inQuote = c
+			continue
+		case c == '$' && i+1 < len(s) && s[i+1] == '{':
+			inRef = true
+			continue
+		}
+		for _, op := range jobConditionOperators {
+			if strings.HasPrefix(s[i:], op) {
+				cond := &JobCondition{
+					left:  strings.TrimSpace(s[:i]),
+					op:    op,
+					right: strings.TrimSpace(s[i+len(op):]),
+				}
+				if err := validateJobOperand(cond.left); err != nil {
+					return nil, errors.Wrapf(err, "invalid condition %q", s)
+				}
+				if err := validateJobOperand(cond.right); err != nil {
+					return nil, errors.Wrapf(err, "invalid condition %q", s)
+				}
+				return cond, nil
+			}
+		}
+	}
+	if inQuote != 0 || inRef {
+		return nil, errors.Errorf("invalid condition %q: unterminated quote or reference", s)
+	}
+	if err := validateJobOperand(s); err != nil {
+		return nil, errors.Wrapf(err, "invalid condition %q", s)
+	}
+	return &JobCondition{left: s}, nil
+}
+
+func validateJobOperand(operand string) error {
+	switch {
+	case operand == "":
+		return errors.New("missing operand")
+	case jobReferenceRegex.FindString(operand) == operand:
+		return nil
+	case len(operand) >= 2 && (operand[0] == '"' || operand[0] == '\'') && operand[len(operand)-1] == operand[0]:
+		return nil
+	case operand == "true" || operand == "false":
+		return nil
+	}
+	if _, err := strconv.ParseFloat(operand, 64); err != nil {
+		return errors.Errorf("operand %s is not a reference, number, quoted string or boolean", operand)
+	}
+	return nil
+}
+
+// Evaluate returns whether the condition holds for the given results. References without a
+// value are treated as unset.
+func (cond *JobCondition) Evaluate(results JobStepResults) bool {
+	left := cond.operand(cond.left, results)
+	if cond.op == "" {
+		return truthy(left)
+	}
+	right := cond.operand(cond.right, results)
+
+	leftNum, leftIsNum := toFloat(left)
+	rightNum, rightIsNum := toFloat(right)
+	if leftIsNum && rightIsNum {
+		switch cond.op {
+		case "==":
+			return leftNum == rightNum
+		case "!=":
+			return leftNum != rightNum
+		case "<":
+			return leftNum < rightNum
+		case "<=":
+			return leftNum <= rightNum
+		case ">":
+			return leftNum > rightNum
+		case ">=":
+			return leftNum >= rightNum
+		}
+	}
+	if left == nil || right == nil {
+		// only equality is meaningful against an unset value.
+		switch cond.op {
+		case "==":
+			return left == nil && right == nil
+		case "!=":
+			return (left == nil) != (right == nil)
+		default:
+			return false
+		}
+	}
+	leftStr, rightStr := fmt.Sprint(left), fmt.Sprint(right)
+	switch cond.op {
+	case "==":
+		return leftStr == rightStr
+	case "!=":
+		return leftStr != rightStr
+	case "<":
+		return leftStr < rightStr
+	case "<=":
+		return leftStr <= rightStr
+	case ">":
+		return leftStr > rightStr
+	case ">=":
+		return leftStr >= rightStr
+	}
+	return false
+}
+
+func (cond *JobCondition) operand(operand string, results JobStepResults) any {
+	switch {
+	case strings.HasPrefix(operand, "${"):
+		value, _ := results.Lookup(operand[2 : len(operand)-1])
+		return value
+	case operand[0] == '"' || operand[0] == '\'':
+		return operand[1 : len(operand)-1]
+	case operand == "true":
+		return true
+	case operand == "false":
+		return false
+	}
+	// operands were validated when parsing.
+	num, _ := strconv.ParseFloat(operand, 64) //nolint:errcheck
+	return num
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func truthy(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case map[string]any:
+		return len(v) != 0
+	case []any:
+		return len(v) != 0
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+)
+
+func TestJobStepResults(t *testing.T) {
+	results := JobStepResults{
+		"read": {
+			"readings": map[string]any{"temperature": 31.5, "unit": "C"},
+			"history":  []any{1.0, 2.0},
+		},
+	}
+
+	value, ok := results.Lookup("read.readings.temperature")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, value, test.ShouldEqual, 31.5)
+	value, ok = results.Lookup("read.history.1")
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, value, test.ShouldEqual, 2.0)
+	_, ok = results.Lookup("read.history.2")
+	test.That(t, ok, test.ShouldBeFalse)
+	_, ok = results.Lookup("move.readings")
+	test.That(t, ok, test.ShouldBeFalse)
+
+	resolved, err := results.ResolveArguments(map[string]any{
+		"value":   "${read.readings.temperature}",
+		"message": "temperature is ${read.readings.temperature}${read.readings.unit}",
+		"nested":  []any{map[string]any{"first": "${read.history.0}"}},
+		"literal": 3,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resolved, test.ShouldResemble, map[string]any{
+		"value":   31.5,
+		"message": "temperature is 31.5C",
+		"nested":  []any{map[string]any{"first": 1.0}},
+		"literal": 3,
+	})
+
+	_, err = results.ResolveArguments(map[string]any{"value": "${read.readings.humidity}"})
+	test.That(t, err, test.ShouldBeError, "no result for reference ${read.readings.humidity}")
+}
+
+func TestJobCondition(t *testing.T) {
+	results := JobStepResults{
+		"read":    {"readings": map[string]any{"temperature": 31.5, "state": "idle", "ok": true}},
+		"capture": {JobStepErrorKey: "camera unavailable"},
+	}
+
+	for _, tc := range []struct {
+		condition string
+		holds     bool
+	}{
+		{"${read.readings.temperature} > 30", true},
+		{"${read.readings.temperature} <= 30", false},
+		{"${read.readings.temperature}>=31.5", true},
+		{"${read.readings.state} == 'idle'", true},
+		{`${read.readings.state} != "idle"`, false},
+		{"${read.readings.ok}", true},
+		{"${read.readings.ok} == false", false},
+		{"${capture.error}", true},
+		{"${read.error}", false},
+		{"${read.missing} > 3", false},
+		{"${read.missing} == ${capture.missing}", true},
+		{"'a > b' == 'a > b'", true},
+	} {
+		cond, err := ParseJobCondition(tc.condition)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cond.Evaluate(results), test.ShouldEqual, tc.holds)
+	}
+
+	for _, bad := range []string{"", "${read.x} >", "temperature > 30", "'unterminated", "${read.x"} {
+		_, err := ParseJobCondition(bad)
+		test.That(t, err, test.ShouldNotBeNil)
+	}
+}
+
+func TestJobStepsValidate(t *testing.T) {
+	jc := JobConfig{JobConfigData{
+		Name:     "routine",
+		Schedule: "1h",
+		Steps: []JobStep{
+			{Name: "read", Resource: "sensor", Method: "GetReadings"},
+			{Name: "move", Resource: "gantry", Method: "MoveToPosition", If: "${read.readings.temperature} > 30"},
+		},
+	}}
+	test.That(t, jc.Validate("jobs.0"), test.ShouldBeNil)
+
+	jc.Resource = "sensor"
+	test.That(t, jc.Validate("jobs.0"), test.ShouldBeError)
+	jc.Resource = ""
+
+	jc.Steps[1].Name = "read"
+	err := jc.Validate("jobs.0")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `duplicate step name "read"`)
+	jc.Steps[1].Name = "move"
+
+	jc.Steps[1].If = "temperature > 30"
+	test.That(t, jc.Validate("jobs.0"), test.ShouldNotBeNil)
+	jc.Steps[1].If = ""
+
+	jc.Steps[1].OnError = "ignore"
+	err = jc.Validate("jobs.0")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown on_error "ignore"`)
+}
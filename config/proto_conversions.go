@@ -166,6 +166,9 @@ func ComponentConfigFromProto(protoConf *pb.ComponentConfig, logger logging.Logg
 		logConfig = &resource.LogConfig{Level: level}
 	}
 
+	// The proto has no health check or configuration timeout fields, so resources from the
+	// cloud only get health checks they declare by implementing resource.HealthChecker and
+	// always use the default configuration timeout.
 	componentConf := resource.Config{
 		Name:                      protoConf.GetName(),
 		API:                       api,
@@ -257,6 +260,9 @@ func ServiceConfigFromProto(protoConf *pb.ServiceConfig, logger logging.Logger)
 		logConfig = &resource.LogConfig{Level: level}
 	}
 
+	// The proto has no health check or configuration timeout fields, so resources from the
+	// cloud only get health checks they declare by implementing resource.HealthChecker and
+	// always use the default configuration timeout.
 	conf := resource.Config{
 		Name:                      protoConf.GetName(),
 		API:                       api,
@@ -540,6 +546,10 @@ func FrameConfigFromProto(proto *pb.Frame) (*referenceframe.LinkConfig, error) {
 
 // RemoteConfigToProto converts Remote to the proto equivalent.
 func RemoteConfigToProto(remote *Remote) (*pb.RemoteConfig, error) {
+	if len(remote.Addresses) > 0 {
+		return nil, errors.Errorf("remote %q: addresses can only be set in a local config file", remote.Name)
+	}
+
 	serviceConfigs, err := mapSliceWithErrors(remote.AssociatedResourceConfigs, AssociatedResourceConfigToProto)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert service configs")
@@ -1047,8 +1057,12 @@ func LogConfigFromProto(proto *pb.LogPatternConfig, _ logging.Logger) (*logging.
 	}, nil
 }
 
-// JobsConfigToProto converts a JobConfig to its proto equivalent.
+// JobsConfigToProto converts a JobConfig to its proto equivalent. It fails if the job sets
+// a field the proto has no room for, rather than silently dropping it.
 func JobsConfigToProto(jc *JobConfig) (*pb.JobConfig, error) {
+	if field := jc.localOnlyField(); field != "" {
+		return nil, errors.Errorf("job %q: %s can only be set in a local config file", jc.Name, field)
+	}
 	protoConfig := &pb.JobConfig{
 		Name:     jc.Name,
 		Schedule: jc.Schedule,
@@ -617,6 +617,14 @@ func TestRemoteConfigToProto(t *testing.T) {
 		test.That(t, out.Prefix, test.ShouldEqual, proto.Prefix)
 		test.That(t, out.Auth, test.ShouldResemble, RemoteAuth{})
 	})
+
+	t.Run("With Addresses", func(t *testing.T) {
+		remote := testRemote
+		remote.Addresses = []string{"remote.local:8080"}
+		_, err := RemoteConfigToProto(&remote)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "addresses can only be set in a local config file")
+	})
 }
 
 //nolint:thelper
@@ -1119,6 +1127,18 @@ func TestJobsConfigProtoConversions(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, out.LogConfiguration.Level, test.ShouldEqual, logging.DEBUG)
 	test.That(t, *out, test.ShouldResemble, testJobConfigCommand)
+
+	testJobConfigLocalOnly := testJobConfigNoCommand
+	testJobConfigLocalOnly.Retries = 2
+	_, err = JobsConfigToProto(&testJobConfigLocalOnly)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `job "test": retries can only be set in a local config file`)
+
+	testJobConfigSteps := testJobConfigNoCommand
+	testJobConfigSteps.Steps = []JobStep{{Resource: "my-resource", Method: "doStuff"}}
+	_, err = JobsConfigToProto(&testJobConfigSteps)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `job "test": steps can only be set in a local config file`)
 }
 
 func TestTracingConfigToProtoEmpty(t *testing.T) {
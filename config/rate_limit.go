@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// RequestPriority is the priority class of a robot gRPC method call. It decides how a call is
+// treated when a resource is busy or a client is being rate limited.
+type RequestPriority string
+
+const (
+	// RequestPriorityCritical calls are never rejected by request limits or rate limits.
+	RequestPriorityCritical RequestPriority = "critical"
+	// RequestPriorityNormal calls are subject to the in-flight request limit of a resource and
+	// to rate limits.
+	RequestPriorityNormal RequestPriority = "normal"
+	// RequestPriorityLow calls may only use part of the in-flight request limit of a resource,
+	// so that they cannot starve normal calls, and are subject to rate limits.
+	RequestPriorityLow RequestPriority = "low"
+)
+
+// safetyMethods are gRPC methods that stop actuation or keep a session alive. They are always
+// critical regardless of configuration.
+var safetyMethods = map[string]struct{}{
+	"Stop":                 {},
+	"StopAll":              {},
+	"SendSessionHeartbeat": {},
+}
+
+// IsSafetyMethod returns whether the given gRPC method stops actuation or keeps a session
+// alive. Such calls are always critical.
+func IsSafetyMethod(method string) bool {
+	_, ok := safetyMethods[method]
+	return ok
+}
+
+// RateLimitConfig configures per client rate limits and priority classes for calls made to the
+// robot's gRPC API. The cloud config cannot carry it, so it is always read from the local config
+// file, even on cloud-managed machines.
+type RateLimitConfig struct {
+	// Limits are token bucket rate limits. A call must be allowed by every limit it matches.
+	Limits []RateLimitRule `json:"limits,omitempty"`
+	// Priorities assign priority classes to calls. The first matching rule wins and calls that
+	// match no rule are normal priority. Safety calls, see IsSafetyMethod, are always critical.
+	Priorities []PriorityRule `json:"priorities,omitempty"`
+}
+
+// RateLimitRule limits how often each client may make the calls matched by the embedded
+// RoleRule. Every client gets its own token bucket.
+type RateLimitRule struct {
+	RoleRule
+	// Clients match the identity of the caller: its authenticated entity or, if the caller is
+	// not authenticated, its SDK type and version (e.g. "typescript;*"). If empty, every
+	// client is limited.
+	Clients []string `json:"clients,omitempty"`
+	// RequestsPerSecond is the rate at which tokens are added to each client's bucket.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	// Burst is the size of each client's bucket. Defaults to RequestsPerSecond rounded up.
+	Burst int `json:"burst,omitempty"`
+}
+
+// PriorityRule assigns a priority class to the calls matched by the embedded RoleRule.
+type PriorityRule struct {
+	RoleRule
+	Priority RequestPriority `json:"priority"`
+}
+
+// Validate ensures all parts of the rate limit are valid. Sets defaults for unset fields.
+func (rule *RateLimitRule) Validate(path string) error {
+	if err := rule.RoleRule.Validate(path); err != nil {
+		return err
+	}
+	for _, pattern := range rule.Clients {
+		if err := validateGlob(pattern); err != nil {
+			return resource.NewConfigValidationError(path, err)
+		}
+	}
+	if rule.RequestsPerSecond <= 0 {
+		return resource.NewConfigValidationError(path, errors.New("requests_per_second must be positive"))
+	}
+	if rule.Burst < 0 {
+		return resource.NewConfigValidationError(path, errors.New("burst must be non-negative"))
+	}
+	if rule.Burst == 0 {
+		rule.Burst = int(math.Ceil(rule.RequestsPerSecond))
+	}
+	return nil
+}
+
+// MatchesClient returns whether the rule limits a client with the given authenticated entity
+// and SDK info. Either may be empty.
+func (rule *RateLimitRule) MatchesClient(entity, clientInfo string) bool {
+	if len(rule.Clients) == 0 {
+		return true
+	}
+	return (entity != "" && anyGlobMatches(rule.Clients, entity)) ||
+		(clientInfo != "" && anyGlobMatches(rule.Clients, clientInfo))
+}
+
+// Validate ensures all parts of the priority rule are valid.
+func (rule *PriorityRule) Validate(path string) error {
+	if err := rule.RoleRule.Validate(path); err != nil {
+		return err
+	}
+	switch rule.Priority {
+	case RequestPriorityCritical, RequestPriorityNormal, RequestPriorityLow:
+		return nil
+	default:
+		return resource.NewConfigValidationError(path, errors.Errorf("unknown priority %q", rule.Priority))
+	}
+}
+
+// Validate ensures all parts of the config are valid. Sets defaults for unset fields.
+func (c *RateLimitConfig) Validate(path string) error {
+	for idx := range c.Limits {
+		if err := c.Limits[idx].Validate(fmt.Sprintf("%s.limits.%d", path, idx)); err != nil {
+			return err
+		}
+	}
+	for idx := range c.Priorities {
+		if err := c.Priorities[idx].Validate(fmt.Sprintf("%s.priorities.%d", path, idx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PriorityFor returns the priority class of a call. See RoleRule.Matches for a description of
+// the arguments. A nil config treats everything but safety calls as normal priority.
+func (c *RateLimitConfig) PriorityFor(api, service, method, resourceName string) RequestPriority {
+	if IsSafetyMethod(method) {
+		return RequestPriorityCritical
+	}
+	if c == nil {
+		return RequestPriorityNormal
+	}
+	for _, rule := range c.Priorities {
+		if rule.Matches(api, service, method, resourceName) {
+			return rule.Priority
+		}
+	}
+	return RequestPriorityNormal
+}
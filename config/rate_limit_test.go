@@ -0,0 +1,59 @@
+package config_test
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestRateLimitConfig(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		conf := config.RateLimitConfig{
+			Limits: []config.RateLimitRule{{RoleRule: config.RoleRule{Methods: []string{"GetImages"}}, RequestsPerSecond: 2.5}},
+		}
+		test.That(t, conf.Validate("rate_limits"), test.ShouldBeNil)
+		test.That(t, conf.Limits[0].Burst, test.ShouldEqual, 3)
+
+		conf = config.RateLimitConfig{Limits: []config.RateLimitRule{{}}}
+		err := conf.Validate("rate_limits")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "rate_limits.limits.0")
+
+		conf = config.RateLimitConfig{Limits: []config.RateLimitRule{{RequestsPerSecond: 1, Clients: []string{"["}}}}
+		test.That(t, conf.Validate("rate_limits"), test.ShouldNotBeNil)
+
+		conf = config.RateLimitConfig{Priorities: []config.PriorityRule{{Priority: "urgent"}}}
+		err = conf.Validate("rate_limits")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `unknown priority "urgent"`)
+	})
+
+	t.Run("priority", func(t *testing.T) {
+		var unset *config.RateLimitConfig
+		test.That(t, unset.PriorityFor("", "viam.robot.v1.RobotService", "StopAll", ""), test.ShouldEqual, config.RequestPriorityCritical)
+		test.That(t, unset.PriorityFor("", "viam.robot.v1.RobotService", "GetMachineStatus", ""), test.ShouldEqual,
+			config.RequestPriorityNormal)
+
+		conf := config.RateLimitConfig{Priorities: []config.PriorityRule{
+			{RoleRule: config.RoleRule{API: "rdk:component:camera"}, Priority: config.RequestPriorityLow},
+			{RoleRule: config.RoleRule{API: "rdk:component:base"}, Priority: config.RequestPriorityCritical},
+		}}
+		test.That(t, conf.Validate("rate_limits"), test.ShouldBeNil)
+		camera := "viam.component.camera.v1.CameraService"
+		test.That(t, conf.PriorityFor("rdk:component:camera", camera, "GetImages", "cam"), test.ShouldEqual, config.RequestPriorityLow)
+		test.That(t, conf.PriorityFor("rdk:component:base", "viam.component.base.v1.BaseService", "SetVelocity", "base"),
+			test.ShouldEqual, config.RequestPriorityCritical)
+		// safety calls cannot be deprioritized.
+		test.That(t, conf.PriorityFor("rdk:component:camera", camera, "Stop", "cam"), test.ShouldEqual, config.RequestPriorityCritical)
+	})
+
+	t.Run("clients", func(t *testing.T) {
+		rule := config.RateLimitRule{Clients: []string{"dashboard-key", "typescript;*"}, RequestsPerSecond: 1}
+		test.That(t, rule.MatchesClient("dashboard-key", ""), test.ShouldBeTrue)
+		test.That(t, rule.MatchesClient("", "typescript;v0.40.0;v0.1.0"), test.ShouldBeTrue)
+		test.That(t, rule.MatchesClient("teleop-key", "go;v0.80.0;v0.1.0"), test.ShouldBeFalse)
+		test.That(t, (&config.RateLimitRule{}).MatchesClient("", ""), test.ShouldBeTrue)
+	})
+}
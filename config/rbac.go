@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// RoleConfig describes a role that authenticated entities can be bound to and the robot
+// gRPC methods that the role may call. A request is allowed if it matches at least one
+// Allow rule and no Deny rules.
+type RoleConfig struct {
+	Name string `json:"name"`
+	// Entities are the authenticated entities bound to this role, such as API key IDs, TLS
+	// auth entities or the subjects of externally issued tokens.
+	Entities []string   `json:"entities,omitempty"`
+	Allow    []RoleRule `json:"allow,omitempty"`
+	Deny     []RoleRule `json:"deny,omitempty"`
+}
+
+// RoleRule matches robot gRPC methods. Every field is optional and an empty field matches
+// everything. All fields support path.Match style globs.
+type RoleRule struct {
+	// API matches the resource API served by the gRPC service, e.g. "rdk:component:camera"
+	// or "rdk:component:*".
+	API string `json:"api,omitempty"`
+	// Service matches the fully qualified gRPC service name, e.g. "viam.robot.v1.RobotService".
+	Service string `json:"service,omitempty"`
+	// Methods match the gRPC method name, e.g. "Stop" or "Get*".
+	Methods []string `json:"methods,omitempty"`
+	// Resources match the name of the resource the request targets. Requests that do not
+	// target a resource never match a rule with Resources set.
+	Resources []string `json:"resources,omitempty"`
+}
+
+// Validate ensures all parts of the role rule are valid.
+func (rule *RoleRule) Validate(path string) error {
+	patterns := append([]string{rule.API, rule.Service}, rule.Methods...)
+	patterns = append(patterns, rule.Resources...)
+	for _, pattern := range patterns {
+		if err := validateGlob(pattern); err != nil {
+			return resource.NewConfigValidationError(path, err)
+		}
+	}
+	return nil
+}
+
+// Validate ensures all parts of the role are valid.
+func (role *RoleConfig) Validate(path string) error {
+	if role.Name == "" {
+		return resource.NewConfigValidationFieldRequiredError(path, "name")
+	}
+	for idx, rule := range role.Allow {
+		if err := rule.Validate(fmt.Sprintf("%s.allow.%d", path, idx)); err != nil {
+			return err
+		}
+	}
+	for idx, rule := range role.Deny {
+		if err := rule.Validate(fmt.Sprintf("%s.deny.%d", path, idx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Matches returns whether the rule matches a call to the given method. api is the resource
+// API served by the service, if any, and resourceName is the name of the resource the
+// request targets, if any.
+func (rule *RoleRule) Matches(api, service, method, resourceName string) bool {
+	if !globMatches(rule.API, api) || !globMatches(rule.Service, service) {
+		return false
+	}
+	if len(rule.Methods) != 0 && !anyGlobMatches(rule.Methods, method) {
+		return false
+	}
+	if len(rule.Resources) != 0 && (resourceName == "" || !anyGlobMatches(rule.Resources, resourceName)) {
+		return false
+	}
+	return true
+}
+
+// Allows returns whether the role may call the given method. See RoleRule.Matches for a
+// description of the arguments.
+func (role *RoleConfig) Allows(api, service, method, resourceName string) bool {
+	for _, rule := range role.Deny {
+		if rule.Matches(api, service, method, resourceName) {
+			return false
+		}
+	}
+	for _, rule := range role.Allow {
+		if rule.Matches(api, service, method, resourceName) {
+			return true
+		}
+	}
+	return false
+}
+
+// DependsOnResource returns whether the role allowing a call to the given method can depend
+// on the resource the request targets, i.e. whether any rule matching the method restricts
+// resources.
+func (role *RoleConfig) DependsOnResource(api, service, method string) bool {
+	for _, rules := range [][]RoleRule{role.Deny, role.Allow} {
+		for _, rule := range rules {
+			if len(rule.Resources) == 0 {
+				continue
+			}
+			withoutResources := rule
+			withoutResources.Resources = nil
+			if withoutResources.Matches(api, service, method, "") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateRoles ensures roles have unique names, that no entity is bound to more than one
+// role, and that the default role exists.
+func (config *AuthConfig) validateRoles(path string) error {
+	roleNames := make(map[string]struct{}, len(config.Roles))
+	entities := make(map[string]string)
+	for idx, role := range config.Roles {
+		rolePath := fmt.Sprintf("%s.roles.%d", path, idx)
+		if err := role.Validate(rolePath); err != nil {
+			return err
+		}
+		if _, ok := roleNames[role.Name]; ok {
+			return resource.NewConfigValidationError(rolePath, errors.Errorf("duplicate role %q", role.Name))
+		}
+		roleNames[role.Name] = struct{}{}
+		for _, entity := range role.Entities {
+			if other, ok := entities[entity]; ok {
+				return resource.NewConfigValidationError(rolePath,
+					errors.Errorf("entity %q is already bound to role %q", entity, other))
+			}
+			entities[entity] = role.Name
+		}
+	}
+	if config.DefaultRole != "" {
+		if _, ok := roleNames[config.DefaultRole]; !ok {
+			return resource.NewConfigValidationError(fmt.Sprintf("%s.default_role", path),
+				errors.Errorf("unknown role %q", config.DefaultRole))
+		}
+	}
+	return nil
+}
+
+// RoleForEntity returns the role an authenticated entity is bound to, falling back to the
+// default role. It returns nil if the entity is not restricted by any role.
+func (config *AuthConfig) RoleForEntity(entity string) *RoleConfig {
+	var defaultRole *RoleConfig
+	for idx := range config.Roles {
+		role := &config.Roles[idx]
+		for _, e := range role.Entities {
+			if e == entity {
+				return role
+			}
+		}
+		if role.Name == config.DefaultRole {
+			defaultRole = role
+		}
+	}
+	return defaultRole
+}
+
+func validateGlob(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	return nil
+}
+
+func globMatches(pattern, s string) bool {
+	if pattern == "" {
+		return true
+	}
+	// patterns are validated up front so errors are not possible here.
+	matched, _ := path.Match(pattern, s)
+	return matched
+}
+
+func anyGlobMatches(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if globMatches(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestRoleConfig(t *testing.T) {
+	operator := config.RoleConfig{
+		Name:     "operator",
+		Entities: []string{"contractor"},
+		Allow: []config.RoleRule{
+			{Service: "viam.robot.v1.RobotService", Methods: []string{"ResourceNames"}},
+			{API: "rdk:component:camera"},
+			{API: "rdk:component:*", Methods: []string{"Stop"}},
+			{API: "rdk:component:arm", Methods: []string{"Get*"}, Resources: []string{"left_*"}},
+		},
+		Deny: []config.RoleRule{{Resources: []string{"secret_cam"}}},
+	}
+
+	t.Run("allows", func(t *testing.T) {
+		for _, tc := range []struct {
+			api, service, method, resource string
+			allowed                        bool
+		}{
+			{"", "viam.robot.v1.RobotService", "ResourceNames", "", true},
+			{"", "viam.robot.v1.RobotService", "StopAll", "", false},
+			{"rdk:component:camera", "viam.component.camera.v1.CameraService", "GetImages", "cam", true},
+			{"rdk:component:camera", "viam.component.camera.v1.CameraService", "GetImages", "secret_cam", false},
+			{"rdk:component:arm", "viam.component.arm.v1.ArmService", "Stop", "arm", true},
+			{"rdk:component:arm", "viam.component.arm.v1.ArmService", "MoveToPosition", "arm", false},
+			{"rdk:component:arm", "viam.component.arm.v1.ArmService", "GetEndPosition", "left_arm", true},
+			{"rdk:component:arm", "viam.component.arm.v1.ArmService", "GetEndPosition", "right_arm", false},
+			{"rdk:service:shell", "viam.service.shell.v1.ShellService", "Shell", "builtin", false},
+		} {
+			test.That(t, operator.Allows(tc.api, tc.service, tc.method, tc.resource), test.ShouldEqual, tc.allowed)
+		}
+	})
+
+	t.Run("depends on resource", func(t *testing.T) {
+		viewer := config.RoleConfig{
+			Name: "viewer",
+			Allow: []config.RoleRule{
+				{Service: "viam.robot.v1.RobotService"},
+				{API: "rdk:component:arm", Methods: []string{"Get*"}, Resources: []string{"left_*"}},
+			},
+		}
+		test.That(t, viewer.DependsOnResource("", "viam.robot.v1.RobotService", "StreamStatus"), test.ShouldBeFalse)
+		test.That(t, viewer.DependsOnResource("rdk:service:shell", "viam.service.shell.v1.ShellService", "Shell"),
+			test.ShouldBeFalse)
+		test.That(t, viewer.DependsOnResource("rdk:component:arm", "viam.component.arm.v1.ArmService", "GetEndPosition"),
+			test.ShouldBeTrue)
+		test.That(t, operator.DependsOnResource("rdk:service:shell", "viam.service.shell.v1.ShellService", "Shell"),
+			test.ShouldBeTrue)
+	})
+
+	t.Run("role for entity", func(t *testing.T) {
+		auth := config.AuthConfig{Roles: []config.RoleConfig{operator, {Name: "viewer"}}}
+		test.That(t, auth.Validate("auth"), test.ShouldBeNil)
+		test.That(t, auth.RoleForEntity("contractor").Name, test.ShouldEqual, "operator")
+		test.That(t, auth.RoleForEntity("admin"), test.ShouldBeNil)
+
+		auth.DefaultRole = "viewer"
+		test.That(t, auth.Validate("auth"), test.ShouldBeNil)
+		test.That(t, auth.RoleForEntity("admin").Name, test.ShouldEqual, "viewer")
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		auth := config.AuthConfig{Roles: []config.RoleConfig{operator, operator}}
+		err := auth.Validate("auth")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `duplicate role "operator"`)
+
+		auth = config.AuthConfig{Roles: []config.RoleConfig{operator, {Name: "other", Entities: []string{"contractor"}}}}
+		err = auth.Validate("auth")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `entity "contractor" is already bound to role "operator"`)
+
+		auth = config.AuthConfig{Roles: []config.RoleConfig{operator}, DefaultRole: "missing"}
+		err = auth.Validate("auth")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "auth.default_role")
+
+		auth = config.AuthConfig{Roles: []config.RoleConfig{{Name: "bad", Allow: []config.RoleRule{{Methods: []string{"["}}}}}}
+		err = auth.Validate("auth")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "auth.roles.0.allow.0")
+
+		auth = config.AuthConfig{Roles: []config.RoleConfig{{}}}
+		err = auth.Validate("auth")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `Field: "name"`)
+	})
+}
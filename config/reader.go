@@ -272,6 +272,7 @@ func readFromCloud(
 	}
 
 	mergeCloudConfig(cfg)
+	mergeLocalConfig(cfg, originalCfg)
 	unprocessedConfig.Cloud.TLSCertificate = tls.certificate
 	unprocessedConfig.Cloud.TLSPrivateKey = tls.privateKey
 
@@ -281,6 +282,19 @@ func readFromCloud(
 	return cfg, nil
 }
 
+// mergeLocalConfig copies the sections that can only be set in the local config file into a
+// config read from the cloud. The cloud config has no fields for them, so cloud-managed
+// machines read them from the local config file that holds their cloud credentials.
+func mergeLocalConfig(to, local *Config) {
+	to.ConfigHistory = local.ConfigHistory
+	// roles must not be dropped on the way through the cloud, or every entity could call any
+	// method.
+	to.Auth.Roles = local.Auth.Roles
+	to.Auth.DefaultRole = local.Auth.DefaultRole
+	to.Audit = local.Audit
+	to.RateLimits = local.RateLimits
+}
+
 type tlsConfig struct {
 	certificate string
 	privateKey  string
@@ -135,6 +135,59 @@ func TestFromReader(t *testing.T) {
 		test.That(t, gotCfg.Cloud, test.ShouldResemble, &expectedCloud)
 	})
 
+	t.Run("online with local-only sections", func(t *testing.T) {
+		setupClearCache(t)
+
+		fakeServer, cleanup := testutils.NewFakeCloudServer(t, ctx, logger)
+		defer cleanup()
+
+		cloudResponse := &Cloud{
+			SignalingAddress:  "abc",
+			SignalingInsecure: true,
+			ID:                robotPartID,
+			Secret:            secret,
+			FQDN:              "fqdn",
+			LocalFQDN:         "localFqdn",
+		}
+		cloudConfProto, err := CloudConfigToProto(cloudResponse)
+		test.That(t, err, test.ShouldBeNil)
+		fakeServer.StoreDeviceConfig(robotPartID, &pb.RobotConfig{Cloud: cloudConfProto}, &pb.CertificateResponse{})
+
+		appAddress := fmt.Sprintf("http://%s", fakeServer.Addr().String())
+		appConn, err := grpc.NewAppConn(ctx, appAddress, robotPartID, cloudResponse.GetCloudCredsDialOpt(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer appConn.Close()
+		cfgText := fmt.Sprintf(`{
+			"cloud":{"id":%q,"app_address":%q,"secret":%q},
+			"config_history":{"critical_resources":["arm1"]},
+			"auth":{
+				"roles":[{"name":"viewer","entities":["key1"],"allow":[{"methods":["Get*"]}]}],
+				"default_role":"viewer"
+			},
+			"audit":{"apis":["rdk:component:arm"]},
+			"rate_limits":{"limits":[{"methods":["Get*"],"requests_per_second":5}]}
+		}`, robotPartID, appAddress, secret)
+		gotCfg, err := FromReader(ctx, "", strings.NewReader(cfgText), logger, appConn)
+		test.That(t, err, test.ShouldBeNil)
+		defer clearCache(robotPartID)
+
+		// the cloud config cannot carry these sections, so they are read from the local file.
+		test.That(t, gotCfg.ConfigHistory, test.ShouldResemble, &HistoryConfig{
+			Size:              DefaultHistorySize,
+			CriticalResources: []string{"arm1"},
+			RollbackAfter:     DefaultRollbackAfter,
+		})
+		test.That(t, gotCfg.Auth.Roles, test.ShouldResemble, []RoleConfig{
+			{Name: "viewer", Entities: []string{"key1"}, Allow: []RoleRule{{Methods: []string{"Get*"}}}},
+		})
+		test.That(t, gotCfg.Auth.DefaultRole, test.ShouldEqual, "viewer")
+		test.That(t, gotCfg.Audit, test.ShouldNotBeNil)
+		test.That(t, gotCfg.Audit.APIs, test.ShouldResemble, []string{"rdk:component:arm"})
+		test.That(t, gotCfg.RateLimits, test.ShouldNotBeNil)
+		test.That(t, gotCfg.RateLimits.Limits, test.ShouldHaveLength, 1)
+		test.That(t, gotCfg.RateLimits.Limits[0].Burst, test.ShouldEqual, 5)
+	})
+
 	t.Run("online with insecure signaling", func(t *testing.T) {
 		setupClearCache(t)
 
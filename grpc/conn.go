@@ -90,6 +90,14 @@ func (c *ReconfigurableClientConn) ReplaceConn(conn rpc.ClientConn) {
 	c.connMu.Unlock()
 }
 
+// Conn returns the underlying client connection, which changes whenever it is replaced, such as
+// on reconnect. Nil if there is none.
+func (c *ReconfigurableClientConn) Conn() rpc.ClientConn {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn
+}
+
 // PeerConn returns the backing PeerConnection object, if applicable. Nil otherwise.
 func (c *ReconfigurableClientConn) PeerConn() *webrtc.PeerConnection {
 	c.connMu.Lock()
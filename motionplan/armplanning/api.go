@@ -180,6 +180,11 @@ type PlanMeta struct {
 	Partial        bool
 	PartialError   error
 	GoalsProcessed int
+	// CacheHit is set when the plan was reused from a PlanCache rather than planned.
+	CacheHit bool
+
+	// goalSteps holds, for each goal reached, the index of the step of the trajectory reaching it.
+	goalSteps []int
 }
 
 // PlanMotion plans a motion from a provided plan request.
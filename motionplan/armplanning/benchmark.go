@@ -0,0 +1,364 @@
+package armplanning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+)
+
+const (
+	defaultBenchmarkRuns = 10
+
+	defaultSuccessRateTolerance = 0.05
+	defaultTimeTolerance        = 0.25
+	defaultCostTolerance        = 0.1
+)
+
+// BenchmarkScenario is a motion which is planned many times over to benchmark the planner.
+type BenchmarkScenario struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Request     *PlanRequest `json:"request,omitempty"`
+	// RequestFile is a json file holding the request, relative to the scenario file, so that
+	// scenarios can share the requests tests plan. It is only read if Request is not set.
+	RequestFile string `json:"request_file,omitempty"`
+}
+
+// ReadBenchmarkScenario reads a scenario from a json file. The file may hold a BenchmarkScenario or,
+// like the files cmd-plan reads, just a PlanRequest, in which case the scenario is named after the
+// file.
+func ReadBenchmarkScenario(fileName string) (*BenchmarkScenario, error) {
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.Wrapf(err, "reading benchmark scenario %s", fileName)
+	}
+
+	scenario := &BenchmarkScenario{}
+	_, hasRequest := fields["request"]
+	_, hasRequestFile := fields["request_file"]
+	if hasRequest || hasRequestFile {
+		err = json.Unmarshal(data, scenario)
+	} else {
+		scenario.Request = &PlanRequest{}
+		err = json.Unmarshal(data, scenario.Request)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading benchmark scenario %s", fileName)
+	}
+	if scenario.Name == "" {
+		scenario.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	if scenario.Request == nil && scenario.RequestFile != "" {
+		requestFile := scenario.RequestFile
+		if !filepath.IsAbs(requestFile) {
+			requestFile = filepath.Join(filepath.Dir(fileName), requestFile)
+		}
+		if scenario.Request, err = ReadRequestFromFile(requestFile); err != nil {
+			return nil, errors.Wrapf(err, "reading request of benchmark scenario %s", fileName)
+		}
+	}
+	if scenario.Request == nil {
+		return nil, errors.Errorf("benchmark scenario %s has no request", fileName)
+	}
+	return scenario, nil
+}
+
+// ReadBenchmarkScenarios reads scenarios from json files, and from every json file in any directory
+// given.
+func ReadBenchmarkScenarios(paths ...string) ([]*BenchmarkScenario, error) {
+	scenarios := []*BenchmarkScenario{}
+	for _, path := range paths {
+		fileNames := []string{path}
+		if info, err := os.Stat(path); err != nil {
+			return nil, err
+		} else if info.IsDir() {
+			if fileNames, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+				return nil, err
+			}
+		}
+		for _, fileName := range fileNames {
+			scenario, err := ReadBenchmarkScenario(fileName)
+			if err != nil {
+				return nil, err
+			}
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	return scenarios, nil
+}
+
+// WriteToFile writes a scenario to a json file.
+func (scenario *BenchmarkScenario) WriteToFile(fileName string) error {
+	return writeJSONFile(fileName, scenario)
+}
+
+// BenchmarkOptions describe how scenarios are benchmarked.
+type BenchmarkOptions struct {
+	// Runs is how many times each scenario is planned. Zero uses a default.
+	Runs int
+	// FirstSeed is the random seed the first run of each scenario is planned with. Each run after
+	// uses the next seed, so that a benchmark is repeatable.
+	FirstSeed int
+}
+
+// BenchmarkRun is the outcome of planning a scenario once.
+type BenchmarkRun struct {
+	Seed     int     `json:"seed"`
+	Error    string  `json:"error,omitempty"`
+	TimeSec  float64 `json:"time_sec"`
+	PathMM   float64 `json:"path_mm,omitempty"`
+	Distance float64 `json:"joint_distance,omitempty"`
+}
+
+// BenchmarkResult summarizes the runs of one scenario. Times are of all runs, failed or not, while
+// the path length and joint travel are averaged over the runs which succeeded.
+type BenchmarkResult struct {
+	Scenario    string  `json:"scenario"`
+	Runs        int     `json:"runs"`
+	Successes   int     `json:"successes"`
+	SuccessRate float64 `json:"success_rate"`
+	TimeP50Sec  float64 `json:"time_p50_sec"`
+	TimeP90Sec  float64 `json:"time_p90_sec"`
+	TimeP99Sec  float64 `json:"time_p99_sec"`
+	TimeMaxSec  float64 `json:"time_max_sec"`
+	// PathLengthMM is the distance travelled by every frame along the planned path.
+	PathLengthMM float64 `json:"path_length_mm"`
+	// JointTravel is the sum over every step of the plan of the L2 distance between the inputs of
+	// each frame.
+	JointTravel float64        `json:"joint_travel"`
+	RunDetails  []BenchmarkRun `json:"run_details,omitempty"`
+}
+
+// BenchmarkReport holds the results of benchmarking a set of scenarios.
+type BenchmarkReport struct {
+	Created time.Time         `json:"created"`
+	Runs    int               `json:"runs"`
+	Results []BenchmarkResult `json:"results"`
+}
+
+// RunBenchmark plans each scenario a number of times with different seeds and reports how well the
+// planner did. An error is only returned if the benchmark itself cannot be run, and failing to plan
+// counts against the success rate of the scenario.
+func RunBenchmark(
+	ctx context.Context,
+	logger logging.Logger,
+	scenarios []*BenchmarkScenario,
+	opts BenchmarkOptions,
+) (*BenchmarkReport, error) {
+	if opts.Runs <= 0 {
+		opts.Runs = defaultBenchmarkRuns
+	}
+	report := &BenchmarkReport{Created: time.Now(), Runs: opts.Runs}
+	for _, scenario := range scenarios {
+		// build the seed cache up front so that it is not counted against the first run
+		if err := PrepSmartSeed(scenario.Request.FrameSystem, logger); err != nil {
+			return nil, errors.Wrapf(err, "preparing benchmark scenario %s", scenario.Name)
+		}
+		runs := make([]BenchmarkRun, 0, opts.Runs)
+		for i := 0; i < opts.Runs; i++ {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			run := runBenchmarkScenario(ctx, logger, scenario, opts.FirstSeed+i)
+			logger.Debugf("benchmark scenario %s seed %d took %0.3fs, error: %s", scenario.Name, run.Seed, run.TimeSec, run.Error)
+			runs = append(runs, run)
+		}
+		report.Results = append(report.Results, summarizeBenchmarkRuns(scenario.Name, runs))
+	}
+	return report, nil
+}
+
+func runBenchmarkScenario(ctx context.Context, logger logging.Logger, scenario *BenchmarkScenario, seed int) BenchmarkRun {
+	// plan with a copy of the request so that the seed is not left set on the scenario
+	req := *scenario.Request
+	if req.PlannerOptions == nil {
+		req.PlannerOptions = NewBasicPlannerOptions()
+	} else {
+		plannerOptions := *req.PlannerOptions
+		req.PlannerOptions = &plannerOptions
+	}
+	req.PlannerOptions.RandomSeed = seed
+
+	run := BenchmarkRun{Seed: seed}
+	start := time.Now()
+	plan, _, err := PlanMotion(ctx, logger, &req)
+	run.TimeSec = time.Since(start).Seconds()
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+	run.PathMM, run.Distance = planCosts(plan)
+	return run
+}
+
+// planCosts returns the distance travelled by every frame along a plan's path, and the L2 distance
+// travelled by the inputs of every frame along its trajectory.
+func planCosts(plan motionplan.Plan) (float64, float64) {
+	var pathMM, distance float64
+	path, traj := plan.Path(), plan.Trajectory()
+	for i := 1; i < len(path); i++ {
+		for name, pose := range path[i] {
+			if previous, ok := path[i-1][name]; ok {
+				pathMM += pose.Pose().Point().Distance(previous.Pose().Point())
+			}
+		}
+	}
+	for i := 1; i < len(traj); i++ {
+		for name, inputs := range traj[i] {
+			if previous, ok := traj[i-1][name]; ok && len(inputs) == len(previous) {
+				distance += referenceframe.InputsL2Distance(previous, inputs)
+			}
+		}
+	}
+	return pathMM, distance
+}
+
+func summarizeBenchmarkRuns(scenario string, runs []BenchmarkRun) BenchmarkResult {
+	result := BenchmarkResult{Scenario: scenario, Runs: len(runs), RunDetails: runs}
+	times := make([]float64, 0, len(runs))
+	for _, run := range runs {
+		times = append(times, run.TimeSec)
+		if run.Error != "" {
+			continue
+		}
+		result.Successes++
+		result.PathLengthMM += run.PathMM
+		result.JointTravel += run.Distance
+	}
+	if result.Successes > 0 {
+		result.PathLengthMM /= float64(result.Successes)
+		result.JointTravel /= float64(result.Successes)
+	}
+	if len(runs) > 0 {
+		result.SuccessRate = float64(result.Successes) / float64(len(runs))
+	}
+	slices.Sort(times)
+	result.TimeP50Sec = percentile(times, 0.5)
+	result.TimeP90Sec = percentile(times, 0.9)
+	result.TimeP99Sec = percentile(times, 0.99)
+	result.TimeMaxSec = percentile(times, 1)
+	return result
+}
+
+// percentile returns the nearest rank percentile of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
+
+// ReadBenchmarkReport reads a report written by WriteToFile, such as a baseline to compare against.
+func ReadBenchmarkReport(fileName string) (*BenchmarkReport, error) {
+	data, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, err
+	}
+	report := &BenchmarkReport{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, errors.Wrapf(err, "reading benchmark report %s", fileName)
+	}
+	return report, nil
+}
+
+// WriteToFile writes a report to a json file.
+func (report *BenchmarkReport) WriteToFile(fileName string) error {
+	return writeJSONFile(fileName, report)
+}
+
+// BenchmarkTolerances are how much worse than a baseline a benchmark may do before it is a
+// regression. Zero values use defaults.
+type BenchmarkTolerances struct {
+	// SuccessRate is how much lower the success rate of a scenario may be.
+	SuccessRate float64
+	// Time is the fraction by which the 90th percentile planning time of a scenario may grow.
+	Time float64
+	// Cost is the fraction by which the mean path length and joint travel of a scenario may grow.
+	Cost float64
+}
+
+// BenchmarkRegression is a metric of a scenario which is worse than its baseline.
+type BenchmarkRegression struct {
+	Scenario string
+	Metric   string
+	Baseline float64
+	Current  float64
+}
+
+func (r BenchmarkRegression) String() string {
+	return fmt.Sprintf("%s: %s regressed from %0.4f to %0.4f", r.Scenario, r.Metric, r.Baseline, r.Current)
+}
+
+// Compare returns the metrics of each scenario which have regressed from those of a baseline by more
+// than the tolerances. Scenarios which are not in the baseline are not compared.
+func (report *BenchmarkReport) Compare(baseline *BenchmarkReport, tolerances BenchmarkTolerances) []BenchmarkRegression {
+	if tolerances.SuccessRate <= 0 {
+		tolerances.SuccessRate = defaultSuccessRateTolerance
+	}
+	if tolerances.Time <= 0 {
+		tolerances.Time = defaultTimeTolerance
+	}
+	if tolerances.Cost <= 0 {
+		tolerances.Cost = defaultCostTolerance
+	}
+
+	baselines := map[string]BenchmarkResult{}
+	for _, result := range baseline.Results {
+		baselines[result.Scenario] = result
+	}
+	var regressions []BenchmarkRegression
+	for _, result := range report.Results {
+		base, ok := baselines[result.Scenario]
+		if !ok {
+			continue
+		}
+		check := func(metric string, baseline, current float64, worse bool) {
+			if worse {
+				regressions = append(regressions, BenchmarkRegression{
+					Scenario: result.Scenario,
+					Metric:   metric,
+					Baseline: baseline,
+					Current:  current,
+				})
+			}
+		}
+		check("success rate", base.SuccessRate, result.SuccessRate, result.SuccessRate < base.SuccessRate-tolerances.SuccessRate)
+		check("p90 planning time", base.TimeP90Sec, result.TimeP90Sec, result.TimeP90Sec > base.TimeP90Sec*(1+tolerances.Time))
+		// costs are only comparable if both have plans to compare
+		if base.Successes > 0 && result.Successes > 0 {
+			check("path length", base.PathLengthMM, result.PathLengthMM, result.PathLengthMM > base.PathLengthMM*(1+tolerances.Cost))
+			check("joint travel", base.JointTravel, result.JointTravel, result.JointTravel > base.JointTravel*(1+tolerances.Cost))
+		}
+	}
+	return regressions
+}
+
+func writeJSONFile(fileName string, v interface{}) error {
+	file, err := os.OpenFile(filepath.Clean(fileName), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer utils.UncheckedErrorFunc(file.Close)
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
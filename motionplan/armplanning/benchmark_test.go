@@ -0,0 +1,133 @@
+package armplanning
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+func TestBenchmark(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/ur5e.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	dir := t.TempDir()
+	scenario := &BenchmarkScenario{
+		Name:        "ur5e joint move",
+		Description: "a short move between two configurations",
+		Request: &PlanRequest{
+			FrameSystem: fs,
+			StartState:  NewPlanState(nil, referenceframe.FrameSystemInputs{"arm": {0, -1.2, 1.5, -1.9, -1.57, 0}}),
+			Goals:       []*PlanState{NewPlanState(nil, referenceframe.FrameSystemInputs{"arm": {0.5, -1, 1.3, -1.9, -1.57, 0}})},
+		},
+	}
+	test.That(t, scenario.WriteToFile(filepath.Join(dir, "scenario.json")), test.ShouldBeNil)
+	test.That(t, scenario.Request.WriteToFile(filepath.Join(dir, "request.json")), test.ShouldBeNil)
+
+	scenarios, err := ReadBenchmarkScenarios(dir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, scenarios, test.ShouldHaveLength, 2)
+	// a plain request is named after its file
+	test.That(t, scenarios[0].Name, test.ShouldEqual, "request")
+	test.That(t, scenarios[1].Name, test.ShouldEqual, "ur5e joint move")
+	test.That(t, scenarios[1].Description, test.ShouldEqual, scenario.Description)
+
+	report, err := RunBenchmark(ctx, logger, scenarios[1:], BenchmarkOptions{Runs: 3, FirstSeed: 7})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, report.Results, test.ShouldHaveLength, 1)
+	result := report.Results[0]
+	test.That(t, result.Scenario, test.ShouldEqual, "ur5e joint move")
+	test.That(t, result.Runs, test.ShouldEqual, 3)
+	test.That(t, result.SuccessRate, test.ShouldEqual, 1)
+	test.That(t, result.PathLengthMM, test.ShouldBeGreaterThan, 0)
+	test.That(t, result.JointTravel, test.ShouldBeGreaterThanOrEqualTo, referenceframe.InputsL2Distance(
+		scenario.Request.StartState.Configuration()["arm"],
+		scenario.Request.Goals[0].Configuration()["arm"],
+	)-1e-6)
+	test.That(t, result.TimeP50Sec, test.ShouldBeLessThanOrEqualTo, result.TimeMaxSec)
+	for i, run := range result.RunDetails {
+		test.That(t, run.Seed, test.ShouldEqual, 7+i)
+	}
+	// the seeds are not left on the scenario
+	test.That(t, scenarios[1].Request.PlannerOptions, test.ShouldBeNil)
+
+	reportFile := filepath.Join(dir, "report.json.out")
+	test.That(t, report.WriteToFile(reportFile), test.ShouldBeNil)
+	baseline, err := ReadBenchmarkReport(reportFile)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, baseline.Results, test.ShouldResemble, report.Results)
+	test.That(t, report.Compare(baseline, BenchmarkTolerances{}), test.ShouldBeEmpty)
+}
+
+func TestBenchmarkCompare(t *testing.T) {
+	baseline := &BenchmarkReport{Results: []BenchmarkResult{
+		{Scenario: "a", Successes: 10, SuccessRate: 1, TimeP90Sec: 1, PathLengthMM: 100, JointTravel: 1},
+		{Scenario: "b", Successes: 10, SuccessRate: 1, TimeP90Sec: 1, PathLengthMM: 100, JointTravel: 1},
+	}}
+
+	t.Run("within tolerance", func(t *testing.T) {
+		report := &BenchmarkReport{Results: []BenchmarkResult{
+			{Scenario: "a", Successes: 10, SuccessRate: 0.96, TimeP90Sec: 1.2, PathLengthMM: 105, JointTravel: 0.5},
+			{Scenario: "new", SuccessRate: 0},
+		}}
+		test.That(t, report.Compare(baseline, BenchmarkTolerances{}), test.ShouldBeEmpty)
+	})
+
+	t.Run("regressions", func(t *testing.T) {
+		report := &BenchmarkReport{Results: []BenchmarkResult{
+			{Scenario: "a", Successes: 8, SuccessRate: 0.8, TimeP90Sec: 2, PathLengthMM: 150, JointTravel: 1},
+			{Scenario: "b", Successes: 0, SuccessRate: 0, TimeP90Sec: 1},
+		}}
+		regressions := report.Compare(baseline, BenchmarkTolerances{})
+		test.That(t, regressions, test.ShouldResemble, []BenchmarkRegression{
+			{Scenario: "a", Metric: "success rate", Baseline: 1, Current: 0.8},
+			{Scenario: "a", Metric: "p90 planning time", Baseline: 1, Current: 2},
+			{Scenario: "a", Metric: "path length", Baseline: 100, Current: 150},
+			{Scenario: "b", Metric: "success rate", Baseline: 1, Current: 0},
+		})
+		test.That(t, regressions[0].String(), test.ShouldEqual, "a: success rate regressed from 1.0000 to 0.8000")
+
+		// looser tolerances let them through
+		test.That(t, report.Compare(baseline, BenchmarkTolerances{SuccessRate: 1, Time: 1.5, Cost: 0.6}), test.ShouldBeEmpty)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	test.That(t, percentile(nil, 0.5), test.ShouldEqual, 0)
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	test.That(t, percentile(values, 0), test.ShouldEqual, 1)
+	test.That(t, percentile(values, 0.5), test.ShouldEqual, 5)
+	test.That(t, percentile(values, 0.9), test.ShouldEqual, 9)
+	test.That(t, percentile(values, 0.99), test.ShouldEqual, 10)
+	test.That(t, percentile(values, 1), test.ShouldEqual, 10)
+}
+
+func TestBenchmarkScenarios(t *testing.T) {
+	scenarios, err := ReadBenchmarkScenarios("testdata/benchmark")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, scenarios, test.ShouldNotBeEmpty)
+	names := map[string]bool{}
+	for _, scenario := range scenarios {
+		test.That(t, names[scenario.Name], test.ShouldBeFalse)
+		names[scenario.Name] = true
+		test.That(t, scenario.Description, test.ShouldNotBeEmpty)
+		// the request is read from the file the scenario points to
+		test.That(t, scenario.RequestFile, test.ShouldNotBeEmpty)
+		test.That(t, scenario.Request, test.ShouldNotBeNil)
+		test.That(t, scenario.Request.FrameSystem, test.ShouldNotBeNil)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "scenario.json")
+	test.That(t, (&BenchmarkScenario{Name: "missing", RequestFile: "missing.json"}).WriteToFile(fileName), test.ShouldBeNil)
+	_, err = ReadBenchmarkScenario(fileName)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,119 @@
+// package main benchmarks armplanning against a set of scenarios
+//
+// The scenarios in motionplan/armplanning/testdata/benchmark are the requests planned by the tests
+// in real_test.go. Each scenario file names a request in motionplan/armplanning/data. To benchmark
+// a change to the planner against them, build with nlopt (so without the no_cgo tag) and, from
+// motionplan/armplanning, run
+//
+//	go run ./cmd-benchmark -baseline testdata/benchmark-baseline.json testdata/benchmark
+//
+// which exits with an error if any scenario regressed past the tolerances. Planning times depend on
+// the machine, so compare against a baseline made on the same machine: run the benchmark on the
+// commit before the change with -o to write a fresh baseline, then on the change with -baseline.
+// When a change is meant to move the numbers, update the committed baseline with
+//
+//	go run ./cmd-benchmark -runs 20 -o testdata/benchmark-baseline.json testdata/benchmark
+//
+// On machines with a single cpu MP_NUM_THREADS must be set to at least 1.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan/armplanning"
+)
+
+func main() {
+	err := realMain()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func realMain() error {
+	ctx := context.Background()
+	logger, reg := logging.NewLoggerWithRegistry("cmd-benchmark")
+
+	runs := flag.Int("runs", 10, "how many times to plan each scenario, each with a different seed")
+	seed := flag.Int("seed", 0, "seed of the first run of each scenario")
+	baselineFile := flag.String("baseline", "", "report to compare against, exiting with an error on regressions")
+	outputFile := flag.String("o", "", "json file to write the report to, such as a new baseline")
+	successTolerance := flag.Float64("success-tolerance", 0, "how much lower the success rate may be than the baseline")
+	timeTolerance := flag.Float64("time-tolerance", 0, "fraction by which p90 planning time may exceed the baseline")
+	costTolerance := flag.Float64("cost-tolerance", 0, "fraction by which path length and joint travel may exceed the baseline")
+	verbose := flag.Bool("v", false, "verbose")
+
+	flag.Parse()
+
+	if len(flag.Args()) == 0 {
+		return errors.New("need scenario json files or directories of them")
+	}
+
+	// planning logs every run, which drowns out the report unless asked for
+	if !*verbose {
+		reg.Update([]logging.LoggerPatternConfig{
+			{
+				Pattern: "*.mp*",
+				Level:   "WARN",
+			},
+		}, logger)
+	}
+
+	scenarios, err := armplanning.ReadBenchmarkScenarios(flag.Args()...)
+	if err != nil {
+		return err
+	}
+	logger.Infof("benchmarking %d scenarios with %d runs each", len(scenarios), *runs)
+
+	report, err := armplanning.RunBenchmark(ctx, logger, scenarios, armplanning.BenchmarkOptions{Runs: *runs, FirstSeed: *seed})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "scenario\tsuccess\tp50 (s)\tp90 (s)\tp99 (s)\tmax (s)\tpath (mm)\tjoint travel\t")
+	for _, result := range report.Results {
+		fmt.Fprintf(w, "%s\t%d/%d\t%0.3f\t%0.3f\t%0.3f\t%0.3f\t%0.1f\t%0.3f\t\n",
+			result.Scenario, result.Successes, result.Runs,
+			result.TimeP50Sec, result.TimeP90Sec, result.TimeP99Sec, result.TimeMaxSec,
+			result.PathLengthMM, result.JointTravel)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if *outputFile != "" {
+		if err := report.WriteToFile(*outputFile); err != nil {
+			return err
+		}
+		logger.Infof("wrote report to %s", *outputFile)
+	}
+
+	if *baselineFile == "" {
+		return nil
+	}
+	baseline, err := armplanning.ReadBenchmarkReport(*baselineFile)
+	if err != nil {
+		return err
+	}
+	regressions := report.Compare(baseline, armplanning.BenchmarkTolerances{
+		SuccessRate: *successTolerance,
+		Time:        *timeTolerance,
+		Cost:        *costTolerance,
+	})
+	for _, regression := range regressions {
+		fmt.Println(regression)
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%d regressions against baseline %s", len(regressions), *baselineFile)
+	}
+	fmt.Printf("no regressions against baseline %s\n", *baselineFile)
+	return nil
+}
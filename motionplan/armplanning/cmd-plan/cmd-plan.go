@@ -61,6 +61,7 @@ func realMain() error {
 	waypointsFile := flag.String("output-waypoints", "", "json file to output waypoints")
 	showPoses := flag.Bool("show-poses", false, "show shadows at each path position")
 	tryManySeeds := flag.Int("try-many-seeds", 1, "try planning with more seeds and report L2 distances")
+	exportFile := flag.String("export", "", "file to export an animation of the plan to, as .gltf or .html")
 
 	flag.Parse()
 
@@ -157,6 +158,12 @@ func realMain() error {
 		logger.Errorw("Got error while shutting down tracing", "err", err)
 	}
 	metricsExporter.Stop()
+	if *exportFile != "" && plan != nil {
+		if err := exportPlan(req, plan, *exportFile); err != nil {
+			return err
+		}
+		mylog.Printf("exported plan to %s", *exportFile)
+	}
 	if *interactive {
 		if interactiveErr := doInteractive(req, plan, err, mylog, *showPoses); interactiveErr != nil {
 			logger.Fatal("Interactive mode failed:", interactiveErr)
@@ -427,6 +434,32 @@ func visualize(req *armplanning.PlanRequest, plan motionplan.Plan, mylog *log.Lo
 	return nil
 }
 
+// exportPlan writes an animation of the plan to a glTF or HTML file, depending on its extension.
+func exportPlan(req *armplanning.PlanRequest, plan motionplan.Plan, fileName string) error {
+	opts := motionplan.SceneExportOptions{Title: filepath.Base(flag.Arg(0))}
+	if req.Constraints != nil {
+		opts.CollisionSpecifications = req.Constraints.CollisionSpecification
+	}
+	if req.PlannerOptions != nil {
+		opts.CollisionBufferMM = req.PlannerOptions.CollisionBufferMM
+	}
+	export := motionplan.ExportSceneGLTF
+	switch ext := filepath.Ext(fileName); ext {
+	case ".gltf":
+	case ".html":
+		export = motionplan.ExportSceneHTML
+	default:
+		return fmt.Errorf("cannot export a plan to a %q file, only .gltf or .html", ext)
+	}
+
+	file, err := os.Create(filepath.Clean(fileName))
+	if err != nil {
+		return err
+	}
+	defer utils.UncheckedErrorFunc(file.Close)
+	return export(file, req.FrameSystem, req.WorldState, plan.Trajectory(), opts)
+}
+
 func drawGoalPoses(req *armplanning.PlanRequest) error {
 	var goalPoses []spatialmath.Pose
 	for _, goalPlanState := range req.Goals {
@@ -0,0 +1,421 @@
+package armplanning
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/trace"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// defaultCoordinatedStepFraction is the fraction of an input's range it may move in one tick of a
+// coordinated plan.
+const defaultCoordinatedStepFraction = 0.002
+
+// CoordinatedPlanRequest is a request to move several components at once, each to its own goal.
+type CoordinatedPlanRequest struct {
+	FrameSystem *referenceframe.FrameSystem `json:"frame_system"`
+	// The configuration all components start from.
+	StartState *PlanState `json:"start_state"`
+	// The goal of each component to move, as either a pose or a configuration of that component.
+	Goal *PlanState `json:"goal"`
+	// The order in which components get right of way when their paths cross; a component waits for
+	// those before it. Components not listed come after those that are, in order of name.
+	Priority []string `json:"priority"`
+	// The data representation of the robot's environment.
+	WorldState *referenceframe.WorldState `json:"world_state"`
+	// Additional parameters constraining the motion of each component.
+	Constraints *motionplan.Constraints `json:"constraints"`
+	// Other more granular parameters for the plan used to move each component.
+	PlannerOptions *PlannerOptions `json:"planner_options"`
+	// The fraction of its range that any input may move in one tick of the plan. Defaults to 0.002.
+	StepFraction float64 `json:"step_fraction"`
+}
+
+// coordinatedGroup is the motion of one component of a coordinated plan.
+type coordinatedGroup struct {
+	name   string
+	goal   *PlanState
+	inputs []string // the frames whose inputs this component moves
+	frames []string // the frames with geometries which this component moves
+	path   []*referenceframe.LinearInputs
+	// geometries of frames, in world, at each step of path.
+	geometries [][]spatialmath.Geometry
+	progress   int
+	replanned  bool
+}
+
+func (g *coordinatedGroup) done() bool {
+	return g.progress == len(g.path)-1
+}
+
+// PlanCoordinated plans for several components to move at once, each to its own goal, such that they
+// never collide with each other. Each component's path is planned on its own, and then the paths are
+// laid along a shared timeline in which every step of the resulting plan is one tick of time for all
+// components, so that executing the steps in lock-step keeps the components apart. When paths cross, a
+// component waits for those with priority over it to pass. If a component cannot go on once those with
+// priority have finished, its path is planned again from where it waits.
+func PlanCoordinated(ctx context.Context, logger logging.Logger, request *CoordinatedPlanRequest) (motionplan.Plan, error) {
+	ctx, span := trace.StartSpan(ctx, "PlanCoordinated")
+	defer span.End()
+
+	if request == nil || request.FrameSystem == nil {
+		return nil, errors.New("CoordinatedPlanRequest must have a framesystem")
+	}
+	if request.StartState == nil || len(request.StartState.Configuration()) == 0 {
+		return nil, errors.New("CoordinatedPlanRequest must have a start configuration")
+	}
+	if request.Goal == nil {
+		return nil, errors.New("CoordinatedPlanRequest must have a goal")
+	}
+	stepFraction := request.StepFraction
+	if stepFraction < 0 || stepFraction > 1 {
+		return nil, fmt.Errorf("step_fraction must be between 0 and 1, got %v", stepFraction)
+	}
+	if stepFraction == 0 {
+		stepFraction = defaultCoordinatedStepFraction
+	}
+	planOpts := request.PlannerOptions
+	if planOpts == nil {
+		planOpts = NewBasicPlannerOptions()
+	}
+
+	groups, err := coordinatedGroups(request.Goal, request.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &coordinatedPlanner{
+		request:  request,
+		fs:       request.FrameSystem,
+		planOpts: planOpts,
+		steps:    make(map[string][]float64),
+		groups:   groups,
+		logger:   logger,
+	}
+	start := request.StartState.LinearConfiguration()
+	for name, inputs := range start.Items() {
+		f := cp.fs.Frame(name)
+		if f == nil {
+			return nil, referenceframe.NewFrameMissingError(name)
+		}
+		steps := make([]float64, len(inputs))
+		for i, limit := range f.DoF() {
+			_, _, r := limit.GoodLimits()
+			if limit.IsRotational() && r > 2*math.Pi {
+				r = 2 * math.Pi
+			}
+			steps[i] = r * stepFraction
+		}
+		cp.steps[name] = steps
+	}
+
+	for _, g := range groups {
+		if err := cp.planGroup(ctx, g, start); err != nil {
+			return nil, fmt.Errorf("cannot plan for %s: %w", g.name, err)
+		}
+	}
+	if err := cp.checkIndependent(); err != nil {
+		return nil, err
+	}
+	if err := cp.findAllowedCollisions(); err != nil {
+		return nil, err
+	}
+
+	traj, err := cp.schedule(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+	return motionplan.NewSimplePlanFromTrajectory(traj, cp.fs)
+}
+
+// coordinatedGroups splits a goal into the goals of each component, in order of priority.
+func coordinatedGroups(goal *PlanState, priority []string) ([]*coordinatedGroup, error) {
+	byName := map[string]*coordinatedGroup{}
+	for name, pif := range goal.Poses() {
+		byName[name] = &coordinatedGroup{name: name, goal: NewPlanState(referenceframe.FrameSystemPoses{name: pif}, nil)}
+	}
+	for name, inputs := range goal.Configuration() {
+		if _, ok := byName[name]; ok {
+			return nil, fmt.Errorf("goal of %s cannot have both a pose and a configuration", name)
+		}
+		byName[name] = &coordinatedGroup{name: name, goal: NewPlanState(nil, referenceframe.FrameSystemInputs{name: inputs})}
+	}
+	if len(byName) == 0 {
+		return nil, errors.New("coordinated goal must have a pose or configuration for some component")
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rank := func(name string) int {
+		if i := slices.Index(priority, name); i >= 0 {
+			return i
+		}
+		return len(priority)
+	}
+	sort.SliceStable(names, func(i, j int) bool { return rank(names[i]) < rank(names[j]) })
+
+	groups := make([]*coordinatedGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, byName[name])
+	}
+	return groups, nil
+}
+
+type coordinatedPlanner struct {
+	request  *CoordinatedPlanRequest
+	fs       *referenceframe.FrameSystem
+	planOpts *PlannerOptions
+	steps    map[string][]float64 // the most each input may move in a tick
+	groups   []*coordinatedGroup
+	// pairs of geometry labels that collide at the start and so are allowed to.
+	allowed map[[2]string]bool
+	logger  logging.Logger
+}
+
+// planGroup plans the path of a component on its own, from the given configuration of all
+// components, and breaks it into ticks.
+func (cp *coordinatedPlanner) planGroup(ctx context.Context, g *coordinatedGroup, from *referenceframe.LinearInputs) error {
+	// a goal configuration leaves the other components where they are.
+	goal := g.goal
+	if len(goal.Configuration()) > 0 {
+		conf := from.ToFrameSystemInputs()
+		for name, inputs := range goal.Configuration() {
+			conf[name] = inputs
+		}
+		goal = NewPlanState(nil, conf)
+	}
+	planOpts := *cp.planOpts
+	plan, _, err := PlanMotion(ctx, cp.logger, &PlanRequest{
+		FrameSystem:    cp.fs,
+		StartState:     NewPlanState(nil, from.ToFrameSystemInputs()),
+		Goals:          []*PlanState{goal},
+		WorldState:     cp.request.WorldState,
+		Constraints:    cp.request.Constraints,
+		PlannerOptions: &planOpts,
+	})
+	if err != nil {
+		return err
+	}
+
+	coarse := make([]*referenceframe.LinearInputs, 0, len(plan.Trajectory()))
+	for _, step := range plan.Trajectory() {
+		coarse = append(coarse, step.ToLinearInputs())
+	}
+	for name, inputs := range from.Items() {
+		if !slices.Contains(g.inputs, name) && slices.ContainsFunc(coarse, func(step *referenceframe.LinearInputs) bool {
+			return !slices.Equal(step.Get(name), inputs)
+		}) {
+			g.inputs = append(g.inputs, name)
+		}
+	}
+
+	g.path = []*referenceframe.LinearInputs{from.Copy()}
+	for i := 1; i < len(coarse); i++ {
+		prev, next := coarse[i-1], coarse[i]
+		ticks := 1
+		for _, name := range g.inputs {
+			for j, step := range cp.steps[name] {
+				if step > 0 {
+					ticks = max(ticks, int(math.Ceil(math.Abs(next.Get(name)[j]-prev.Get(name)[j])/step)))
+				}
+			}
+		}
+		for tick := 1; tick <= ticks; tick++ {
+			conf := from.Copy()
+			for _, name := range g.inputs {
+				inputs, err := cp.fs.Frame(name).Interpolate(prev.Get(name), next.Get(name), float64(tick)/float64(ticks))
+				if err != nil {
+					return err
+				}
+				conf.Put(name, inputs)
+			}
+			g.path = append(g.path, conf)
+		}
+	}
+	g.progress = 0
+	return cp.computeGeometries(g)
+}
+
+// computeGeometries finds the frames a component moves and places their geometries along its path.
+func (cp *coordinatedPlanner) computeGeometries(g *coordinatedGroup) error {
+	g.frames = nil
+	for _, name := range cp.fs.FrameNames() {
+		chain, err := cp.fs.TracebackFrame(cp.fs.Frame(name))
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(chain, func(f referenceframe.Frame) bool { return slices.Contains(g.inputs, f.Name()) }) {
+			g.frames = append(g.frames, name)
+		}
+	}
+
+	g.geometries = make([][]spatialmath.Geometry, 0, len(g.path))
+	for _, conf := range g.path {
+		geometries := []spatialmath.Geometry{}
+		for _, name := range g.frames {
+			gif, err := cp.fs.Frame(name).Geometries(conf.Get(name))
+			if err != nil {
+				return err
+			}
+			if len(gif.Geometries()) == 0 {
+				continue
+			}
+			tf, err := cp.fs.Transform(conf, gif, referenceframe.World)
+			if err != nil {
+				return err
+			}
+			geometries = append(geometries, tf.(*referenceframe.GeometriesInFrame).Geometries()...)
+		}
+		g.geometries = append(g.geometries, geometries)
+	}
+	return nil
+}
+
+// checkIndependent ensures no two components move the same frames, such as an arm mounted on
+// another, as their motions could not then be laid out independently.
+func (cp *coordinatedPlanner) checkIndependent() error {
+	owner := map[string]string{}
+	for _, g := range cp.groups {
+		for _, name := range g.frames {
+			if other, ok := owner[name]; ok {
+				return fmt.Errorf("cannot coordinate %s and %s as both move %s", other, g.name, name)
+			}
+			owner[name] = g.name
+		}
+	}
+	return nil
+}
+
+// findAllowedCollisions records which geometries of different components collide as they start,
+// which they are then allowed to do throughout.
+func (cp *coordinatedPlanner) findAllowedCollisions() error {
+	cp.allowed = map[[2]string]bool{}
+	for i, a := range cp.groups {
+		for _, b := range cp.groups[i+1:] {
+			for _, ga := range a.geometries[0] {
+				for _, gb := range b.geometries[0] {
+					collides, _, err := ga.CollidesWith(gb, cp.planOpts.CollisionBufferMM)
+					if err != nil {
+						return err
+					}
+					if collides {
+						cp.allowed[[2]string{ga.Label(), gb.Label()}] = true
+						cp.allowed[[2]string{gb.Label(), ga.Label()}] = true
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// collides returns whether two sets of geometries of different components collide.
+func (cp *coordinatedPlanner) collides(a, b []spatialmath.Geometry) (bool, error) {
+	for _, ga := range a {
+		for _, gb := range b {
+			if cp.allowed[[2]string{ga.Label(), gb.Label()}] {
+				continue
+			}
+			collides, _, err := ga.CollidesWith(gb, cp.planOpts.CollisionBufferMM)
+			if err != nil || collides {
+				return collides, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// canAdvance returns whether the component at index i of the groups may take its next step. It may if
+// it will then be clear of every step that components with priority over it have left to take, so
+// that they never need to wait for it, and of where the components without priority are now.
+func (cp *coordinatedPlanner) canAdvance(i int) (bool, error) {
+	next := cp.groups[i].geometries[cp.groups[i].progress+1]
+	for j, other := range cp.groups {
+		if j == i {
+			continue
+		}
+		end := other.progress
+		if j < i {
+			end = len(other.path) - 1
+		}
+		for k := other.progress; k <= end; k++ {
+			collides, err := cp.collides(next, other.geometries[k])
+			if err != nil || collides {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// schedule lays the paths of all components along a shared timeline.
+func (cp *coordinatedPlanner) schedule(ctx context.Context, start *referenceframe.LinearInputs) ([]*referenceframe.LinearInputs, error) {
+	current := func() *referenceframe.LinearInputs {
+		conf := start.Copy()
+		for _, g := range cp.groups {
+			for _, name := range g.inputs {
+				conf.Put(name, g.path[g.progress].Get(name))
+			}
+		}
+		return conf
+	}
+
+	traj := []*referenceframe.LinearInputs{start.Copy()}
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		advanced, finished := false, true
+		stuck := -1
+		for i, g := range cp.groups {
+			if g.done() {
+				continue
+			}
+			finished = false
+			ok, err := cp.canAdvance(i)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				g.progress++
+				advanced = true
+			} else if stuck < 0 {
+				stuck = i
+			}
+		}
+		if finished {
+			return traj, nil
+		}
+		if advanced {
+			traj = append(traj, current())
+			continue
+		}
+
+		// Nothing could move. The first component stuck only waits for those with priority over it,
+		// which will never move again if none could, so plan it again from where it is.
+		g := cp.groups[stuck]
+		if g.replanned {
+			return nil, fmt.Errorf("cannot coordinate the motion of %s with that of the other components", g.name)
+		}
+		cp.logger.CDebugf(ctx, "%s cannot go on from step %d of %d, planning again", g.name, g.progress, len(g.path)-1)
+		g.replanned = true
+		if err := cp.planGroup(ctx, g, current()); err != nil {
+			return nil, fmt.Errorf("cannot coordinate the motion of %s with that of the other components: %w", g.name, err)
+		}
+		if err := cp.checkIndependent(); err != nil {
+			return nil, err
+		}
+	}
+}
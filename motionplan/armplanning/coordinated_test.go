@@ -0,0 +1,131 @@
+package armplanning
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// a single jointed arm with a 100mm long link pointing along x.
+var stickArmJSON = []byte(`{
+	"name": "stick",
+	"links": [
+		{"id": "base", "parent": "world"},
+		{"id": "stick", "parent": "waist", "translation": {"x": 100},
+			"geometry": {"x": 100, "y": 10, "z": 10, "translation": {"x": 50}}}
+	],
+	"joints": [
+		{"id": "waist", "type": "revolute", "parent": "base", "axis": {"z": 1}, "max": 360, "min": -360}
+	]
+}`)
+
+func TestPlanCoordinated(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	// two arms 150mm apart, whose links overlap when pointing at one another.
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	a, err := referenceframe.UnmarshalModelJSON(stickArmJSON, "a")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(a, fs.World()), test.ShouldBeNil)
+	bOffset, err := referenceframe.NewStaticFrame("b_offset", spatialmath.NewPoseFromPoint(r3.Vector{X: 150}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(bOffset, fs.World()), test.ShouldBeNil)
+	b, err := referenceframe.UnmarshalModelJSON(stickArmJSON, "b")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(b, bOffset), test.ShouldBeNil)
+
+	start := NewPlanState(nil, referenceframe.FrameSystemInputs{"a": {math.Pi / 2}, "b": {math.Pi / 2}, "b_offset": {}})
+	// alone, each arm swings straight through pointing at the other halfway along.
+	crossing := NewPlanState(nil, referenceframe.FrameSystemInputs{"a": {-math.Pi / 2}, "b": {3 * math.Pi / 2}})
+
+	collisionFree := func(traj []referenceframe.FrameSystemInputs) {
+		t.Helper()
+		for _, step := range traj {
+			geometries, err := referenceframe.FrameSystemGeometries(fs, step)
+			test.That(t, err, test.ShouldBeNil)
+			collides, _, err := geometries["a"].Geometries()[0].CollidesWith(geometries["b"].Geometries()[0], 0)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, collides, test.ShouldBeFalse)
+		}
+	}
+	// moving returns the number of steps through which a frame moves before it first waits.
+	moving := func(traj []referenceframe.FrameSystemInputs, frame string) int {
+		for i := 1; i < len(traj); i++ {
+			if traj[i][frame][0] == traj[i-1][frame][0] {
+				return i - 1
+			}
+		}
+		return len(traj) - 1
+	}
+
+	t.Run("waits for components with priority", func(t *testing.T) {
+		plan, err := PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{FrameSystem: fs, StartState: start, Goal: crossing})
+		test.That(t, err, test.ShouldBeNil)
+		traj := plan.Trajectory()
+		test.That(t, traj[0], test.ShouldResemble, start.Configuration())
+		test.That(t, traj[len(traj)-1]["a"][0], test.ShouldAlmostEqual, -math.Pi/2)
+		test.That(t, traj[len(traj)-1]["b"][0], test.ShouldAlmostEqual, 3*math.Pi/2)
+		collisionFree(traj)
+
+		// a moves without stopping while b waits for it to pass.
+		aMoving := moving(traj, "a")
+		test.That(t, traj[aMoving]["a"][0], test.ShouldAlmostEqual, -math.Pi/2)
+		test.That(t, moving(traj, "b"), test.ShouldBeLessThan, len(traj)-1)
+		test.That(t, aMoving, test.ShouldBeLessThan, len(traj)-1)
+	})
+
+	t.Run("follows the given priority", func(t *testing.T) {
+		plan, err := PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{
+			FrameSystem: fs, StartState: start, Goal: crossing, Priority: []string{"b"},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		traj := plan.Trajectory()
+		collisionFree(traj)
+		bMoving := moving(traj, "b")
+		test.That(t, traj[bMoving]["b"][0], test.ShouldAlmostEqual, 3*math.Pi/2)
+		test.That(t, moving(traj, "a"), test.ShouldBeLessThan, len(traj)-1)
+	})
+
+	t.Run("moves together when paths do not cross", func(t *testing.T) {
+		plan, err := PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{
+			FrameSystem: fs,
+			StartState:  start,
+			Goal:        NewPlanState(nil, referenceframe.FrameSystemInputs{"a": {math.Pi}, "b": {0}}),
+		})
+		test.That(t, err, test.ShouldBeNil)
+		traj := plan.Trajectory()
+		collisionFree(traj)
+		test.That(t, moving(traj, "a"), test.ShouldEqual, len(traj)-1)
+		test.That(t, moving(traj, "b"), test.ShouldEqual, len(traj)-1)
+	})
+
+	t.Run("fails when goals collide", func(t *testing.T) {
+		_, err := PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{
+			FrameSystem: fs,
+			StartState:  start,
+			Goal:        NewPlanState(nil, referenceframe.FrameSystemInputs{"a": {0}, "b": {math.Pi}}),
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("fails on malformed requests", func(t *testing.T) {
+		_, err := PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{FrameSystem: fs, StartState: start})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{
+			FrameSystem: fs, StartState: start, Goal: NewPlanState(nil, nil),
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = PlanCoordinated(ctx, logger, &CoordinatedPlanRequest{
+			FrameSystem: fs, StartState: start, Goal: crossing, StepFraction: 2,
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,296 @@
+package armplanning
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils/trace"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+const (
+	// DefaultPlanCacheStartTolerance is how far, in radians or mm, any input of a request's start
+	// configuration may be from that of a cached plan for the plan to be reused.
+	DefaultPlanCacheStartTolerance = 1e-3
+
+	// how far in mm the goals of a request may move between a cached plan and its reuse, which
+	// only happens for goals given relative to frames which move with the start configuration.
+	planCacheGoalEpsilon = 1e-3
+)
+
+// PlanCacheStats counts how a PlanCache has been used.
+type PlanCacheStats struct {
+	// Hits is the number of requests answered with a cached plan.
+	Hits int `json:"hits"`
+	// Misses is the number of requests which had to be planned from scratch.
+	Misses int `json:"misses"`
+	// Invalidations is the number of cached plans found to be no longer valid when reused, and dropped.
+	Invalidations int `json:"invalidations"`
+	// Entries is the number of plans currently cached.
+	Entries int `json:"entries"`
+}
+
+// planCacheKey identifies requests which, given close enough start configurations, can share a plan.
+type planCacheKey struct {
+	frameSystem int
+	worldState  int
+	request     uint64
+}
+
+type planCacheEntry struct {
+	key        planCacheKey
+	start      *referenceframe.LinearInputs
+	goals      []referenceframe.FrameSystemPoses // in world, as of start
+	trajectory []*referenceframe.LinearInputs
+	// goalSteps holds, for each goal, the index of the step of trajectory reaching it.
+	goalSteps []int
+}
+
+// PlanCache remembers the plans made for requests so that repeats of a request can reuse them rather
+// than plan again. A cached plan is reused for a request with the same frame system, world state,
+// goals, constraints and planner options, whose start configuration is within a tolerance of the
+// cached plan's, and only after checking the plan still satisfies the request's constraints from the
+// new start. It is safe for concurrent use.
+type PlanCache struct {
+	mu             sync.Mutex
+	capacity       int
+	startTolerance float64
+	entries        []*planCacheEntry // least recently used first
+	stats          PlanCacheStats
+}
+
+// NewPlanCache returns a PlanCache holding up to capacity plans. A startTolerance of 0 uses
+// DefaultPlanCacheStartTolerance.
+func NewPlanCache(capacity int, startTolerance float64) *PlanCache {
+	if startTolerance <= 0 {
+		startTolerance = DefaultPlanCacheStartTolerance
+	}
+	return &PlanCache{capacity: capacity, startTolerance: startTolerance}
+}
+
+// Stats returns the hit and miss counts of the cache.
+func (c *PlanCache) Stats() PlanCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	return stats
+}
+
+// Clear drops all cached plans.
+func (c *PlanCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// PlanMotion returns a cached plan for the request if there is a valid one, and otherwise plans it
+// with PlanMotion and caches the result. Partial plans are never cached.
+func (c *PlanCache) PlanMotion(ctx context.Context, logger logging.Logger, request *PlanRequest) (motionplan.Plan, *PlanMeta, error) {
+	start := time.Now()
+	if err := request.validatePlanRequest(); err != nil {
+		return nil, &PlanMeta{}, err
+	}
+	key, err := newPlanCacheKey(request)
+	if err != nil {
+		return nil, &PlanMeta{}, err
+	}
+	startInputs := request.StartState.LinearConfiguration()
+	goals, err := worldGoals(ctx, request, startInputs)
+	if err != nil {
+		return nil, &PlanMeta{}, err
+	}
+
+	for _, entry := range c.candidates(key, startInputs) {
+		plan, err := c.reuse(ctx, logger, request, entry, startInputs, goals)
+		if err == nil {
+			logger.CDebugf(ctx, "reusing cached plan of %d steps", len(entry.trajectory))
+			c.mu.Lock()
+			c.stats.Hits++
+			c.touch(entry)
+			c.mu.Unlock()
+			return plan, &PlanMeta{Duration: time.Since(start), GoalsProcessed: len(request.Goals), CacheHit: true}, nil
+		}
+		logger.CDebugf(ctx, "cached plan is no longer valid: %v", err)
+		c.mu.Lock()
+		c.stats.Invalidations++
+		c.remove(entry)
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	plan, meta, err := PlanMotion(ctx, logger, request)
+	if err != nil || meta.Partial || c.capacity <= 0 {
+		return plan, meta, err
+	}
+	if len(meta.goalSteps) != len(goals) {
+		// without knowing which steps reach which goal, the plan cannot be checked when reused.
+		return plan, meta, nil
+	}
+	entry := &planCacheEntry{key: key, start: startInputs.Copy(), goals: goals, goalSteps: meta.goalSteps}
+	for _, step := range plan.Trajectory() {
+		entry.trajectory = append(entry.trajectory, step.ToLinearInputs())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+	if len(c.entries) > c.capacity {
+		c.entries = c.entries[len(c.entries)-c.capacity:]
+	}
+	return plan, meta, nil
+}
+
+// candidates returns the cached entries which may be reused for a request, most recently used first.
+func (c *PlanCache) candidates(key planCacheKey, start *referenceframe.LinearInputs) []*planCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := []*planCacheEntry{}
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		entry := c.entries[i]
+		if entry.key == key && inputsWithin(entry.start, start, c.startTolerance) {
+			found = append(found, entry)
+		}
+	}
+	return found
+}
+
+// reuse checks that a cached plan still solves the request from its actual start and returns it,
+// starting from there.
+func (c *PlanCache) reuse(
+	ctx context.Context,
+	logger logging.Logger,
+	request *PlanRequest,
+	entry *planCacheEntry,
+	start *referenceframe.LinearInputs,
+	goals []referenceframe.FrameSystemPoses,
+) (motionplan.Plan, error) {
+	ctx, span := trace.StartSpan(ctx, "reusePlan")
+	defer span.End()
+
+	for i, goal := range goals {
+		for name, pif := range goal {
+			cached, ok := entry.goals[i][name]
+			if !ok || !spatialmath.PoseAlmostEqualEps(pif.Pose(), cached.Pose(), planCacheGoalEpsilon) {
+				return nil, errors.Errorf("goal for %s has moved", name)
+			}
+		}
+	}
+
+	pc, err := newPlanContext(ctx, logger, request, &PlanMeta{})
+	if err != nil {
+		return nil, err
+	}
+	// each goal's segment of the plan is checked against the constraints of that segment, as
+	// it was when planned.
+	trajectory := append([]*referenceframe.LinearInputs{start.Copy()}, entry.trajectory[1:]...)
+	segmentStart := 0
+	for i, goal := range goals {
+		segmentEnd := entry.goalSteps[i]
+		psc, err := newPlanSegmentContext(ctx, pc, trajectory[segmentStart], goal)
+		if err != nil {
+			return nil, err
+		}
+		for step := segmentStart + 1; step <= segmentEnd; step++ {
+			if err := psc.checkPath(ctx, trajectory[step-1], trajectory[step], true); err != nil {
+				return nil, errors.Wrapf(err, "step %d towards goal %d", step, i)
+			}
+		}
+		segmentStart = segmentEnd
+	}
+	return motionplan.NewSimplePlanFromTrajectory(trajectory, pc.fs)
+}
+
+func (c *PlanCache) touch(entry *planCacheEntry) {
+	if c.remove(entry) {
+		c.entries = append(c.entries, entry)
+	}
+}
+
+func (c *PlanCache) remove(entry *planCacheEntry) bool {
+	for i, e := range c.entries {
+		if e == entry {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// newPlanCacheKey hashes everything about a request other than its start configuration. The
+// planner's timeout is left out as it does not change which plans are valid.
+func newPlanCacheKey(request *PlanRequest) (planCacheKey, error) {
+	opts := *request.PlannerOptions
+	opts.Timeout = 0
+	data, err := json.Marshal(struct {
+		Goals       []*PlanState            `json:"goals"`
+		StartPoses  *PlanState              `json:"start_poses"`
+		Constraints *motionplan.Constraints `json:"constraints"`
+		Options     *PlannerOptions         `json:"planner_options"`
+	}{
+		Goals:       request.Goals,
+		StartPoses:  NewPlanState(request.StartState.Poses(), nil),
+		Constraints: request.Constraints,
+		Options:     &opts,
+	})
+	if err != nil {
+		return planCacheKey{}, err
+	}
+	h := fnv.New64a()
+	h.Write(data) //nolint:errcheck,gosec
+	return planCacheKey{
+		frameSystem: request.FrameSystem.Hash(),
+		worldState:  request.WorldState.Hash(),
+		request:     h.Sum64(),
+	}, nil
+}
+
+// worldGoals returns the goals of a request in the world frame, as of the start configuration.
+func worldGoals(
+	ctx context.Context, request *PlanRequest, start *referenceframe.LinearInputs,
+) ([]referenceframe.FrameSystemPoses, error) {
+	goals := make([]referenceframe.FrameSystemPoses, 0, len(request.Goals))
+	for _, g := range request.Goals {
+		poses, err := g.ComputePoses(ctx, request.FrameSystem)
+		if err != nil {
+			return nil, err
+		}
+		poses, err = translateGoalsToWorldPosition(request.FrameSystem, start, poses)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, poses)
+	}
+	return goals, nil
+}
+
+// inputsWithin returns whether two configurations have the same frames and no input differing by
+// more than tolerance.
+func inputsWithin(a, b *referenceframe.LinearInputs, tolerance float64) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for name, aInputs := range a.Items() {
+		bInputs := b.Get(name)
+		if len(aInputs) != len(bInputs) {
+			return false
+		}
+		for i := range aInputs {
+			if math.Abs(aInputs[i]-bInputs[i]) > tolerance {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+package armplanning
+
+import (
+	"context"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/utils"
+)
+
+func TestPlanCache(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/ur5e.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	goal := referenceframe.FrameSystemInputs{"arm": {0.5, -1, 1.3, -1.9, -1.57, 0}}
+	request := func(start []referenceframe.Input) *PlanRequest {
+		return &PlanRequest{
+			FrameSystem: fs,
+			StartState:  NewPlanState(nil, referenceframe.FrameSystemInputs{"arm": start}),
+			Goals:       []*PlanState{NewPlanState(nil, goal)},
+		}
+	}
+	cache := NewPlanCache(2, 0)
+
+	first, meta, err := cache.PlanMotion(ctx, logger, request([]referenceframe.Input{0, -1.2, 1.5, -1.9, -1.57, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, meta.CacheHit, test.ShouldBeFalse)
+	test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Misses: 1, Entries: 1})
+
+	t.Run("reuses plans from nearby starts", func(t *testing.T) {
+		nearby := []referenceframe.Input{0.0005, -1.2, 1.5, -1.9, -1.57, 0}
+		plan, meta, err := cache.PlanMotion(ctx, logger, request(nearby))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, meta.CacheHit, test.ShouldBeTrue)
+		test.That(t, len(plan.Trajectory()), test.ShouldEqual, len(first.Trajectory()))
+		test.That(t, plan.Trajectory()[0]["arm"], test.ShouldResemble, nearby)
+		test.That(t, plan.Trajectory()[len(plan.Trajectory())-1], test.ShouldResemble, goal)
+		test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Hits: 1, Misses: 1, Entries: 1})
+	})
+
+	t.Run("plans from starts too far away", func(t *testing.T) {
+		_, meta, err := cache.PlanMotion(ctx, logger, request([]referenceframe.Input{0.1, -1.2, 1.5, -1.9, -1.57, 0}))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, meta.CacheHit, test.ShouldBeFalse)
+		test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Hits: 1, Misses: 2, Entries: 2})
+	})
+
+	t.Run("plans for different options", func(t *testing.T) {
+		req := request([]referenceframe.Input{0, -1.2, 1.5, -1.9, -1.57, 0})
+		req.PlannerOptions = NewBasicPlannerOptions()
+		req.PlannerOptions.CollisionBufferMM = 5
+		_, meta, err := cache.PlanMotion(ctx, logger, req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, meta.CacheHit, test.ShouldBeFalse)
+		// the least recently used plan has been evicted.
+		test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Hits: 1, Misses: 3, Entries: 2})
+	})
+
+	t.Run("drops plans which are no longer valid", func(t *testing.T) {
+		req := request([]referenceframe.Input{0.1, -1.2, 1.5, -1.9, -1.57, 0})
+		_, meta, err := cache.PlanMotion(ctx, logger, req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, meta.CacheHit, test.ShouldBeTrue)
+
+		// a cached step beyond the arm's limits fails validation.
+		cache.entries[len(cache.entries)-1].trajectory[1].Put("arm", []referenceframe.Input{100, -1.2, 1.5, -1.9, -1.57, 0})
+		_, meta, err = cache.PlanMotion(ctx, logger, req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, meta.CacheHit, test.ShouldBeFalse)
+		test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Hits: 2, Misses: 4, Invalidations: 1, Entries: 2})
+	})
+}
+
+func TestPlanCacheMultipleGoals(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/ur5e.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	waypoint := referenceframe.FrameSystemInputs{"arm": {0.5, -1, 1.3, -1.9, -1.57, 0}}
+	goal := referenceframe.FrameSystemInputs{"arm": {-0.5, -1.2, 1.5, -1.9, -1.57, 0}}
+	request := func(start []referenceframe.Input) *PlanRequest {
+		return &PlanRequest{
+			FrameSystem: fs,
+			StartState:  NewPlanState(nil, referenceframe.FrameSystemInputs{"arm": start}),
+			Goals:       []*PlanState{NewPlanState(nil, waypoint), NewPlanState(nil, goal)},
+		}
+	}
+	cache := NewPlanCache(1, 0)
+
+	first, meta, err := cache.PlanMotion(ctx, logger, request([]referenceframe.Input{0, -1.2, 1.5, -1.9, -1.57, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, meta.CacheHit, test.ShouldBeFalse)
+	entry := cache.entries[0]
+	test.That(t, entry.goalSteps, test.ShouldHaveLength, 2)
+	test.That(t, entry.trajectory[entry.goalSteps[0]].Get("arm"), test.ShouldResemble, waypoint["arm"])
+	test.That(t, entry.goalSteps[1], test.ShouldEqual, len(first.Trajectory())-1)
+
+	// every segment of the plan is checked when it is reused.
+	plan, meta, err := cache.PlanMotion(ctx, logger, request([]referenceframe.Input{0.0005, -1.2, 1.5, -1.9, -1.57, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, meta.CacheHit, test.ShouldBeTrue)
+	test.That(t, plan.Trajectory()[entry.goalSteps[0]], test.ShouldResemble, waypoint)
+	test.That(t, plan.Trajectory()[len(plan.Trajectory())-1], test.ShouldResemble, goal)
+
+	entry.trajectory[entry.goalSteps[0]].Put("arm", []referenceframe.Input{100, -1, 1.3, -1.9, -1.57, 0})
+	_, meta, err = cache.PlanMotion(ctx, logger, request([]referenceframe.Input{0, -1.2, 1.5, -1.9, -1.57, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, meta.CacheHit, test.ShouldBeFalse)
+	test.That(t, cache.Stats(), test.ShouldResemble, PlanCacheStats{Hits: 1, Misses: 2, Invalidations: 1, Entries: 1})
+}
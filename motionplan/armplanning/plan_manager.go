@@ -106,6 +106,7 @@ func (pm *planManager) planMultiWaypoint(ctx context.Context) ([]*referenceframe
 			}
 		}
 		start = to
+		pm.pc.planMeta.goalSteps = append(pm.pc.planMeta.goalSteps, len(linearTraj)-1)
 	}
 
 	return linearTraj, len(pm.request.Goals), nil
@@ -0,0 +1,578 @@
+package armplanning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	"gonum.org/v1/gonum/mat"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+const (
+	defaultPrimitiveStepMM       = 2.
+	defaultPrimitiveStepDegs     = 1.
+	defaultPrimitiveMaxJointStep = 0.1 // rad or mm
+
+	// maxPrimitiveSubdivisions bounds how finely a step is divided when inputs move further than
+	// the max joint step across it.
+	maxPrimitiveSubdivisions = 64
+	// primitiveSplineSamples is how many points of each piece of a path are used to measure it.
+	primitiveSplineSamples = 32
+
+	primitiveIKIterations   = 100
+	primitivePositionTolMM  = 1e-3
+	primitiveOrientationTol = 1e-5 // rad
+	// primitiveOrientationScale weighs orientation error, in rad, against position error, in mm.
+	primitiveOrientationScale = 100.
+	primitiveDamping          = 1e-3
+	// primitiveJacobianStep is large enough for the rotations it causes to register.
+	primitiveJacobianStep = 1e-4
+)
+
+// MotionPrimitiveType is a kind of explicit path for a frame to follow.
+type MotionPrimitiveType string
+
+// The supported motion primitives.
+const (
+	// LinearPrimitive moves a frame in a straight line to a pose, turning it evenly to the pose's
+	// orientation on the way, so an unchanged orientation is held fixed.
+	LinearPrimitive MotionPrimitiveType = "linear"
+	// ArcPrimitive moves a frame along the circular arc through a via pose to a pose.
+	ArcPrimitive MotionPrimitiveType = "arc"
+	// CartesianSplinePrimitive moves a frame along a smooth curve through a list of poses.
+	CartesianSplinePrimitive MotionPrimitiveType = "cartesian_spline"
+	// JointSplinePrimitive moves a frame's inputs along a smooth curve through a list of
+	// configurations.
+	JointSplinePrimitive MotionPrimitiveType = "joint_spline"
+)
+
+// MotionPrimitive is an explicit path for a frame to follow from where it starts, rather than any
+// path that reaches a goal.
+type MotionPrimitive struct {
+	Type MotionPrimitiveType `json:"type"`
+	// Frame is the frame that follows the path.
+	Frame string `json:"frame"`
+	// Poses are what the frame passes through after its starting pose, in order. A linear move has
+	// one, an arc has its via pose followed by its end, and a spline has any number.
+	Poses []*referenceframe.PoseInFrame `json:"poses,omitempty"`
+	// Configurations are what the frame's inputs pass through in a joint spline, in order.
+	Configurations [][]referenceframe.Input `json:"configurations,omitempty"`
+
+	// StepMM and StepDegs are the largest movement of the frame between steps of the path.
+	StepMM   float64 `json:"step_mm,omitempty"`
+	StepDegs float64 `json:"step_degs,omitempty"`
+	// MaxJointStep is how far, in radians or mm, any input may move between steps of the path;
+	// further suggests the path passes through a singularity.
+	MaxJointStep float64 `json:"max_joint_step,omitempty"`
+}
+
+// PrimitiveRequest is the data needed to plan a motion primitive.
+type PrimitiveRequest struct {
+	FrameSystem        *referenceframe.FrameSystem
+	StartConfiguration referenceframe.FrameSystemInputs
+	Primitive          *MotionPrimitive
+	WorldState         *referenceframe.WorldState
+	Constraints        *motionplan.Constraints
+	PlannerOptions     *PlannerOptions
+}
+
+// PlanPrimitive plans the inputs for a frame to follow a motion primitive. Inverse kinematics are
+// solved at each step of the path starting from the inputs of the last, so that the inputs move
+// continuously, and every step is checked for collisions and against the request's constraints.
+func PlanPrimitive(ctx context.Context, logger logging.Logger, request *PrimitiveRequest) (motionplan.Plan, error) {
+	if request == nil || request.Primitive == nil {
+		return nil, errors.New("PrimitiveRequest must have a primitive")
+	}
+	primitive := *request.Primitive
+	if primitive.StepMM < 0 || primitive.StepDegs < 0 || primitive.MaxJointStep < 0 {
+		return nil, errors.New("primitive step sizes may not be negative")
+	}
+	if primitive.StepMM == 0 {
+		primitive.StepMM = defaultPrimitiveStepMM
+	}
+	if primitive.StepDegs == 0 {
+		primitive.StepDegs = defaultPrimitiveStepDegs
+	}
+	if primitive.MaxJointStep == 0 {
+		primitive.MaxJointStep = defaultPrimitiveMaxJointStep
+	}
+	if request.FrameSystem == nil {
+		return nil, errors.New("PrimitiveRequest cannot have nil framesystem")
+	}
+	frame := request.FrameSystem.Frame(primitive.Frame)
+	if frame == nil {
+		return nil, referenceframe.NewFrameMissingError(primitive.Frame)
+	}
+
+	var goal *PlanState
+	switch primitive.Type {
+	case LinearPrimitive, ArcPrimitive, CartesianSplinePrimitive:
+		switch {
+		case primitive.Type == LinearPrimitive && len(primitive.Poses) != 1:
+			return nil, fmt.Errorf("linear primitive needs 1 pose, got %d", len(primitive.Poses))
+		case primitive.Type == ArcPrimitive && len(primitive.Poses) != 2:
+			return nil, fmt.Errorf("arc primitive needs a via pose and an end pose, got %d poses", len(primitive.Poses))
+		case len(primitive.Poses) == 0:
+			return nil, fmt.Errorf("%s primitive needs some poses", primitive.Type)
+		}
+		goal = NewPlanState(referenceframe.FrameSystemPoses{primitive.Frame: primitive.Poses[len(primitive.Poses)-1]}, nil)
+	case JointSplinePrimitive:
+		if len(primitive.Configurations) == 0 {
+			return nil, fmt.Errorf("%s primitive needs some configurations", primitive.Type)
+		}
+		for _, conf := range primitive.Configurations {
+			if len(conf) != len(frame.DoF()) {
+				return nil, referenceframe.NewIncorrectDoFError(len(conf), len(frame.DoF()))
+			}
+		}
+		goal = NewPlanState(nil, referenceframe.FrameSystemInputs{
+			primitive.Frame: primitive.Configurations[len(primitive.Configurations)-1],
+		})
+	default:
+		return nil, fmt.Errorf("unknown motion primitive type %q", primitive.Type)
+	}
+
+	planRequest := &PlanRequest{
+		FrameSystem:    request.FrameSystem,
+		Goals:          []*PlanState{goal},
+		StartState:     NewPlanState(nil, request.StartConfiguration),
+		WorldState:     request.WorldState,
+		Constraints:    request.Constraints,
+		PlannerOptions: request.PlannerOptions,
+	}
+	if err := planRequest.validatePlanRequest(); err != nil {
+		return nil, err
+	}
+	pc, err := newPlanContext(ctx, logger, planRequest, &PlanMeta{})
+	if err != nil {
+		return nil, err
+	}
+	start := planRequest.StartState.LinearConfiguration()
+	goalPoses, err := goal.ComputePoses(ctx, pc.fs)
+	if err != nil {
+		return nil, err
+	}
+	psc, err := newPlanSegmentContext(ctx, pc, start, goalPoses)
+	if err != nil {
+		return nil, err
+	}
+
+	pp := &primitivePlanner{psc: psc, frame: frame, primitive: primitive, logger: logger}
+	var traj []*referenceframe.LinearInputs
+	if primitive.Type == JointSplinePrimitive {
+		traj, err = pp.planJointSpline(ctx, start)
+	} else {
+		traj, err = pp.planCartesian(ctx, start)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return motionplan.NewSimplePlanFromTrajectory(traj, pc.fs)
+}
+
+// DeserializeMotionPrimitive turns a motion primitive given as the extras of a request into a
+// MotionPrimitive, with its poses given as PoseInFrame protobufs.
+func DeserializeMotionPrimitive(iface map[string]interface{}) (*MotionPrimitive, error) {
+	posesIface := iface["poses"]
+	rest := map[string]interface{}{}
+	for k, v := range iface {
+		if k != "poses" {
+			rest[k] = v
+		}
+	}
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return nil, err
+	}
+	primitive := &MotionPrimitive{}
+	if err := json.Unmarshal(data, primitive); err != nil {
+		return nil, err
+	}
+	if posesIface == nil {
+		return primitive, nil
+	}
+	posesList, ok := posesIface.([]interface{})
+	if !ok {
+		return nil, errors.New("could not decode contents of motion primitive poses")
+	}
+	for _, pifIface := range posesList {
+		pifJSON, err := json.Marshal(pifIface)
+		if err != nil {
+			return nil, err
+		}
+		pifPb := &commonpb.PoseInFrame{}
+		if err := json.Unmarshal(pifJSON, pifPb); err != nil {
+			return nil, err
+		}
+		primitive.Poses = append(primitive.Poses, referenceframe.ProtobufToPoseInFrame(pifPb))
+	}
+	return primitive, nil
+}
+
+type primitivePlanner struct {
+	psc       *planSegmentContext
+	frame     referenceframe.Frame
+	primitive MotionPrimitive
+	logger    logging.Logger
+}
+
+// pathPiece is a piece of a Cartesian path, giving the pose along it from 0 to 1.
+type pathPiece func(t float64) spatialmath.Pose
+
+func (pp *primitivePlanner) planCartesian(ctx context.Context, start *referenceframe.LinearInputs) ([]*referenceframe.LinearInputs, error) {
+	fs := pp.psc.pc.fs
+	startPose, err := pp.framePose(start)
+	if err != nil {
+		return nil, err
+	}
+	poses := []spatialmath.Pose{startPose}
+	for _, pif := range pp.primitive.Poses {
+		tf, err := fs.Transform(start, pif, referenceframe.World)
+		if err != nil {
+			return nil, err
+		}
+		poses = append(poses, tf.(*referenceframe.PoseInFrame).Pose())
+	}
+
+	var pieces []pathPiece
+	switch pp.primitive.Type {
+	case LinearPrimitive:
+		pieces = []pathPiece{func(t float64) spatialmath.Pose { return spatialmath.Interpolate(poses[0], poses[1], t) }}
+	case ArcPrimitive:
+		piece, err := arcPiece(poses[0], poses[1], poses[2])
+		if err != nil {
+			return nil, err
+		}
+		pieces = []pathPiece{piece}
+	default:
+		pieces = splinePieces(poses)
+	}
+
+	chain, err := newPrimitiveChain(fs, pp.frame)
+	if err != nil {
+		return nil, err
+	}
+	traj := []*referenceframe.LinearInputs{start}
+	current := start
+	for i, piece := range pieces {
+		steps := pp.cartesianSteps(piece)
+		for k := 1; k <= steps; k++ {
+			from, to := float64(k-1)/float64(steps), float64(k)/float64(steps)
+			next, err := pp.step(ctx, chain, current, piece, from, to, 0)
+			if err != nil {
+				return nil, fmt.Errorf("cannot follow %s primitive through piece %d of %d: %w", pp.primitive.Type, i+1, len(pieces), err)
+			}
+			traj = append(traj, next...)
+			current = next[len(next)-1]
+		}
+	}
+	return traj, nil
+}
+
+// cartesianSteps returns how many steps a piece of path is split into so that no step moves the
+// frame too far.
+func (pp *primitivePlanner) cartesianSteps(piece pathPiece) int {
+	var length, angle float64
+	prev := piece(0)
+	for k := 1; k <= primitiveSplineSamples; k++ {
+		pose := piece(float64(k) / primitiveSplineSamples)
+		length += pose.Point().Distance(prev.Point())
+		angle += utils.RadToDeg(spatialmath.QuatToR3AA(spatialmath.OrientationBetween(prev.Orientation(), pose.Orientation()).Quaternion()).Norm())
+		prev = pose
+	}
+	return int(math.Max(1, math.Max(math.Ceil(length/pp.primitive.StepMM), math.Ceil(angle/pp.primitive.StepDegs))))
+}
+
+// step solves for the inputs at the end of a step along a piece of path from the current inputs,
+// subdividing the step if any input moves further than the max joint step across it, and checks
+// the motion for collisions and constraint violations.
+func (pp *primitivePlanner) step(
+	ctx context.Context,
+	chain *primitiveChain,
+	current *referenceframe.LinearInputs,
+	piece pathPiece,
+	from, to float64,
+	depth int,
+) ([]*referenceframe.LinearInputs, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	next, err := chain.solve(current, piece(to))
+	if err != nil {
+		return nil, err
+	}
+	jump := maxInputChange(current, next)
+	if jump > pp.primitive.MaxJointStep {
+		if depth > 0 {
+			return nil, fmt.Errorf("inputs jump by %.3f between steps, the path likely passes through a singularity", jump)
+		}
+		parts := int(math.Min(maxPrimitiveSubdivisions, math.Ceil(jump/pp.primitive.MaxJointStep)))
+		var steps []*referenceframe.LinearInputs
+		for k := 1; k <= parts; k++ {
+			subFrom := from + (to-from)*float64(k-1)/float64(parts)
+			subTo := from + (to-from)*float64(k)/float64(parts)
+			sub, err := pp.step(ctx, chain, current, piece, subFrom, subTo, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, sub...)
+			current = sub[len(sub)-1]
+		}
+		return steps, nil
+	}
+	if err := pp.psc.checkPath(ctx, current, next, true); err != nil {
+		return nil, err
+	}
+	return []*referenceframe.LinearInputs{next}, nil
+}
+
+func (pp *primitivePlanner) planJointSpline(ctx context.Context, start *referenceframe.LinearInputs) ([]*referenceframe.LinearInputs, error) {
+	points := [][]float64{start.Get(pp.primitive.Frame)}
+	points = append(points, pp.primitive.Configurations...)
+	limits := pp.frame.DoF()
+	maxStep := math.Min(pp.primitive.MaxJointStep, utils.DegToRad(pp.primitive.StepDegs))
+
+	traj := []*referenceframe.LinearInputs{start}
+	current := start
+	for i := 0; i+1 < len(points); i++ {
+		piece := func(t float64) []float64 { return catmullRom(points, i, t) }
+		// the fastest any input changes along the piece, as the piece is split into steps evenly.
+		var rate float64
+		prev := piece(0)
+		for k := 1; k <= primitiveSplineSamples; k++ {
+			q := piece(float64(k) / primitiveSplineSamples)
+			for j := range q {
+				rate = math.Max(rate, math.Abs(q[j]-prev[j])*primitiveSplineSamples)
+			}
+			prev = q
+		}
+		// leave room for the rate to peak between samples.
+		steps := int(math.Max(1, math.Ceil(1.1*rate/maxStep)))
+		for k := 1; k <= steps; k++ {
+			q := piece(float64(k) / float64(steps))
+			for j, limit := range limits {
+				if q[j] < limit.Min || q[j] > limit.Max {
+					return nil, fmt.Errorf("joint spline takes input %d of %s to %.3f, outside its limits", j, pp.primitive.Frame, q[j])
+				}
+			}
+			next := current.Copy()
+			next.Put(pp.primitive.Frame, q)
+			if err := pp.psc.checkPath(ctx, current, next, true); err != nil {
+				return nil, fmt.Errorf("cannot follow %s primitive through piece %d of %d: %w", pp.primitive.Type, i+1, len(points)-1, err)
+			}
+			traj = append(traj, next)
+			current = next
+		}
+	}
+	return traj, nil
+}
+
+func (pp *primitivePlanner) framePose(inputs *referenceframe.LinearInputs) (spatialmath.Pose, error) {
+	tf, err := pp.psc.pc.fs.Transform(inputs, referenceframe.NewPoseInFrame(pp.primitive.Frame, spatialmath.NewZeroPose()), referenceframe.World)
+	if err != nil {
+		return nil, err
+	}
+	return tf.(*referenceframe.PoseInFrame).Pose(), nil
+}
+
+// arcPiece returns the circular arc from start through via to end, turning evenly from the
+// orientation of start to that of end.
+func arcPiece(start, via, end spatialmath.Pose) (pathPiece, error) {
+	s, v, e := start.Point(), via.Point(), end.Point()
+	a, b := v.Sub(s), e.Sub(s)
+	normal := a.Cross(b)
+	if normal.Norm() < 1e-6*a.Norm()*b.Norm() || a.Norm() < 1e-9 || b.Norm() < 1e-9 {
+		return nil, errors.New("arc start, via and end points must not be collinear")
+	}
+	// the circumcenter of the triangle through the three points.
+	center := s.Add(b.Cross(normal).Mul(a.Norm2()).Add(normal.Cross(a).Mul(b.Norm2())).Mul(1 / (2 * normal.Norm2())))
+	radius := s.Distance(center)
+	x := s.Sub(center).Normalize()
+	y := normal.Normalize().Cross(x)
+	angleOf := func(p r3.Vector) float64 {
+		d := p.Sub(center)
+		angle := math.Atan2(d.Dot(y), d.Dot(x))
+		if angle < 0 {
+			angle += 2 * math.Pi
+		}
+		return angle
+	}
+	sweep := angleOf(e)
+	return func(t float64) spatialmath.Pose {
+		sin, cos := math.Sincos(t * sweep)
+		point := center.Add(x.Mul(radius * cos)).Add(y.Mul(radius * sin))
+		return spatialmath.NewPose(point, spatialmath.Interpolate(start, end, t).Orientation())
+	}, nil
+}
+
+// splinePieces returns the pieces of a Catmull-Rom spline through the positions of the poses,
+// turning evenly between the orientations of each.
+func splinePieces(poses []spatialmath.Pose) []pathPiece {
+	points := make([][]float64, 0, len(poses))
+	for _, pose := range poses {
+		pt := pose.Point()
+		points = append(points, []float64{pt.X, pt.Y, pt.Z})
+	}
+	pieces := make([]pathPiece, 0, len(poses)-1)
+	for i := 0; i+1 < len(poses); i++ {
+		pieces = append(pieces, func(t float64) spatialmath.Pose {
+			pt := catmullRom(points, i, t)
+			return spatialmath.NewPose(r3.Vector{X: pt[0], Y: pt[1], Z: pt[2]}, spatialmath.Interpolate(poses[i], poses[i+1], t).Orientation())
+		})
+	}
+	return pieces
+}
+
+// catmullRom returns the point a fraction t of the way between points i and i+1 of a Catmull-Rom
+// spline, which passes through every point.
+func catmullRom(points [][]float64, i int, t float64) []float64 {
+	p1, p2 := points[i], points[i+1]
+	p0, p3 := p1, p2
+	if i > 0 {
+		p0 = points[i-1]
+	}
+	if i+2 < len(points) {
+		p3 = points[i+2]
+	}
+	t2, t3 := t*t, t*t*t
+	out := make([]float64, len(p1))
+	for j := range out {
+		m1 := (p2[j] - p0[j]) / 2
+		m2 := (p3[j] - p1[j]) / 2
+		out[j] = (2*t3-3*t2+1)*p1[j] + (t3-2*t2+t)*m1 + (-2*t3+3*t2)*p2[j] + (t3-t2)*m2
+	}
+	return out
+}
+
+func maxInputChange(a, b *referenceframe.LinearInputs) float64 {
+	var jump float64
+	for name, inputs := range b.Items() {
+		prev := a.Get(name)
+		for j := range inputs {
+			jump = math.Max(jump, math.Abs(inputs[j]-prev[j]))
+		}
+	}
+	return jump
+}
+
+// primitiveChain solves for the inputs of the frames between a frame and the world which put the
+// frame at a pose, by damped least squares from nearby inputs.
+type primitiveChain struct {
+	fs     *referenceframe.FrameSystem
+	frame  string
+	frames []referenceframe.Frame
+	limits []referenceframe.Limit
+}
+
+func newPrimitiveChain(fs *referenceframe.FrameSystem, frame referenceframe.Frame) (*primitiveChain, error) {
+	traceback, err := fs.TracebackFrame(frame)
+	if err != nil {
+		return nil, err
+	}
+	chain := &primitiveChain{fs: fs, frame: frame.Name()}
+	for _, f := range traceback {
+		if len(f.DoF()) > 0 {
+			chain.frames = append(chain.frames, f)
+			chain.limits = append(chain.limits, f.DoF()...)
+		}
+	}
+	if len(chain.limits) == 0 {
+		return nil, fmt.Errorf("nothing can move frame %s", frame.Name())
+	}
+	return chain, nil
+}
+
+func (c *primitiveChain) get(inputs *referenceframe.LinearInputs) []float64 {
+	q := make([]float64, 0, len(c.limits))
+	for _, f := range c.frames {
+		q = append(q, inputs.Get(f.Name())...)
+	}
+	return q
+}
+
+func (c *primitiveChain) put(inputs *referenceframe.LinearInputs, q []float64) *referenceframe.LinearInputs {
+	out := inputs.Copy()
+	offset := 0
+	for _, f := range c.frames {
+		n := len(f.DoF())
+		out.Put(f.Name(), append([]referenceframe.Input{}, q[offset:offset+n]...))
+		offset += n
+	}
+	return out
+}
+
+func (c *primitiveChain) pose(inputs *referenceframe.LinearInputs) (spatialmath.Pose, error) {
+	tf, err := c.fs.Transform(inputs, referenceframe.NewPoseInFrame(c.frame, spatialmath.NewZeroPose()), referenceframe.World)
+	if err != nil {
+		return nil, err
+	}
+	return tf.(*referenceframe.PoseInFrame).Pose(), nil
+}
+
+// poseError returns how far pose b is from pose a, as a translation in mm followed by a scaled
+// rotation vector.
+func poseError(a, b spatialmath.Pose) []float64 {
+	d := b.Point().Sub(a.Point())
+	q := spatialmath.OrientationBetween(a.Orientation(), b.Orientation()).Quaternion()
+	r := spatialmath.QuatToR3AA(q).Mul(primitiveOrientationScale)
+	return []float64{d.X, d.Y, d.Z, r.X, r.Y, r.Z}
+}
+
+func (c *primitiveChain) solve(start *referenceframe.LinearInputs, target spatialmath.Pose) (*referenceframe.LinearInputs, error) {
+	q := c.get(start)
+	n := len(q)
+	inputs := start
+	for iter := 0; iter < primitiveIKIterations; iter++ {
+		current, err := c.pose(inputs)
+		if err != nil {
+			return nil, err
+		}
+		e := poseError(current, target)
+		if math.Sqrt(e[0]*e[0]+e[1]*e[1]+e[2]*e[2]) < primitivePositionTolMM &&
+			math.Sqrt(e[3]*e[3]+e[4]*e[4]+e[5]*e[5]) < primitiveOrientationTol*primitiveOrientationScale {
+			return inputs, nil
+		}
+
+		jacobian := mat.NewDense(6, n, nil)
+		for j := 0; j < n; j++ {
+			nudged := append([]float64{}, q...)
+			nudged[j] += primitiveJacobianStep
+			pose, err := c.pose(c.put(inputs, nudged))
+			if err != nil {
+				return nil, err
+			}
+			column := poseError(current, pose)
+			for i := range column {
+				jacobian.Set(i, j, column[i]/primitiveJacobianStep)
+			}
+		}
+
+		// (JᵀJ + λ²I)Δq = Jᵀe
+		var lhs mat.Dense
+		lhs.Mul(jacobian.T(), jacobian)
+		for j := 0; j < n; j++ {
+			lhs.Set(j, j, lhs.At(j, j)+primitiveDamping*primitiveDamping)
+		}
+		var rhs mat.VecDense
+		rhs.MulVec(jacobian.T(), mat.NewVecDense(6, e))
+		var dq mat.VecDense
+		if err := dq.SolveVec(&lhs, &rhs); err != nil {
+			return nil, err
+		}
+		for j := range q {
+			q[j] = math.Max(c.limits[j].Min, math.Min(c.limits[j].Max, q[j]+dq.AtVec(j)))
+		}
+		inputs = c.put(start, q)
+	}
+	return nil, errors.New("pose is unreachable from the previous step")
+}
@@ -0,0 +1,187 @@
+package armplanning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func TestPlanPrimitive(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/ur5e.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	startInputs := []referenceframe.Input{0, -1.2, 1.5, -1.9, -1.57, 0}
+	start := referenceframe.FrameSystemInputs{"arm": startInputs}
+	startPose, err := model.Transform(startInputs)
+	test.That(t, err, test.ShouldBeNil)
+	offset := func(v r3.Vector) *referenceframe.PoseInFrame {
+		return referenceframe.NewPoseInFrame(referenceframe.World, spatialmath.NewPose(startPose.Point().Add(v), startPose.Orientation()))
+	}
+	plan := func(primitive *MotionPrimitive, worldState *referenceframe.WorldState) ([]spatialmath.Pose, error) {
+		p, err := PlanPrimitive(ctx, logger, &PrimitiveRequest{
+			FrameSystem:        fs,
+			StartConfiguration: start,
+			Primitive:          primitive,
+			WorldState:         worldState,
+		})
+		if err != nil {
+			return nil, err
+		}
+		poses, err := p.Path().GetFramePoses("arm")
+		test.That(t, err, test.ShouldBeNil)
+		// steps must be small for the inputs to move continuously.
+		inputs, err := p.Trajectory().GetFrameInputs("arm")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs[0], test.ShouldResemble, startInputs)
+		for i := 1; i < len(inputs); i++ {
+			for j := range inputs[i] {
+				test.That(t, inputs[i][j]-inputs[i-1][j], test.ShouldBeBetween, -0.1-1e-9, 0.1+1e-9)
+			}
+		}
+		return poses, nil
+	}
+
+	t.Run("linear", func(t *testing.T) {
+		poses, err := plan(&MotionPrimitive{Type: LinearPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{
+			offset(r3.Vector{X: 100, Z: -50}),
+		}}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(poses), test.ShouldBeGreaterThanOrEqualTo, 56)
+		end := startPose.Point().Add(r3.Vector{X: 100, Z: -50})
+		for _, pose := range poses {
+			test.That(t, spatialmath.DistToLineSegment(startPose.Point(), end, pose.Point()), test.ShouldBeLessThan, 0.01)
+			test.That(t, spatialmath.OrientationAlmostEqual(pose.Orientation(), startPose.Orientation()), test.ShouldBeTrue)
+		}
+		test.That(t, spatialmath.R3VectorAlmostEqual(poses[len(poses)-1].Point(), end, 0.01), test.ShouldBeTrue)
+	})
+
+	t.Run("arc", func(t *testing.T) {
+		poses, err := plan(&MotionPrimitive{Type: ArcPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{
+			offset(r3.Vector{X: 50, Y: 50}),
+			offset(r3.Vector{X: 100}),
+		}}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		center := startPose.Point().Add(r3.Vector{X: 50})
+		passesVia := false
+		for _, pose := range poses {
+			test.That(t, pose.Point().Distance(center), test.ShouldAlmostEqual, 50, 0.01)
+			test.That(t, pose.Point().Z, test.ShouldAlmostEqual, startPose.Point().Z, 0.01)
+			if pose.Point().Distance(startPose.Point().Add(r3.Vector{X: 50, Y: 50})) < 2 {
+				passesVia = true
+			}
+		}
+		test.That(t, passesVia, test.ShouldBeTrue)
+
+		_, err = plan(&MotionPrimitive{Type: ArcPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{
+			offset(r3.Vector{X: 50}),
+			offset(r3.Vector{X: 100}),
+		}}, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "collinear")
+	})
+
+	t.Run("cartesian spline", func(t *testing.T) {
+		through := []r3.Vector{{X: 40, Y: 20}, {X: 80, Y: -20}, {X: 120}}
+		pifs := []*referenceframe.PoseInFrame{}
+		for _, v := range through {
+			pifs = append(pifs, offset(v))
+		}
+		poses, err := plan(&MotionPrimitive{Type: CartesianSplinePrimitive, Frame: "arm", Poses: pifs}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		for _, v := range through {
+			found := false
+			for _, pose := range poses {
+				if pose.Point().Distance(startPose.Point().Add(v)) < 0.01 {
+					found = true
+				}
+			}
+			test.That(t, found, test.ShouldBeTrue)
+		}
+	})
+
+	t.Run("joint spline", func(t *testing.T) {
+		configurations := [][]referenceframe.Input{
+			{0.3, -1.2, 1.5, -1.9, -1.57, 0},
+			{0.3, -1.0, 1.3, -1.9, -1.57, 0.5},
+		}
+		p, err := PlanPrimitive(ctx, logger, &PrimitiveRequest{
+			FrameSystem:        fs,
+			StartConfiguration: start,
+			Primitive:          &MotionPrimitive{Type: JointSplinePrimitive, Frame: "arm", Configurations: configurations},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		inputs, err := p.Trajectory().GetFrameInputs("arm")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs, test.ShouldContain, configurations[0])
+		test.That(t, inputs[len(inputs)-1], test.ShouldResemble, configurations[1])
+		for i := 1; i < len(inputs); i++ {
+			for j := range inputs[i] {
+				test.That(t, inputs[i][j]-inputs[i-1][j], test.ShouldBeBetween, -utils.DegToRad(1)-1e-9, utils.DegToRad(1)+1e-9)
+			}
+		}
+	})
+
+	t.Run("fails on obstacles in the way", func(t *testing.T) {
+		box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(startPose.Point().Add(r3.Vector{X: 60})), r3.Vector{X: 10, Y: 100, Z: 100}, "wall")
+		test.That(t, err, test.ShouldBeNil)
+		worldState, err := referenceframe.NewWorldState(
+			[]*referenceframe.GeometriesInFrame{referenceframe.NewGeometriesInFrame(referenceframe.World, []spatialmath.Geometry{box})}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		_, err = plan(&MotionPrimitive{Type: LinearPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{
+			offset(r3.Vector{X: 100}),
+		}}, worldState)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "wall")
+	})
+
+	t.Run("fails on unreachable poses", func(t *testing.T) {
+		_, err := plan(&MotionPrimitive{Type: LinearPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{
+			offset(r3.Vector{X: 5000}),
+		}}, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("fails on malformed primitives", func(t *testing.T) {
+		for _, primitive := range []*MotionPrimitive{
+			{Type: "wiggle", Frame: "arm", Poses: []*referenceframe.PoseInFrame{offset(r3.Vector{})}},
+			{Type: LinearPrimitive, Frame: "nope", Poses: []*referenceframe.PoseInFrame{offset(r3.Vector{})}},
+			{Type: LinearPrimitive, Frame: "arm"},
+			{Type: ArcPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{offset(r3.Vector{})}},
+			{Type: JointSplinePrimitive, Frame: "arm", Configurations: [][]referenceframe.Input{{0}}},
+			{Type: LinearPrimitive, Frame: "arm", Poses: []*referenceframe.PoseInFrame{offset(r3.Vector{})}, StepMM: -1},
+		} {
+			_, err := plan(primitive, nil)
+			test.That(t, err, test.ShouldNotBeNil)
+		}
+	})
+}
+
+func TestDeserializeMotionPrimitive(t *testing.T) {
+	primitive, err := DeserializeMotionPrimitive(map[string]interface{}{
+		"type":    "arc",
+		"step_mm": 1.5,
+		"poses": []interface{}{
+			map[string]interface{}{"reference_frame": "world", "pose": map[string]interface{}{"x": 1, "o_z": 1}},
+			map[string]interface{}{"reference_frame": "world", "pose": map[string]interface{}{"y": 2, "o_z": 1}},
+		},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, primitive.Type, test.ShouldEqual, ArcPrimitive)
+	test.That(t, primitive.StepMM, test.ShouldEqual, 1.5)
+	test.That(t, len(primitive.Poses), test.ShouldEqual, 2)
+	test.That(t, primitive.Poses[1].Pose().Point(), test.ShouldResemble, r3.Vector{Y: 2})
+
+	_, err = DeserializeMotionPrimitive(map[string]interface{}{"type": "arc", "poses": "nope"})
+	test.That(t, err, test.ShouldNotBeNil)
+}
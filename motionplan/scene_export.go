@@ -0,0 +1,712 @@
+package motionplan
+
+import (
+	"bytes"
+	"cmp"
+	_ "embed"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+const (
+	defaultSceneFrameDuration = 20 * time.Millisecond
+	defaultSceneResolution    = 2.
+	// sceneMarkerRadiusMM is the radius geometries without any volume, like points, are drawn with.
+	sceneMarkerRadiusMM = 5.
+	// sceneSphereSegments is how many faces spheres and capsules are drawn with around their equator.
+	sceneSphereSegments = 24
+	// sceneHighlightScale is how much larger the highlight of a colliding geometry is drawn than the
+	// geometry itself, so that the highlight is drawn over it.
+	sceneHighlightScale = 1.02
+
+	gltfFloat       = 5126
+	gltfTriangles   = 4
+	gltfArrayBuffer = 34962
+	gltfScalar      = "SCALAR"
+	gltfVec3        = "VEC3"
+	gltfVec4        = "VEC4"
+	gltfLinear      = "LINEAR"
+	gltfStep        = "STEP"
+)
+
+//go:embed data/scene_export.html
+var sceneExportHTML string
+
+var sceneExportTemplate = template.Must(template.New("scene").Parse(sceneExportHTML))
+
+// SceneExportOptions describe how ExportSceneGLTF and ExportSceneHTML draw a trajectory.
+type SceneExportOptions struct {
+	// Title names the scene.
+	Title string
+	// FrameDuration is how long each configuration is shown for in the animation. Zero uses a
+	// default.
+	FrameDuration time.Duration
+	// Resolution is passed to InterpolateSegmentFS to add configurations between those of the
+	// trajectory, so that the animation is smooth and collisions part way along a segment are
+	// shown. Zero uses a default and a negative resolution only shows the trajectory's own
+	// configurations.
+	Resolution float64
+	// CollisionBufferMM is how close geometries have to be to be highlighted as colliding.
+	CollisionBufferMM float64
+	// CollisionSpecifications are the frames which are allowed to collide, and so are never
+	// highlighted.
+	CollisionSpecifications []CollisionSpecification
+}
+
+// sceneGeometry is one geometry of a scene, along with its pose in the world frame in every
+// configuration of the animation.
+type sceneGeometry struct {
+	name     string
+	label    string
+	obstacle bool
+	vertices []r3.Vector
+	poses    []spatialmath.Pose
+	// colliding is set for the configurations in which the geometry is colliding with another.
+	colliding []bool
+}
+
+// sceneCollisions are the geometries colliding with one another at a point in the animation.
+type sceneCollisions struct {
+	Time  float64     `json:"time"`
+	Pairs [][2]string `json:"pairs"`
+}
+
+// ExportSceneGLTF writes a glTF 2.0 file which animates the geometries of a frame system and the
+// obstacles of a world state along a trajectory. Geometries which collide, besides any which were
+// already colliding at the start of the trajectory or which are allowed to collide, are
+// highlighted, and the collisions are listed under "collisions" in the extras of the scene. The
+// file is self contained, with its buffer embedded, and uses meters with y up.
+func ExportSceneGLTF(
+	w io.Writer,
+	fs *referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	traj Trajectory,
+	opts SceneExportOptions,
+) error {
+	doc, err := buildSceneGLTF(fs, worldState, traj, opts)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(doc)
+}
+
+// ExportSceneHTML writes the glTF scene of ExportSceneGLTF into an HTML page which plays it with
+// WebGL, along with controls to pause and scrub through it and the collisions at each point. Like
+// the glTF file, the page is self contained and needs no network access to open.
+func ExportSceneHTML(
+	w io.Writer,
+	fs *referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	traj Trajectory,
+	opts SceneExportOptions,
+) error {
+	doc, err := buildSceneGLTF(fs, worldState, traj, opts)
+	if err != nil {
+		return err
+	}
+	title := opts.Title
+	if title == "" {
+		title = "motion plan"
+	}
+	return sceneExportTemplate.Execute(w, struct {
+		Title string
+		GLTF  *gltfDocument
+	}{title, doc})
+}
+
+func buildSceneGLTF(
+	fs *referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	traj Trajectory,
+	opts SceneExportOptions,
+) (*gltfDocument, error) {
+	if len(traj) == 0 {
+		return nil, errors.New("cannot export an empty trajectory")
+	}
+	if worldState == nil {
+		worldState = &referenceframe.WorldState{}
+	}
+	if opts.FrameDuration <= 0 {
+		opts.FrameDuration = defaultSceneFrameDuration
+	}
+	if opts.Resolution == 0 {
+		opts.Resolution = defaultSceneResolution
+	}
+
+	configurations, err := sceneConfigurations(fs, traj, opts.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	geometries, collisions, err := sceneGeometries(fs, worldState, configurations, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &gltfBuilder{}
+	robotMaterial := b.addMaterial("robot", [4]float64{0.6, 0.65, 0.75, 1})
+	obstacleMaterial := b.addMaterial("obstacle", [4]float64{0.85, 0.55, 0.3, 0.6})
+	collisionMaterial := b.addMaterial("collision", [4]float64{1, 0.1, 0.1, 1})
+
+	times := make([]float32, 0, len(configurations))
+	for i := range configurations {
+		times = append(times, float32((time.Duration(i) * opts.FrameDuration).Seconds()))
+	}
+	timesAccessor := -1
+	if len(times) > 1 {
+		timesAccessor = b.addAccessor(times, gltfScalar, 0)
+	}
+
+	// the scene is drawn in meters with z up inside a root node which turns it to the meters with
+	// y up of glTF
+	root := gltfNode{
+		Name:     fs.World().Name(),
+		Rotation: []float64{-math.Sqrt2 / 2, 0, 0, math.Sqrt2 / 2},
+		Scale:    []float64{1e-3, 1e-3, 1e-3},
+	}
+	b.doc.Nodes = append(b.doc.Nodes, root)
+	animation := gltfAnimation{Name: "trajectory"}
+
+	for _, g := range geometries {
+		material := robotMaterial
+		if g.obstacle {
+			material = obstacleMaterial
+		}
+		positions, normals := sceneTriangles(g.vertices)
+		positionAccessor := b.addAccessor(positions, gltfVec3, gltfArrayBuffer)
+		normalAccessor := b.addAccessor(normals, gltfVec3, gltfArrayBuffer)
+		mesh := b.addMesh(g.name, positionAccessor, normalAccessor, material)
+
+		node := len(b.doc.Nodes)
+		translation, rotation := sceneTransform(g.poses[0])
+		b.doc.Nodes = append(b.doc.Nodes, gltfNode{
+			Name:        g.name,
+			Mesh:        &mesh,
+			Translation: translation,
+			Rotation:    rotation,
+			Extras:      map[string]interface{}{"label": g.label, "obstacle": g.obstacle},
+		})
+		b.doc.Nodes[0].Children = append(b.doc.Nodes[0].Children, node)
+
+		if timesAccessor >= 0 && sceneMoves(g.poses) {
+			translations := make([]float32, 0, 3*len(g.poses))
+			rotations := make([]float32, 0, 4*len(g.poses))
+			var previous []float64
+			for _, pose := range g.poses {
+				translation, rotation := sceneTransform(pose)
+				// keep to one hemisphere so that rotations are interpolated the short way around
+				if previous != nil && dot4(previous, rotation) < 0 {
+					for i := range rotation {
+						rotation[i] = -rotation[i]
+					}
+				}
+				previous = rotation
+				translations = append(translations, toFloat32(translation)...)
+				rotations = append(rotations, toFloat32(rotation)...)
+			}
+			animation.addChannel(node, "translation", timesAccessor, b.addAccessor(translations, gltfVec3, 0), gltfLinear)
+			animation.addChannel(node, "rotation", timesAccessor, b.addAccessor(rotations, gltfVec4, 0), gltfLinear)
+		}
+
+		if !slices.Contains(g.colliding, true) {
+			continue
+		}
+		highlightMesh := b.addMesh(g.name+" collision", positionAccessor, normalAccessor, collisionMaterial)
+		highlight := len(b.doc.Nodes)
+		scale := func(colliding bool) []float64 {
+			if colliding {
+				return []float64{sceneHighlightScale, sceneHighlightScale, sceneHighlightScale}
+			}
+			return []float64{0, 0, 0}
+		}
+		b.doc.Nodes = append(b.doc.Nodes, gltfNode{Name: g.name + " collision", Mesh: &highlightMesh, Scale: scale(g.colliding[0])})
+		b.doc.Nodes[node].Children = append(b.doc.Nodes[node].Children, highlight)
+		if timesAccessor >= 0 {
+			scales := make([]float32, 0, 3*len(g.colliding))
+			for _, colliding := range g.colliding {
+				scales = append(scales, toFloat32(scale(colliding))...)
+			}
+			animation.addChannel(highlight, "scale", timesAccessor, b.addAccessor(scales, gltfVec3, 0), gltfStep)
+		}
+	}
+	if len(animation.Channels) > 0 {
+		b.doc.Animations = append(b.doc.Animations, animation)
+	}
+
+	b.doc.Scenes = []gltfScene{{
+		Name:  opts.Title,
+		Nodes: []int{0},
+		Extras: map[string]interface{}{
+			"frame_duration": opts.FrameDuration.Seconds(),
+			"collisions":     collisions,
+		},
+	}}
+	return b.finish(), nil
+}
+
+// sceneConfigurations returns the configurations to show, which are those of the trajectory along
+// with any interpolated between them.
+func sceneConfigurations(
+	fs *referenceframe.FrameSystem, traj Trajectory, resolution float64,
+) ([]referenceframe.FrameSystemInputs, error) {
+	configurations := []referenceframe.FrameSystemInputs{traj[0]}
+	for i := 1; i < len(traj); i++ {
+		if resolution < 0 {
+			configurations = append(configurations, traj[i])
+			continue
+		}
+		interpolated, err := InterpolateSegmentFS(&SegmentFS{
+			StartConfiguration: traj[i-1].ToLinearInputs(),
+			EndConfiguration:   traj[i].ToLinearInputs(),
+			FS:                 fs,
+		}, resolution)
+		if err != nil {
+			return nil, err
+		}
+		// the first configuration is the end of the last segment
+		for _, inputs := range interpolated[1:] {
+			configurations = append(configurations, inputs.ToFrameSystemInputs())
+		}
+	}
+	return configurations, nil
+}
+
+// sceneGeometries places every geometry of the frame system and world state in each
+// configuration, finding which of them collide as it does.
+func sceneGeometries(
+	fs *referenceframe.FrameSystem,
+	worldState *referenceframe.WorldState,
+	configurations []referenceframe.FrameSystemInputs,
+	opts SceneExportOptions,
+) ([]*sceneGeometry, []sceneCollisions, error) {
+	var geometries []*sceneGeometry
+	var ignoredSelf, ignoredObstacles []Collision
+	collisions := []sceneCollisions{}
+	for step, inputs := range configurations {
+		frameGeometries, err := referenceframe.FrameSystemGeometries(fs, inputs)
+		if err != nil {
+			return nil, nil, err
+		}
+		obstaclesInFrame, err := worldState.ObstaclesInWorldFrame(fs, inputs)
+		if err != nil {
+			return nil, nil, err
+		}
+		var robot []spatialmath.Geometry
+		for _, name := range slices.Sorted(maps.Keys(frameGeometries)) {
+			robot = append(robot, frameGeometries[name].Geometries()...)
+		}
+		obstacles := obstaclesInFrame.Geometries()
+
+		if step == 0 {
+			// the pairs colliding at the start are ignored, as they are when planning
+			frameNames := map[string]bool{}
+			for _, name := range fs.FrameNames() {
+				frameNames[name] = true
+			}
+			allowed, err := collisionSpecifications(opts.CollisionSpecifications, frameGeometries, frameNames, worldState.ObstacleNames())
+			if err != nil {
+				return nil, nil, err
+			}
+			ignoredSelf, err = computeInitialCollisionsToIgnore(fs, robot, robot, allowed, opts.CollisionBufferMM)
+			if err != nil {
+				return nil, nil, err
+			}
+			ignoredObstacles, err = computeInitialCollisionsToIgnore(fs, robot, obstacles, allowed, opts.CollisionBufferMM)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for i, g := range robot {
+				geometries = append(geometries, newSceneGeometry(g, fmt.Sprintf("robot %d", i), false))
+			}
+			for i, g := range obstacles {
+				geometries = append(geometries, newSceneGeometry(g, fmt.Sprintf("obstacle %d", i), true))
+			}
+		}
+		all := append(slices.Clone(robot), obstacles...)
+		if len(all) != len(geometries) {
+			return nil, nil, errors.Errorf("the number of geometries changed along the trajectory, from %d to %d",
+				len(geometries), len(all))
+		}
+
+		found, _, err := CheckCollisions(robot, robot, ignoredSelf, opts.CollisionBufferMM, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		foundObstacles, _, err := CheckCollisions(robot, obstacles, ignoredObstacles, opts.CollisionBufferMM, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		colliding := map[string]bool{}
+		pairs := [][2]string{}
+		for _, collision := range append(found, foundObstacles...) {
+			colliding[collision.name1] = true
+			colliding[collision.name2] = true
+			pairs = append(pairs, [2]string{collision.name1, collision.name2})
+		}
+		if len(pairs) > 0 {
+			slices.SortFunc(pairs, func(a, b [2]string) int {
+				return cmp.Or(strings.Compare(a[0], b[0]), strings.Compare(a[1], b[1]))
+			})
+			collisions = append(collisions, sceneCollisions{
+				Time:  (time.Duration(step) * opts.FrameDuration).Seconds(),
+				Pairs: pairs,
+			})
+		}
+
+		for i, g := range all {
+			geometries[i].poses = append(geometries[i].poses, g.Pose())
+			geometries[i].colliding = append(geometries[i].colliding, g.Label() != "" && colliding[g.Label()])
+		}
+	}
+
+	// geometries which cannot be drawn are left out
+	drawn := geometries[:0]
+	for _, g := range geometries {
+		if g.vertices != nil {
+			drawn = append(drawn, g)
+		}
+	}
+	return drawn, collisions, nil
+}
+
+func newSceneGeometry(g spatialmath.Geometry, fallbackName string, obstacle bool) *sceneGeometry {
+	name := g.Label()
+	if name == "" {
+		name = fallbackName
+	}
+	return &sceneGeometry{name: name, label: g.Label(), obstacle: obstacle, vertices: sceneVertices(g)}
+}
+
+// sceneVertices returns the triangles of a geometry in its own frame, as consecutive triples of
+// vertices, or nil if it is not a kind of geometry which can be drawn.
+func sceneVertices(g spatialmath.Geometry) []r3.Vector {
+	if mesh, ok := g.(*spatialmath.Mesh); ok {
+		vertices := make([]r3.Vector, 0, 3*len(mesh.Triangles()))
+		for _, tri := range mesh.Triangles() {
+			vertices = append(vertices, tri.Points()...)
+		}
+		return vertices
+	}
+
+	pb := g.ToProtobuf()
+	switch {
+	case pb.GetBox() != nil:
+		dims := pb.GetBox().GetDimsMm()
+		return boxVertices(r3.Vector{X: dims.GetX(), Y: dims.GetY(), Z: dims.GetZ()}.Mul(0.5))
+	case pb.GetSphere() != nil:
+		return capsuleVertices(math.Max(pb.GetSphere().GetRadiusMm(), sceneMarkerRadiusMM), 0)
+	case pb.GetCapsule() != nil:
+		capsule := pb.GetCapsule()
+		return capsuleVertices(capsule.GetRadiusMm(), capsule.GetLengthMm()-2*capsule.GetRadiusMm())
+	default:
+		return nil
+	}
+}
+
+func boxVertices(half r3.Vector) []r3.Vector {
+	var vertices []r3.Vector
+	for axis := 0; axis < 3; axis++ {
+		for _, sign := range []float64{-1, 1} {
+			// the four corners of the face, in order around it
+			var corners [4]r3.Vector
+			for i, uv := range [][2]float64{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}} {
+				c := [3]float64{}
+				c[axis] = sign
+				c[(axis+1)%3] = uv[0]
+				c[(axis+2)%3] = uv[1]
+				corners[i] = r3.Vector{X: c[0] * half.X, Y: c[1] * half.Y, Z: c[2] * half.Z}
+			}
+			vertices = append(vertices, outward(corners[0], corners[1], corners[2])...)
+			vertices = append(vertices, outward(corners[0], corners[2], corners[3])...)
+		}
+	}
+	return vertices
+}
+
+// capsuleVertices returns the triangles of a capsule along the z axis whose hemispherical ends are
+// a distance apart, which is a sphere if they are not apart at all.
+func capsuleVertices(radius, apart float64) []r3.Vector {
+	const n = sceneSphereSegments
+	// rings of vertices from the bottom pole to the top, with the equator in both halves
+	ring := func(k int, offset float64) []r3.Vector {
+		sinLat, cosLat := math.Sincos(-math.Pi/2 + math.Pi*float64(k)/float64(n))
+		vertices := make([]r3.Vector, 0, n)
+		for s := 0; s < n; s++ {
+			sinLong, cosLong := math.Sincos(2 * math.Pi * float64(s) / float64(n))
+			vertices = append(vertices, r3.Vector{X: radius * cosLat * cosLong, Y: radius * cosLat * sinLong, Z: radius*sinLat + offset})
+		}
+		return vertices
+	}
+	var rings [][]r3.Vector
+	for k := 0; k <= n/2; k++ {
+		rings = append(rings, ring(k, -apart/2))
+	}
+	for k := n / 2; k <= n; k++ {
+		rings = append(rings, ring(k, apart/2))
+	}
+
+	var vertices []r3.Vector
+	for j := 0; j+1 < len(rings); j++ {
+		for s := 0; s < n; s++ {
+			a, b := rings[j][s], rings[j][(s+1)%n]
+			c, d := rings[j+1][(s+1)%n], rings[j+1][s]
+			vertices = append(vertices, outward(a, b, c)...)
+			vertices = append(vertices, outward(a, c, d)...)
+		}
+	}
+	return vertices
+}
+
+// outward returns a triangle of a convex shape centred on the origin with its vertices ordered to
+// face away from the origin, or nothing if the triangle has no area.
+func outward(a, b, c r3.Vector) []r3.Vector {
+	normal := b.Sub(a).Cross(c.Sub(a))
+	if normal.Norm() < 1e-9 {
+		return nil
+	}
+	if normal.Dot(a.Add(b).Add(c)) < 0 {
+		return []r3.Vector{a, c, b}
+	}
+	return []r3.Vector{a, b, c}
+}
+
+// sceneTriangles flattens the vertices of triangles into positions, along with a normal for each.
+func sceneTriangles(vertices []r3.Vector) ([]float32, []float32) {
+	positions := make([]float32, 0, 3*len(vertices))
+	normals := make([]float32, 0, 3*len(vertices))
+	for i := 0; i+2 < len(vertices); i += 3 {
+		normal := vertices[i+1].Sub(vertices[i]).Cross(vertices[i+2].Sub(vertices[i])).Normalize()
+		for _, v := range vertices[i : i+3] {
+			positions = append(positions, float32(v.X), float32(v.Y), float32(v.Z))
+			normals = append(normals, float32(normal.X), float32(normal.Y), float32(normal.Z))
+		}
+	}
+	return positions, normals
+}
+
+// sceneTransform returns the translation and rotation of a glTF node at a pose.
+func sceneTransform(pose spatialmath.Pose) ([]float64, []float64) {
+	pt := pose.Point()
+	q := pose.Orientation().Quaternion()
+	norm := math.Sqrt(q.Real*q.Real + q.Imag*q.Imag + q.Jmag*q.Jmag + q.Kmag*q.Kmag)
+	return []float64{pt.X, pt.Y, pt.Z}, []float64{q.Imag / norm, q.Jmag / norm, q.Kmag / norm, q.Real / norm}
+}
+
+func sceneMoves(poses []spatialmath.Pose) bool {
+	for _, pose := range poses[1:] {
+		if !spatialmath.PoseAlmostEqual(poses[0], pose) {
+			return true
+		}
+	}
+	return false
+}
+
+func dot4(a, b []float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2] + a[3]*b[3]
+}
+
+func toFloat32(values []float64) []float32 {
+	converted := make([]float32, 0, len(values))
+	for _, v := range values {
+		converted = append(converted, float32(v))
+	}
+	return converted
+}
+
+type gltfDocument struct {
+	Asset       gltfAsset        `json:"asset"`
+	Scene       int              `json:"scene"`
+	Scenes      []gltfScene      `json:"scenes"`
+	Nodes       []gltfNode       `json:"nodes"`
+	Meshes      []gltfMesh       `json:"meshes,omitempty"`
+	Materials   []gltfMaterial   `json:"materials,omitempty"`
+	Animations  []gltfAnimation  `json:"animations,omitempty"`
+	Accessors   []gltfAccessor   `json:"accessors,omitempty"`
+	BufferViews []gltfBufferView `json:"bufferViews,omitempty"`
+	Buffers     []gltfBuffer     `json:"buffers,omitempty"`
+}
+
+type gltfAsset struct {
+	Version   string `json:"version"`
+	Generator string `json:"generator"`
+}
+
+type gltfScene struct {
+	Name   string                 `json:"name,omitempty"`
+	Nodes  []int                  `json:"nodes"`
+	Extras map[string]interface{} `json:"extras,omitempty"`
+}
+
+type gltfNode struct {
+	Name        string                 `json:"name,omitempty"`
+	Mesh        *int                   `json:"mesh,omitempty"`
+	Children    []int                  `json:"children,omitempty"`
+	Translation []float64              `json:"translation,omitempty"`
+	Rotation    []float64              `json:"rotation,omitempty"`
+	Scale       []float64              `json:"scale,omitempty"`
+	Extras      map[string]interface{} `json:"extras,omitempty"`
+}
+
+type gltfMesh struct {
+	Name       string          `json:"name,omitempty"`
+	Primitives []gltfPrimitive `json:"primitives"`
+}
+
+type gltfPrimitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Material   int            `json:"material"`
+	Mode       int            `json:"mode"`
+}
+
+type gltfMaterial struct {
+	Name                 string               `json:"name"`
+	PBRMetallicRoughness gltfPBRMetallicRough `json:"pbrMetallicRoughness"`
+	AlphaMode            string               `json:"alphaMode,omitempty"`
+	DoubleSided          bool                 `json:"doubleSided,omitempty"`
+}
+
+type gltfPBRMetallicRough struct {
+	BaseColorFactor [4]float64 `json:"baseColorFactor"`
+	MetallicFactor  float64    `json:"metallicFactor"`
+	RoughnessFactor float64    `json:"roughnessFactor"`
+}
+
+type gltfAnimation struct {
+	Name     string                 `json:"name,omitempty"`
+	Channels []gltfChannel          `json:"channels"`
+	Samplers []gltfAnimationSampler `json:"samplers"`
+}
+
+type gltfChannel struct {
+	Sampler int               `json:"sampler"`
+	Target  gltfChannelTarget `json:"target"`
+}
+
+type gltfChannelTarget struct {
+	Node int    `json:"node"`
+	Path string `json:"path"`
+}
+
+type gltfAnimationSampler struct {
+	Input         int    `json:"input"`
+	Output        int    `json:"output"`
+	Interpolation string `json:"interpolation"`
+}
+
+type gltfAccessor struct {
+	BufferView    int       `json:"bufferView"`
+	ComponentType int       `json:"componentType"`
+	Count         int       `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float32 `json:"min,omitempty"`
+	Max           []float32 `json:"max,omitempty"`
+}
+
+type gltfBufferView struct {
+	Buffer     int  `json:"buffer"`
+	ByteOffset int  `json:"byteOffset"`
+	ByteLength int  `json:"byteLength"`
+	Target     *int `json:"target,omitempty"`
+}
+
+type gltfBuffer struct {
+	ByteLength int    `json:"byteLength"`
+	URI        string `json:"uri"`
+}
+
+// gltfBuilder builds a glTF document whose accessors all read from one embedded buffer.
+type gltfBuilder struct {
+	doc    gltfDocument
+	buffer bytes.Buffer
+}
+
+func (b *gltfBuilder) addMaterial(name string, color [4]float64) int {
+	material := gltfMaterial{
+		Name:                 name,
+		PBRMetallicRoughness: gltfPBRMetallicRough{BaseColorFactor: color, RoughnessFactor: 0.8},
+		DoubleSided:          true,
+	}
+	if color[3] < 1 {
+		material.AlphaMode = "BLEND"
+	}
+	b.doc.Materials = append(b.doc.Materials, material)
+	return len(b.doc.Materials) - 1
+}
+
+func (b *gltfBuilder) addMesh(name string, positions, normals, material int) int {
+	b.doc.Meshes = append(b.doc.Meshes, gltfMesh{
+		Name: name,
+		Primitives: []gltfPrimitive{{
+			Attributes: map[string]int{"POSITION": positions, "NORMAL": normals},
+			Material:   material,
+			Mode:       gltfTriangles,
+		}},
+	})
+	return len(b.doc.Meshes) - 1
+}
+
+// addAccessor adds float data of a type, such as "VEC3", to the buffer, giving the target of its
+// buffer view if it has one.
+func (b *gltfBuilder) addAccessor(data []float32, accessorType string, target int) int {
+	components := map[string]int{gltfScalar: 1, gltfVec3: 3, gltfVec4: 4}[accessorType]
+	view := gltfBufferView{ByteOffset: b.buffer.Len(), ByteLength: 4 * len(data)}
+	if target != 0 {
+		view.Target = &target
+	}
+	//nolint:errcheck
+	binary.Write(&b.buffer, binary.LittleEndian, data)
+	b.doc.BufferViews = append(b.doc.BufferViews, view)
+
+	accessor := gltfAccessor{
+		BufferView:    len(b.doc.BufferViews) - 1,
+		ComponentType: gltfFloat,
+		Count:         len(data) / components,
+		Type:          accessorType,
+	}
+	if len(data) > 0 {
+		accessor.Min = slices.Clone(data[:components])
+		accessor.Max = slices.Clone(data[:components])
+		for i, v := range data {
+			accessor.Min[i%components] = min(accessor.Min[i%components], v)
+			accessor.Max[i%components] = max(accessor.Max[i%components], v)
+		}
+	}
+	b.doc.Accessors = append(b.doc.Accessors, accessor)
+	return len(b.doc.Accessors) - 1
+}
+
+func (b *gltfBuilder) finish() *gltfDocument {
+	b.doc.Asset = gltfAsset{Version: "2.0", Generator: "go.viam.com/rdk/motionplan"}
+	if b.buffer.Len() > 0 {
+		b.doc.Buffers = []gltfBuffer{{
+			ByteLength: b.buffer.Len(),
+			URI:        "data:application/octet-stream;base64," + base64.StdEncoding.EncodeToString(b.buffer.Bytes()),
+		}}
+	}
+	return &b.doc
+}
+
+func (a *gltfAnimation) addChannel(node int, path string, input, output int, interpolation string) {
+	a.Samplers = append(a.Samplers, gltfAnimationSampler{Input: input, Output: output, Interpolation: interpolation})
+	a.Channels = append(a.Channels, gltfChannel{
+		Sampler: len(a.Samplers) - 1,
+		Target:  gltfChannelTarget{Node: node, Path: path},
+	})
+}
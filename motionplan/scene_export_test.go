@@ -0,0 +1,127 @@
+package motionplan
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestExportScene(t *testing.T) {
+	carriage, err := spatialmath.NewBox(spatialmath.NewZeroPose(), r3.Vector{100, 100, 100}, "carriage")
+	test.That(t, err, test.ShouldBeNil)
+	gantry, err := referenceframe.NewTranslationalFrameWithGeometry("gantry", r3.Vector{X: 1}, referenceframe.Limit{Min: -1000, Max: 1000}, carriage)
+	test.That(t, err, test.ShouldBeNil)
+	fs := referenceframe.NewEmptyFrameSystem("test")
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+
+	wall, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: 500}), r3.Vector{100, 100, 100}, "wall")
+	test.That(t, err, test.ShouldBeNil)
+	worldState, err := referenceframe.NewWorldState([]*referenceframe.GeometriesInFrame{
+		referenceframe.NewGeometriesInFrame(referenceframe.World, []spatialmath.Geometry{wall}),
+	}, nil)
+	test.That(t, err, test.ShouldBeNil)
+
+	traj := Trajectory{}
+	for _, x := range []float64{0, 250, 500, 750, 1000} {
+		traj = append(traj, referenceframe.FrameSystemInputs{"gantry": {x}})
+	}
+	opts := SceneExportOptions{Title: "gantry", Resolution: -1}
+
+	t.Run("glTF", func(t *testing.T) {
+		var buf bytes.Buffer
+		test.That(t, ExportSceneGLTF(&buf, fs, worldState, traj, opts), test.ShouldBeNil)
+		var doc gltfDocument
+		test.That(t, json.Unmarshal(buf.Bytes(), &doc), test.ShouldBeNil)
+
+		test.That(t, doc.Asset.Version, test.ShouldEqual, "2.0")
+		test.That(t, doc.Scenes, test.ShouldHaveLength, 1)
+		collisions, err := json.Marshal(doc.Scenes[0].Extras["collisions"])
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, string(collisions), test.ShouldEqual, `[{"pairs":[["carriage","wall"]],"time":0.04}]`)
+
+		// the root, then the carriage and the wall each with their highlight
+		test.That(t, doc.Nodes, test.ShouldHaveLength, 5)
+		test.That(t, doc.Nodes[0].Children, test.ShouldResemble, []int{1, 3})
+		test.That(t, doc.Nodes[1].Children, test.ShouldResemble, []int{2})
+		test.That(t, doc.Nodes[2].Scale, test.ShouldResemble, []float64{0, 0, 0})
+		test.That(t, doc.Nodes[3].Translation, test.ShouldResemble, []float64{500, 0, 0})
+		test.That(t, doc.Nodes[3].Children, test.ShouldResemble, []int{4})
+
+		// only the carriage moves, while both highlights are shown and hidden
+		test.That(t, doc.Animations, test.ShouldHaveLength, 1)
+		paths := []string{}
+		for _, channel := range doc.Animations[0].Channels {
+			test.That(t, channel.Target.Node, test.ShouldBeIn, []int{1, 2, 4})
+			paths = append(paths, channel.Target.Path)
+			sampler := doc.Animations[0].Samplers[channel.Sampler]
+			test.That(t, doc.Accessors[sampler.Input].Count, test.ShouldEqual, len(traj))
+			test.That(t, doc.Accessors[sampler.Output].Count, test.ShouldEqual, len(traj))
+		}
+		test.That(t, paths, test.ShouldResemble, []string{"translation", "rotation", "scale", "scale"})
+
+		length := 0
+		for _, view := range doc.BufferViews {
+			test.That(t, view.ByteOffset, test.ShouldEqual, length)
+			length += view.ByteLength
+		}
+		test.That(t, doc.Buffers, test.ShouldHaveLength, 1)
+		test.That(t, doc.Buffers[0].ByteLength, test.ShouldEqual, length)
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		test.That(t, ExportSceneHTML(&buf, fs, worldState, traj, opts), test.ShouldBeNil)
+		page := buf.String()
+		test.That(t, page, test.ShouldContainSubstring, "<title>gantry</title>")
+		test.That(t, page, test.ShouldContainSubstring, `"asset":{"version":"2.0"`)
+		test.That(t, strings.Contains(page, "{{"), test.ShouldBeFalse)
+		// the page plays the scene without loading anything.
+		test.That(t, strings.Contains(page, "://"), test.ShouldBeFalse)
+	})
+
+	t.Run("collisions at the start are not highlighted", func(t *testing.T) {
+		var buf bytes.Buffer
+		test.That(t, ExportSceneGLTF(&buf, fs, worldState, traj[2:], opts), test.ShouldBeNil)
+		var doc gltfDocument
+		test.That(t, json.Unmarshal(buf.Bytes(), &doc), test.ShouldBeNil)
+		test.That(t, doc.Scenes[0].Extras["collisions"], test.ShouldBeEmpty)
+		test.That(t, doc.Nodes, test.ShouldHaveLength, 3)
+	})
+
+	t.Run("empty trajectory", func(t *testing.T) {
+		test.That(t, ExportSceneGLTF(&bytes.Buffer{}, fs, worldState, nil, opts), test.ShouldNotBeNil)
+	})
+}
+
+func TestSceneVertices(t *testing.T) {
+	box, err := spatialmath.NewBox(spatialmath.NewZeroPose(), r3.Vector{10, 20, 30}, "")
+	test.That(t, err, test.ShouldBeNil)
+	capsule, err := spatialmath.NewCapsule(spatialmath.NewZeroPose(), 10, 50, "")
+	test.That(t, err, test.ShouldBeNil)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, g := range []spatialmath.Geometry{box, capsule, sphere} {
+		vertices := sceneVertices(g)
+		test.That(t, len(vertices), test.ShouldBeGreaterThan, 0)
+		test.That(t, len(vertices)%3, test.ShouldEqual, 0)
+		for i := 0; i < len(vertices); i += 3 {
+			a, b, c := vertices[i], vertices[i+1], vertices[i+2]
+			// every vertex is on the surface and every triangle faces outward
+			for _, v := range []r3.Vector{a, b, c} {
+				collides, _, err := g.CollidesWith(spatialmath.NewPoint(v, ""), 1e-6)
+				test.That(t, err, test.ShouldBeNil)
+				test.That(t, collides, test.ShouldBeTrue)
+			}
+			test.That(t, b.Sub(a).Cross(c.Sub(a)).Dot(a.Add(b).Add(c)), test.ShouldBeGreaterThan, 0)
+		}
+	}
+	test.That(t, len(sceneVertices(spatialmath.NewPoint(r3.Vector{}, ""))), test.ShouldBeGreaterThan, 0)
+}
@@ -0,0 +1,712 @@
+package motionplan
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+const (
+	// defaultTimingSteps is how many steps the path is split into when no resolution is given.
+	defaultTimingSteps = 200
+	// maxTimingSteps bounds the work done for long paths with a fine resolution.
+	maxTimingSteps = 20000
+	// timingSamplesPerStep is how many points of each step of a timed trajectory are checked
+	// against the limits.
+	timingSamplesPerStep = 8
+	timingEpsilon        = 1e-9
+)
+
+// TimingOptions describe how a Trajectory should be timed.
+type TimingOptions struct {
+	// Limits are the dynamic limits of the inputs of each frame, keyed by frame name. Every input
+	// that moves must have a velocity and acceleration limit; jerk limits are optional.
+	Limits map[string][]referenceframe.DynamicLimit
+	// MaxBlendDeviation is how far, in input units, the trajectory may cut the corner at each
+	// waypoint so that it does not have to stop there. Zero stops at every waypoint.
+	MaxBlendDeviation float64
+	// Resolution is the distance, in input units, between the points along the path at which the
+	// limits are enforced. Zero splits the path into a fixed number of steps.
+	Resolution float64
+}
+
+// FrameSystemDynamicLimits returns the dynamic limits of every frame in a frame system that
+// knows them.
+func FrameSystemDynamicLimits(fs *referenceframe.FrameSystem) map[string][]referenceframe.DynamicLimit {
+	limits := map[string][]referenceframe.DynamicLimit{}
+	for _, name := range fs.FrameNames() {
+		if limited, ok := fs.Frame(name).(referenceframe.DynamicallyLimited); ok {
+			if frameLimits := limited.DynamicLimits(); frameLimits != nil {
+				limits[name] = frameLimits
+			}
+		}
+	}
+	return limits
+}
+
+// TimedTrajectory is a Trajectory parameterized by time, such that following it keeps every input
+// within its velocity, acceleration and jerk limits. Between its knots it is a cubic, so its
+// positions and velocities are continuous, or a quintic if jerk is limited, so its accelerations
+// are continuous too.
+type TimedTrajectory struct {
+	// start holds the inputs of every frame at the start, which is where frames that do not move
+	// stay.
+	start referenceframe.FrameSystemInputs
+	// frames are the names of the frames that move, whose inputs are laid end to end in the
+	// knots, starting at offsets.
+	frames  []string
+	offsets []int
+
+	times                 []float64
+	positions, velocities [][]float64
+	// accelerations are nil if the trajectory is made of cubics.
+	accelerations [][]float64
+}
+
+// TimeParameterize times a trajectory so that it is followed as quickly as the limits in the
+// options allow, starting and ending at rest. Velocity and acceleration limits are met in near
+// minimum time; jerk limits are then met by slowing the whole trajectory down.
+func TimeParameterize(traj Trajectory, opts TimingOptions) (*TimedTrajectory, error) {
+	if len(traj) == 0 {
+		return nil, errors.New("cannot time an empty trajectory")
+	}
+	if opts.MaxBlendDeviation < 0 {
+		return nil, errors.New("max blend deviation may not be negative")
+	}
+	if opts.Resolution < 0 {
+		return nil, errors.New("resolution may not be negative")
+	}
+
+	tt := &TimedTrajectory{start: referenceframe.FrameSystemInputs{}}
+	for name, inputs := range traj[0] {
+		tt.start[name] = slices.Clone(inputs)
+	}
+	names := make([]string, 0, len(traj[0]))
+	for name := range traj[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var velLimits, accLimits, jerkLimits []float64
+	dof := 0
+	for _, name := range names {
+		n := len(traj[0][name])
+		moving := make([]bool, n)
+		anyMoving := false
+		for _, step := range traj {
+			inputs, ok := step[name]
+			if !ok || len(inputs) != n {
+				return nil, fmt.Errorf("frame %s does not have %d inputs at every step of the trajectory", name, n)
+			}
+			for j := range inputs {
+				if math.Abs(inputs[j]-traj[0][name][j]) > timingEpsilon {
+					moving[j] = true
+					anyMoving = true
+				}
+			}
+		}
+		if !anyMoving {
+			continue
+		}
+		limits := opts.Limits[name]
+		if limits != nil && len(limits) != n {
+			return nil, fmt.Errorf("frame %s has %d dynamic limits but %d inputs", name, len(limits), n)
+		}
+		for j := 0; j < n; j++ {
+			var limit referenceframe.DynamicLimit
+			if limits != nil {
+				limit = limits[j]
+			}
+			if !moving[j] {
+				velLimits = append(velLimits, math.Inf(1))
+				accLimits = append(accLimits, math.Inf(1))
+				jerkLimits = append(jerkLimits, 0)
+				continue
+			}
+			if limit.Velocity <= 0 || limit.Acceleration <= 0 {
+				return nil, fmt.Errorf("input %d of frame %s moves but has no velocity and acceleration limits", j, name)
+			}
+			velLimits = append(velLimits, limit.Velocity)
+			accLimits = append(accLimits, limit.Acceleration)
+			jerkLimits = append(jerkLimits, math.Max(limit.Jerk, 0))
+		}
+		tt.frames = append(tt.frames, name)
+		tt.offsets = append(tt.offsets, dof)
+		dof += n
+	}
+
+	waypoints := make([][]float64, 0, len(traj))
+	for _, step := range traj {
+		q := make([]float64, 0, dof)
+		for _, name := range tt.frames {
+			q = append(q, step[name]...)
+		}
+		if len(waypoints) == 0 || vecDist(q, waypoints[len(waypoints)-1]) > timingEpsilon {
+			waypoints = append(waypoints, q)
+		}
+	}
+	if len(waypoints) < 2 {
+		tt.times = []float64{0}
+		tt.positions = [][]float64{waypoints[0]}
+		tt.velocities = [][]float64{make([]float64, dof)}
+		return tt, nil
+	}
+
+	path := newBlendedPath(waypoints, opts.MaxBlendDeviation)
+	grid := path.grid(opts.Resolution)
+	p := &timingProblem{grid: grid, velLimits: velLimits, accLimits: accLimits}
+	if err := p.solve(); err != nil {
+		return nil, err
+	}
+	tt.times, tt.positions, tt.velocities, tt.accelerations = p.knots()
+	if !slices.ContainsFunc(jerkLimits, func(jerk float64) bool { return jerk > 0 }) {
+		tt.accelerations = nil
+	}
+	tt.scaleToLimits(velLimits, accLimits, jerkLimits)
+	return tt, nil
+}
+
+// Duration returns how long the trajectory takes to follow.
+func (tt *TimedTrajectory) Duration() time.Duration {
+	return secondsToDuration(tt.times[len(tt.times)-1])
+}
+
+// At returns the inputs of every frame at a time since the start of the trajectory. Times
+// outside of the trajectory are clamped to its start or end.
+func (tt *TimedTrajectory) At(t time.Duration) referenceframe.FrameSystemInputs {
+	q, _ := tt.evaluate(t.Seconds())
+	return tt.frameInputs(q)
+}
+
+// VelocitiesAt returns how fast the inputs of every frame are changing at a time since the start
+// of the trajectory, per second.
+func (tt *TimedTrajectory) VelocitiesAt(t time.Duration) referenceframe.FrameSystemInputs {
+	_, qd := tt.evaluate(t.Seconds())
+	velocities := tt.frameInputs(qd)
+	for name, inputs := range velocities {
+		if !slices.Contains(tt.frames, name) {
+			clear(inputs)
+		}
+	}
+	return velocities
+}
+
+// Sample returns the inputs of a frame every interval along the trajectory, ending with its
+// final inputs, for streaming to a component.
+func (tt *TimedTrajectory) Sample(frame string, interval time.Duration) ([]referenceframe.TimedInputs, error) {
+	if interval <= 0 {
+		return nil, errors.New("sample interval must be positive")
+	}
+	if _, ok := tt.start[frame]; !ok {
+		return nil, fmt.Errorf("frame %s is not part of the trajectory", frame)
+	}
+	duration := tt.Duration()
+	samples := make([]referenceframe.TimedInputs, 0, int(duration/interval)+2)
+	for t := time.Duration(0); ; t += interval {
+		if t > duration {
+			t = duration
+		}
+		samples = append(samples, referenceframe.TimedInputs{
+			Time:       t,
+			Inputs:     tt.At(t)[frame],
+			Velocities: tt.VelocitiesAt(t)[frame],
+		})
+		if t == duration {
+			return samples, nil
+		}
+	}
+}
+
+// Trajectory returns the knots of the timed trajectory as an untimed Trajectory.
+func (tt *TimedTrajectory) Trajectory() Trajectory {
+	traj := make(Trajectory, 0, len(tt.positions))
+	for _, q := range tt.positions {
+		traj = append(traj, tt.frameInputs(q))
+	}
+	return traj
+}
+
+func (tt *TimedTrajectory) frameInputs(q []float64) referenceframe.FrameSystemInputs {
+	inputs := referenceframe.FrameSystemInputs{}
+	for name, start := range tt.start {
+		inputs[name] = slices.Clone(start)
+	}
+	for i, name := range tt.frames {
+		copy(inputs[name], q[tt.offsets[i]:])
+	}
+	return inputs
+}
+
+// evaluate returns the combined positions and velocities of the moving frames at a time.
+func (tt *TimedTrajectory) evaluate(t float64) ([]float64, []float64) {
+	last := len(tt.times) - 1
+	if t <= 0 || last == 0 {
+		return slices.Clone(tt.positions[0]), slices.Clone(tt.velocities[0])
+	}
+	if t >= tt.times[last] {
+		return slices.Clone(tt.positions[last]), slices.Clone(tt.velocities[last])
+	}
+	i := sort.SearchFloat64s(tt.times, t)
+	if i > 0 {
+		i--
+	}
+	q := make([]float64, len(tt.positions[i]))
+	qd := make([]float64, len(q))
+	for j := range q {
+		c := tt.quintic(i, j)
+		q[j], qd[j], _, _ = c.at(t - tt.times[i])
+	}
+	return q, qd
+}
+
+func (tt *TimedTrajectory) quintic(i, j int) quintic {
+	if tt.accelerations == nil {
+		return newCubic(
+			tt.positions[i][j], tt.velocities[i][j],
+			tt.positions[i+1][j], tt.velocities[i+1][j],
+			tt.times[i+1]-tt.times[i],
+		)
+	}
+	return newQuintic(
+		tt.positions[i][j], tt.velocities[i][j], tt.accelerations[i][j],
+		tt.positions[i+1][j], tt.velocities[i+1][j], tt.accelerations[i+1][j],
+		tt.times[i+1]-tt.times[i],
+	)
+}
+
+// scaleToLimits slows the trajectory down uniformly until the polynomials between its knots are
+// within every limit. Slowing down by k divides velocities by k, accelerations by k² and jerks
+// by k³.
+func (tt *TimedTrajectory) scaleToLimits(velLimits, accLimits, jerkLimits []float64) {
+	k := 1.
+	for i := 0; i+1 < len(tt.times); i++ {
+		h := tt.times[i+1] - tt.times[i]
+		for j := range velLimits {
+			c := tt.quintic(i, j)
+			for step := 0; step <= timingSamplesPerStep; step++ {
+				_, qd, qdd, qddd := c.at(h * float64(step) / timingSamplesPerStep)
+				k = math.Max(k, math.Abs(qd)/velLimits[j])
+				k = math.Max(k, math.Sqrt(math.Abs(qdd)/accLimits[j]))
+				if jerkLimits[j] > 0 {
+					k = math.Max(k, math.Cbrt(math.Abs(qddd)/jerkLimits[j]))
+				}
+			}
+		}
+	}
+	// leave a little room for the limits to be checked at other points than the samples.
+	if k <= 1+timingEpsilon {
+		return
+	}
+	k *= 1 + 1e-6
+	for i := range tt.times {
+		tt.times[i] *= k
+		for j := range tt.velocities[i] {
+			tt.velocities[i][j] /= k
+			if tt.accelerations != nil {
+				tt.accelerations[i][j] /= k * k
+			}
+		}
+	}
+}
+
+// quintic is a polynomial in time between two knots of a timed trajectory, of at most fifth
+// degree.
+type quintic [6]float64
+
+// newCubic returns the cubic which starts and ends h apart at the given positions and velocities.
+func newCubic(p0, v0, p1, v1, h float64) quintic {
+	return quintic{
+		p0,
+		v0,
+		(3*(p1-p0)/h - 2*v0 - v1) / h,
+		(2*(p0-p1)/h + v0 + v1) / (h * h),
+	}
+}
+
+// newQuintic returns the quintic which starts and ends h apart at the given positions, velocities
+// and accelerations.
+func newQuintic(p0, v0, a0, p1, v1, a1, h float64) quintic {
+	d := p1 - p0 - v0*h - a0*h*h/2
+	e := v1 - v0 - a0*h
+	f := a1 - a0
+	h2 := h * h
+	return quintic{
+		p0,
+		v0,
+		a0 / 2,
+		(10*d - 4*e*h + f*h2/2) / (h2 * h),
+		(-15*d + 7*e*h - f*h2) / (h2 * h2),
+		(6*d - 3*e*h + f*h2/2) / (h2 * h2 * h),
+	}
+}
+
+// at returns the position, velocity, acceleration and jerk of the quintic at a time.
+func (c quintic) at(t float64) (float64, float64, float64, float64) {
+	p := c[0] + t*(c[1]+t*(c[2]+t*(c[3]+t*(c[4]+t*c[5]))))
+	v := c[1] + t*(2*c[2]+t*(3*c[3]+t*(4*c[4]+t*5*c[5])))
+	a := 2*c[2] + t*(6*c[3]+t*(12*c[4]+t*20*c[5]))
+	j := 6*c[3] + t*(24*c[4]+t*60*c[5])
+	return p, v, a, j
+}
+
+// pathSegment is a piece of a path through input space, parameterized by distance along it.
+type pathSegment interface {
+	length() float64
+	// evaluate returns the position, the tangent and the curvature of the segment at a distance
+	// along it.
+	evaluate(s float64) ([]float64, []float64, []float64)
+}
+
+type linearSegment struct {
+	start, direction []float64
+	len              float64
+}
+
+func newLinearSegment(from, to []float64) *linearSegment {
+	length := vecDist(from, to)
+	direction := make([]float64, len(from))
+	for i := range from {
+		direction[i] = (to[i] - from[i]) / length
+	}
+	return &linearSegment{start: from, direction: direction, len: length}
+}
+
+func (l *linearSegment) length() float64 {
+	return l.len
+}
+
+func (l *linearSegment) evaluate(s float64) ([]float64, []float64, []float64) {
+	q := make([]float64, len(l.start))
+	for i := range q {
+		q[i] = l.start[i] + s*l.direction[i]
+	}
+	return q, l.direction, make([]float64, len(q))
+}
+
+// circularSegment is an arc of a circle which blends two linear segments meeting at a corner.
+type circularSegment struct {
+	center, x, y  []float64
+	radius, angle float64
+}
+
+func (c *circularSegment) length() float64 {
+	return c.radius * c.angle
+}
+
+func (c *circularSegment) evaluate(s float64) ([]float64, []float64, []float64) {
+	sin, cos := math.Sincos(s / c.radius)
+	q := make([]float64, len(c.center))
+	tangent := make([]float64, len(q))
+	curvature := make([]float64, len(q))
+	for i := range q {
+		q[i] = c.center[i] + c.radius*(c.x[i]*cos+c.y[i]*sin)
+		tangent[i] = -c.x[i]*sin + c.y[i]*cos
+		curvature[i] = -(c.x[i]*cos + c.y[i]*sin) / c.radius
+	}
+	return q, tangent, curvature
+}
+
+// blendedPath runs straight through each waypoint, or around it on an arc if the corner there can
+// be blended.
+type blendedPath struct {
+	segments []pathSegment
+	// stops holds, for each segment, whether the path must come to rest at its end because it
+	// turns a corner there.
+	stops []bool
+}
+
+func newBlendedPath(waypoints [][]float64, maxDeviation float64) *blendedPath {
+	path := &blendedPath{}
+	add := func(segment pathSegment, stop bool) {
+		if segment.length() > timingEpsilon {
+			path.segments = append(path.segments, segment)
+			path.stops = append(path.stops, stop)
+		} else if stop && len(path.stops) > 0 {
+			path.stops[len(path.stops)-1] = true
+		}
+	}
+
+	current := waypoints[0]
+	for k := 1; k+1 < len(waypoints); k++ {
+		prev, corner, next := waypoints[k-1], waypoints[k], waypoints[k+1]
+		in := newLinearSegment(prev, corner).direction
+		out := newLinearSegment(corner, next).direction
+		angle := math.Acos(math.Max(-1, math.Min(1, vecDot(in, out))))
+		if angle < timingEpsilon {
+			// the path carries straight on.
+			add(newLinearSegment(current, corner), false)
+			current = corner
+			continue
+		}
+		// the arc may take up at most half of each segment, so that it does not overlap the arcs
+		// at either end.
+		blend := math.Min(vecDist(prev, corner), vecDist(corner, next)) / 2
+		if half := angle / 2; maxDeviation > 0 && angle < math.Pi-1e-6 {
+			blend = math.Min(blend, maxDeviation*math.Sin(half)/(1-math.Cos(half)))
+		} else {
+			blend = 0
+		}
+		if blend < timingEpsilon {
+			add(newLinearSegment(current, corner), true)
+			current = corner
+			continue
+		}
+
+		half := angle / 2
+		radius := blend / math.Tan(half)
+		bisector := make([]float64, len(corner))
+		for i := range bisector {
+			bisector[i] = out[i] - in[i]
+		}
+		bisectorNorm := vecNorm(bisector)
+		arcStart := make([]float64, len(corner))
+		arcEnd := make([]float64, len(corner))
+		center := make([]float64, len(corner))
+		x := make([]float64, len(corner))
+		for i := range corner {
+			arcStart[i] = corner[i] - blend*in[i]
+			arcEnd[i] = corner[i] + blend*out[i]
+			center[i] = corner[i] + bisector[i]/bisectorNorm*radius/math.Cos(half)
+			x[i] = (arcStart[i] - center[i]) / radius
+		}
+		add(newLinearSegment(current, arcStart), false)
+		add(&circularSegment{center: center, x: x, y: in, radius: radius, angle: angle}, false)
+		current = arcEnd
+	}
+	add(newLinearSegment(current, waypoints[len(waypoints)-1]), false)
+	return path
+}
+
+// timingGrid is the path sampled at the points where the limits are enforced.
+type timingGrid struct {
+	// steps holds the distance from each point to the next.
+	steps []float64
+	// positions, tangents and curvatures are of the path at each point, as seen from the step
+	// after it, and endTangents and endCurvatures as seen from the step before it.
+	positions, tangents, curvatures [][]float64
+	endTangents, endCurvatures      [][]float64
+	// stops marks the points where the path must come to rest.
+	stops []bool
+}
+
+func (path *blendedPath) grid(resolution float64) *timingGrid {
+	total := 0.
+	for _, segment := range path.segments {
+		total += segment.length()
+	}
+	if resolution <= 0 {
+		resolution = total / defaultTimingSteps
+	}
+	resolution = math.Max(resolution, total/maxTimingSteps)
+
+	g := &timingGrid{}
+	for i, segment := range path.segments {
+		n := int(math.Max(1, math.Ceil(segment.length()/resolution)))
+		step := segment.length() / float64(n)
+		for k := 0; k < n; k++ {
+			q, tangent, curvature := segment.evaluate(float64(k) * step)
+			_, endTangent, endCurvature := segment.evaluate(float64(k+1) * step)
+			g.positions = append(g.positions, q)
+			g.tangents = append(g.tangents, tangent)
+			g.curvatures = append(g.curvatures, curvature)
+			g.endTangents = append(g.endTangents, endTangent)
+			g.endCurvatures = append(g.endCurvatures, endCurvature)
+			g.steps = append(g.steps, step)
+			g.stops = append(g.stops, k == 0 && i > 0 && path.stops[i-1])
+		}
+	}
+	last := path.segments[len(path.segments)-1]
+	q, tangent, curvature := last.evaluate(last.length())
+	g.positions = append(g.positions, q)
+	g.tangents = append(g.tangents, tangent)
+	g.curvatures = append(g.curvatures, curvature)
+	g.stops = append(g.stops, true)
+	return g
+}
+
+// timingProblem finds the fastest way along a path within velocity and acceleration limits by
+// reachability analysis (TOPP-RA). The path speed is squared, x = ṡ², so that its change over a
+// step is linear in the path acceleration u = s̈, and the limits are linear in both.
+type timingProblem struct {
+	grid                 *timingGrid
+	velLimits, accLimits []float64
+	// speeds holds x at each point of the grid and accels u over each step.
+	speeds, accels []float64
+}
+
+// velocityBound returns the largest x at a point within the velocity limits.
+func (p *timingProblem) velocityBound(i int) float64 {
+	bound := math.Inf(1)
+	for j, a := range p.grid.tangents[i] {
+		if math.Abs(a) > timingEpsilon {
+			bound = math.Min(bound, math.Pow(p.velLimits[j]/a, 2))
+		}
+	}
+	return bound
+}
+
+// accelBounds returns the range of u at the start of a step, moving at x, within the
+// acceleration limits, given that u must also be within [lo, hi].
+func (p *timingProblem) accelBounds(i int, x, lo, hi float64) (float64, float64, bool) {
+	for j, a := range p.grid.tangents[i] {
+		b := p.grid.curvatures[i][j]
+		limit := p.accLimits[j]
+		if math.Abs(a) <= timingEpsilon {
+			if math.Abs(b*x) > limit {
+				return 0, 0, false
+			}
+			continue
+		}
+		l, h := (-limit-b*x)/a, (limit-b*x)/a
+		if a < 0 {
+			l, h = h, l
+		}
+		lo, hi = math.Max(lo, l), math.Min(hi, h)
+	}
+	return lo, hi, lo <= hi+timingEpsilon
+}
+
+func (p *timingProblem) solve() error {
+	g := p.grid
+	n := len(g.steps)
+
+	// going backwards from rest at the end, find the fastest each point may be passed while still
+	// being able to slow down in time for everything after it.
+	reachable := make([]float64, n+1)
+	for i := n - 1; i >= 0; i-- {
+		if g.stops[i] {
+			continue
+		}
+		step := g.steps[i]
+		next := reachable[i+1]
+		feasible := func(x float64) bool {
+			_, _, ok := p.accelBounds(i, x, -x/(2*step), (next-x)/(2*step))
+			return ok
+		}
+		bound := p.velocityBound(i)
+		if math.IsInf(bound, 1) {
+			return errors.New("path does not move any limited input")
+		}
+		if feasible(bound) {
+			reachable[i] = bound
+			continue
+		}
+		lo, hi := 0., bound
+		for iter := 0; iter < 60; iter++ {
+			mid := (lo + hi) / 2
+			if feasible(mid) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		reachable[i] = lo
+	}
+
+	// going forwards from rest at the start, accelerate as hard as possible while staying within
+	// what was found to be reachable.
+	p.speeds = make([]float64, n+1)
+	p.accels = make([]float64, n)
+	for i := 0; i < n; i++ {
+		x, step := p.speeds[i], g.steps[i]
+		_, hi, ok := p.accelBounds(i, x, -x/(2*step), (reachable[i+1]-x)/(2*step))
+		if !ok {
+			// only possible through rounding, as x was reachable.
+			hi = (reachable[i+1] - x) / (2 * step)
+		}
+		next := math.Max(0, math.Min(reachable[i+1], x+2*step*hi))
+		if g.stops[i+1] {
+			next = 0
+		}
+		if x+next <= 0 {
+			return fmt.Errorf("cannot move along the path within the limits at %d of %d steps", i, n)
+		}
+		p.speeds[i+1] = next
+		p.accels[i] = (next - x) / (2 * step)
+	}
+	return nil
+}
+
+// knots returns the times, positions, velocities and accelerations of the inputs at each point
+// of the grid.
+func (p *timingProblem) knots() ([]float64, [][]float64, [][]float64, [][]float64) {
+	g := p.grid
+	n := len(g.steps)
+	times := make([]float64, n+1)
+	velocities := make([][]float64, n+1)
+	accelerations := make([][]float64, n+1)
+	for i := 0; i <= n; i++ {
+		if i > 0 {
+			times[i] = times[i-1] + 2*g.steps[i-1]/(math.Sqrt(p.speeds[i-1])+math.Sqrt(p.speeds[i]))
+		}
+		speed := math.Sqrt(p.speeds[i])
+		velocities[i] = make([]float64, len(g.positions[i]))
+		accelerations[i] = make([]float64, len(g.positions[i]))
+		for j := range velocities[i] {
+			velocities[i][j] = g.tangents[i][j] * speed
+			// the acceleration at a point is averaged over the steps either side of it, which
+			// keeps it continuous.
+			var sum float64
+			var count int
+			if i < n {
+				sum += g.tangents[i][j]*p.accels[i] + g.curvatures[i][j]*p.speeds[i]
+				count++
+			}
+			if i > 0 {
+				sum += g.endTangents[i-1][j]*p.accels[i-1] + g.endCurvatures[i-1][j]*p.speeds[i]
+				count++
+			}
+			accelerations[i][j] = sum / float64(count)
+		}
+	}
+	return times, g.positions, velocities, accelerations
+}
+
+// TimedPlan is a Plan along with the timing of its trajectory.
+type TimedPlan struct {
+	Plan
+	timing *TimedTrajectory
+}
+
+// NewTimedPlan times the trajectory of a plan.
+func NewTimedPlan(plan Plan, opts TimingOptions) (*TimedPlan, error) {
+	timing, err := TimeParameterize(plan.Trajectory(), opts)
+	if err != nil {
+		return nil, err
+	}
+	return &TimedPlan{Plan: plan, timing: timing}, nil
+}
+
+// Timing returns the timing of the plan's trajectory.
+func (p *TimedPlan) Timing() *TimedTrajectory {
+	return p.timing
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds * float64(time.Second)))
+}
+
+func vecDot(a, b []float64) float64 {
+	var dot float64
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
+
+func vecNorm(a []float64) float64 {
+	return math.Sqrt(vecDot(a, a))
+}
+
+func vecDist(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,151 @@
+package motionplan
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// checkWithinLimits checks the timed trajectory at many points along it, returning the largest
+// ratio of velocity, acceleration and jerk to their limits of any input.
+func checkWithinLimits(t *testing.T, tt *TimedTrajectory, limits []referenceframe.DynamicLimit) (float64, float64, float64) {
+	t.Helper()
+	var vel, acc, jerk float64
+	for i := 0; i+1 < len(tt.times); i++ {
+		h := tt.times[i+1] - tt.times[i]
+		test.That(t, h, test.ShouldBeGreaterThan, 0)
+		for j, limit := range limits {
+			c := tt.quintic(i, j)
+			for step := 0; step <= 20; step++ {
+				_, qd, qdd, qddd := c.at(h * float64(step) / 20)
+				vel = math.Max(vel, math.Abs(qd)/limit.Velocity)
+				acc = math.Max(acc, math.Abs(qdd)/limit.Acceleration)
+				if limit.Jerk > 0 {
+					jerk = math.Max(jerk, math.Abs(qddd)/limit.Jerk)
+				}
+			}
+		}
+	}
+	return vel, acc, jerk
+}
+
+func TestTimeParameterizeStraightLine(t *testing.T) {
+	limits := []referenceframe.DynamicLimit{{Velocity: 1, Acceleration: 2}, {Velocity: 1, Acceleration: 2}}
+	traj := Trajectory{
+		{"arm": {0, 0}, "gripper": {5}},
+		{"arm": {2, 0}, "gripper": {5}},
+	}
+	tt, err := TimeParameterize(traj, TimingOptions{Limits: map[string][]referenceframe.DynamicLimit{"arm": limits}})
+	test.That(t, err, test.ShouldBeNil)
+
+	// accelerating to full speed takes 0.5s and 0.25 rad, as does slowing down, leaving 1.5 rad
+	// to cover at full speed.
+	test.That(t, tt.Duration().Seconds(), test.ShouldAlmostEqual, 2.5, 0.02)
+	vel, acc, _ := checkWithinLimits(t, tt, limits)
+	test.That(t, vel, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, acc, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+
+	test.That(t, tt.At(0), test.ShouldResemble, traj[0])
+	test.That(t, tt.At(tt.Duration())["arm"][0], test.ShouldAlmostEqual, 2)
+	test.That(t, tt.At(time.Hour)["arm"][0], test.ShouldAlmostEqual, 2)
+	halfway := tt.Duration() / 2
+	test.That(t, tt.At(halfway)["arm"][0], test.ShouldAlmostEqual, 1, 1e-3)
+	test.That(t, tt.VelocitiesAt(halfway)["arm"][0], test.ShouldAlmostEqual, 1, 1e-3)
+	test.That(t, tt.VelocitiesAt(halfway)["gripper"], test.ShouldResemble, []float64{0})
+
+	samples, err := tt.Sample("arm", 100*time.Millisecond)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(samples), test.ShouldBeGreaterThanOrEqualTo, 26)
+	test.That(t, samples[0].Time, test.ShouldEqual, 0)
+	last := samples[len(samples)-1]
+	test.That(t, last.Time, test.ShouldEqual, tt.Duration())
+	test.That(t, last.Inputs[0], test.ShouldAlmostEqual, 2)
+	test.That(t, last.Velocities[0], test.ShouldAlmostEqual, 0)
+	for i := 1; i < len(samples); i++ {
+		test.That(t, samples[i].Inputs[0], test.ShouldBeGreaterThanOrEqualTo, samples[i-1].Inputs[0]-1e-9)
+	}
+
+	_, err = tt.Sample("arm", 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = tt.Sample("nope", time.Second)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestTimeParameterizeCorners(t *testing.T) {
+	limits := []referenceframe.DynamicLimit{{Velocity: 1, Acceleration: 1}, {Velocity: 1, Acceleration: 1}}
+	traj := Trajectory{
+		{"arm": {0, 0}},
+		{"arm": {1, 0}},
+		{"arm": {1, 1}},
+	}
+	stopped, err := TimeParameterize(traj, TimingOptions{Limits: map[string][]referenceframe.DynamicLimit{"arm": limits}})
+	test.That(t, err, test.ShouldBeNil)
+	vel, acc, _ := checkWithinLimits(t, stopped, limits)
+	test.That(t, vel, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, acc, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	// without blending, the trajectory passes exactly through the corner, at rest.
+	passesCorner := false
+	for i, q := range stopped.positions {
+		if vecDist(q, []float64{1, 0}) < 1e-9 {
+			passesCorner = true
+			test.That(t, vecNorm(stopped.velocities[i]), test.ShouldAlmostEqual, 0)
+		}
+	}
+	test.That(t, passesCorner, test.ShouldBeTrue)
+
+	blended, err := TimeParameterize(traj, TimingOptions{
+		Limits:            map[string][]referenceframe.DynamicLimit{"arm": limits},
+		MaxBlendDeviation: 0.1,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	vel, acc, _ = checkWithinLimits(t, blended, limits)
+	test.That(t, vel, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, acc, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, blended.Duration(), test.ShouldBeLessThan, stopped.Duration())
+	closest := math.Inf(1)
+	for d := time.Duration(0); d <= blended.Duration(); d += time.Millisecond {
+		closest = math.Min(closest, vecDist(blended.At(d)["arm"], []float64{1, 0}))
+	}
+	test.That(t, closest, test.ShouldBeGreaterThan, 0)
+	test.That(t, closest, test.ShouldBeLessThanOrEqualTo, 0.1+1e-3)
+	test.That(t, blended.At(blended.Duration())["arm"], test.ShouldResemble, []float64{1, 1})
+}
+
+func TestTimeParameterizeJerk(t *testing.T) {
+	limits := []referenceframe.DynamicLimit{{Velocity: 1, Acceleration: 2}}
+	traj := Trajectory{{"arm": {0}}, {"arm": {1}}}
+	unlimited, err := TimeParameterize(traj, TimingOptions{Limits: map[string][]referenceframe.DynamicLimit{"arm": limits}})
+	test.That(t, err, test.ShouldBeNil)
+
+	limits[0].Jerk = 20
+	limited, err := TimeParameterize(traj, TimingOptions{Limits: map[string][]referenceframe.DynamicLimit{"arm": limits}})
+	test.That(t, err, test.ShouldBeNil)
+	vel, acc, jerk := checkWithinLimits(t, limited, limits)
+	test.That(t, vel, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, acc, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, jerk, test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+	test.That(t, limited.Duration(), test.ShouldBeGreaterThan, unlimited.Duration())
+}
+
+func TestTimeParameterizeErrors(t *testing.T) {
+	traj := Trajectory{{"arm": {0}}, {"arm": {1}}}
+	_, err := TimeParameterize(nil, TimingOptions{})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = TimeParameterize(traj, TimingOptions{})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no velocity and acceleration limits")
+	_, err = TimeParameterize(traj, TimingOptions{Limits: map[string][]referenceframe.DynamicLimit{"arm": {{}, {}}}})
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = TimeParameterize(Trajectory{{"arm": {0}}, {"arm": {1, 2}}}, TimingOptions{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// nothing needs limits if nothing moves.
+	tt, err := TimeParameterize(Trajectory{{"arm": {0}}, {"arm": {0}}}, TimingOptions{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, tt.Duration(), test.ShouldEqual, 0)
+	test.That(t, tt.At(time.Second), test.ShouldResemble, referenceframe.FrameSystemInputs{"arm": {0}})
+}
@@ -0,0 +1,196 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultHistorySize is the number of completed operations a Manager remembers.
+const DefaultHistorySize = 100
+
+// historyMinDuration is how long an operation must run for to be remembered if it
+// succeeded without reporting progress. This keeps frequent short calls, such as
+// polling for readings, from pushing long-running operations out of the history.
+const historyMinDuration = time.Second
+
+// Status describes how an operation ended.
+type Status string
+
+const (
+	// StatusSucceeded means the operation completed without an error.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed means the operation returned an error.
+	StatusFailed Status = "failed"
+	// StatusCancelled means the operation was cancelled, either explicitly or because
+	// its caller went away.
+	StatusCancelled Status = "cancelled"
+)
+
+// Progress is how far along a long-running operation is, e.g. step 3 of 7 of a move.
+type Progress struct {
+	// Current is the step being executed, starting at 1. Zero means no step has started.
+	Current int `json:"current,omitempty"`
+	// Total is the number of steps, if known.
+	Total int `json:"total,omitempty"`
+	// Message describes what the operation is currently doing.
+	Message string `json:"message,omitempty"`
+}
+
+// IsZero returns whether no progress has been reported.
+func (p Progress) IsZero() bool {
+	return p == Progress{}
+}
+
+func (p Progress) String() string {
+	var steps string
+	switch {
+	case p.Total > 0:
+		steps = fmt.Sprintf("%d of %d", p.Current, p.Total)
+	case p.Current > 0:
+		steps = fmt.Sprintf("%d", p.Current)
+	}
+	switch {
+	case steps == "":
+		return p.Message
+	case p.Message == "":
+		return steps
+	default:
+		return p.Message + " " + steps
+	}
+}
+
+// Summary describes a running operation.
+type Summary struct {
+	ID        uuid.UUID `json:"id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Method    string    `json:"method"`
+	Started   time.Time `json:"started"`
+	Progress  Progress  `json:"progress"`
+}
+
+// Summary returns a description of the operation.
+func (o *Operation) Summary() Summary {
+	return Summary{
+		ID:        o.ID,
+		SessionID: o.SessionID,
+		Method:    o.Method,
+		Started:   o.Started,
+		Progress:  o.Progress(),
+	}
+}
+
+// CompletedOperation is a record of an operation that has completed.
+type CompletedOperation struct {
+	ID        uuid.UUID `json:"id"`
+	SessionID uuid.UUID `json:"session_id"`
+	Method    string    `json:"method"`
+	Started   time.Time `json:"started"`
+	Ended     time.Time `json:"ended"`
+	Status    Status    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	// Progress is the last progress the operation reported.
+	Progress Progress `json:"progress"`
+}
+
+// Duration returns how long the operation ran for.
+func (c CompletedOperation) Duration() time.Duration {
+	return c.Ended.Sub(c.Started)
+}
+
+// SetProgress reports how far along the operation is.
+func (o *Operation) SetProgress(progress Progress) {
+	o.stateLock.Lock()
+	defer o.stateLock.Unlock()
+	o.progress = progress
+}
+
+// Progress returns the last progress reported by the operation.
+func (o *Operation) Progress() Progress {
+	o.stateLock.Lock()
+	defer o.stateLock.Unlock()
+	return o.progress
+}
+
+// SetResult records the error, if any, that the operation completed with.
+func (o *Operation) SetResult(err error) {
+	o.stateLock.Lock()
+	defer o.stateLock.Unlock()
+	o.err = err
+}
+
+// SetProgress reports how far along the current Operation is.
+// if no Operation is set, will do nothing.
+func SetProgress(ctx context.Context, progress Progress) {
+	if o := Get(ctx); o != nil {
+		o.SetProgress(progress)
+	}
+}
+
+func (m *Manager) recordCompleted(o *Operation, ended time.Time) {
+	o.stateLock.Lock()
+	completed := CompletedOperation{
+		ID:        o.ID,
+		SessionID: o.SessionID,
+		Method:    o.Method,
+		Started:   o.Started,
+		Ended:     ended,
+		Status:    StatusSucceeded,
+		Progress:  o.progress,
+	}
+	switch {
+	case o.cancelled || errors.Is(o.err, context.Canceled):
+		completed.Status = StatusCancelled
+	case o.err != nil:
+		completed.Status = StatusFailed
+	}
+	if o.err != nil {
+		completed.Error = o.err.Error()
+	}
+	o.stateLock.Unlock()
+
+	if completed.Status == StatusSucceeded && completed.Progress.IsZero() && completed.Duration() < historyMinDuration {
+		return
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.history = append(m.history, completed)
+	if len(m.history) > m.historySize {
+		m.history = m.history[len(m.history)-m.historySize:]
+	}
+}
+
+// Report is a snapshot of the running operations, with their progress, and the recently
+// completed operations.
+type Report struct {
+	Running []Summary            `json:"running"`
+	History []CompletedOperation `json:"history"`
+}
+
+// History returns recently completed operations, newest first. Operations that
+// succeeded quickly without reporting progress are not remembered.
+func (m *Manager) History() []CompletedOperation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	history := make([]CompletedOperation, 0, len(m.history))
+	for i := len(m.history) - 1; i >= 0; i-- {
+		history = append(history, m.history[i])
+	}
+	return history
+}
+
+// FindCompleted returns a completed operation from the history.
+func (m *Manager) FindCompleted(id string) (CompletedOperation, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if m.history[i].ID.String() == id {
+			return m.history[i], true
+		}
+	}
+	return CompletedOperation{}, false
+}
@@ -0,0 +1,73 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	m := NewManager(logger)
+
+	// quick successful operations are not remembered.
+	_, done := m.Create(ctx, "/viam.component.arm.v1.ArmService/GetEndPosition", nil)
+	done()
+	test.That(t, m.History(), test.ShouldBeEmpty)
+
+	moveCtx, done := m.Create(ctx, "/viam.service.motion.v1.MotionService/Move", nil)
+	op := Get(moveCtx)
+	SetProgress(moveCtx, Progress{Current: 3, Total: 7, Message: "executing move"})
+	test.That(t, op.Summary().Progress.String(), test.ShouldEqual, "executing move 3 of 7")
+	done()
+
+	_, done = m.Create(ctx, "/viam.component.gantry.v1.GantryService/Home", nil)
+	m.All()[0].SetResult(errors.New("limit switch not found"))
+	done()
+
+	cancelCtx, done := m.Create(ctx, "/viam.component.arm.v1.ArmService/MoveToPosition", nil)
+	cancelled := Get(cancelCtx)
+	test.That(t, m.FindString(cancelled.ID.String()), test.ShouldNotBeNil)
+	cancelled.Cancel()
+	test.That(t, cancelCtx.Err(), test.ShouldNotBeNil)
+	done()
+
+	history := m.History()
+	test.That(t, history, test.ShouldHaveLength, 3)
+	test.That(t, history[0].Method, test.ShouldEqual, "/viam.component.arm.v1.ArmService/MoveToPosition")
+	test.That(t, history[0].Status, test.ShouldEqual, StatusCancelled)
+	test.That(t, history[1].Status, test.ShouldEqual, StatusFailed)
+	test.That(t, history[1].Error, test.ShouldEqual, "limit switch not found")
+	test.That(t, history[2].Status, test.ShouldEqual, StatusSucceeded)
+	test.That(t, history[2].Progress, test.ShouldResemble, Progress{Current: 3, Total: 7, Message: "executing move"})
+
+	completed, ok := m.FindCompleted(op.ID.String())
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, completed.Duration(), test.ShouldBeGreaterThanOrEqualTo, time.Duration(0))
+	test.That(t, m.All(), test.ShouldBeEmpty)
+
+	// the history is bounded.
+	m.historySize = 2
+	_, done = m.Create(ctx, "/viam.robot.v1.RobotService/StopAll", nil)
+	m.All()[0].SetResult(context.Canceled)
+	done()
+	history = m.History()
+	test.That(t, history, test.ShouldHaveLength, 2)
+	test.That(t, history[0].Method, test.ShouldEqual, "/viam.robot.v1.RobotService/StopAll")
+	test.That(t, history[0].Status, test.ShouldEqual, StatusCancelled)
+	_, ok = m.FindCompleted(op.ID.String())
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestProgressString(t *testing.T) {
+	test.That(t, Progress{}.String(), test.ShouldEqual, "")
+	test.That(t, Progress{Message: "planning"}.String(), test.ShouldEqual, "planning")
+	test.That(t, Progress{Current: 2}.String(), test.ShouldEqual, "2")
+	test.That(t, Progress{Current: 2, Total: 5}.String(), test.ShouldEqual, "2 of 5")
+}
@@ -35,10 +35,20 @@ type Operation struct {
 	cancel     context.CancelFunc
 	labelsLock sync.Mutex
 	labels     []string
+
+	// stateLock guards the fields below, which are recorded in the history once the
+	// operation completes.
+	stateLock sync.Mutex
+	progress  Progress
+	err       error
+	cancelled bool
 }
 
 // Cancel cancel the context associated with an operation.
 func (o *Operation) Cancel() {
+	o.stateLock.Lock()
+	o.cancelled = true
+	o.stateLock.Unlock()
 	o.cancel()
 }
 
@@ -73,12 +83,13 @@ func (o *Operation) CancelOtherWithLabel(label string) {
 
 func (o *Operation) cleanup() {
 	o.myManager.remove(o.ID)
+	o.myManager.recordCompleted(o, time.Now())
 }
 
 // NewManager creates a new manager for holding Operations.
 func NewManager(logger logging.Logger) *Manager {
 	opLogger := logger.Sublogger("operation_manager")
-	return &Manager{ops: map[string]*Operation{}, logger: opLogger}
+	return &Manager{ops: map[string]*Operation{}, historySize: DefaultHistorySize, logger: opLogger}
 }
 
 // Manager holds Operations.
@@ -86,6 +97,10 @@ type Manager struct {
 	ops    map[string]*Operation
 	lock   sync.Mutex
 	logger logging.Logger
+
+	// history holds recently completed operations, oldest first.
+	history     []CompletedOperation
+	historySize int
 }
 
 func (m *Manager) remove(id uuid.UUID) {
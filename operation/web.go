@@ -67,7 +67,11 @@ func (m *Manager) UnaryServerInterceptor(
 			m.logger.CDebugw(ctx, "error while setting header", "err", err)
 		}
 	}
-	return handler(ctx, req)
+	resp, err := handler(ctx, req)
+	if op := Get(ctx); op != nil {
+		op.SetResult(err)
+	}
+	return resp, err
 }
 
 // StreamServerInterceptor creates a new operation in the current context before passing
@@ -84,7 +88,11 @@ func (m *Manager) StreamServerInterceptor(
 	if op := Get(ctx); op != nil && op.ID.String() != "" {
 		utils.UncheckedError(ss.SetHeader(metadata.MD{opidMetadataKey: []string{op.ID.String()}}))
 	}
-	return handler(srv, &ssStreamContextWrapper{ss, ctx})
+	err := handler(srv, &ssStreamContextWrapper{ss, ctx})
+	if op := Get(ctx); op != nil {
+		op.SetResult(err)
+	}
+	return err
 }
 
 // CreateFromIncomingContext creates a new operation from an incoming context.
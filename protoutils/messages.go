@@ -4,9 +4,11 @@ package protoutils
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/golang/geo/r3"
 	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	robotpb "go.viam.com/api/robot/v1"
 	"go.viam.com/utils/protoutils"
@@ -192,13 +194,53 @@ func DoFromResourceClient(ctx context.Context, svc ClientDoCommander, name strin
 	return resp.Result.AsMap(), nil
 }
 
-// DoFromResourceServer is a helper to allow DoCommand() calls from any server.
+// SafeStopFromResourceClient is a helper to allow SafeStop() calls from any client. The
+// resource APIs have no SafeStop method, so it is sent as a resource.DoSafeStop DoCommand.
+// resource.ErrSafeStopUnsupported is returned if the resource does not implement
+// resource.SafeStopper, or if its server predates the command and does not implement DoCommand.
+func SafeStopFromResourceClient(ctx context.Context, svc ClientDoCommander, name string, extra map[string]interface{}) error {
+	if extra == nil {
+		extra = map[string]interface{}{}
+	}
+	resp, err := DoFromResourceClient(ctx, svc, name, map[string]interface{}{resource.DoSafeStop: extra})
+	if err != nil {
+		if strings.Contains(err.Error(), resource.ErrDoUnimplemented.Error()) {
+			return resource.ErrSafeStopUnsupported
+		}
+		return err
+	}
+	if stopped, _ := resp[resource.DoSafeStop].(bool); !stopped {
+		return resource.ErrSafeStopUnsupported
+	}
+	return nil
+}
+
+// DoFromResourceServer is a helper to allow DoCommand() calls from any server. It answers
+// resource.DoSafeStop itself, with false for resources that do not implement
+// resource.SafeStopper.
 func DoFromResourceServer(
 	ctx context.Context,
 	res resource.Resource,
 	req *commonpb.DoCommandRequest,
 ) (*commonpb.DoCommandResponse, error) {
-	resp, err := res.DoCommand(ctx, req.Command.AsMap())
+	cmd := req.Command.AsMap()
+	if extra, ok := cmd[resource.DoSafeStop]; ok {
+		stopped := false
+		if stopper, ok := res.(resource.SafeStopper); ok {
+			extraMap, _ := extra.(map[string]interface{})
+			err := stopper.SafeStop(ctx, extraMap)
+			if err != nil && !errors.Is(err, resource.ErrSafeStopUnsupported) {
+				return nil, err
+			}
+			stopped = err == nil
+		}
+		pbRes, err := protoutils.StructToStructPb(map[string]interface{}{resource.DoSafeStop: stopped})
+		if err != nil {
+			return nil, err
+		}
+		return &commonpb.DoCommandResponse{Result: pbRes}, nil
+	}
+	resp, err := res.DoCommand(ctx, cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -196,6 +196,21 @@ type Limited interface {
 	DoF() []Limit
 }
 
+// DynamicLimit is how fast a degree of freedom may change, in radians or mm per second, per
+// second squared and per second cubed. Zero means unknown.
+type DynamicLimit struct {
+	Velocity     float64 `json:"velocity,omitempty"`
+	Acceleration float64 `json:"acceleration,omitempty"`
+	Jerk         float64 `json:"jerk,omitempty"`
+}
+
+// DynamicallyLimited represents anything that knows how fast its degrees of freedom may change.
+type DynamicallyLimited interface {
+	// DynamicLimits returns a limit for each degree of freedom, in the same order as DoF, or nil
+	// if none are known.
+	DynamicLimits() []DynamicLimit
+}
+
 // Frame represents a reference frame, e.g. an arm, a joint, a gripper, a board, etc.
 type Frame interface {
 	Limited
@@ -56,6 +56,11 @@ type JointConfig struct {
 	Min      float64                 `json:"min"`                // in mm or degs
 	Geometry *spatial.GeometryConfig `json:"geometry,omitempty"` // only valid for prismatic/translational joints
 	Mimic    *MimicConfig            `json:"mimic,omitempty"`
+	// Maximum velocity, acceleration and jerk of the joint in mm or degs per second, per second
+	// squared and per second cubed. Zero means unknown.
+	MaxVel  float64 `json:"max_vel,omitempty"`
+	MaxAcc  float64 `json:"max_acc,omitempty"`
+	MaxJerk float64 `json:"max_jerk,omitempty"`
 }
 
 // DHParamConfig is a revolute and static frame combined in a set of Denavit Hartenberg parameters.
@@ -68,6 +73,11 @@ type DHParamConfig struct {
 	Max      float64                 `json:"max"` // in mm or degs
 	Min      float64                 `json:"min"` // in mm or degs
 	Geometry *spatial.GeometryConfig `json:"geometry,omitempty"`
+	// Maximum velocity, acceleration and jerk of the joint in degs per second, per second
+	// squared and per second cubed. Zero means unknown.
+	MaxVel  float64 `json:"max_vel,omitempty"`
+	MaxAcc  float64 `json:"max_acc,omitempty"`
+	MaxJerk float64 `json:"max_jerk,omitempty"`
 }
 
 // NewLinkConfig constructs a config from a Frame.
@@ -1,5 +1,7 @@
 package referenceframe
 
+import "go.viam.com/rdk/spatialmath"
+
 // Hash returns a hash value for this frame system.
 func (sfs *FrameSystem) Hash() int {
 	hash := len(sfs.frames) * 1000
@@ -13,6 +15,34 @@ func (sfs *FrameSystem) Hash() int {
 	return hash
 }
 
+// Hash returns a hash value for the obstacles and transforms of this world state.
+func (ws *WorldState) Hash() int {
+	if ws == nil {
+		return 0
+	}
+	hash := len(ws.obstacleNames)*1000 + len(ws.transforms)*100
+
+	for _, gf := range ws.obstacles {
+		for _, g := range gf.geometries {
+			hash += hashString(gf.frame)
+			hash += hashString(g.Label())
+			hash += g.Hash()
+		}
+	}
+	for _, lif := range ws.transforms {
+		hash += hashString(lif.name)
+		hash += 3 * hashString(lif.parent)
+		if lif.pose != nil {
+			hash += spatialmath.HashPose(lif.pose)
+		}
+		if lif.geometry != nil {
+			hash += 111 * lif.geometry.Hash()
+		}
+	}
+
+	return hash
+}
+
 func hashString(s string) int {
 	hash := 0
 	for idx, c := range s {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"time"
 
 	pb "go.viam.com/api/component/arm/v1"
 	"gonum.org/v1/gonum/floats"
@@ -17,6 +18,14 @@ import (
 //   - prismatic inputs should be in mm.
 type Input = float64
 
+// TimedInputs are the inputs a frame should be at, and how fast they should be changing, at a time
+// measured from the start of a trajectory.
+type TimedInputs struct {
+	Time       time.Duration
+	Inputs     []Input
+	Velocities []Input
+}
+
 // JointPositionsFromInputs converts the given slice of Input to a JointPositions struct,
 // using the ProtobufFromInput function provided by the given Frame.
 func JointPositionsFromInputs(f Frame, inputs []Input) (*pb.JointPositions, error) {
@@ -15,6 +15,7 @@ import (
 	"gonum.org/v1/gonum/num/quat"
 
 	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
 )
 
 // A Model represents a frame that can change its name, and can return itself as a ModelConfig struct.
@@ -375,6 +376,49 @@ func (m *SimpleModel) ModelConfig() *ModelConfigJSON {
 	return m.modelConfig
 }
 
+// DynamicLimits returns the velocity, acceleration and jerk limits of the model's joints given in
+// its config, in the same order as DoF, or nil if there are none.
+func (m *SimpleModel) DynamicLimits() []DynamicLimit {
+	if m.modelConfig == nil {
+		return nil
+	}
+	byJoint := map[string]DynamicLimit{}
+	for _, joint := range m.modelConfig.Joints {
+		limit := DynamicLimit{Velocity: joint.MaxVel, Acceleration: joint.MaxAcc, Jerk: joint.MaxJerk}
+		if joint.Type == RevoluteJoint {
+			limit = DynamicLimit{
+				Velocity:     utils.DegToRad(joint.MaxVel),
+				Acceleration: utils.DegToRad(joint.MaxAcc),
+				Jerk:         utils.DegToRad(joint.MaxJerk),
+			}
+		}
+		byJoint[joint.ID] = limit
+	}
+	for _, dh := range m.modelConfig.DHParams {
+		byJoint[dh.ID+"_j"] = DynamicLimit{
+			Velocity:     utils.DegToRad(dh.MaxVel),
+			Acceleration: utils.DegToRad(dh.MaxAcc),
+			Jerk:         utils.DegToRad(dh.MaxJerk),
+		}
+	}
+
+	var limits []DynamicLimit
+	known := false
+	for _, name := range m.MoveableFrameNames() {
+		limit := byJoint[name]
+		if limit != (DynamicLimit{}) {
+			known = true
+		}
+		for range m.internalFS.Frame(name).DoF() {
+			limits = append(limits, limit)
+		}
+	}
+	if !known || len(limits) != len(m.DoF()) {
+		return nil
+	}
+	return limits
+}
+
 // Hash returns a hash value for this simple model.
 func (m *SimpleModel) Hash() int {
 	h := m.hash()
@@ -2,6 +2,7 @@ package referenceframe
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -93,6 +94,42 @@ func TestLimitsParsing(t *testing.T) {
 	test.That(t, simpleModelDeserialized.DoF()[0], test.ShouldResemble, Limit{0, 1})
 }
 
+func TestDynamicLimitsParsing(t *testing.T) {
+	model, err := ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/xarm6.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.(DynamicallyLimited).DynamicLimits(), test.ShouldBeNil)
+
+	jsonData := []byte(`{
+		"name": "limited",
+		"links": [
+			{"id": "base", "parent": "world"},
+			{"id": "arm", "parent": "waist", "translation": {"x": 100}}
+		],
+		"joints": [
+			{"id": "waist", "type": "revolute", "parent": "base", "axis": {"z": 1},
+				"max": 360, "min": -360, "max_vel": 180, "max_acc": 360, "max_jerk": 720},
+			{"id": "slide", "type": "prismatic", "parent": "arm", "axis": {"x": 1},
+				"max": 500, "min": 0, "max_vel": 250}
+		]
+	}`)
+	model, err = UnmarshalModelJSON(jsonData, "")
+	test.That(t, err, test.ShouldBeNil)
+	limits := model.(DynamicallyLimited).DynamicLimits()
+	test.That(t, len(limits), test.ShouldEqual, 2)
+	test.That(t, limits[0].Velocity, test.ShouldAlmostEqual, math.Pi)
+	test.That(t, limits[0].Acceleration, test.ShouldAlmostEqual, 2*math.Pi)
+	test.That(t, limits[0].Jerk, test.ShouldAlmostEqual, 4*math.Pi)
+	test.That(t, limits[1], test.ShouldResemble, DynamicLimit{Velocity: 250})
+
+	// velocity limits in URDFs are in radians per second.
+	model, err = ParseModelXMLFile(utils.ResolveFile("referenceframe/testfiles/ur5e.urdf"), "", nil)
+	test.That(t, err, test.ShouldBeNil)
+	limits = model.(DynamicallyLimited).DynamicLimits()
+	test.That(t, len(limits), test.ShouldEqual, 6)
+	test.That(t, limits[0].Velocity, test.ShouldAlmostEqual, 3.141592)
+	test.That(t, limits[0].Acceleration, test.ShouldEqual, 0)
+}
+
 // Tests that yml files are properly parsed and correctly loaded into the model
 // Should not need to actually test the contained rotation/translation values
 // since that will be caught by tests to the actual kinematics
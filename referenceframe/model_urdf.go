@@ -172,10 +172,15 @@ func UnmarshalModelXML(
 			case ContinuousJoint:
 				thisJoint.Type = RevoluteJoint // Currently, we treate a continuous joint as a special case of a revolute joint
 				thisJoint.Min, thisJoint.Max = math.Inf(-1), math.Inf(1)
+				if jointElem.Limit != nil {
+					thisJoint.MaxVel = utils.RadToDeg(jointElem.Limit.Velocity)
+				}
 			case PrismaticJoint:
 				thisJoint.Min, thisJoint.Max = utils.MetersToMM(jointElem.Limit.Lower), utils.MetersToMM(jointElem.Limit.Upper)
+				thisJoint.MaxVel = utils.MetersToMM(jointElem.Limit.Velocity)
 			case RevoluteJoint:
 				thisJoint.Min, thisJoint.Max = utils.RadToDeg(jointElem.Limit.Lower), utils.RadToDeg(jointElem.Limit.Upper)
+				thisJoint.MaxVel = utils.RadToDeg(jointElem.Limit.Velocity)
 			default:
 				return nil, err
 			}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/golang/geo/r3"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"go.viam.com/test"
 
@@ -78,3 +79,23 @@ func TestString(t *testing.T) {
 
 	test.That(t, fmt.Sprint(ws), test.ShouldEqual, testTable.Render())
 }
+
+func TestWorldStateHash(t *testing.T) {
+	var nilWS *WorldState
+	test.That(t, nilWS.Hash(), test.ShouldEqual, 0)
+
+	newWS := func(radius, x float64) *WorldState {
+		sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), radius, "foo")
+		test.That(t, err, test.ShouldBeNil)
+		ws, err := NewWorldState(
+			[]*GeometriesInFrame{NewGeometriesInFrame(World, []spatialmath.Geometry{sphere})},
+			[]*LinkInFrame{NewLinkInFrame(World, spatialmath.NewPoseFromPoint(r3.Vector{X: x}), "link", nil)},
+		)
+		test.That(t, err, test.ShouldBeNil)
+		return ws
+	}
+	test.That(t, newWS(10, 0).Hash(), test.ShouldEqual, newWS(10, 0).Hash())
+	test.That(t, newWS(10, 0).Hash(), test.ShouldNotEqual, newWS(20, 0).Hash())
+	test.That(t, newWS(10, 0).Hash(), test.ShouldNotEqual, newWS(10, 5).Hash())
+	test.That(t, newWS(10, 0).Hash(), test.ShouldNotEqual, NewEmptyWorldState().Hash())
+}
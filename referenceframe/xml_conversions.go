@@ -330,6 +330,8 @@ type limit struct {
 	XMLName xml.Name `xml:"limit"`
 	Lower   float64  `xml:"lower,attr"` // translation limits are in meters, revolute limits are in radians
 	Upper   float64  `xml:"upper,attr"` // translation limits are in meters, revolute limits are in radians
+	// Velocity is in meters or radians per second.
+	Velocity float64 `xml:"velocity,attr,omitempty"`
 }
 
 type mimicXML struct {
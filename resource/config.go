@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/pkg/errors"
@@ -24,6 +25,15 @@ type Config struct {
 	LogConfiguration *LogConfig
 	Attributes       utils.AttributeMap
 
+	// HealthCheck configures how the resource is probed for health. It is only read from
+	// local configs; see HealthCheckConfig.
+	HealthCheck *HealthCheckConfig
+
+	// ConfigurationTimeout overrides how long the resource is allowed to (re)configure
+	// for. If unset, utils.GetResourceConfigurationTimeout is used. The cloud config has
+	// no field for it, so it is only read from local configs.
+	ConfigurationTimeout time.Duration
+
 	AssociatedResourceConfigs []AssociatedResourceConfig
 	AssociatedAttributes      map[Name]AssociatedConfig
 	ConvertedAttributes       ConfigValidator
@@ -50,6 +60,8 @@ type typeSpecificConfigData struct {
 	Frame                     *referenceframe.LinkConfig `json:"frame,omitempty"`
 	DependsOn                 []string                   `json:"depends_on,omitempty"`
 	LogConfiguration          *LogConfig                 `json:"log_configuration,omitempty"`
+	HealthCheck               *HealthCheckConfig         `json:"health_check,omitempty"`
+	ConfigurationTimeout      string                     `json:"configuration_timeout,omitempty"`
 	AssociatedResourceConfigs []AssociatedResourceConfig `json:"service_configs,omitempty"`
 	Attributes                utils.AttributeMap         `json:"attributes,omitempty"`
 }
@@ -62,6 +74,8 @@ type configData struct {
 	Frame                     *referenceframe.LinkConfig `json:"frame,omitempty"`
 	DependsOn                 []string                   `json:"depends_on,omitempty"`
 	LogConfiguration          *LogConfig                 `json:"log_configuration,omitempty"`
+	HealthCheck               *HealthCheckConfig         `json:"health_check,omitempty"`
+	ConfigurationTimeout      string                     `json:"configuration_timeout,omitempty"`
 	AssociatedResourceConfigs []AssociatedResourceConfig `json:"service_configs,omitempty"`
 	Attributes                utils.AttributeMap         `json:"attributes,omitempty"`
 }
@@ -83,9 +97,10 @@ func (conf *Config) UnmarshalJSON(data []byte) error {
 		conf.Frame = confData.Frame
 		conf.DependsOn = confData.DependsOn
 		conf.LogConfiguration = confData.LogConfiguration
+		conf.HealthCheck = confData.HealthCheck
 		conf.AssociatedResourceConfigs = confData.AssociatedResourceConfigs
 		conf.Attributes = confData.Attributes
-		return nil
+		return conf.unmarshalConfigurationTimeout(confData.ConfigurationTimeout)
 	}
 
 	var typeSpecificConf typeSpecificConfigData
@@ -99,13 +114,31 @@ func (conf *Config) UnmarshalJSON(data []byte) error {
 	conf.Frame = typeSpecificConf.Frame
 	conf.DependsOn = typeSpecificConf.DependsOn
 	conf.LogConfiguration = typeSpecificConf.LogConfiguration
+	conf.HealthCheck = typeSpecificConf.HealthCheck
 	conf.AssociatedResourceConfigs = typeSpecificConf.AssociatedResourceConfigs
 	conf.Attributes = typeSpecificConf.Attributes
+	return conf.unmarshalConfigurationTimeout(typeSpecificConf.ConfigurationTimeout)
+}
+
+func (conf *Config) unmarshalConfigurationTimeout(timeout string) error {
+	conf.ConfigurationTimeout = 0
+	if timeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return errors.Wrap(err, "error parsing configuration_timeout")
+	}
+	conf.ConfigurationTimeout = d
 	return nil
 }
 
 // MarshalJSON marshals JSON from the config.
 func (conf Config) MarshalJSON() ([]byte, error) {
+	var timeout string
+	if conf.ConfigurationTimeout != 0 {
+		timeout = conf.ConfigurationTimeout.String()
+	}
 	return json.Marshal(configData{
 		Name:                      conf.Name,
 		API:                       conf.API,
@@ -113,6 +146,8 @@ func (conf Config) MarshalJSON() ([]byte, error) {
 		Frame:                     conf.Frame,
 		DependsOn:                 conf.DependsOn,
 		LogConfiguration:          conf.LogConfiguration,
+		HealthCheck:               conf.HealthCheck,
+		ConfigurationTimeout:      timeout,
 		AssociatedResourceConfigs: conf.AssociatedResourceConfigs,
 		Attributes:                conf.Attributes,
 	})
@@ -323,6 +358,14 @@ func (conf *Config) validate(path, defaultAPIType string) ([]string, []string, e
 	if err := conf.API.Validate(); err != nil {
 		return nil, nil, err
 	}
+	if conf.HealthCheck != nil {
+		if err := conf.HealthCheck.Validate(fmt.Sprintf("%s.health_check", path)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.ConfigurationTimeout < 0 {
+		return nil, nil, NewConfigValidationError(path, errors.New("configuration_timeout cannot be negative"))
+	}
 	if conf.ConvertedAttributes != nil {
 		var err error
 		requiredDeps, optionalDeps, err = conf.ConvertedAttributes.Validate(path)
@@ -1,7 +1,9 @@
 package resource_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"go.viam.com/test"
 
@@ -663,3 +665,29 @@ func TestEqual(t *testing.T) {
 		})
 	})
 }
+
+func TestConfigurationTimeout(t *testing.T) {
+	var conf resource.Config
+	err := json.Unmarshal([]byte(`{
+		"name": "cam",
+		"api": "rdk:component:camera",
+		"model": "rdk:builtin:fake",
+		"configuration_timeout": "5m"
+	}`), &conf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conf.ConfigurationTimeout, test.ShouldEqual, 5*time.Minute)
+
+	md, err := json.Marshal(conf)
+	test.That(t, err, test.ShouldBeNil)
+	var roundTrip resource.Config
+	test.That(t, json.Unmarshal(md, &roundTrip), test.ShouldBeNil)
+	test.That(t, roundTrip.ConfigurationTimeout, test.ShouldEqual, conf.ConfigurationTimeout)
+
+	err = json.Unmarshal([]byte(`{"name": "cam", "type": "camera", "configuration_timeout": "later"}`), &conf)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	conf = resource.Config{Name: "cam", API: sensor.API, Model: resource.DefaultModelFamily.WithModel("fake"), ConfigurationTimeout: -time.Second}
+	_, _, err = conf.Validate("path", resource.APITypeComponentName)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "configuration_timeout")
+}
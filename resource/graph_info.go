@@ -0,0 +1,419 @@
+package resource
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// GraphNodeInfo describes a single resource in a GraphInfo.
+type GraphNodeInfo struct {
+	// Name is the fully qualified resource name.
+	Name   string `json:"name"`
+	API    string `json:"api"`
+	Model  string `json:"model,omitempty"`
+	Remote string `json:"remote,omitempty"`
+	// Module is the name of the module serving the resource, if any. It is filled in by
+	// the robot as the graph itself does not know about modules.
+	Module string `json:"module,omitempty"`
+
+	State            string     `json:"state"`
+	Error            string     `json:"error,omitempty"`
+	Health           string     `json:"health,omitempty"`
+	HealthError      string     `json:"health_error,omitempty"`
+	Revision         string     `json:"revision,omitempty"`
+	LastUpdated      time.Time  `json:"last_updated"`
+	LastReconfigured *time.Time `json:"last_reconfigured,omitempty"`
+
+	// UnresolvedDependencies are the configured dependencies of the resource that could not
+	// yet be matched to resources in the graph.
+	UnresolvedDependencies []string `json:"unresolved_dependencies,omitempty"`
+}
+
+// GraphEdgeInfo is a dependency between two resources in a GraphInfo: From depends on To.
+type GraphEdgeInfo struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphInfo is a structured snapshot of the resource graph. Nodes and edges are sorted by
+// name so that the same graph always produces the same GraphInfo.
+type GraphInfo struct {
+	CreatedAt time.Time       `json:"created_at"`
+	Nodes     []GraphNodeInfo `json:"nodes"`
+	Edges     []GraphEdgeInfo `json:"edges"`
+}
+
+// GraphInfoFileName returns the name of the file a robot with the given part ID stores
+// its resource graph in.
+func GraphInfoFileName(id string) string {
+	return fmt.Sprintf("resource_graph_%s.json", id)
+}
+
+// GraphInfoFilePath returns the path of the resource graph file for the given part ID
+// within the given Viam home directory.
+func GraphInfoFilePath(homeDir, id string) string {
+	return filepath.Join(homeDir, GraphInfoFileName(id))
+}
+
+// Info returns a structured snapshot of the resource graph. As with ExportDot, nodes are
+// not all locked for the duration of the call so the snapshot is best-effort.
+func (g *Graph) Info() GraphInfo {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	info := GraphInfo{CreatedAt: time.Now()}
+	for _, nameNode := range nodesSortedByName(g.nodes) {
+		info.Nodes = append(info.Nodes, nodeInfo(nameNode.Name, nameNode.Node))
+	}
+	for _, edge := range edgesSortedByName(g.children) {
+		info.Edges = append(info.Edges, GraphEdgeInfo{From: edge.source.String(), To: edge.dest.String()})
+	}
+	return info
+}
+
+func nodeInfo(name Name, node *GraphNode) GraphNodeInfo {
+	node.mu.RLock()
+	defer node.mu.RUnlock()
+	status := node.status()
+
+	info := GraphNodeInfo{
+		Name:                   name.String(),
+		API:                    name.API.String(),
+		Remote:                 name.Remote,
+		State:                  status.State.String(),
+		Revision:               status.Revision,
+		LastUpdated:            status.LastUpdated,
+		LastReconfigured:       node.lastReconfigured,
+		UnresolvedDependencies: slices.Clone(node.unresolvedDependencies),
+	}
+	if node.currentModel != (Model{}) {
+		info.Model = node.currentModel.String()
+	} else if node.config.Model != (Model{}) {
+		info.Model = node.config.Model.String()
+	}
+	if status.Error != nil {
+		info.Error = status.Error.Error()
+	}
+	if status.Health.State != HealthStateUnknown {
+		info.Health = status.Health.State.String()
+		if status.Health.Error != nil {
+			info.HealthError = status.Health.Error.Error()
+		}
+	}
+	return info
+}
+
+// FindNode returns the node with the given name. The name may be fully qualified, or a
+// short name (optionally prefixed with a remote) if it is unambiguous.
+func (gi GraphInfo) FindNode(name string) (GraphNodeInfo, error) {
+	var matches []GraphNodeInfo
+	for _, node := range gi.Nodes {
+		if node.Name == name {
+			return node, nil
+		}
+		if shortNodeName(node) == name {
+			matches = append(matches, node)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return GraphNodeInfo{}, errors.Errorf("no resource named %q in the resource graph", name)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, 0, len(matches))
+		for _, match := range matches {
+			names = append(names, match.Name)
+		}
+		return GraphNodeInfo{}, errors.Errorf("resource name %q is ambiguous; use one of: %s", name, strings.Join(names, ", "))
+	}
+}
+
+func shortNodeName(node GraphNodeInfo) string {
+	return node.Name[strings.LastIndex(node.Name, "/")+1:]
+}
+
+// Dependents returns the names of all resources that depend on the given resource,
+// directly or transitively, sorted by name.
+func (gi GraphInfo) Dependents(name string) ([]string, error) {
+	node, err := gi.FindNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return gi.walk(node.Name, func(edge GraphEdgeInfo) (string, string) { return edge.To, edge.From }), nil
+}
+
+// Dependencies returns the names of all resources the given resource depends on, directly
+// or transitively, sorted by name.
+func (gi GraphInfo) Dependencies(name string) ([]string, error) {
+	node, err := gi.FindNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return gi.walk(node.Name, func(edge GraphEdgeInfo) (string, string) { return edge.From, edge.To }), nil
+}
+
+// walk returns every node reachable from start by following edges in the direction given
+// by dir, which returns the (source, destination) of an edge.
+func (gi GraphInfo) walk(start string, dir func(GraphEdgeInfo) (string, string)) []string {
+	next := make(map[string][]string)
+	for _, edge := range gi.Edges {
+		src, dst := dir(edge)
+		next[src] = append(next[src], dst)
+	}
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	var result []string
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range next[cur] {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			result = append(result, n)
+			queue = append(queue, n)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// isConfigured returns whether a node has a working resource.
+func (node GraphNodeInfo) isConfigured() bool {
+	return node.State == NodeStateReady.String() && node.Error == "" && len(node.UnresolvedDependencies) == 0
+}
+
+// WhyNotConfigured explains why the given resource is not configured. It reports the
+// resource's own state, error and unresolved dependencies, and follows its dependencies to
+// the resources that are the root cause. It returns no reasons if the resource is configured.
+func (gi GraphInfo) WhyNotConfigured(name string) ([]string, error) {
+	node, err := gi.FindNode(name)
+	if err != nil {
+		return nil, err
+	}
+	if node.isConfigured() {
+		return nil, nil
+	}
+
+	byName := make(map[string]GraphNodeInfo, len(gi.Nodes))
+	for _, n := range gi.Nodes {
+		byName[n.Name] = n
+	}
+	deps := make(map[string][]string)
+	for _, edge := range gi.Edges {
+		deps[edge.From] = append(deps[edge.From], edge.To)
+	}
+
+	var reasons []string
+	seen := make(map[string]bool)
+	var explain func(n GraphNodeInfo, depth int)
+	explain = func(n GraphNodeInfo, depth int) {
+		if seen[n.Name] {
+			return
+		}
+		seen[n.Name] = true
+		indent := strings.Repeat("  ", depth)
+
+		reason := fmt.Sprintf("%s%s is %s", indent, n.Name, strings.ToLower(n.State))
+		if n.Error != "" {
+			reason += ": " + n.Error
+		}
+		reasons = append(reasons, reason)
+		for _, dep := range n.UnresolvedDependencies {
+			reasons = append(reasons, fmt.Sprintf("%s  dependency %q could not be resolved to a resource", indent, dep))
+		}
+		for _, depName := range deps[n.Name] {
+			dep, ok := byName[depName]
+			if !ok || dep.isConfigured() {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("%s  depends on %s, which is not configured", indent, depName))
+			explain(dep, depth+2)
+		}
+	}
+	explain(node, 0)
+	return reasons, nil
+}
+
+// Text renders the graph as a tree of resources and their dependencies. Each resource is
+// listed at the top level along with its state, followed by the resources it depends on.
+func (gi GraphInfo) Text() string {
+	deps := make(map[string][]string)
+	for _, edge := range gi.Edges {
+		deps[edge.From] = append(deps[edge.From], edge.To)
+	}
+	byName := make(map[string]GraphNodeInfo, len(gi.Nodes))
+	for _, node := range gi.Nodes {
+		byName[node.Name] = node
+	}
+
+	var sb strings.Builder
+	var writeNode func(name string, prefix string, last, root bool, path map[string]bool)
+	writeNode = func(name, prefix string, last, root bool, path map[string]bool) {
+		branch, childPrefix := "├── ", prefix+"│   "
+		if last {
+			branch, childPrefix = "└── ", prefix+"    "
+		}
+		if root {
+			branch, childPrefix = "", ""
+		}
+		sb.WriteString(prefix + branch + name)
+		if node, ok := byName[name]; ok {
+			sb.WriteString(" [" + nodeSummary(node) + "]")
+		}
+		if path[name] {
+			sb.WriteString(" (cycle)\n")
+			return
+		}
+		sb.WriteString("\n")
+		path[name] = true
+		children := deps[name]
+		for idx, child := range children {
+			writeNode(child, childPrefix, idx == len(children)-1, false, path)
+		}
+		delete(path, name)
+	}
+	for _, node := range gi.Nodes {
+		writeNode(node.Name, "", true, true, map[string]bool{})
+	}
+	return sb.String()
+}
+
+func nodeSummary(node GraphNodeInfo) string {
+	parts := []string{strings.ToLower(node.State)}
+	if node.Health != "" {
+		parts = append(parts, "health: "+strings.ToLower(node.Health))
+	}
+	if node.Module != "" {
+		parts = append(parts, "module: "+node.Module)
+	}
+	if node.Error != "" {
+		parts = append(parts, "error: "+node.Error)
+	}
+	if len(node.UnresolvedDependencies) > 0 {
+		parts = append(parts, "unresolved: "+strings.Join(node.UnresolvedDependencies, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nodeColor returns the color used for a node in rendered graphs, matching ExportDot.
+func nodeColor(node GraphNodeInfo) string {
+	switch {
+	case node.isConfigured() && node.Health != HealthStateUnhealthy.String():
+		return "bisque"
+	case node.Error == "" && node.State == NodeStateReady.String():
+		return "salmon"
+	default:
+		return "indianred"
+	}
+}
+
+// DOT renders the graph in Graphviz DOT format with resources grouped by remote.
+// DOT reference: https://graphviz.org/doc/info/lang.html.
+func (gi GraphInfo) DOT() string {
+	writer := &blockWriter{}
+	writer.NewBlock("digraph")
+	writer.WriteStrings([]string{
+		"rankdir=LR;",
+		"bgcolor=azure;",
+		"node [style=filled];",
+	})
+	for idx, group := range gi.nodesByRemote() {
+		if group.remote != "" {
+			writer.NewBlockf("subgraph cluster_remote_%d", idx)
+			writer.WriteStrings([]string{
+				"color=lightblue;",
+				"style=filled;",
+				fmt.Sprintf("label=%q", group.remote),
+			})
+		}
+		for _, node := range group.nodes {
+			writer.WriteStringf("%q [color=%s,tooltip=%q,label=%q];",
+				node.Name, nodeColor(node), nodeSummary(node), shortNodeName(node)+"\n"+node.API)
+		}
+		if group.remote != "" {
+			writer.EndBlock()
+		}
+	}
+	for _, edge := range gi.Edges {
+		writer.WriteStringf("%q -> %q", edge.From, edge.To)
+	}
+	writer.EndBlock()
+	return writer.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart with resources grouped by remote.
+// Mermaid reference: https://mermaid.js.org/syntax/flowchart.html.
+func (gi GraphInfo) Mermaid() string {
+	ids := make(map[string]string, len(gi.Nodes))
+	id := func(name string) string {
+		if nodeID, ok := ids[name]; ok {
+			return nodeID
+		}
+		nodeID := fmt.Sprintf("n%d", len(ids))
+		ids[name] = nodeID
+		return nodeID
+	}
+	escape := func(s string) string {
+		return strings.ReplaceAll(s, `"`, "#quot;")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	classes := make(map[string][]string)
+	for idx, group := range gi.nodesByRemote() {
+		indent := "    "
+		if group.remote != "" {
+			fmt.Fprintf(&sb, "    subgraph remote_%d [\"%s\"]\n", idx, escape(group.remote))
+			indent = "        "
+		}
+		for _, node := range group.nodes {
+			nodeID := id(node.Name)
+			fmt.Fprintf(&sb, "%s%s[\"%s<br/>%s<br/>%s\"]\n", indent, nodeID,
+				escape(shortNodeName(node)), escape(node.API), escape(nodeSummary(node)))
+			color := nodeColor(node)
+			classes[color] = append(classes[color], nodeID)
+		}
+		if group.remote != "" {
+			sb.WriteString("    end\n")
+		}
+	}
+	for _, edge := range gi.Edges {
+		fmt.Fprintf(&sb, "    %s --> %s\n", id(edge.From), id(edge.To))
+	}
+	for _, color := range slices.Sorted(maps.Keys(classes)) {
+		fmt.Fprintf(&sb, "    classDef %s fill:%s\n", color, color)
+		fmt.Fprintf(&sb, "    class %s %s\n", strings.Join(classes[color], ","), color)
+	}
+	return sb.String()
+}
+
+type remoteNodes struct {
+	remote string
+	nodes  []GraphNodeInfo
+}
+
+// nodesByRemote groups nodes by remote, with local resources first.
+func (gi GraphInfo) nodesByRemote() []remoteNodes {
+	groups := make(map[string][]GraphNodeInfo)
+	for _, node := range gi.Nodes {
+		groups[node.Remote] = append(groups[node.Remote], node)
+	}
+	result := make([]remoteNodes, 0, len(groups))
+	for remote, nodes := range groups {
+		result = append(result, remoteNodes{remote: remote, nodes: nodes})
+	}
+	slices.SortFunc(result, func(left, right remoteNodes) int {
+		return cmp.Compare(left.remote, right.remote)
+	})
+	return result
+}
@@ -0,0 +1,139 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+)
+
+func newTestGraphInfo(t *testing.T) GraphInfo {
+	t.Helper()
+	logger := logging.NewTestLogger(t)
+	api := APINamespaceRDK.WithComponentType("thing")
+	model := DefaultModelFamily.WithModel("fake")
+
+	g := NewGraph(logger)
+	ready := func(name string) Name {
+		n := NewName(api, name)
+		node := NewConfiguredGraphNode(Config{Name: name, API: api, Model: model}, nil, model)
+		node.InitializeLogger(logger, name)
+		test.That(t, g.AddNode(n, node), test.ShouldBeNil)
+		return n
+	}
+	board := ready("board")
+	motor := ready("motor")
+	test.That(t, g.AddChild(motor, board), test.ShouldBeNil)
+
+	arm := NewName(api, "arm")
+	armNode := NewUnconfiguredGraphNode(Config{Name: "arm", API: api, Model: model}, []string{"missing"})
+	armNode.InitializeLogger(logger, "arm")
+	armNode.LogAndSetLastError(errors.New("cannot connect"))
+	test.That(t, g.AddNode(arm, armNode), test.ShouldBeNil)
+	test.That(t, g.AddChild(arm, motor), test.ShouldBeNil)
+
+	// base is waiting on arm.
+	base := NewName(api, "base")
+	baseNode := NewUnconfiguredGraphNode(Config{Name: "base", API: api, Model: model}, nil)
+	baseNode.InitializeLogger(logger, "base")
+	test.That(t, g.AddNode(base, baseNode), test.ShouldBeNil)
+	test.That(t, g.AddChild(base, arm), test.ShouldBeNil)
+
+	remoteMotor := NewName(api, "motor")
+	remoteMotor.Remote = "remote1"
+	test.That(t, g.AddNode(remoteMotor, NewConfiguredGraphNode(Config{}, nil, model)), test.ShouldBeNil)
+
+	return g.Info()
+}
+
+func TestGraphInfo(t *testing.T) {
+	info := newTestGraphInfo(t)
+	test.That(t, info.Nodes, test.ShouldHaveLength, 5)
+	test.That(t, info.Nodes[0].Name, test.ShouldEqual, "rdk:component:thing/arm")
+	test.That(t, info.Nodes[0].State, test.ShouldEqual, NodeStateUnhealthy.String())
+	test.That(t, info.Nodes[0].Error, test.ShouldContainSubstring, "cannot connect")
+	test.That(t, info.Nodes[0].UnresolvedDependencies, test.ShouldResemble, []string{"missing"})
+	test.That(t, info.Nodes[0].Model, test.ShouldEqual, "rdk:builtin:fake")
+	test.That(t, info.Nodes[1].Name, test.ShouldEqual, "rdk:component:thing/base")
+	test.That(t, info.Nodes[1].State, test.ShouldEqual, NodeStateConfiguring.String())
+	test.That(t, info.Nodes[1].LastReconfigured, test.ShouldBeNil)
+	test.That(t, info.Nodes[2].Name, test.ShouldEqual, "rdk:component:thing/board")
+	test.That(t, info.Nodes[2].State, test.ShouldEqual, NodeStateReady.String())
+	test.That(t, info.Nodes[2].LastReconfigured, test.ShouldNotBeNil)
+	test.That(t, info.Nodes[4].Remote, test.ShouldEqual, "remote1")
+	test.That(t, info.Edges, test.ShouldResemble, []GraphEdgeInfo{
+		{From: "rdk:component:thing/arm", To: "rdk:component:thing/motor"},
+		{From: "rdk:component:thing/base", To: "rdk:component:thing/arm"},
+		{From: "rdk:component:thing/motor", To: "rdk:component:thing/board"},
+	})
+
+	md, err := json.Marshal(info)
+	test.That(t, err, test.ShouldBeNil)
+	var roundTrip GraphInfo
+	test.That(t, json.Unmarshal(md, &roundTrip), test.ShouldBeNil)
+	test.That(t, roundTrip.Edges, test.ShouldResemble, info.Edges)
+	test.That(t, roundTrip.Nodes[0].Error, test.ShouldEqual, info.Nodes[0].Error)
+}
+
+func TestGraphInfoQueries(t *testing.T) {
+	info := newTestGraphInfo(t)
+
+	dependents, err := info.Dependents("board")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dependents, test.ShouldResemble, []string{
+		"rdk:component:thing/arm", "rdk:component:thing/base", "rdk:component:thing/motor",
+	})
+
+	dependencies, err := info.Dependencies("rdk:component:thing/base")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dependencies, test.ShouldResemble, []string{
+		"rdk:component:thing/arm", "rdk:component:thing/board", "rdk:component:thing/motor",
+	})
+
+	// short names of remote resources include the remote.
+	node, err := info.FindNode("motor")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, node.Remote, test.ShouldBeEmpty)
+	node, err = info.FindNode("remote1:motor")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, node.Remote, test.ShouldEqual, "remote1")
+	_, err = info.FindNode("nope")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	reasons, err := info.WhyNotConfigured("board")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reasons, test.ShouldBeEmpty)
+
+	reasons, err = info.WhyNotConfigured("base")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reasons, test.ShouldHaveLength, 4)
+	test.That(t, reasons[0], test.ShouldEqual, "rdk:component:thing/base is configuring")
+	test.That(t, reasons[1], test.ShouldEqual, "  depends on rdk:component:thing/arm, which is not configured")
+	test.That(t, reasons[2], test.ShouldContainSubstring, "    rdk:component:thing/arm is unhealthy: ")
+	test.That(t, reasons[2], test.ShouldContainSubstring, "cannot connect")
+	test.That(t, reasons[3], test.ShouldEqual, `      dependency "missing" could not be resolved to a resource`)
+}
+
+func TestGraphInfoRender(t *testing.T) {
+	info := newTestGraphInfo(t)
+
+	text := info.Text()
+	test.That(t, text, test.ShouldContainSubstring, "rdk:component:thing/base [configuring]\n└── rdk:component:thing/arm [unhealthy")
+	test.That(t, text, test.ShouldContainSubstring,
+		"    └── rdk:component:thing/motor [ready]\n        └── rdk:component:thing/board [ready]")
+
+	dot := info.DOT()
+	test.That(t, dot, test.ShouldStartWith, "digraph {")
+	test.That(t, dot, test.ShouldContainSubstring, `"rdk:component:thing/arm" -> "rdk:component:thing/motor"`)
+	test.That(t, dot, test.ShouldContainSubstring, "subgraph cluster_remote_1 {")
+	test.That(t, dot, test.ShouldContainSubstring, `"rdk:component:thing/arm" [color=indianred`)
+
+	mermaid := info.Mermaid()
+	test.That(t, mermaid, test.ShouldStartWith, "flowchart LR\n")
+	test.That(t, mermaid, test.ShouldContainSubstring, "    n0 --> n3\n")
+	test.That(t, mermaid, test.ShouldContainSubstring, `subgraph remote_1 ["remote1"]`)
+	test.That(t, mermaid, test.ShouldContainSubstring, "classDef indianred fill:indianred")
+}
@@ -82,6 +82,14 @@ type GraphNode struct {
 	pendingRevision string
 	revision        string
 
+	// health stores the outcome of the most recent health checks of the current resource.
+	// It is reset whenever the resource is swapped.
+	health HealthStatus
+
+	// configurationDuration is how long the most recent attempt to construct or reconfigure
+	// the resource took, regardless of whether it succeeded.
+	configurationDuration time.Duration
+
 	// unreachable is an informational field that indicates if a resource on a remote
 	// machine is disconnected.
 	unreachable bool
@@ -257,6 +265,7 @@ func (w *GraphNode) SwapResource(newRes Resource, newModel Model, ftdc *ftdc.FTD
 	w.currentModel = newModel
 	w.revision = w.pendingRevision
 	w.lastErr = nil
+	w.health = HealthStatus{}
 	w.transitionTo(NodeStateReady)
 
 	// these should already be set
@@ -279,6 +288,39 @@ func (w *GraphNode) SwapResource(newRes Resource, newModel Model, ftdc *ftdc.FTD
 	}
 }
 
+// Health returns the outcome of the most recent health checks of the current resource.
+func (w *GraphNode) Health() HealthStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.health
+}
+
+// RecordHealthCheck updates the health of the current resource with the result of a probe
+// and returns the new health status. Unlike LogAndSetLastError, a failing health check does
+// not make the resource unavailable to external users of the graph.
+func (w *GraphNode) RecordHealthCheck(probeErr error, checkedAt time.Time, hc HealthCheckConfig) HealthStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.health = w.health.next(probeErr, checkedAt, hc)
+	return w.health
+}
+
+// ConfigurationDuration returns how long the most recent attempt to construct or reconfigure
+// the resource took.
+func (w *GraphNode) ConfigurationDuration() time.Duration {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.configurationDuration
+}
+
+// RecordConfigurationDuration records how long an attempt to construct or reconfigure the
+// resource took.
+func (w *GraphNode) RecordConfigurationDuration(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.configurationDuration = d
+}
+
 // MarkForRemoval marks this node for removal at a later time. Also increases the logical clock.
 func (w *GraphNode) MarkForRemoval() {
 	w.mu.Lock()
@@ -632,12 +674,18 @@ func (w *GraphNode) status() NodeStatus {
 		LastUpdated: w.transitionedAt,
 		Revision:    w.revision,
 		Error:       err,
+		Health:      w.health,
 	}
 }
 
 type graphNodeStats struct {
 	State    int
 	ResStats any
+
+	HealthState         int
+	HealthCheckFailures int
+
+	ConfigurationDurationMs int64
 }
 
 // Stats satisfies the FTDC Statser interface.
@@ -658,6 +706,11 @@ func (w *GraphNode) Stats() any {
 		ret.State = 3
 	}
 
+	health := w.Health()
+	ret.HealthState = int(health.State)
+	ret.HealthCheckFailures = health.ConsecutiveFailures
+	ret.ConfigurationDurationMs = w.ConfigurationDuration().Milliseconds()
+
 	if statser, isStatser := res.(ftdc.Statser); isStatser && err == nil {
 		ret.ResStats = statser.Stats()
 	}
@@ -675,4 +728,8 @@ type NodeStatus struct {
 	// Error contains any errors on the resource if it currently unhealthy.
 	// This field will be nil if the resource is not in the [NodeStateUnhealthy] state.
 	Error error
+
+	// Health contains the outcome of the most recent health checks of the resource. A
+	// resource may be [NodeStateReady] while its health checks are failing.
+	Health HealthStatus
 }
@@ -0,0 +1,217 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Defaults used for health checks when the corresponding fields are not set.
+const (
+	DefaultHealthCheckInterval         = 10 * time.Second
+	DefaultHealthCheckTimeout          = 5 * time.Second
+	DefaultHealthCheckFailureThreshold = 3
+	DefaultHealthCheckSuccessThreshold = 1
+)
+
+// HealthChecker is implemented by resources that can report whether they are actually
+// working after construction. Resources implementing it are probed periodically even if
+// their config does not declare a health check.
+type HealthChecker interface {
+	// CheckHealth returns an error if the resource is not currently working.
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthCheckMethod is the resource method called to probe a resource's health.
+type HealthCheckMethod string
+
+// The set of supported health check methods.
+const (
+	// HealthCheckMethodReadings calls Readings on a sensor.
+	HealthCheckMethodReadings = HealthCheckMethod("readings")
+	// HealthCheckMethodGetImages calls Images on a camera and expects at least one image.
+	HealthCheckMethodGetImages = HealthCheckMethod("get_images")
+	// HealthCheckMethodIsMoving calls IsMoving on an actuator.
+	HealthCheckMethodIsMoving = HealthCheckMethod("is_moving")
+	// HealthCheckMethodDoCommand calls DoCommand with the configured command.
+	HealthCheckMethodDoCommand = HealthCheckMethod("do_command")
+)
+
+// HealthCheckConfig describes how and how often a resource is probed for health. The cloud
+// config has no field for it, so it only takes effect in local configs; resources from cloud
+// configs are still probed if they implement HealthChecker.
+type HealthCheckConfig struct {
+	// Method is the probe to use. If empty, the resource's CheckHealth is used if it
+	// implements HealthChecker, otherwise a method is chosen based on the resource's API.
+	Method HealthCheckMethod
+
+	// Command is the DoCommand payload used with HealthCheckMethodDoCommand.
+	Command map[string]interface{}
+
+	// Interval is the time between probes.
+	Interval time.Duration
+
+	// Timeout bounds how long a single probe may take.
+	Timeout time.Duration
+
+	// FailureThreshold is the number of consecutive failed probes after which the
+	// resource is considered unhealthy.
+	FailureThreshold int
+
+	// SuccessThreshold is the number of consecutive successful probes after which an
+	// unhealthy resource is considered healthy again.
+	SuccessThreshold int
+
+	// RebuildOnFailure causes the resource to be closed and rebuilt when it becomes unhealthy.
+	RebuildOnFailure bool
+}
+
+// NOTE: This data must be maintained with what is in HealthCheckConfig.
+type healthCheckConfigData struct {
+	Method           HealthCheckMethod      `json:"method,omitempty"`
+	Command          map[string]interface{} `json:"command,omitempty"`
+	Interval         string                 `json:"interval,omitempty"`
+	Timeout          string                 `json:"timeout,omitempty"`
+	FailureThreshold int                    `json:"failure_threshold,omitempty"`
+	SuccessThreshold int                    `json:"success_threshold,omitempty"`
+	RebuildOnFailure bool                   `json:"rebuild_on_failure,omitempty"`
+}
+
+// UnmarshalJSON unmarshals JSON into the config.
+func (hc *HealthCheckConfig) UnmarshalJSON(data []byte) error {
+	var temp healthCheckConfigData
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	*hc = HealthCheckConfig{
+		Method:           temp.Method,
+		Command:          temp.Command,
+		FailureThreshold: temp.FailureThreshold,
+		SuccessThreshold: temp.SuccessThreshold,
+		RebuildOnFailure: temp.RebuildOnFailure,
+	}
+	if temp.Interval != "" {
+		dur, err := time.ParseDuration(temp.Interval)
+		if err != nil {
+			return err
+		}
+		hc.Interval = dur
+	}
+	if temp.Timeout != "" {
+		dur, err := time.ParseDuration(temp.Timeout)
+		if err != nil {
+			return err
+		}
+		hc.Timeout = dur
+	}
+	return nil
+}
+
+// MarshalJSON marshals JSON from the config.
+func (hc HealthCheckConfig) MarshalJSON() ([]byte, error) {
+	temp := healthCheckConfigData{
+		Method:           hc.Method,
+		Command:          hc.Command,
+		FailureThreshold: hc.FailureThreshold,
+		SuccessThreshold: hc.SuccessThreshold,
+		RebuildOnFailure: hc.RebuildOnFailure,
+	}
+	if hc.Interval != 0 {
+		temp.Interval = hc.Interval.String()
+	}
+	if hc.Timeout != 0 {
+		temp.Timeout = hc.Timeout.String()
+	}
+	return json.Marshal(temp)
+}
+
+// Validate ensures all parts of the config are valid. Sets defaults for unset fields.
+func (hc *HealthCheckConfig) Validate(path string) error {
+	switch hc.Method {
+	case "", HealthCheckMethodReadings, HealthCheckMethodGetImages, HealthCheckMethodIsMoving:
+	case HealthCheckMethodDoCommand:
+		if len(hc.Command) == 0 {
+			return NewConfigValidationFieldRequiredError(path, "command")
+		}
+	default:
+		return NewConfigValidationError(path, errors.Errorf("unknown health check method %q", hc.Method))
+	}
+	if hc.Interval < 0 {
+		return NewConfigValidationError(path, errors.New("interval must be non-negative"))
+	}
+	if hc.Interval == 0 {
+		hc.Interval = DefaultHealthCheckInterval
+	}
+	if hc.Timeout < 0 {
+		return NewConfigValidationError(path, errors.New("timeout must be non-negative"))
+	}
+	if hc.Timeout == 0 {
+		hc.Timeout = DefaultHealthCheckTimeout
+	}
+	if hc.FailureThreshold < 0 {
+		return NewConfigValidationError(path, errors.New("failure_threshold must be non-negative"))
+	}
+	if hc.FailureThreshold == 0 {
+		hc.FailureThreshold = DefaultHealthCheckFailureThreshold
+	}
+	if hc.SuccessThreshold < 0 {
+		return NewConfigValidationError(path, errors.New("success_threshold must be non-negative"))
+	}
+	if hc.SuccessThreshold == 0 {
+		hc.SuccessThreshold = DefaultHealthCheckSuccessThreshold
+	}
+	return nil
+}
+
+//go:generate stringer -type HealthState -trimprefix HealthState
+
+// HealthState is the result of probing a resource's health.
+type HealthState uint8
+
+const (
+	// HealthStateUnknown denotes a resource that has not been probed since it was last configured,
+	// or that has no health check.
+	HealthStateUnknown HealthState = iota
+
+	// HealthStateHealthy denotes a resource whose health checks are passing.
+	HealthStateHealthy
+
+	// HealthStateUnhealthy denotes a resource whose health checks have failed at least
+	// FailureThreshold times in a row.
+	HealthStateUnhealthy
+)
+
+// HealthStatus captures the outcome of the most recent health checks of a resource.
+type HealthStatus struct {
+	State                HealthState
+	LastChecked          time.Time
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+
+	// Error is the error of the most recent failed probe while the resource is unhealthy.
+	Error error
+}
+
+// next returns the status after a probe with the given result.
+func (hs HealthStatus) next(probeErr error, checkedAt time.Time, hc HealthCheckConfig) HealthStatus {
+	hs.LastChecked = checkedAt
+	if probeErr == nil {
+		hs.ConsecutiveFailures = 0
+		hs.ConsecutiveSuccesses++
+		if hs.State != HealthStateUnhealthy || hs.ConsecutiveSuccesses >= hc.SuccessThreshold {
+			hs.State = HealthStateHealthy
+			hs.Error = nil
+		}
+		return hs
+	}
+
+	hs.ConsecutiveSuccesses = 0
+	hs.ConsecutiveFailures++
+	if hs.State == HealthStateUnhealthy || hs.ConsecutiveFailures >= hc.FailureThreshold {
+		hs.State = HealthStateUnhealthy
+		hs.Error = probeErr
+	}
+	return hs
+}
@@ -0,0 +1,110 @@
+package resource_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/resource"
+)
+
+func TestHealthCheckConfigJSON(t *testing.T) {
+	var conf resource.Config
+	err := json.Unmarshal([]byte(`{
+		"name": "cam",
+		"api": "rdk:component:camera",
+		"model": "rdk:builtin:fake",
+		"health_check": {
+			"method": "get_images",
+			"interval": "30s",
+			"timeout": "2s",
+			"failure_threshold": 4,
+			"rebuild_on_failure": true
+		}
+	}`), &conf)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conf.HealthCheck, test.ShouldResemble, &resource.HealthCheckConfig{
+		Method:           resource.HealthCheckMethodGetImages,
+		Interval:         30 * time.Second,
+		Timeout:          2 * time.Second,
+		FailureThreshold: 4,
+		RebuildOnFailure: true,
+	})
+
+	md, err := json.Marshal(conf)
+	test.That(t, err, test.ShouldBeNil)
+	var roundTrip resource.Config
+	test.That(t, json.Unmarshal(md, &roundTrip), test.ShouldBeNil)
+	test.That(t, roundTrip.HealthCheck, test.ShouldResemble, conf.HealthCheck)
+
+	err = json.Unmarshal([]byte(`{"name": "cam", "api": "rdk:component:camera", "health_check": {"interval": "soon"}}`), &conf)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestHealthCheckConfigValidate(t *testing.T) {
+	hc := resource.HealthCheckConfig{}
+	test.That(t, hc.Validate("path"), test.ShouldBeNil)
+	test.That(t, hc, test.ShouldResemble, resource.HealthCheckConfig{
+		Interval:         resource.DefaultHealthCheckInterval,
+		Timeout:          resource.DefaultHealthCheckTimeout,
+		FailureThreshold: resource.DefaultHealthCheckFailureThreshold,
+		SuccessThreshold: resource.DefaultHealthCheckSuccessThreshold,
+	})
+
+	hc = resource.HealthCheckConfig{Method: resource.HealthCheckMethodDoCommand}
+	err := hc.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "command")
+
+	hc = resource.HealthCheckConfig{Method: "ping"}
+	err = hc.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unknown health check method")
+
+	hc = resource.HealthCheckConfig{FailureThreshold: -1}
+	test.That(t, hc.Validate("path"), test.ShouldNotBeNil)
+}
+
+func TestGraphNodeHealth(t *testing.T) {
+	model := resource.DefaultModelFamily.WithModel("foo")
+	node := withTestLogger(t, resource.NewConfiguredGraphNode(resource.Config{}, &someResource{}, model))
+	test.That(t, node.Health().State, test.ShouldEqual, resource.HealthStateUnknown)
+
+	hc := resource.HealthCheckConfig{FailureThreshold: 2, SuccessThreshold: 2}
+	probeErr := errors.New("no frames")
+	now := time.Now()
+
+	health := node.RecordHealthCheck(nil, now, hc)
+	test.That(t, health.State, test.ShouldEqual, resource.HealthStateHealthy)
+	test.That(t, health.LastChecked, test.ShouldEqual, now)
+
+	health = node.RecordHealthCheck(probeErr, now, hc)
+	test.That(t, health.State, test.ShouldEqual, resource.HealthStateHealthy)
+	test.That(t, health.ConsecutiveFailures, test.ShouldEqual, 1)
+	test.That(t, health.Error, test.ShouldBeNil)
+
+	health = node.RecordHealthCheck(probeErr, now, hc)
+	test.That(t, health.State, test.ShouldEqual, resource.HealthStateUnhealthy)
+	test.That(t, health.Error, test.ShouldBeError, probeErr)
+
+	// a failing health check does not make the resource unavailable.
+	status := node.Status()
+	test.That(t, status.State, test.ShouldEqual, resource.NodeStateReady)
+	test.That(t, status.Health.State, test.ShouldEqual, resource.HealthStateUnhealthy)
+	_, err := node.Resource()
+	test.That(t, err, test.ShouldBeNil)
+
+	health = node.RecordHealthCheck(nil, now, hc)
+	test.That(t, health.State, test.ShouldEqual, resource.HealthStateUnhealthy)
+	health = node.RecordHealthCheck(nil, now, hc)
+	test.That(t, health.State, test.ShouldEqual, resource.HealthStateHealthy)
+	test.That(t, health.Error, test.ShouldBeNil)
+
+	// swapping the resource resets its health.
+	node.RecordHealthCheck(probeErr, now, hc)
+	node.SwapResource(&someResource{}, model, nil)
+	test.That(t, node.Health(), test.ShouldResemble, resource.HealthStatus{})
+}
@@ -18,9 +18,11 @@ package resource
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/jhump/protoreflect/desc"
 	"github.com/pkg/errors"
@@ -221,6 +223,64 @@ type Actuator interface {
 	Stop(context.Context, map[string]interface{}) error
 }
 
+// SafeStopper is any resource that can bring itself to a safe state, such as parking an
+// arm, braking a base, or de-energizing a motor. SafeStop is called when the robot shuts
+// down, when the resource is removed from the config, and when the session controlling
+// the resource expires. It is called in place of Stop and must not assume Stop was called
+// beforehand.
+//
+// SafeStop example:
+//
+//	// This example shows a gripper that releases its grip before it is removed.
+//	func (g *myGripper) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+//		return g.Open(ctx, extra)
+//	}
+type SafeStopper interface {
+	// SafeStop brings the resource to a safe state.
+	SafeStop(ctx context.Context, extra map[string]interface{}) error
+}
+
+// DoSafeStop is the DoCommand key that resource clients use to call SafeStop on remote and
+// modular resources, since the resource APIs have no method for it. The value is the extra
+// map to pass along. Servers answer it with {DoSafeStop: true} for resources that implement
+// SafeStopper.
+const DoSafeStop = "safe_stop"
+
+// ErrSafeStopUnsupported is returned by the SafeStop method of resource clients when the
+// resource on the other end does not implement SafeStopper.
+var ErrSafeStopUnsupported = errors.New("resource does not support SafeStop")
+
+// DefaultSafeStopTimeout is how long a single resource is given to stop or reach a safe state
+// before giving up on it.
+const DefaultSafeStopTimeout = 10 * time.Second
+
+// StopWithTimeout calls stop for the named resource and waits at most timeout for it to
+// return. If stop hangs it is left running since it may be running outside code.
+func StopWithTimeout(ctx context.Context, name Name, timeout time.Duration, stop func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- fmt.Errorf("panic stopping %q: %v", name, r)
+			}
+		}()
+		errCh <- stop(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return errors.Errorf("timed out after %v", timeout)
+		}
+		return ctx.Err()
+	}
+}
+
 // Shaped is any resource that can have geometries.
 //
 // Geometries example:
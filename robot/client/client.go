@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/fullstorydev/grpcurl"
+	"github.com/google/uuid"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/grpcreflect"
@@ -44,12 +46,14 @@ import (
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/pointcloud"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
 	rprotoutils "go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/robot/framesystem"
 	"go.viam.com/rdk/robot/packages"
+	"go.viam.com/rdk/robot/server"
 	"go.viam.com/rdk/session"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/tunnel"
@@ -120,10 +124,26 @@ type RobotClient struct {
 	// webrtc. Some operations such as video streaming are much more performant when using
 	// webrtc. We don't want a network disconnect to result in reconnecting over tcp such that
 	// performance would be impacted.
-	serverIsWebrtcEnabled bool
+	serverIsWebrtcEnabled atomic.Bool
 
 	pc         *webrtc.PeerConnection
 	sharedConn *grpc.SharedConn
+
+	// offlineQueue holds calls made while disconnected. It is nil unless the client was
+	// created with WithOfflineQueue.
+	offlineQueue *offlineQueue
+
+	// readCache serves reads configured with WithReadCache. It is nil otherwise.
+	readCache *readCache
+
+	// addresses are every address the machine can be reached through: address first, then any
+	// fallbacks given with WithFallbackAddresses.
+	addresses        []string
+	addressMu        sync.Mutex
+	activeAddress    string
+	addressHealth    map[string]*addressHealth
+	lastAddressProbe time.Time
+	probingAddresses atomic.Bool
 }
 
 // GetResource implements resource.Provider for a RobotClient by looking up a resource by name.
@@ -199,6 +219,10 @@ func (rc *RobotClient) handleUnaryDisconnect(
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 
+	if rc.shouldQueueOffline(ctx, method) {
+		return rc.queueOfflineCall(ctx, method, req, reply, opts...)
+	}
+
 	if err := rc.checkConnected(); err != nil {
 		rc.Logger().CDebugw(ctx, "connection is down, skipping method call", "method", method)
 		return status.Error(codes.Unavailable, err.Error())
@@ -208,6 +232,9 @@ func (rc *RobotClient) handleUnaryDisconnect(
 	// we might lose connection before our background check detects it - in this case we
 	// should still surface a helpful error message.
 	if isDisconnectedError(err) {
+		if rc.offlineQueue != nil && rc.offlineQueue.queueable(ctx, method) {
+			return rc.queueOfflineCall(ctx, method, req, reply, opts...)
+		}
 		return status.Error(codes.Unavailable, rc.notConnectedToRemoteError().Error())
 	}
 	return err
@@ -292,6 +319,22 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 		heartbeatCtx:        heartbeatCtx,
 		heartbeatCtxCancel:  heartbeatCtxCancel,
 	}
+	if rOpts.offlineQueue != nil {
+		rc.offlineQueue = newOfflineQueue(rOpts.offlineQueue)
+	}
+	rc.addresses = []string{address}
+	for _, fallback := range rOpts.fallbackAddresses {
+		if !slices.Contains(rc.addresses, fallback) {
+			rc.addresses = append(rc.addresses, fallback)
+		}
+	}
+	rc.addressHealth = make(map[string]*addressHealth, len(rc.addresses))
+	for _, addr := range rc.addresses {
+		rc.addressHealth[addr] = &addressHealth{}
+	}
+	if len(rOpts.readCacheTTLs) != 0 {
+		rc.readCache = newReadCache(rOpts.readCacheTTLs)
+	}
 
 	otelStatsHandler := otelgrpc.NewClientHandler(
 		otelgrpc.WithTracerProvider(trace.GetProvider()),
@@ -302,6 +345,8 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 	rc.dialOptions = append(
 		rc.dialOptions,
 		rpc.WithUnaryClientInterceptor(contextutils.ContextWithMetadataUnaryClientInterceptor),
+		// read caching
+		rpc.WithUnaryClientInterceptor(rc.readCacheUnaryClientInterceptor),
 		// error handling
 		rpc.WithUnaryClientInterceptor(rc.handleUnaryDisconnect),
 		rpc.WithStreamClientInterceptor(rc.handleStreamDisconnect),
@@ -485,11 +530,15 @@ func (rc *RobotClient) Connect(ctx context.Context) error {
 	if err := rc.connectWithLock(ctx); err != nil {
 		return err
 	}
-	rc.Logger().CInfow(ctx, "successfully (re)connected to remote at address", "address", rc.address)
+	rc.Logger().CInfow(ctx, "successfully (re)connected to remote at address", "address", rc.ActiveAddress())
 	if rc.notifyParent != nil {
 		rc.notifyParent()
 		rc.Logger().CDebugw(ctx, "successfully notified parent after (re)connection", "address", rc.address)
 	}
+	if rc.readCache != nil {
+		rc.readCache.clear()
+	}
+	rc.replayOfflineQueue()
 	return nil
 }
 
@@ -501,6 +550,26 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 		return err
 	}
 
+	// with several addresses, try each in order of preference until one connects.
+	var errs error
+	for _, address := range rc.candidateAddresses() {
+		start := time.Now()
+		conn, err := rc.dial(ctx, address)
+		rc.recordDial(address, time.Since(start), err)
+		if err != nil {
+			if len(rc.addresses) > 1 {
+				err = fmt.Errorf("%s: %w", address, err)
+			}
+			errs = multierr.Combine(errs, err)
+			continue
+		}
+		return rc.useConnLocked(ctx, conn, address)
+	}
+	return errs
+}
+
+// dial connects to the machine at the given address.
+func (rc *RobotClient) dial(ctx context.Context, address string) (rpc.ClientConn, error) {
 	// Try forcing a webrtc connection.
 	dialOptionsWebRTCOnly := make([]rpc.DialOption, len(rc.dialOptions)+1)
 	// Put our "disable GRPC" option in front and the user input values at the end. This ensures
@@ -509,16 +578,15 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 	dialOptionsWebRTCOnly[0] = rpc.WithDisableDirectGRPC()
 
 	dialLogger := rc.logger.Sublogger("networking")
-	conn, err := grpc.Dial(ctx, rc.address, dialLogger, dialOptionsWebRTCOnly...)
+	conn, err := grpc.Dial(ctx, address, dialLogger, dialOptionsWebRTCOnly...)
 	if err == nil {
 		// If we succeed with a webrtc connection, flip the `serverIsWebrtcEnabled` to force all future
 		// connections to use webrtc.
-		if !rc.serverIsWebrtcEnabled {
+		if !rc.serverIsWebrtcEnabled.Swap(true) {
 			rc.logger.Info("A WebRTC connection was made to the robot.",
 				"Reconnects will disallow direct gRPC connections.")
-			rc.serverIsWebrtcEnabled = true
 		}
-	} else if !rc.serverIsWebrtcEnabled {
+	} else if !rc.serverIsWebrtcEnabled.Load() {
 		// If we failed to connect via webrtc and* we've never previously connected over webrtc, try
 		// to connect with a grpc over a tcp connection.
 		//
@@ -533,7 +601,7 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 		// we add this flag to partially override the above override.
 		dialOptionsGRPCOnly[1] = rpc.WithDialMulticastDNSOptions(rpc.DialMulticastDNSOptions{Disable: false})
 
-		grpcConn, grpcErr := grpc.Dial(ctx, rc.address, dialLogger, dialOptionsGRPCOnly...)
+		grpcConn, grpcErr := grpc.Dial(ctx, address, dialLogger, dialOptionsGRPCOnly...)
 		if grpcErr == nil {
 			conn = grpcConn
 			err = nil
@@ -547,7 +615,7 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 				statusErr.Code() == codes.NotFound &&
 				errors.Is(grpcErr, rpc.ErrMDNSNoCandidatesFound) &&
 				errors.Is(grpcErr, context.DeadlineExceeded) {
-				return err
+				return nil, err
 			}
 			// A context.DeadlineExceeded from the WebRTC dial implies the client is unable to reach
 			// the signaling server, which likely means that the client is offline. In that case, if the errors returned from
@@ -558,21 +626,27 @@ func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 			if errors.Is(err, context.DeadlineExceeded) &&
 				errors.Is(grpcErr, context.DeadlineExceeded) &&
 				errors.Is(grpcErr, rpc.ErrMDNSNoCandidatesFound) {
-				return fmt.Errorf("failed to connect to machine within time limit. check network connection, whether the viam-server is running, " +
+				return nil, fmt.Errorf("failed to connect to machine within time limit. check network connection, whether the viam-server is running, " +
 					"and try again. see " + connTimeoutURL + " for troubleshooting steps")
 			}
 			err = multierr.Combine(err, grpcErr)
 		}
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return conn, nil
+}
 
+// useConnLocked makes the client use a new connection, made through the given address. The
+// client's mu must be held.
+func (rc *RobotClient) useConnLocked(ctx context.Context, conn rpc.ClientConn, address string) error {
 	client := pb.NewRobotServiceClient(conn)
 
 	refClient := grpcreflect.NewClientV1Alpha(rc.backgroundCtx, reflectpb.NewServerReflectionClient(conn))
 
 	rc.conn.ReplaceConn(conn)
+	rc.setActiveAddress(address)
 	rc.client = client
 	rc.refClient = refClient
 	rc.connected.Store(true)
@@ -660,7 +734,11 @@ func (rc *RobotClient) checkConnection(ctx context.Context, checkEvery, reconnec
 				outerError = nil
 				break
 			}
-			if outerError != nil {
+			if outerError == nil {
+				// calls may have been queued after a failure the check did not see.
+				rc.replayOfflineQueue()
+				rc.startAddressProbe(ctx)
+			} else {
 				rc.Logger().CErrorw(ctx,
 					"lost connection to remote",
 					"error", outerError,
@@ -695,6 +773,7 @@ func (rc *RobotClient) checkConnection(ctx context.Context, checkEvery, reconnec
 func (rc *RobotClient) Close(ctx context.Context) error {
 	rc.backgroundCtxCancel()
 	rc.activeBackgroundWorkers.Wait()
+	rc.dropOfflineQueue("client closed")
 	if rc.changeChan != nil {
 		close(rc.changeChan)
 		rc.changeChan = nil
@@ -1236,6 +1315,31 @@ func (rc *RobotClient) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// RunJob asks the robot to run the named job now, outside of its schedule. It returns once
+// the run has started.
+func (rc *RobotClient) RunJob(ctx context.Context, name string) error {
+	_, err := rdkpb.NewJobServiceClient(&rc.conn).RunJob(ctx, &rdkpb.RunJobRequest{Name: name})
+	return err
+}
+
+// RemoteStatus returns the status of the connection of the robot to each of its remotes, by
+// remote name. MachineStatus does not fill in Remotes, since the robot API has no field for
+// them.
+func (rc *RobotClient) RemoteStatus(ctx context.Context) (map[string]robot.RemoteStatus, error) {
+	resp, err := rdkpb.NewRemoteServiceClient(&rc.conn).GetRemoteStatus(ctx, &rdkpb.GetRemoteStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	remotes := make(map[string]robot.RemoteStatus, len(resp.GetRemotes()))
+	for name, remote := range resp.GetRemotes() {
+		remotes[name] = robot.RemoteStatus{
+			Connected:     remote.GetConnected(),
+			ActiveAddress: remote.GetActiveAddress(),
+		}
+	}
+	return remotes, nil
+}
+
 // MachineStatus returns the current status of the robot.
 func (rc *RobotClient) MachineStatus(ctx context.Context) (robot.MachineStatus, error) {
 	mStatus := robot.MachineStatus{}
@@ -1413,6 +1517,72 @@ func (rc *RobotClient) Tunnel(ctx context.Context, conn io.ReadWriteCloser, dest
 	return errors.Join(readerSenderErr, recvWriterErr)
 }
 
+// Operations returns the operations currently running on the robot, along with their
+// progress if the robot reports it.
+func (rc *RobotClient) Operations(ctx context.Context) ([]operation.Summary, error) {
+	report, err := rc.operationReport(ctx)
+	switch status.Code(err) {
+	case codes.OK:
+		return report.Running, nil
+	case codes.Unimplemented:
+		// robots that predate the operation service only report operations without progress.
+		return rc.operationsWithoutProgress(ctx)
+	default:
+		return nil, err
+	}
+}
+
+// OperationHistory returns the operations that recently completed on the robot, newest first.
+func (rc *RobotClient) OperationHistory(ctx context.Context) ([]operation.CompletedOperation, error) {
+	report, err := rc.operationReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return report.History, nil
+}
+
+func (rc *RobotClient) operationReport(ctx context.Context) (operation.Report, error) {
+	resp, err := rdkpb.NewOperationServiceClient(&rc.conn).GetOperationReport(ctx, &rdkpb.GetOperationReportRequest{})
+	if err != nil {
+		return operation.Report{}, err
+	}
+	return server.OperationReportFromProto(resp)
+}
+
+func (rc *RobotClient) operationsWithoutProgress(ctx context.Context) ([]operation.Summary, error) {
+	resp, err := rc.client.GetOperations(ctx, &pb.GetOperationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]operation.Summary, 0, len(resp.Operations))
+	for _, op := range resp.Operations {
+		id, err := uuid.Parse(op.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation id %q: %w", op.Id, err)
+		}
+		summary := operation.Summary{ID: id, Method: op.Method, Started: op.Started.AsTime()}
+		if op.SessionId != nil {
+			if summary.SessionID, err = uuid.Parse(*op.SessionId); err != nil {
+				return nil, fmt.Errorf("invalid session id %q: %w", *op.SessionId, err)
+			}
+		}
+		ops = append(ops, summary)
+	}
+	return ops, nil
+}
+
+// CancelOperation cancels a running operation on the robot by ID.
+func (rc *RobotClient) CancelOperation(ctx context.Context, id string) error {
+	_, err := rc.client.CancelOperation(ctx, &pb.CancelOperationRequest{Id: id})
+	return err
+}
+
+// BlockForOperation blocks until a running operation on the robot completes.
+func (rc *RobotClient) BlockForOperation(ctx context.Context, id string) error {
+	_, err := rc.client.BlockForOperation(ctx, &pb.BlockForOperationRequest{Id: id})
+	return err
+}
+
 // ListTunnels lists all available tunnels configured on the robot.
 func (rc *RobotClient) ListTunnels(ctx context.Context) ([]config.TrafficTunnelEndpoint, error) {
 	var ttes []config.TrafficTunnelEndpoint
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxReadCacheEntries bounds the number of responses kept by the read cache.
+const maxReadCacheEntries = 1024
+
+// ReadCacheStats describe how reads configured with WithReadCache were served.
+type ReadCacheStats struct {
+	// Hits is the number of reads served from the cache.
+	Hits uint64
+	// Coalesced is the number of reads that shared the result of an identical read already in
+	// flight.
+	Coalesced uint64
+	// Misses is the number of reads that went to the machine.
+	Misses uint64
+}
+
+type readCacheEntry struct {
+	resp    proto.Message
+	expires time.Time
+}
+
+// readCache is a read-through cache of unary responses keyed by method and request.
+type readCache struct {
+	ttls map[string]time.Duration
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]readCacheEntry
+	stats   ReadCacheStats
+}
+
+func newReadCache(ttls map[string]time.Duration) *readCache {
+	c := &readCache{
+		ttls:    make(map[string]time.Duration, len(ttls)),
+		entries: make(map[string]readCacheEntry),
+	}
+	for key, ttl := range ttls {
+		c.ttls[strings.TrimPrefix(key, "/")] = ttl
+	}
+	return c
+}
+
+// ttl returns the TTL configured for a method and whether reads of it are cached at all. A
+// method is configured either by its full name or, for methods whose name starts with Get,
+// by its service.
+func (c *readCache) ttl(method string) (time.Duration, bool) {
+	method = strings.TrimPrefix(method, "/")
+	if ttl, ok := c.ttls[method]; ok {
+		return ttl, true
+	}
+	service, name, ok := strings.Cut(method, "/")
+	if !ok || !strings.HasPrefix(name, "Get") {
+		return 0, false
+	}
+	ttl, ok := c.ttls[service]
+	return ttl, ok
+}
+
+func (c *readCache) get(key string) (proto.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.stats.Hits++
+	return entry.resp, true
+}
+
+func (c *readCache) put(key string, resp proto.Message, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxReadCacheEntries {
+		now := time.Now()
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxReadCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = readCacheEntry{resp: resp, expires: time.Now().Add(ttl)}
+}
+
+func (c *readCache) count(coalesced bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if coalesced {
+		c.stats.Coalesced++
+	} else {
+		c.stats.Misses++
+	}
+}
+
+func (c *readCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]readCacheEntry)
+}
+
+// ReadCacheStats returns statistics about reads configured with WithReadCache. It returns the
+// zero value if the client was not created with WithReadCache.
+func (rc *RobotClient) ReadCacheStats() ReadCacheStats {
+	if rc.readCache == nil {
+		return ReadCacheStats{}
+	}
+	rc.readCache.mu.Lock()
+	defer rc.readCache.mu.Unlock()
+	return rc.readCache.stats
+}
+
+// readCacheUnaryClientInterceptor serves configured reads from the cache, and otherwise
+// shares the response of an identical read in flight with every caller making it.
+func (rc *RobotClient) readCacheUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *googlegrpc.ClientConn,
+	invoker googlegrpc.UnaryInvoker,
+	opts ...googlegrpc.CallOption,
+) error {
+	c := rc.readCache
+	if c == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	ttl, ok := c.ttl(method)
+	if !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	reqMsg, reqOK := req.(proto.Message)
+	replyMsg, replyOK := reply.(proto.Message)
+	if !reqOK || !replyOK {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	reqBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(reqMsg)
+	if err != nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	key := method + "\x00" + string(reqBytes)
+
+	if resp, ok := c.get(key); ok {
+		proto.Reset(replyMsg)
+		proto.Merge(replyMsg, resp)
+		return nil
+	}
+
+	leader := false
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		leader = true
+		c.count(false)
+		// the read is shared by every caller making it, so it must not be canceled when the
+		// caller that happened to start it gives up. It keeps that caller's metadata and
+		// deadline.
+		sharedCtx := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			sharedCtx, cancel = context.WithDeadline(sharedCtx, deadline)
+			defer cancel()
+		}
+		resp := replyMsg.ProtoReflect().New().Interface()
+		if err := invoker(sharedCtx, method, proto.Clone(reqMsg), resp, cc, opts...); err != nil {
+			return nil, err
+		}
+		if ttl > 0 {
+			c.put(key, resp, ttl)
+		}
+		return resp, nil
+	})
+	var res singleflight.Result
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	if !leader {
+		c.count(true)
+	}
+	if res.Err != nil {
+		// a caller with a later deadline than the one that started the read still has time
+		// to make it on its own.
+		if !leader && status.Code(res.Err) == codes.DeadlineExceeded && ctx.Err() == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		return res.Err
+	}
+	proto.Reset(replyMsg)
+	proto.Merge(replyMsg, res.Val.(proto.Message))
+	return nil
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.viam.com/utils"
+	"go.viam.com/utils/rpc"
+)
+
+var (
+	// addressProbeInterval is how often a client connected to a machine with several addresses
+	// checks whether another address has become faster.
+	addressProbeInterval = time.Minute
+
+	// addressSwitchRatio is how much faster than the active address another address has to
+	// connect for the client to switch to it.
+	addressSwitchRatio = 0.75
+)
+
+// AddressStatus describes one of the addresses a client can reach a machine through.
+type AddressStatus struct {
+	Address string
+	// Active is whether the client is currently connected through the address.
+	Active bool
+	// Latency is how long the most recent successful connection through the address took to
+	// establish. It is zero if the address has never connected.
+	Latency time.Duration
+	// LastAttempt is when the client last tried to connect through the address.
+	LastAttempt time.Time
+	// LastError is the error of the last attempt to connect through the address, if it failed.
+	LastError error
+}
+
+type addressHealth struct {
+	latency     time.Duration
+	lastAttempt time.Time
+	lastErr     error
+}
+
+// ActiveAddress returns the address the client is connected through, or the address it was
+// last connected through if it is disconnected.
+func (rc *RobotClient) ActiveAddress() string {
+	rc.addressMu.Lock()
+	defer rc.addressMu.Unlock()
+	return rc.activeAddress
+}
+
+// Addresses returns the status of every address the client can reach the machine through, in
+// the order they were given.
+func (rc *RobotClient) Addresses() []AddressStatus {
+	rc.addressMu.Lock()
+	defer rc.addressMu.Unlock()
+	statuses := make([]AddressStatus, 0, len(rc.addresses))
+	for _, address := range rc.addresses {
+		health := rc.addressHealth[address]
+		statuses = append(statuses, AddressStatus{
+			Address:     address,
+			Active:      address == rc.activeAddress,
+			Latency:     health.latency,
+			LastAttempt: health.lastAttempt,
+			LastError:   health.lastErr,
+		})
+	}
+	return statuses
+}
+
+// candidateAddresses returns the addresses to try connecting through, most preferred first:
+// addresses that connected last time, fastest first, then addresses that were never tried,
+// then addresses that failed last time, each in the order they were given.
+func (rc *RobotClient) candidateAddresses() []string {
+	rc.addressMu.Lock()
+	defer rc.addressMu.Unlock()
+	rank := func(address string) int {
+		health := rc.addressHealth[address]
+		switch {
+		case health.lastErr != nil:
+			return 2
+		case health.lastAttempt.IsZero():
+			return 1
+		default:
+			return 0
+		}
+	}
+	candidates := append([]string(nil), rc.addresses...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		rankI, rankJ := rank(candidates[i]), rank(candidates[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		if rankI == 0 {
+			return rc.addressHealth[candidates[i]].latency < rc.addressHealth[candidates[j]].latency
+		}
+		return false
+	})
+	return candidates
+}
+
+func (rc *RobotClient) recordDial(address string, latency time.Duration, err error) {
+	rc.addressMu.Lock()
+	defer rc.addressMu.Unlock()
+	health := rc.addressHealth[address]
+	health.lastAttempt = time.Now()
+	health.lastErr = err
+	if err == nil {
+		health.latency = latency
+	}
+}
+
+func (rc *RobotClient) setActiveAddress(address string) {
+	rc.addressMu.Lock()
+	defer rc.addressMu.Unlock()
+	if rc.activeAddress != "" && rc.activeAddress != address {
+		rc.logger.Infow("switched address of remote", "from", rc.activeAddress, "to", address)
+	}
+	rc.activeAddress = address
+}
+
+// startAddressProbe runs probeAddresses in the background, unless a probe is already running,
+// so that dialing slow or unreachable addresses does not delay noticing that the active
+// connection was lost.
+func (rc *RobotClient) startAddressProbe(ctx context.Context) {
+	if len(rc.addresses) < 2 || !rc.probingAddresses.CompareAndSwap(false, true) {
+		return
+	}
+	rc.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		rc.probeAddresses(ctx)
+	}, func() {
+		rc.probingAddresses.Store(false)
+		rc.activeBackgroundWorkers.Done()
+	})
+}
+
+// probeAddresses tries connecting through every address other than the active one, at most
+// once per addressProbeInterval, and switches to the fastest if it is noticeably faster than
+// the active address. This brings the client back to a faster path, such as the LAN, once it
+// recovers.
+func (rc *RobotClient) probeAddresses(ctx context.Context) {
+	if len(rc.addresses) < 2 {
+		return
+	}
+	rc.addressMu.Lock()
+	if time.Since(rc.lastAddressProbe) < addressProbeInterval {
+		rc.addressMu.Unlock()
+		return
+	}
+	rc.lastAddressProbe = time.Now()
+	active := rc.activeAddress
+	maxLatency := time.Duration(float64(rc.addressHealth[active].latency) * addressSwitchRatio)
+	rc.addressMu.Unlock()
+
+	var best string
+	var bestConn rpc.ClientConn
+	for _, address := range rc.addresses {
+		if address == active {
+			continue
+		}
+		start := time.Now()
+		conn, err := rc.dial(ctx, address)
+		latency := time.Since(start)
+		rc.recordDial(address, latency, err)
+		if err != nil {
+			rc.Logger().CDebugw(ctx, "address of remote is unreachable", "address", address, "error", err)
+			continue
+		}
+		if latency >= maxLatency {
+			utils.UncheckedError(conn.Close())
+			continue
+		}
+		if bestConn != nil {
+			utils.UncheckedError(bestConn.Close())
+		}
+		best, bestConn, maxLatency = address, conn, latency
+	}
+	if bestConn == nil {
+		return
+	}
+
+	if err := rc.switchConn(ctx, bestConn, best); err != nil {
+		rc.Logger().CErrorw(ctx, "failed to switch address of remote", "address", best, "error", err)
+		return
+	}
+	if rc.notifyParent != nil {
+		rc.notifyParent()
+	}
+}
+
+// switchConn replaces the connection in use with one made through another address.
+func (rc *RobotClient) switchConn(ctx context.Context, conn rpc.ClientConn, address string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if !rc.connected.Load() {
+		// the connection was lost while probing; let the reconnect loop handle it.
+		return conn.Close()
+	}
+	if err := rc.conn.Close(); err != nil {
+		rc.Logger().CDebugw(ctx, "error closing previous connection", "error", err)
+	}
+	return rc.useConnLocked(ctx, conn, address)
+}
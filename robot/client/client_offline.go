@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultOfflineQueueSize is the number of calls kept while disconnected if no positive size
+// is given to WithOfflineQueue.
+const defaultOfflineQueueSize = 100
+
+// ErrOfflineCallDropped is returned by calls that were queued while the client was
+// disconnected but dropped before they could be replayed.
+var ErrOfflineCallDropped = errors.New("call queued while disconnected was dropped")
+
+type queueWhileOfflineKey struct{}
+
+// WithQueueWhileOffline returns a context that marks unary calls made with it as safe to
+// queue and replay if the client is disconnected. It has no effect unless the client was
+// created with WithOfflineQueue.
+func WithQueueWhileOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queueWhileOfflineKey{}, true)
+}
+
+type replayingOfflineCallKey struct{}
+
+// OfflineQueueStats describe the calls queued by a client while disconnected.
+type OfflineQueueStats struct {
+	// Pending is the number of calls waiting to be replayed.
+	Pending int
+	// Replayed is the number of queued calls that were replayed after reconnecting.
+	Replayed uint64
+	// Dropped is the number of queued calls that were dropped because the queue was full,
+	// they expired, their caller gave up, or the client was closed.
+	Dropped uint64
+}
+
+type queuedCall struct {
+	ctx        context.Context
+	method     string
+	req, reply interface{}
+	opts       []googlegrpc.CallOption
+	queuedAt   time.Time
+	// done receives the result of the call once it is replayed or dropped.
+	done chan error
+}
+
+// offlineQueue holds unary calls made while disconnected, oldest first.
+type offlineQueue struct {
+	size    int
+	ttl     time.Duration
+	methods map[string]bool
+
+	// replayMu ensures only one replay runs at a time so calls go out in order.
+	replayMu sync.Mutex
+
+	mu       sync.Mutex
+	calls    []*queuedCall
+	replayed uint64
+	dropped  uint64
+}
+
+func newOfflineQueue(opts *offlineQueueOpts) *offlineQueue {
+	q := &offlineQueue{
+		size:    opts.size,
+		ttl:     opts.ttl,
+		methods: make(map[string]bool, len(opts.methods)),
+	}
+	if q.size <= 0 {
+		q.size = defaultOfflineQueueSize
+	}
+	for _, method := range opts.methods {
+		q.methods[method] = true
+	}
+	return q
+}
+
+// queueable returns whether a call may be queued while disconnected.
+func (q *offlineQueue) queueable(ctx context.Context, method string) bool {
+	if ctx.Value(replayingOfflineCallKey{}) != nil {
+		return false
+	}
+	if marked, _ := ctx.Value(queueWhileOfflineKey{}).(bool); marked {
+		return true
+	}
+	return q.methods[method]
+}
+
+func (q *offlineQueue) pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.calls)
+}
+
+// push adds a call to the back of the queue, returning the oldest call if it had to be
+// evicted to make room.
+func (q *offlineQueue) push(call *queuedCall) *queuedCall {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var evicted *queuedCall
+	if len(q.calls) >= q.size {
+		evicted = q.calls[0]
+		q.calls = q.calls[1:]
+	}
+	q.calls = append(q.calls, call)
+	return evicted
+}
+
+func (q *offlineQueue) pushFront(call *queuedCall) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.calls = append([]*queuedCall{call}, q.calls...)
+}
+
+func (q *offlineQueue) pop() *queuedCall {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.calls) == 0 {
+		return nil
+	}
+	call := q.calls[0]
+	q.calls = q.calls[1:]
+	return call
+}
+
+// remove takes a call out of the queue, returning false if it is no longer queued.
+func (q *offlineQueue) remove(call *queuedCall) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, c := range q.calls {
+		if c == call {
+			q.calls = append(q.calls[:i], q.calls[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *offlineQueue) expired(call *queuedCall) bool {
+	return q.ttl > 0 && time.Since(call.queuedAt) > q.ttl
+}
+
+// OfflineQueueStats returns statistics about the calls queued while disconnected. It returns
+// the zero value if the client was not created with WithOfflineQueue.
+func (rc *RobotClient) OfflineQueueStats() OfflineQueueStats {
+	q := rc.offlineQueue
+	if q == nil {
+		return OfflineQueueStats{}
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return OfflineQueueStats{Pending: len(q.calls), Replayed: q.replayed, Dropped: q.dropped}
+}
+
+// shouldQueueOffline returns whether a call should be queued rather than made. Calls are
+// queued while disconnected, and also while earlier calls are still queued so that they
+// keep their order.
+func (rc *RobotClient) shouldQueueOffline(ctx context.Context, method string) bool {
+	q := rc.offlineQueue
+	if q == nil || !q.queueable(ctx, method) {
+		return false
+	}
+	return !rc.connected.Load() || q.pending() > 0
+}
+
+// queueOfflineCall queues a call until the client reconnects and waits for its result.
+func (rc *RobotClient) queueOfflineCall(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	opts ...googlegrpc.CallOption,
+) error {
+	q := rc.offlineQueue
+	call := &queuedCall{
+		ctx:      ctx,
+		method:   method,
+		req:      req,
+		reply:    reply,
+		opts:     opts,
+		queuedAt: time.Now(),
+		done:     make(chan error, 1),
+	}
+	rc.Logger().CDebugw(ctx, "connection is down, queueing method call", "method", method)
+	if evicted := q.push(call); evicted != nil {
+		rc.dropOfflineCall(evicted, "queue is full")
+	}
+
+	var expiry <-chan time.Time
+	if q.ttl > 0 {
+		timer := time.NewTimer(q.ttl)
+		defer timer.Stop()
+		expiry = timer.C
+	}
+	select {
+	case err := <-call.done:
+		return err
+	case <-expiry:
+		if q.remove(call) {
+			rc.dropOfflineCall(call, "expired")
+		}
+	case <-ctx.Done():
+		if q.remove(call) {
+			rc.dropOfflineCall(call, ctx.Err().Error())
+		}
+	}
+	// if the call was not in the queue it is being replayed, so wait for its result.
+	return <-call.done
+}
+
+func (rc *RobotClient) dropOfflineCall(call *queuedCall, reason string) {
+	q := rc.offlineQueue
+	q.mu.Lock()
+	q.dropped++
+	q.mu.Unlock()
+	rc.Logger().CWarnw(call.ctx, "dropped method call queued while disconnected",
+		"method", call.method, "reason", reason, "queued_for", time.Since(call.queuedAt).String())
+	call.done <- fmt.Errorf("%w: %s %s", ErrOfflineCallDropped, call.method, reason)
+}
+
+// replayOfflineQueue makes the calls queued while disconnected, in order. If the connection
+// is lost again, the remaining calls stay queued.
+func (rc *RobotClient) replayOfflineQueue() {
+	q := rc.offlineQueue
+	if q == nil {
+		return
+	}
+	q.replayMu.Lock()
+	defer q.replayMu.Unlock()
+
+	for {
+		call := q.pop()
+		if call == nil {
+			return
+		}
+		if q.expired(call) {
+			rc.dropOfflineCall(call, "expired")
+			continue
+		}
+		if err := call.ctx.Err(); err != nil {
+			rc.dropOfflineCall(call, err.Error())
+			continue
+		}
+
+		ctx := context.WithValue(call.ctx, replayingOfflineCallKey{}, true)
+		err := rc.conn.Invoke(ctx, call.method, call.req, call.reply, call.opts...)
+		if status.Code(err) == codes.Unavailable && call.ctx.Err() == nil {
+			if q.expired(call) {
+				rc.dropOfflineCall(call, "expired")
+				return
+			}
+			rc.Logger().CDebugw(call.ctx, "connection lost while replaying queued method calls", "method", call.method)
+			q.pushFront(call)
+			return
+		}
+		q.mu.Lock()
+		q.replayed++
+		q.mu.Unlock()
+		call.done <- err
+	}
+}
+
+// dropOfflineQueue drops every queued call.
+func (rc *RobotClient) dropOfflineQueue(reason string) {
+	if rc.offlineQueue == nil {
+		return
+	}
+	for call := rc.offlineQueue.pop(); call != nil; call = rc.offlineQueue.pop() {
+		rc.dropOfflineCall(call, reason)
+	}
+}
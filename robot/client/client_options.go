@@ -48,6 +48,23 @@ type robotClientOpts struct {
 	// in production (not in a testing environment) will already allow connecting
 	// to still-initializing machines.
 	doNotWaitForRunning bool
+
+	// offlineQueue, if set, queues calls made while disconnected and replays them on
+	// reconnect.
+	offlineQueue *offlineQueueOpts
+
+	// readCacheTTLs, if set, are how long to cache the responses of reads, by method.
+	readCacheTTLs map[string]time.Duration
+
+	// fallbackAddresses are other addresses the machine can be reached through.
+	fallbackAddresses []string
+}
+
+// offlineQueueOpts configure the queue of calls made while disconnected.
+type offlineQueueOpts struct {
+	size    int
+	ttl     time.Duration
+	methods []string
 }
 
 // RobotClientOption configures how we set up the connection.
@@ -151,6 +168,45 @@ func WithNetworkStats() RobotClientOption {
 	})
 }
 
+// WithOfflineQueue returns a RobotClientOption that queues unary calls made while the client
+// is disconnected instead of failing them, and replays them in order once the client
+// reconnects. Only calls to the given gRPC methods (e.g.
+// "/viam.component.generic.v1.GenericService/DoCommand"), which should be idempotent, and
+// calls whose context was marked with WithQueueWhileOffline are queued. At most size calls
+// are kept; when the queue is full the oldest call is dropped. A call that has not been
+// replayed within ttl is dropped. Callers block until their call is replayed, dropped, or
+// their context is done.
+func WithOfflineQueue(size int, ttl time.Duration, methods ...string) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.offlineQueue = &offlineQueueOpts{size: size, ttl: ttl, methods: methods}
+	})
+}
+
+// WithReadCache returns a RobotClientOption that caches the responses of unary reads for a
+// time, and coalesces identical reads made concurrently into a single call to the machine.
+// ttls are keyed by full gRPC method name (e.g.
+// "/viam.component.sensor.v1.SensorService/GetReadings"), or by gRPC service name (e.g.
+// "viam.component.sensor.v1.SensorService") to cover every method of the service whose name
+// starts with Get. A TTL of zero coalesces concurrent reads without caching their responses.
+// The cache is cleared whenever the client reconnects.
+func WithReadCache(ttls map[string]time.Duration) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.readCacheTTLs = ttls
+	})
+}
+
+// WithFallbackAddresses returns a RobotClientOption with other addresses the machine can be
+// reached through, such as a LAN IP, an mDNS name or a cloud FQDN. When connecting, the
+// client prefers addresses that connected most recently and, among those, the one that
+// connected fastest, and fails over to the others. While connected through an address, the
+// client occasionally checks whether another address has become noticeably faster and
+// switches to it.
+func WithFallbackAddresses(addresses ...string) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.fallbackAddresses = addresses
+	})
+}
+
 // ExtractDialOptions extracts RPC dial options from the given options, if any exist.
 func ExtractDialOptions(opts ...RobotClientOption) []rpc.DialOption {
 	var rOpts robotClientOpts
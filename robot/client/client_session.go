@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/session"
@@ -83,6 +85,12 @@ func (rc *RobotClient) sessionMetadata(ctx context.Context, method string) (cont
 	if !rc.useSessionInRequest(ctx, method) {
 		return ctx, nil
 	}
+	return rc.ensureSessionMetadata(ctx)
+}
+
+// ensureSessionMetadata starts a session if there is none yet and attaches it to the
+// outgoing metadata of ctx.
+func (rc *RobotClient) ensureSessionMetadata(ctx context.Context) (context.Context, error) {
 	ctx = context.WithValue(ctx, ctxKeyInSessionMDReq, true)
 	rc.sessionMu.RLock()
 	if rc.sessionsSupported != nil {
@@ -139,6 +147,60 @@ func (rc *RobotClient) sessionMetadata(ctx context.Context, method string) (cont
 	return rc.sessionMetadataInner(ctx), nil
 }
 
+// AcquireLease gives the client's session exclusive control of the named resources. While
+// the lease is held, actuating calls to the resources from other sessions fail. The lease
+// is released when the session expires.
+func (rc *RobotClient) AcquireLease(ctx context.Context, names ...resource.Name) error {
+	return rc.sendSessionLeaseRequest(ctx, session.LeaseAcquireMetadataKey, names)
+}
+
+// ReleaseLease gives up the client's exclusive control of the named resources.
+func (rc *RobotClient) ReleaseLease(ctx context.Context, names ...resource.Name) error {
+	return rc.sendSessionLeaseRequest(ctx, session.LeaseReleaseMetadataKey, names)
+}
+
+// RevokeLease forcibly takes exclusive control of the named resources away from whichever
+// session holds it. If the robot has roles configured, the client's role must allow
+// LeaseService/RevokeLease.
+func (rc *RobotClient) RevokeLease(ctx context.Context, names ...resource.Name) error {
+	req := &rdkpb.RevokeLeaseRequest{Names: make([]string, 0, len(names))}
+	for _, name := range names {
+		req.Names = append(req.Names, name.String())
+	}
+	_, err := rdkpb.NewLeaseServiceClient(&rc.conn).RevokeLease(ctx, req)
+	return err
+}
+
+// sendSessionLeaseRequest asks the robot to acquire or release leases for the client's
+// session as part of a session heartbeat.
+func (rc *RobotClient) sendSessionLeaseRequest(ctx context.Context, key string, names []resource.Name) error {
+	if rc.sessionsDisabled {
+		return errors.New("sessions are disabled; cannot lease resources")
+	}
+	ctx, err := rc.ensureSessionMetadata(ctx)
+	if err != nil {
+		return err
+	}
+	rc.sessionMu.RLock()
+	supported := rc.sessionsSupported != nil && *rc.sessionsSupported
+	sessID := rc.currentSessionID
+	rc.sessionMu.RUnlock()
+	if !supported || sessID == "" {
+		return errors.New("robot does not support sessions; cannot lease resources")
+	}
+	ctx = appendLeaseMetadata(ctx, key, names)
+	_, err = rc.client.SendSessionHeartbeat(ctx, &pb.SendSessionHeartbeatRequest{Id: sessID})
+	return err
+}
+
+func appendLeaseMetadata(ctx context.Context, key string, names []resource.Name) context.Context {
+	kv := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		kv = append(kv, key, name.String())
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
 func (rc *RobotClient) safetyMonitorFromHeaders(ctx context.Context, hdr metadata.MD) {
 	for _, name := range hdr.Get(session.SafetyMonitoredResourceMetadataKey) {
 		resName, err := resource.NewFromString(name)
@@ -57,6 +57,7 @@ import (
 	rgrpc "go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/rimage"
@@ -2363,3 +2364,440 @@ func TestListTunnels(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, ttes, test.ShouldResemble, expectedTTEs)
 }
+
+func TestClientOfflineQueue(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+
+	var receivedMu sync.Mutex
+	var received []string
+	recordCalls := grpc.ChainUnaryInterceptor(
+		func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			receivedMu.Lock()
+			received = append(received, info.FullMethod)
+			receivedMu.Unlock()
+			return handler(ctx, req)
+		},
+	)
+	gServer := grpc.NewServer(recordCalls)
+
+	injectRobot := &inject.Robot{
+		ResourceNamesFunc:   func() []resource.Name { return nil },
+		ResourceRPCAPIsFunc: func() []resource.RPCAPI { return nil },
+		MachineStatusFunc: func(ctx context.Context) (robot.MachineStatus, error) {
+			return robot.MachineStatus{State: robot.StateRunning}, nil
+		},
+		ListTunnelsFunc: func(ctx context.Context) ([]config.TrafficTunnelEndpoint, error) {
+			return []config.TrafficTunnelEndpoint{{Port: 9090}}, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	const machineStatusMethod = "/viam.robot.v1.RobotService/GetMachineStatus"
+	const listTunnelsMethod = "/viam.robot.v1.RobotService/ListTunnels"
+
+	never := -1 * time.Second
+	newClient := func(t *testing.T, size int, ttl time.Duration) *RobotClient {
+		t.Helper()
+		client, err := New(
+			context.Background(),
+			listener.Addr().String(),
+			logger,
+			WithCheckConnectedEvery(never),
+			WithReconnectEvery(never),
+			WithOfflineQueue(size, ttl, machineStatusMethod),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		receivedMu.Lock()
+		received = nil
+		receivedMu.Unlock()
+		return client
+	}
+	waitForPending := func(t *testing.T, client *RobotClient, pending int) {
+		t.Helper()
+		gotestutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, client.OfflineQueueStats().Pending, test.ShouldEqual, pending)
+		})
+	}
+
+	t.Run("queued calls are replayed in order", func(t *testing.T) {
+		client := newClient(t, 10, time.Minute)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+		client.connected.Store(false)
+
+		// calls that are neither listed nor marked fail right away.
+		_, err := client.ListTunnels(context.Background())
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+
+		var wg sync.WaitGroup
+		var statusErr, tunnelsErr error
+		var ttes []config.TrafficTunnelEndpoint
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, statusErr = client.MachineStatus(context.Background())
+		}()
+		waitForPending(t, client, 1)
+		go func() {
+			defer wg.Done()
+			ttes, tunnelsErr = client.ListTunnels(WithQueueWhileOffline(context.Background()))
+		}()
+		waitForPending(t, client, 2)
+
+		receivedMu.Lock()
+		test.That(t, received, test.ShouldBeEmpty)
+		receivedMu.Unlock()
+
+		client.connected.Store(true)
+		client.replayOfflineQueue()
+		wg.Wait()
+
+		test.That(t, statusErr, test.ShouldBeNil)
+		test.That(t, tunnelsErr, test.ShouldBeNil)
+		test.That(t, ttes, test.ShouldResemble, []config.TrafficTunnelEndpoint{{Port: 9090}})
+		receivedMu.Lock()
+		test.That(t, received, test.ShouldResemble, []string{machineStatusMethod, listTunnelsMethod})
+		receivedMu.Unlock()
+		test.That(t, client.OfflineQueueStats(), test.ShouldResemble, OfflineQueueStats{Replayed: 2})
+	})
+
+	t.Run("oldest call is dropped when the queue is full", func(t *testing.T) {
+		client := newClient(t, 1, time.Minute)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+		client.connected.Store(false)
+
+		errs := make(chan error, 2)
+		go func() {
+			_, err := client.MachineStatus(context.Background())
+			errs <- err
+		}()
+		waitForPending(t, client, 1)
+		go func() {
+			_, err := client.MachineStatus(context.Background())
+			errs <- err
+		}()
+		err := <-errs
+		test.That(t, errors.Is(err, ErrOfflineCallDropped), test.ShouldBeTrue)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "queue is full")
+
+		client.connected.Store(true)
+		client.replayOfflineQueue()
+		test.That(t, <-errs, test.ShouldBeNil)
+		test.That(t, client.OfflineQueueStats(), test.ShouldResemble, OfflineQueueStats{Replayed: 1, Dropped: 1})
+	})
+
+	t.Run("calls expire", func(t *testing.T) {
+		client := newClient(t, 10, 10*time.Millisecond)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+		client.connected.Store(false)
+
+		_, err := client.MachineStatus(context.Background())
+		test.That(t, errors.Is(err, ErrOfflineCallDropped), test.ShouldBeTrue)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "expired")
+		test.That(t, client.OfflineQueueStats(), test.ShouldResemble, OfflineQueueStats{Dropped: 1})
+
+		client.connected.Store(true)
+		client.replayOfflineQueue()
+		receivedMu.Lock()
+		test.That(t, received, test.ShouldBeEmpty)
+		receivedMu.Unlock()
+	})
+
+	t.Run("pending calls are dropped on close", func(t *testing.T) {
+		client := newClient(t, 10, time.Minute)
+		client.connected.Store(false)
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := client.MachineStatus(context.Background())
+			errs <- err
+		}()
+		waitForPending(t, client, 1)
+		test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		err := <-errs
+		test.That(t, errors.Is(err, ErrOfflineCallDropped), test.ShouldBeTrue)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "client closed")
+	})
+}
+
+func TestClientReadCache(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer := grpc.NewServer()
+
+	var statusCalls, tunnelCalls atomic.Int64
+	var block atomic.Pointer[chan struct{}]
+	injectRobot := &inject.Robot{
+		ResourceNamesFunc:   func() []resource.Name { return nil },
+		ResourceRPCAPIsFunc: func() []resource.RPCAPI { return nil },
+		MachineStatusFunc: func(ctx context.Context) (robot.MachineStatus, error) {
+			statusCalls.Add(1)
+			if ch := block.Load(); ch != nil {
+				<-*ch
+			}
+			return robot.MachineStatus{State: robot.StateRunning}, nil
+		},
+		ListTunnelsFunc: func(ctx context.Context) ([]config.TrafficTunnelEndpoint, error) {
+			tunnelCalls.Add(1)
+			return nil, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	t.Run("reads are cached", func(t *testing.T) {
+		client, err := New(
+			context.Background(),
+			listener.Addr().String(),
+			logger,
+			WithReadCache(map[string]time.Duration{"viam.robot.v1.RobotService": time.Minute}),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		before := statusCalls.Load()
+		for range 3 {
+			mStatus, err := client.MachineStatus(context.Background())
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, mStatus.State, test.ShouldEqual, robot.StateRunning)
+		}
+		// the status was already cached while waiting for the machine to run in New.
+		test.That(t, statusCalls.Load(), test.ShouldEqual, before)
+		test.That(t, client.ReadCacheStats().Hits, test.ShouldBeGreaterThanOrEqualTo, 3)
+
+		// methods that are not reads are not cached.
+		for range 2 {
+			_, err := client.ListTunnels(context.Background())
+			test.That(t, err, test.ShouldBeNil)
+		}
+		test.That(t, tunnelCalls.Load(), test.ShouldEqual, 2)
+
+		// reconnecting clears the cache.
+		test.That(t, client.Connect(context.Background()), test.ShouldBeNil)
+		_, err = client.MachineStatus(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statusCalls.Load(), test.ShouldEqual, before+1)
+	})
+
+	t.Run("concurrent reads are coalesced", func(t *testing.T) {
+		client, err := New(
+			context.Background(),
+			listener.Addr().String(),
+			logger,
+			WithReadCache(map[string]time.Duration{"/viam.robot.v1.RobotService/GetMachineStatus": 0}),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		before := statusCalls.Load()
+		statsBefore := client.ReadCacheStats()
+		release := make(chan struct{})
+		block.Store(&release)
+		defer block.Store(nil)
+
+		const numReads = 5
+		var wg sync.WaitGroup
+		errs := make(chan error, numReads)
+		for range numReads {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.MachineStatus(context.Background())
+				errs <- err
+			}()
+		}
+		gotestutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, statusCalls.Load(), test.ShouldEqual, before+1)
+		})
+		time.Sleep(100 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			test.That(t, err, test.ShouldBeNil)
+		}
+
+		stats := client.ReadCacheStats()
+		misses := stats.Misses - statsBefore.Misses
+		coalesced := stats.Coalesced - statsBefore.Coalesced
+		test.That(t, misses+coalesced, test.ShouldEqual, numReads)
+		test.That(t, coalesced, test.ShouldBeGreaterThan, 0)
+		test.That(t, statusCalls.Load()-before, test.ShouldEqual, misses)
+		test.That(t, stats.Hits, test.ShouldEqual, 0)
+	})
+
+	t.Run("canceling the first read does not fail coalesced reads", func(t *testing.T) {
+		client, err := New(
+			context.Background(),
+			listener.Addr().String(),
+			logger,
+			WithReadCache(map[string]time.Duration{"/viam.robot.v1.RobotService/GetMachineStatus": 0}),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		before := statusCalls.Load()
+		release := make(chan struct{})
+		block.Store(&release)
+		defer block.Store(nil)
+
+		firstCtx, cancelFirst := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := client.MachineStatus(firstCtx)
+			firstErr <- err
+		}()
+		gotestutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, statusCalls.Load(), test.ShouldEqual, before+1)
+		})
+		secondErr := make(chan error, 1)
+		go func() {
+			_, err := client.MachineStatus(context.Background())
+			secondErr <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		cancelFirst()
+		test.That(t, status.Code(<-firstErr), test.ShouldEqual, codes.Canceled)
+		close(release)
+		test.That(t, <-secondErr, test.ShouldBeNil)
+		test.That(t, statusCalls.Load(), test.ShouldEqual, before+1)
+	})
+}
+
+func TestClientFallbackAddresses(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	injectRobot := &inject.Robot{
+		ResourceNamesFunc:   func() []resource.Name { return nil },
+		ResourceRPCAPIsFunc: func() []resource.RPCAPI { return nil },
+		MachineStatusFunc: func(ctx context.Context) (robot.MachineStatus, error) {
+			return robot.MachineStatus{
+				State: robot.StateRunning,
+				Remotes: map[string]robot.RemoteStatus{
+					"remote1": {Connected: true, ActiveAddress: "remote1.local:8080"},
+				},
+			}, nil
+		},
+	}
+	serve := func(t *testing.T) string {
+		t.Helper()
+		listener, err := net.Listen("tcp", "localhost:0")
+		test.That(t, err, test.ShouldBeNil)
+		gServer := grpc.NewServer()
+		pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+		rdkpb.RegisterRemoteServiceServer(gServer, server.NewRemoteServer(injectRobot))
+		go gServer.Serve(listener)
+		t.Cleanup(gServer.Stop)
+		return listener.Addr().String()
+	}
+
+	never := -1 * time.Second
+	t.Run("fails over to a fallback address", func(t *testing.T) {
+		// nothing listens on the primary address.
+		listener, err := net.Listen("tcp", "localhost:0")
+		test.That(t, err, test.ShouldBeNil)
+		unreachable := listener.Addr().String()
+		test.That(t, listener.Close(), test.ShouldBeNil)
+		fallback := serve(t)
+
+		client, err := New(
+			context.Background(),
+			unreachable,
+			logger,
+			WithCheckConnectedEvery(never),
+			WithReconnectEvery(never),
+			WithFallbackAddresses(fallback),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+
+		test.That(t, client.ActiveAddress(), test.ShouldEqual, fallback)
+		addresses := client.Addresses()
+		test.That(t, addresses, test.ShouldHaveLength, 2)
+		test.That(t, addresses[0].Address, test.ShouldEqual, unreachable)
+		test.That(t, addresses[0].Active, test.ShouldBeFalse)
+		test.That(t, addresses[0].LastError, test.ShouldNotBeNil)
+		test.That(t, addresses[1].Address, test.ShouldEqual, fallback)
+		test.That(t, addresses[1].Active, test.ShouldBeTrue)
+		test.That(t, addresses[1].LastError, test.ShouldBeNil)
+
+		// the address that failed is tried last from now on.
+		test.That(t, client.candidateAddresses(), test.ShouldResemble, []string{fallback, unreachable})
+	})
+
+	t.Run("switches to a faster address", func(t *testing.T) {
+		primary := serve(t)
+		fallback := serve(t)
+
+		client, err := New(
+			context.Background(),
+			primary,
+			logger,
+			WithCheckConnectedEvery(never),
+			WithReconnectEvery(never),
+			WithFallbackAddresses(fallback),
+		)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() {
+			test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		}()
+		test.That(t, client.ActiveAddress(), test.ShouldEqual, primary)
+
+		// pretend the primary address has become slow.
+		client.addressMu.Lock()
+		client.addressHealth[primary].latency = time.Hour
+		client.addressMu.Unlock()
+
+		// probes run in the background so they do not hold up connection checks.
+		client.startAddressProbe(context.Background())
+		gotestutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			test.That(tb, client.ActiveAddress(), test.ShouldEqual, fallback)
+			test.That(tb, client.probingAddresses.Load(), test.ShouldBeFalse)
+		})
+		test.That(t, client.Connected(), test.ShouldBeTrue)
+		// the remotes of the machine, and the addresses they use, are reported over the network.
+		remotes, err := client.RemoteStatus(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, remotes, test.ShouldResemble, map[string]robot.RemoteStatus{
+			"remote1": {Connected: true, ActiveAddress: "remote1.local:8080"},
+		})
+
+		// probing again within the interval does nothing.
+		client.addressMu.Lock()
+		client.addressHealth[fallback].latency = time.Hour
+		client.addressMu.Unlock()
+		client.probeAddresses(context.Background())
+		test.That(t, client.ActiveAddress(), test.ShouldEqual, fallback)
+	})
+}
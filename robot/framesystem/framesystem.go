@@ -42,6 +42,21 @@ type InputEnabled interface {
 	GoToInputs(context.Context, ...[]referenceframe.Input) error
 }
 
+// TimedInputEnabled is an InputEnabled that can follow a timed trajectory of inputs, such as an
+// arm which streams joint positions to its controller.
+type TimedInputEnabled interface {
+	InputEnabled
+	// GoToTimedInputs moves through each of the inputs at its time since the call, ending at rest
+	// at the last of them. Given no inputs it only checks that they are supported. It returns
+	// ErrTimedInputsUnsupported, without moving, if it turns out that they are not.
+	GoToTimedInputs(context.Context, []referenceframe.TimedInputs) error
+}
+
+// ErrTimedInputsUnsupported is returned by GoToTimedInputs when the component behind it cannot
+// follow timed inputs after all, such as an arm client whose remote driver does not handle them.
+// GoToInputs should be used instead.
+var ErrTimedInputsUnsupported = errors.New("component does not support timed inputs")
+
 // Service is an interface that wraps a RobotFrameSystem in a Resource.
 type Service interface {
 	resource.Resource
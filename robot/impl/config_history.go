@@ -0,0 +1,271 @@
+package robotimpl
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+)
+
+// configHistoryState tracks recently applied configs so that the robot can roll back to the
+// last-known-good config when a newly applied config leaves critical resources failing.
+type configHistoryState struct {
+	mu sync.Mutex
+
+	// history is created lazily for cloud-managed robots or robots that configure
+	// config_history explicitly.
+	history *config.History
+
+	// pending is the most recently applied config while its critical resources are being
+	// watched. pendingSince is when it was applied and failingSince is when any of its
+	// critical resources were first observed failing (zero if none are failing).
+	pending      *config.Config
+	pendingSince time.Time
+	failingSince time.Time
+
+	// lastKnownGood is the most recent config whose critical resources all stayed healthy.
+	lastKnownGood *config.Config
+
+	// rolledBackRevision is the revision of the config that was most recently rolled back.
+	// Incoming configs with this revision are not reapplied.
+	rolledBackRevision string
+
+	// applied is whether the robot has applied a config since it started.
+	applied bool
+}
+
+// historyConfigOrDefault returns the config history settings for cfg, filling in defaults.
+func historyConfigOrDefault(cfg *config.Config) config.HistoryConfig {
+	if cfg.ConfigHistory != nil {
+		return *cfg.ConfigHistory
+	}
+	return config.HistoryConfig{
+		Size:          config.DefaultHistorySize,
+		RollbackAfter: config.DefaultRollbackAfter,
+	}
+}
+
+// loadConfigHistory creates the config history for cfg if it does not exist yet and reports
+// whether the robot keeps one. The last-known-good config and the rolled back revision are
+// seeded from the history stored by the previous run of the robot, so that a restart neither
+// loses the rollback target nor reapplies a config that was rolled back. Must be called with
+// the config history mutex held.
+func (r *localRobot) loadConfigHistory(ctx context.Context, cfg *config.Config) bool {
+	st := &r.configHistory
+	if st.history != nil {
+		return true
+	}
+	if cfg.Cloud == nil && cfg.ConfigHistory == nil {
+		return false
+	}
+	partID := localConfigPartID
+	if cfg.Cloud != nil {
+		partID = cfg.Cloud.ID
+	}
+	var err error
+	st.history, err = config.NewHistory(config.HistoryFilePath(r.homeDir, partID), historyConfigOrDefault(cfg).Size)
+	if err != nil {
+		r.logger.CWarnw(ctx, "error reading config history; starting a new one", "error", err)
+	}
+
+	// Entries are ordered from newest to oldest. After a rollback, the last-known-good config
+	// is reapplied, so the rolled back revision is still in effect as long as only that
+	// config has been applied since.
+	var lastKnownGoodRevision string
+	onlyReapplied := true
+	for _, entry := range st.history.Entries() {
+		if entry.Outcome == config.HistoryOutcomeRolledBack && onlyReapplied {
+			st.rolledBackRevision = entry.Revision
+		}
+		if entry.Outcome == config.HistoryOutcomeApplied && st.lastKnownGood == nil {
+			restored, err := entry.RestoreConfig(cfg, r.logger)
+			if err != nil {
+				r.logger.CWarnw(ctx, "error restoring last-known-good config from config history", "error", err)
+			} else {
+				st.lastKnownGood = restored
+				lastKnownGoodRevision = entry.Revision
+			}
+		}
+		if entry.Outcome != config.HistoryOutcomeApplied || entry.Revision != lastKnownGoodRevision {
+			onlyReapplied = false
+		}
+	}
+	return true
+}
+
+// configToApply returns the config that the robot should apply when given newConfig, or nil if
+// it should not reconfigure. A config with the revision of the config that was most recently
+// rolled back is not applied again. If the robot has not applied any config since it started,
+// the last-known-good config is applied in its place.
+func (r *localRobot) configToApply(ctx context.Context, newConfig *config.Config) *config.Config {
+	if newConfig.Initial {
+		return newConfig
+	}
+	st := &r.configHistory
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if !r.loadConfigHistory(ctx, newConfig) || newConfig.Revision == "" || newConfig.Revision != st.rolledBackRevision {
+		return newConfig
+	}
+	if st.applied || st.lastKnownGood == nil {
+		return nil
+	}
+	return st.lastKnownGood
+}
+
+// recordAppliedConfig adds the applied config to the config history. Configs without
+// critical resources are considered good immediately; otherwise they are watched by
+// checkConfigRollback. The caller must hold the reconfigurationLock.
+func (r *localRobot) recordAppliedConfig(ctx context.Context, cfg *config.Config) {
+	if cfg.Initial {
+		return
+	}
+
+	st := &r.configHistory
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if !r.loadConfigHistory(ctx, cfg) {
+		return
+	}
+	st.applied = true
+	hc := historyConfigOrDefault(cfg)
+	if err := st.history.SetSize(hc.Size); err != nil {
+		r.logger.CWarnw(ctx, "error resizing config history", "error", err)
+	}
+
+	if st.pending != nil {
+		if err := st.history.UpdateLatest(config.HistoryOutcomeSuperseded, nil); err != nil {
+			r.logger.CWarnw(ctx, "error updating config history", "error", err)
+		}
+		st.pending = nil
+	}
+
+	entry, err := config.NewHistoryEntry(cfg, time.Now())
+	if err != nil {
+		r.logger.CWarnw(ctx, "error recording config history", "error", err)
+		return
+	}
+	// The last-known-good config is only ever reapplied by a rollback, at which point it is
+	// known to be good already. Likewise, configs without critical resources have nothing
+	// to watch.
+	if cfg != st.lastKnownGood {
+		// A different config was applied; the rolled back one may be retried if it returns.
+		st.rolledBackRevision = ""
+	}
+	if cfg == st.lastKnownGood || len(hc.CriticalResources) == 0 {
+		entry.Outcome = config.HistoryOutcomeApplied
+		st.lastKnownGood = cfg
+	} else {
+		st.pending = cfg
+		st.pendingSince = entry.AppliedAt
+		st.failingSince = time.Time{}
+	}
+	if err := st.history.Add(entry); err != nil {
+		r.logger.CWarnw(ctx, "error storing config history", "error", err)
+	}
+}
+
+// checkConfigRollback checks the critical resources of a pending config. If they have been
+// failing for longer than the configured period, the robot reconfigures with the
+// last-known-good config. If they have all stayed healthy for that long, the pending config
+// becomes the last-known-good config.
+func (r *localRobot) checkConfigRollback(ctx context.Context) {
+	st := &r.configHistory
+	st.mu.Lock()
+	pending := st.pending
+	if pending == nil {
+		st.mu.Unlock()
+		return
+	}
+
+	hc := historyConfigOrDefault(pending)
+	failing := r.failingCriticalResources(hc.CriticalResources)
+	now := time.Now()
+	if len(failing) == 0 {
+		st.failingSince = time.Time{}
+		if now.Sub(st.pendingSince) >= hc.RollbackAfter {
+			if err := st.history.UpdateLatest(config.HistoryOutcomeApplied, nil); err != nil {
+				r.logger.CWarnw(ctx, "error updating config history", "error", err)
+			}
+			st.lastKnownGood = pending
+			st.pending = nil
+		}
+		st.mu.Unlock()
+		return
+	}
+
+	if st.failingSince.IsZero() {
+		st.failingSince = now
+	}
+	if now.Sub(st.failingSince) < hc.RollbackAfter {
+		st.mu.Unlock()
+		return
+	}
+
+	cause := errors.Errorf("critical resources failing for at least %v: %s", hc.RollbackAfter, strings.Join(failing, ", "))
+	st.pending = nil
+	lastKnownGood := st.lastKnownGood
+	if lastKnownGood == nil {
+		if err := st.history.UpdateLatest(config.HistoryOutcomeFailed, cause); err != nil {
+			r.logger.CWarnw(ctx, "error updating config history", "error", err)
+		}
+		st.mu.Unlock()
+		r.logger.CErrorw(ctx, "config left critical resources failing and there is no known-good config to roll back to",
+			"revision", pending.Revision, "error", cause)
+		return
+	}
+	if err := st.history.UpdateLatest(config.HistoryOutcomeRolledBack, cause); err != nil {
+		r.logger.CWarnw(ctx, "error updating config history", "error", err)
+	}
+	st.rolledBackRevision = pending.Revision
+	st.mu.Unlock()
+
+	r.logger.CWarnw(ctx, "rolling back to last-known-good config",
+		"bad_revision", pending.Revision, "revision", lastKnownGood.Revision, "error", cause)
+	r.reconfigurationLock.Lock()
+	defer r.reconfigurationLock.Unlock()
+	r.reconfigure(ctx, lastKnownGood, false)
+}
+
+// failingCriticalResources returns the names of the given critical resources that are
+// missing, unconfigured, unhealthy or failing their health checks. Names may be short names
+// or fully qualified names.
+func (r *localRobot) failingCriticalResources(names []string) []string {
+	statuses := r.manager.resources.Status()
+	var failing []string
+	for _, name := range names {
+		found := false
+		healthy := false
+		for _, status := range statuses {
+			if status.Name.Name != name && status.Name.String() != name {
+				continue
+			}
+			found = true
+			if status.State != resource.NodeStateUnhealthy && status.State != resource.NodeStateUnconfigured &&
+				status.Health.State != resource.HealthStateUnhealthy {
+				healthy = true
+			}
+		}
+		if !found || !healthy {
+			failing = append(failing, name)
+		}
+	}
+	return failing
+}
+
+// ConfigHistory returns the configs most recently applied by the robot, from newest to oldest.
+func (r *localRobot) ConfigHistory() []config.HistoryEntry {
+	st := &r.configHistory
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.history == nil {
+		return nil
+	}
+	return st.history.Entries()
+}
@@ -0,0 +1,266 @@
+package robotimpl
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/motor/fake"
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+func TestConfigHistoryRollback(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	historyCfg := &config.HistoryConfig{
+		Size:              5,
+		CriticalResources: []string{"m"},
+		RollbackAfter:     10 * time.Millisecond,
+	}
+	motorCfg := func(model resource.Model) []resource.Config {
+		return []resource.Config{
+			{
+				Name:                "m",
+				API:                 motor.API,
+				Model:               model,
+				ConvertedAttributes: &fake.Config{},
+			},
+		}
+	}
+	goodCfg := &config.Config{
+		Revision:      "good",
+		ConfigHistory: historyCfg,
+		Components:    motorCfg(fake.Model),
+	}
+	badCfg := &config.Config{
+		Revision:      "bad",
+		ConfigHistory: historyCfg,
+		Components:    motorCfg(resource.DefaultModelFamily.WithModel("does-not-exist")),
+	}
+
+	lr := setupLocalRobot(t, ctx, goodCfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+
+	history := r.ConfigHistory()
+	test.That(t, history, test.ShouldHaveLength, 1)
+	test.That(t, history[0].Revision, test.ShouldEqual, "good")
+	test.That(t, history[0].Outcome, test.ShouldEqual, config.HistoryOutcomePending)
+
+	// critical resources stay healthy, so the config becomes the last-known-good config.
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+	history = r.ConfigHistory()
+	test.That(t, history[0].Outcome, test.ShouldEqual, config.HistoryOutcomeApplied)
+
+	lr.Reconfigure(ctx, badCfg)
+	_, err := lr.ResourceByName(motor.Named("m"))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the first check notices the failure; rollback happens once it has persisted.
+	r.checkConfigRollback(ctx)
+	test.That(t, r.ConfigHistory()[0].Outcome, test.ShouldEqual, config.HistoryOutcomePending)
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+
+	_, err = lr.ResourceByName(motor.Named("m"))
+	test.That(t, err, test.ShouldBeNil)
+
+	history = r.ConfigHistory()
+	test.That(t, history, test.ShouldHaveLength, 3)
+	test.That(t, history[0].Revision, test.ShouldEqual, "good")
+	test.That(t, history[0].Outcome, test.ShouldEqual, config.HistoryOutcomeApplied)
+	test.That(t, history[1].Revision, test.ShouldEqual, "bad")
+	test.That(t, history[1].Outcome, test.ShouldEqual, config.HistoryOutcomeRolledBack)
+	test.That(t, history[1].Error, test.ShouldContainSubstring, "m")
+	test.That(t, history[2].Revision, test.ShouldEqual, "good")
+
+	// the rolled back config is not reapplied when it is received again.
+	lr.Reconfigure(ctx, badCfg)
+	_, err = lr.ResourceByName(motor.Named("m"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, r.ConfigHistory(), test.ShouldHaveLength, 3)
+
+	// history is persisted in the viam home directory.
+	f, err := os.Open(config.HistoryFilePath(r.homeDir, localConfigPartID))
+	test.That(t, err, test.ShouldBeNil)
+	defer f.Close()
+	entries, err := config.ReadHistory(f)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, entries, test.ShouldHaveLength, len(history))
+	for i, entry := range entries {
+		test.That(t, entry.Revision, test.ShouldEqual, history[i].Revision)
+		test.That(t, entry.Outcome, test.ShouldEqual, history[i].Outcome)
+	}
+}
+
+func TestConfigHistoryAfterRestart(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+	homeDir := t.TempDir()
+
+	historyCfg := &config.HistoryConfig{
+		Size:              5,
+		CriticalResources: []string{"m"},
+		RollbackAfter:     10 * time.Millisecond,
+	}
+	goodCfg := &config.Config{
+		Revision:      "good",
+		ConfigHistory: historyCfg,
+		Components: []resource.Config{
+			{
+				Name:                "m",
+				API:                 motor.API,
+				Model:               fake.Model,
+				ConvertedAttributes: &fake.Config{},
+			},
+		},
+	}
+	badCfg := &config.Config{
+		Revision:      "bad",
+		ConfigHistory: historyCfg,
+		Components: []resource.Config{
+			{
+				Name:  "m",
+				API:   motor.API,
+				Model: resource.DefaultModelFamily.WithModel("does-not-exist"),
+			},
+		},
+	}
+
+	lr, err := New(ctx, goodCfg, nil, logger, WithViamHomeDir(homeDir), WithDisableCompleteConfigWorker())
+	test.That(t, err, test.ShouldBeNil)
+	r := lr.(*localRobot)
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+	lr.Reconfigure(ctx, badCfg)
+	r.checkConfigRollback(ctx)
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+	test.That(t, r.ConfigHistory()[1].Outcome, test.ShouldEqual, config.HistoryOutcomeRolledBack)
+	test.That(t, lr.Close(ctx), test.ShouldBeNil)
+
+	// after a restart, the rolled back config is still not applied and the last-known-good
+	// config stored in the history is applied in its place.
+	lr, err = New(ctx, badCfg, nil, logger, WithViamHomeDir(homeDir), WithDisableCompleteConfigWorker())
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, lr.Close(ctx), test.ShouldBeNil)
+	}()
+	r = lr.(*localRobot)
+	_, err = lr.ResourceByName(motor.Named("m"))
+	test.That(t, err, test.ShouldBeNil)
+
+	history := r.ConfigHistory()
+	test.That(t, history, test.ShouldHaveLength, 4)
+	test.That(t, history[0].Revision, test.ShouldEqual, "good")
+	test.That(t, history[0].Outcome, test.ShouldEqual, config.HistoryOutcomeApplied)
+
+	// the bad config is not reapplied once the robot is running either.
+	lr.Reconfigure(ctx, badCfg)
+	_, err = lr.ResourceByName(motor.Named("m"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, r.ConfigHistory(), test.ShouldHaveLength, 4)
+}
+
+func TestConfigHistoryNoKnownGood(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	badCfg := &config.Config{
+		Revision: "bad",
+		ConfigHistory: &config.HistoryConfig{
+			Size:              5,
+			CriticalResources: []string{"m"},
+			RollbackAfter:     10 * time.Millisecond,
+		},
+	}
+
+	lr := setupLocalRobot(t, ctx, badCfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+
+	r.checkConfigRollback(ctx)
+	time.Sleep(20 * time.Millisecond)
+	r.checkConfigRollback(ctx)
+
+	history := r.ConfigHistory()
+	test.That(t, history, test.ShouldHaveLength, 1)
+	test.That(t, history[0].Outcome, test.ShouldEqual, config.HistoryOutcomeFailed)
+	test.That(t, history[0].Error, test.ShouldContainSubstring, "m")
+}
+
+func TestConfigHistoryRollbackOnFailingHealthCheck(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	model := resource.DefaultModelFamily.WithModel("historyhealthsensor")
+	var failing atomic.Bool
+	var constructed atomic.Int64
+	registerHealthSensor(model, &failing, &constructed)
+
+	historyCfg := &config.HistoryConfig{
+		Size:              5,
+		CriticalResources: []string{"s"},
+		RollbackAfter:     10 * time.Millisecond,
+	}
+	sensorCfg := []resource.Config{
+		{
+			Name:  "s",
+			API:   sensor.API,
+			Model: model,
+			HealthCheck: &resource.HealthCheckConfig{
+				Method:           resource.HealthCheckMethodReadings,
+				Interval:         time.Hour,
+				FailureThreshold: 1,
+			},
+		},
+	}
+	goodCfg := &config.Config{
+		Revision:      "good",
+		ConfigHistory: historyCfg,
+		Components:    sensorCfg,
+	}
+	badCfg := &config.Config{
+		Revision:      "bad",
+		ConfigHistory: historyCfg,
+		Components:    sensorCfg,
+	}
+
+	lr := setupLocalRobot(t, ctx, goodCfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+	test.That(t, r.ConfigHistory()[0].Outcome, test.ShouldEqual, config.HistoryOutcomeApplied)
+
+	// the sensor stays configured under the new config but its health probe fails.
+	lr.Reconfigure(ctx, badCfg)
+	failing.Store(true)
+	node, ok := r.manager.resources.Node(sensor.Named("s"))
+	test.That(t, ok, test.ShouldBeTrue)
+	r.runHealthChecks(ctx, time.Now().Add(time.Hour))
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, node.Health().State, test.ShouldEqual, resource.HealthStateUnhealthy)
+	})
+	test.That(t, node.State(), test.ShouldEqual, resource.NodeStateReady)
+
+	r.checkConfigRollback(ctx)
+	time.Sleep(2 * historyCfg.RollbackAfter)
+	r.checkConfigRollback(ctx)
+
+	history := r.ConfigHistory()
+	test.That(t, history, test.ShouldHaveLength, 3)
+	test.That(t, history[0].Revision, test.ShouldEqual, "good")
+	test.That(t, history[1].Revision, test.ShouldEqual, "bad")
+	test.That(t, history[1].Outcome, test.ShouldEqual, config.HistoryOutcomeRolledBack)
+	test.That(t, history[1].Error, test.ShouldContainSubstring, "s")
+}
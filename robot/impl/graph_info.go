@@ -0,0 +1,107 @@
+package robotimpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.viam.com/utils/artifact"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+)
+
+// resourceGraphFileState tracks the resource graph file written for cloud-managed robots so
+// that the CLI can query the resource graph of a machine.
+type resourceGraphFileState struct {
+	mu   sync.Mutex
+	path string
+	// last is the last written graph without its creation time, used to skip rewriting an
+	// unchanged graph.
+	last []byte
+}
+
+// ResourceGraph returns a structured snapshot of the resource graph, including which module
+// serves each modular resource.
+func (r *localRobot) ResourceGraph() resource.GraphInfo {
+	info := r.manager.resources.Info()
+
+	r.manager.modManagerLock.Lock()
+	modManager := r.manager.moduleManager
+	r.manager.modManagerLock.Unlock()
+	if modManager == nil {
+		return info
+	}
+
+	type apiModel struct{ api, model string }
+	modules := make(map[apiModel]string)
+	for _, mm := range modManager.AllModels() {
+		modules[apiModel{mm.API.String(), mm.Model.String()}] = mm.ModuleName
+	}
+	for idx, node := range info.Nodes {
+		if node.Remote != "" {
+			continue
+		}
+		info.Nodes[idx].Module = modules[apiModel{node.API, node.Model}]
+	}
+	return info
+}
+
+// setResourceGraphFile sets where the resource graph is stored for the given config. The
+// graph is only stored for cloud-managed robots.
+func (r *localRobot) setResourceGraphFile(cfg *config.Config) {
+	st := &r.resourceGraphFile
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	path := ""
+	if cfg.Cloud != nil {
+		path = resource.GraphInfoFilePath(r.homeDir, cfg.Cloud.ID)
+	}
+	if path != st.path {
+		st.path = path
+		st.last = nil
+	}
+}
+
+// storeResourceGraph writes the current resource graph to disk if it changed since it was
+// last written.
+func (r *localRobot) storeResourceGraph(ctx context.Context) {
+	st := &r.resourceGraphFile
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.path == "" {
+		return
+	}
+
+	info := r.ResourceGraph()
+	createdAt := info.CreatedAt
+	info.CreatedAt = time.Time{}
+	graphKey, err := json.Marshal(info)
+	if err != nil {
+		r.logger.CWarnw(ctx, "error marshaling resource graph", "error", err)
+		return
+	}
+	if bytes.Equal(graphKey, st.last) {
+		return
+	}
+
+	info.CreatedAt = createdAt
+	md, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		r.logger.CWarnw(ctx, "error marshaling resource graph", "error", err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o700); err != nil {
+		r.logger.CWarnw(ctx, "error storing resource graph", "error", err)
+		return
+	}
+	if err := artifact.AtomicStore(st.path, bytes.NewReader(md), filepath.Base(st.path)); err != nil {
+		r.logger.CWarnw(ctx, "error storing resource graph", "error", err)
+		return
+	}
+	st.last = graphKey
+}
@@ -0,0 +1,74 @@
+package robotimpl
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/motor/fake"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+)
+
+func TestResourceGraph(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:                "m",
+				API:                 motor.API,
+				Model:               fake.Model,
+				ConvertedAttributes: &fake.Config{},
+			},
+			{
+				Name:      "m2",
+				API:       motor.API,
+				Model:     resource.DefaultModelFamily.WithModel("does-not-exist"),
+				DependsOn: []string{"m"},
+			},
+		},
+	}
+	lr := setupLocalRobot(t, ctx, cfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+
+	info := lr.ResourceGraph()
+	dependents, err := info.Dependents("m")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dependents, test.ShouldResemble, []string{motor.Named("m2").String()})
+
+	reasons, err := info.WhyNotConfigured("m2")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reasons, test.ShouldNotBeEmpty)
+	test.That(t, reasons[0], test.ShouldContainSubstring, "does-not-exist")
+
+	// the graph is only stored for cloud-managed robots, so point the file at the test home.
+	path := filepath.Join(r.homeDir, resource.GraphInfoFileName("part"))
+	r.resourceGraphFile.mu.Lock()
+	r.resourceGraphFile.path = path
+	r.resourceGraphFile.mu.Unlock()
+	r.storeResourceGraph(ctx)
+
+	//nolint:gosec
+	data, err := os.ReadFile(path)
+	test.That(t, err, test.ShouldBeNil)
+	var stored resource.GraphInfo
+	test.That(t, json.Unmarshal(data, &stored), test.ShouldBeNil)
+	test.That(t, stored.Edges, test.ShouldResemble, info.Edges)
+	node, err := stored.FindNode("m")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, node.State, test.ShouldEqual, resource.NodeStateReady.String())
+
+	// an unchanged graph is not rewritten.
+	test.That(t, os.Remove(path), test.ShouldBeNil)
+	r.storeResourceGraph(ctx)
+	_, err = os.Stat(path)
+	test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+}
@@ -0,0 +1,228 @@
+package robotimpl
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/resource"
+)
+
+// healthCheckTick is how often the health check worker looks for resources that are due
+// to be probed.
+var healthCheckTick = time.Second
+
+// healthCheckState tracks when each resource was last probed.
+type healthCheckState struct {
+	mu sync.Mutex
+	// nextCheck is when each resource is next due to be probed. Resources with a probe in
+	// flight are present in running.
+	nextCheck map[resource.Name]time.Time
+	running   map[resource.Name]struct{}
+}
+
+// healthCheckWorker periodically probes the health of configured resources.
+func (r *localRobot) healthCheckWorker() {
+	ticker := time.NewTicker(healthCheckTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.closeContext.Done():
+			return
+		case <-ticker.C:
+		}
+		r.runHealthChecks(r.closeContext, time.Now())
+	}
+}
+
+// healthCheckConfig returns the health check to run for a resource and whether it should be
+// probed at all. Resources are probed if their config declares a health check or if they
+// implement resource.HealthChecker.
+func healthCheckConfig(conf resource.Config, res resource.Resource) (resource.HealthCheckConfig, bool) {
+	if conf.HealthCheck != nil {
+		return *conf.HealthCheck, true
+	}
+	if _, ok := res.(resource.HealthChecker); !ok {
+		return resource.HealthCheckConfig{}, false
+	}
+	hc := resource.HealthCheckConfig{}
+	// the defaults cannot fail validation.
+	//nolint:errcheck
+	hc.Validate("")
+	return hc, true
+}
+
+// runHealthChecks starts a probe for every ready, local resource whose health check is due.
+// Probes run in the background and update the resource's graph node when they complete.
+func (r *localRobot) runHealthChecks(ctx context.Context, now time.Time) {
+	st := &r.healthChecks
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if st.nextCheck == nil {
+		st.nextCheck = make(map[resource.Name]time.Time)
+		st.running = make(map[resource.Name]struct{})
+	}
+
+	seen := make(map[resource.Name]struct{})
+	for _, name := range r.manager.resources.Names() {
+		if name.ContainsRemoteNames() {
+			continue
+		}
+		node, ok := r.manager.resources.Node(name)
+		if !ok || node.State() != resource.NodeStateReady {
+			continue
+		}
+		res, err := node.Resource()
+		if err != nil {
+			continue
+		}
+		hc, ok := healthCheckConfig(node.Config(), res)
+		if !ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if _, ok := st.running[name]; ok {
+			continue
+		}
+		if next, ok := st.nextCheck[name]; ok && now.Before(next) {
+			continue
+		}
+
+		st.nextCheck[name] = now.Add(hc.Interval)
+		st.running[name] = struct{}{}
+		r.activeBackgroundWorkers.Add(1)
+		goutils.ManagedGo(func() {
+			r.probeHealth(ctx, name, node, res, hc)
+		}, r.activeBackgroundWorkers.Done)
+	}
+
+	// forget resources that were removed or no longer have a health check.
+	for name := range st.nextCheck {
+		if _, ok := seen[name]; !ok {
+			delete(st.nextCheck, name)
+		}
+	}
+}
+
+// probeHealth probes a single resource and records the result on its graph node. If the
+// resource becomes unhealthy and its health check requests it, the resource is rebuilt.
+func (r *localRobot) probeHealth(
+	ctx context.Context,
+	name resource.Name,
+	node *resource.GraphNode,
+	res resource.Resource,
+	hc resource.HealthCheckConfig,
+) {
+	defer func() {
+		r.healthChecks.mu.Lock()
+		delete(r.healthChecks.running, name)
+		r.healthChecks.mu.Unlock()
+	}()
+
+	probeCtx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	probeErr := probeResource(probeCtx, res, hc)
+	cancel()
+	if ctx.Err() != nil {
+		return
+	}
+	if probeErr != nil && probeCtx.Err() != nil {
+		probeErr = errors.Wrapf(probeErr, "health check timed out after %v", hc.Timeout)
+	}
+
+	prev := node.Health()
+	health := node.RecordHealthCheck(probeErr, time.Now(), hc)
+	switch {
+	case health.State == resource.HealthStateUnhealthy && prev.State != resource.HealthStateUnhealthy:
+		r.logger.CWarnw(ctx, "resource health check failing", "resource", name.String(),
+			"consecutive_failures", health.ConsecutiveFailures, "error", probeErr)
+		if hc.RebuildOnFailure {
+			r.rebuildUnhealthyResource(ctx, name, node)
+		}
+	case health.State == resource.HealthStateHealthy && prev.State == resource.HealthStateUnhealthy:
+		r.logger.CInfow(ctx, "resource health check recovered", "resource", name.String())
+	}
+}
+
+// probeResource runs the configured health check against a resource.
+func probeResource(ctx context.Context, res resource.Resource, hc resource.HealthCheckConfig) error {
+	method := hc.Method
+	if method == "" {
+		if checker, ok := res.(resource.HealthChecker); ok {
+			return checker.CheckHealth(ctx)
+		}
+		switch res.(type) {
+		case camera.Camera:
+			method = resource.HealthCheckMethodGetImages
+		case resource.Sensor:
+			method = resource.HealthCheckMethodReadings
+		case resource.Actuator:
+			method = resource.HealthCheckMethodIsMoving
+		default:
+			return errors.New("resource does not implement a default health check; set health_check.method")
+		}
+	}
+
+	switch method {
+	case resource.HealthCheckMethodReadings:
+		sensor, ok := res.(resource.Sensor)
+		if !ok {
+			return errors.Errorf("resource does not support health check method %q", method)
+		}
+		_, err := sensor.Readings(ctx, nil)
+		return err
+	case resource.HealthCheckMethodGetImages:
+		cam, ok := res.(camera.Camera)
+		if !ok {
+			return errors.Errorf("resource does not support health check method %q", method)
+		}
+		images, _, err := cam.Images(ctx, nil, nil)
+		if err != nil {
+			return err
+		}
+		if len(images) == 0 {
+			return errors.New("camera returned no images")
+		}
+		return nil
+	case resource.HealthCheckMethodIsMoving:
+		actuator, ok := res.(resource.Actuator)
+		if !ok {
+			return errors.Errorf("resource does not support health check method %q", method)
+		}
+		_, err := actuator.IsMoving(ctx)
+		return err
+	case resource.HealthCheckMethodDoCommand:
+		_, err := res.DoCommand(ctx, hc.Command)
+		return err
+	default:
+		return errors.Errorf("unknown health check method %q", method)
+	}
+}
+
+// rebuildUnhealthyResource closes a resource whose health checks are failing and marks it
+// to be rebuilt by the complete config worker.
+func (r *localRobot) rebuildUnhealthyResource(ctx context.Context, name resource.Name, node *resource.GraphNode) {
+	r.reconfigurationLock.Lock()
+	defer r.reconfigurationLock.Unlock()
+
+	// the resource may have been reconfigured or removed while it was being probed.
+	current, ok := r.manager.resources.Node(name)
+	if !ok || current != node || node.State() != resource.NodeStateReady {
+		return
+	}
+	res, err := node.UnsafeResource()
+	if err != nil {
+		return
+	}
+
+	r.logger.CInfow(ctx, "rebuilding unhealthy resource", "resource", name.String())
+	if err := r.manager.closeResource(ctx, res); err != nil {
+		r.logger.CWarnw(ctx, "error closing unhealthy resource", "resource", name.String(), "error", err)
+	}
+	r.manager.markRebuildResources([]resource.Name{name})
+	r.updateWeakAndOptionalDependents(ctx)
+	r.sendTriggerConfig("health check")
+}
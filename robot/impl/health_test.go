@@ -0,0 +1,165 @@
+package robotimpl
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// registerHealthSensor registers a sensor model whose readings fail while failing is set and
+// which counts how many times it has been constructed.
+func registerHealthSensor(model resource.Model, failing *atomic.Bool, constructed *atomic.Int64) {
+	resource.RegisterComponent(
+		sensor.API,
+		model,
+		resource.Registration[sensor.Sensor, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (sensor.Sensor, error) {
+			constructed.Add(1)
+			injectSensor := inject.NewSensor(conf.Name)
+			injectSensor.ReadingsFunc = func(ctx context.Context, extra map[string]interface{}) (map[string]interface{}, error) {
+				if failing.Load() {
+					return nil, errors.New("no data")
+				}
+				return map[string]interface{}{"a": 1}, nil
+			}
+			return injectSensor, nil
+		}})
+}
+
+func TestHealthChecks(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	model := resource.DefaultModelFamily.WithModel("healthsensor")
+	var failing atomic.Bool
+	var constructed atomic.Int64
+	registerHealthSensor(model, &failing, &constructed)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:  "s",
+				API:   sensor.API,
+				Model: model,
+				HealthCheck: &resource.HealthCheckConfig{
+					Method:           resource.HealthCheckMethodReadings,
+					Interval:         time.Hour,
+					FailureThreshold: 2,
+					SuccessThreshold: 2,
+				},
+			},
+		},
+	}
+	lr := setupLocalRobot(t, ctx, cfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+	node, ok := r.manager.resources.Node(sensor.Named("s"))
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, node.Health().State, test.ShouldEqual, resource.HealthStateUnknown)
+
+	// each check is an interval apart so that every call probes the sensor.
+	now := time.Now()
+	check := func(wantState resource.HealthState, wantFailures int) {
+		t.Helper()
+		now = now.Add(time.Hour)
+		r.runHealthChecks(ctx, now)
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			health := node.Health()
+			test.That(tb, health.State, test.ShouldEqual, wantState)
+			test.That(tb, health.ConsecutiveFailures, test.ShouldEqual, wantFailures)
+		})
+	}
+
+	check(resource.HealthStateHealthy, 0)
+
+	// a single failure is below the threshold.
+	failing.Store(true)
+	check(resource.HealthStateHealthy, 1)
+	check(resource.HealthStateUnhealthy, 2)
+
+	// the resource stays available, but the machine status reports the failing health check.
+	_, err := lr.ResourceByName(sensor.Named("s"))
+	test.That(t, err, test.ShouldBeNil)
+	mStatus, err := lr.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	var found bool
+	for _, status := range mStatus.Resources {
+		if status.Name == sensor.Named("s") {
+			found = true
+			test.That(t, status.State, test.ShouldEqual, resource.NodeStateReady)
+			test.That(t, status.Health.State, test.ShouldEqual, resource.HealthStateUnhealthy)
+			test.That(t, status.Health.Error.Error(), test.ShouldContainSubstring, "no data")
+		}
+	}
+	test.That(t, found, test.ShouldBeTrue)
+
+	// recovering requires two consecutive successes.
+	failing.Store(false)
+	check(resource.HealthStateUnhealthy, 0)
+	check(resource.HealthStateHealthy, 0)
+	test.That(t, constructed.Load(), test.ShouldEqual, 1)
+}
+
+func TestHealthCheckRebuild(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	model := resource.DefaultModelFamily.WithModel("healthsensorrebuild")
+	var failing atomic.Bool
+	var constructed atomic.Int64
+	registerHealthSensor(model, &failing, &constructed)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Name:  "s",
+				API:   sensor.API,
+				Model: model,
+				HealthCheck: &resource.HealthCheckConfig{
+					Interval:         time.Hour,
+					FailureThreshold: 1,
+					RebuildOnFailure: true,
+				},
+			},
+		},
+	}
+	lr := setupLocalRobot(t, ctx, cfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+	test.That(t, constructed.Load(), test.ShouldEqual, 1)
+
+	failing.Store(true)
+	r.runHealthChecks(ctx, time.Now().Add(time.Hour))
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		node, ok := r.manager.resources.Node(sensor.Named("s"))
+		test.That(tb, ok, test.ShouldBeTrue)
+		test.That(tb, node.NeedsReconfigure(), test.ShouldBeTrue)
+	})
+
+	failing.Store(false)
+	r.updateRemotesAndRetryResourceConfigure()
+	test.That(t, constructed.Load(), test.ShouldEqual, 2)
+
+	// the rebuilt resource starts with a clean health status.
+	node, ok := r.manager.resources.Node(sensor.Named("s"))
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, node.State(), test.ShouldEqual, resource.NodeStateReady)
+	test.That(t, node.Health().State, test.ShouldEqual, resource.HealthStateUnknown)
+	_, err := lr.ResourceByName(sensor.Named("s"))
+	test.That(t, err, test.ShouldBeNil)
+}
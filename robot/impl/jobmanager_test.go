@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -1896,3 +1899,341 @@ func TestJobManagerErrors(t *testing.T) {
 		test.That(tb, slices.Contains(errorMessages, "rpc error: code = Unknown desc = test error api function"), test.ShouldBeTrue)
 	})
 }
+
+func TestJobManagerRetriesAndDependencies(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	var calibrateCalls, reportCalls atomic.Int32
+	injectSensor := inject.NewSensor("calibrated")
+	injectSensor.DoFunc = func(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+		switch cmd["command"] {
+		case "calibrate":
+			// fail the first two attempts of every run.
+			if calibrateCalls.Add(1)%3 != 0 {
+				return nil, errors.New("not settled")
+			}
+			return map[string]any{"offset": 1.5}, nil
+		case "report":
+			reportCalls.Add(1)
+			return nil, nil
+		case "hang":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("unknown command")
+	}
+	model := resource.DefaultModelFamily.WithModel(utils.RandomAlphaString(8))
+	resource.RegisterComponent(
+		sensor.API,
+		model,
+		resource.Registration[sensor.Sensor, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (sensor.Sensor, error) {
+			return injectSensor, nil
+		}})
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Model: model,
+				Name:  "sensor",
+				API:   sensor.API,
+			},
+		},
+		Jobs: []config.JobConfig{
+			{
+				config.JobConfigData{
+					Name:         "calibrate",
+					Schedule:     "1h",
+					Resource:     "sensor",
+					Method:       "DoCommand",
+					Command:      map[string]any{"command": "calibrate"},
+					Retries:      2,
+					RetryBackoff: "10ms",
+				},
+			},
+			{
+				config.JobConfigData{
+					Name:     "report",
+					After:    "calibrate",
+					Resource: "sensor",
+					Method:   "DoCommand",
+					Command:  map[string]any{"command": "report"},
+				},
+			},
+			{
+				config.JobConfigData{
+					Name:     "hang",
+					Schedule: "1h",
+					Resource: "sensor",
+					Method:   "DoCommand",
+					Command:  map[string]any{"command": "hang"},
+					Timeout:  "50ms",
+					Retries:  1,
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	lr := setupLocalRobot(t, ctx, cfg, logger)
+	jm := lr.(*localRobot).jobManager
+
+	test.That(t, jm.RunNow("calibrate"), test.ShouldBeNil)
+	test.That(t, jm.RunNow("hang"), test.ShouldBeNil)
+	test.That(t, jm.RunNow("unknown"), test.ShouldNotBeNil)
+
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, len(jm.History("calibrate")), test.ShouldEqual, 1)
+		// the report job runs after calibrate succeeds.
+		test.That(tb, len(jm.History("report")), test.ShouldEqual, 1)
+		test.That(tb, len(jm.History("hang")), test.ShouldEqual, 1)
+	})
+
+	runs := jm.History("calibrate")
+	test.That(t, runs[0].Succeeded(), test.ShouldBeTrue)
+	test.That(t, runs[0].Trigger, test.ShouldEqual, config.JobTriggerManual)
+	test.That(t, runs[0].Attempts, test.ShouldEqual, 3)
+	test.That(t, runs[0].Result, test.ShouldEqual, `{"offset":1.5}`)
+
+	runs = jm.History("report")
+	test.That(t, runs[0].Trigger, test.ShouldEqual, config.JobTriggerAfter+"calibrate")
+	test.That(t, reportCalls.Load(), test.ShouldEqual, 1)
+
+	runs = jm.History("hang")
+	test.That(t, runs[0].Succeeded(), test.ShouldBeFalse)
+	test.That(t, runs[0].Attempts, test.ShouldEqual, 2)
+	test.That(t, runs[0].Error, test.ShouldContainSubstring, "deadline exceeded")
+
+	// failures are reported in the machine status.
+	ms, err := lr.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(ms.JobStatuses["hang"].RecentFailedRuns), test.ShouldEqual, 1)
+	test.That(t, len(ms.JobStatuses["calibrate"].RecentSuccessfulRuns), test.ShouldEqual, 1)
+}
+
+func TestJobManagerRunJobOverNetwork(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Model: resource.DefaultModelFamily.WithModel("fake"),
+				Name:  "sensor",
+				API:   sensor.API,
+			},
+		},
+		Jobs: []config.JobConfig{
+			{
+				config.JobConfigData{
+					Name:     "readings",
+					Schedule: "1h",
+					Resource: "sensor",
+					Method:   "GetReadings",
+				},
+			},
+		},
+		Audit: &config.AuditConfig{},
+	}
+	test.That(t, cfg.Audit.Validate("audit"), test.ShouldBeNil)
+
+	ctx := context.Background()
+	lr := setupLocalRobot(t, ctx, cfg, logger)
+	r := lr.(*localRobot)
+	o, _, addr := robottestutils.CreateBaseOptionsAndListener(t)
+	test.That(t, lr.StartWeb(ctx, o), test.ShouldBeNil)
+	robotClient, err := rclient.New(ctx, addr, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer robotClient.Close(ctx)
+
+	test.That(t, robotClient.RunJob(ctx, "readings"), test.ShouldBeNil)
+	test.That(t, robotClient.RunJob(ctx, "unknown"), test.ShouldNotBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, len(r.jobManager.History("readings")), test.ShouldEqual, 1)
+	})
+	test.That(t, r.jobManager.History("readings")[0].Trigger, test.ShouldEqual, config.JobTriggerManual)
+
+	// manual runs are audited like other mutating calls.
+	f, err := os.Open(filepath.Join(config.AuditDirectory(r.homeDir, localConfigPartID), config.AuditLogFileName))
+	test.That(t, err, test.ShouldBeNil)
+	defer f.Close()
+	entries, err := config.ReadAuditLog(f)
+	test.That(t, err, test.ShouldBeNil)
+	var runJobEntries []config.AuditEntry
+	for _, entry := range entries {
+		if entry.Method == "/rdk.robot.v1.JobService/RunJob" {
+			runJobEntries = append(runJobEntries, entry)
+		}
+	}
+	test.That(t, runJobEntries, test.ShouldHaveLength, 2)
+	test.That(t, runJobEntries[0].Arguments, test.ShouldContainSubstring, "readings")
+	test.That(t, runJobEntries[0].Code, test.ShouldEqual, "OK")
+	test.That(t, runJobEntries[1].Error, test.ShouldNotBeEmpty)
+}
+
+func TestJobManagerHistoryPersisted(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+	homeDir := t.TempDir()
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{
+				Model: resource.DefaultModelFamily.WithModel("fake"),
+				Name:  "sensor",
+				API:   sensor.API,
+			},
+		},
+		Jobs: []config.JobConfig{
+			{
+				config.JobConfigData{
+					Name:     "readings",
+					Schedule: "1h",
+					Resource: "sensor",
+					Method:   "GetReadings",
+				},
+			},
+		},
+	}
+
+	r, err := New(ctx, cfg, nil, logger, WithViamHomeDir(homeDir))
+	test.That(t, err, test.ShouldBeNil)
+	jm := r.(*localRobot).jobManager
+	test.That(t, jm.RunNow("readings"), test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, len(jm.History("readings")), test.ShouldEqual, 1)
+	})
+	test.That(t, r.Close(ctx), test.ShouldBeNil)
+
+	// the run survives a restart.
+	r, err = New(ctx, cfg, nil, logger, WithViamHomeDir(homeDir))
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(ctx), test.ShouldBeNil)
+	}()
+	runs := r.(*localRobot).jobManager.History("readings")
+	test.That(t, len(runs), test.ShouldEqual, 1)
+	test.That(t, runs[0].Succeeded(), test.ShouldBeTrue)
+
+	ms, err := r.MachineStatus(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(ms.JobStatuses["readings"].RecentSuccessfulRuns), test.ShouldEqual, 1)
+}
+
+func TestJobManagerSteps(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	injectSensor := inject.NewSensor("thermometer")
+	injectSensor.ReadingsFunc = func(ctx context.Context, extra map[string]any) (map[string]any, error) {
+		return map[string]any{"temperature": 31.5}, nil
+	}
+	var commands []map[string]any
+	var commandsMu sync.Mutex
+	injectGeneric := inject.NewGenericComponent("controller")
+	injectGeneric.DoFunc = func(ctx context.Context, cmd map[string]any) (map[string]any, error) {
+		commandsMu.Lock()
+		defer commandsMu.Unlock()
+		commands = append(commands, cmd)
+		if cmd["command"] == "capture" {
+			return nil, errors.New("camera unavailable")
+		}
+		return map[string]any{"done": true}, nil
+	}
+	sensorModel := resource.DefaultModelFamily.WithModel(utils.RandomAlphaString(8))
+	resource.RegisterComponent(
+		sensor.API,
+		sensorModel,
+		resource.Registration[sensor.Sensor, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (sensor.Sensor, error) {
+			return injectSensor, nil
+		}})
+	genericModel := resource.DefaultModelFamily.WithModel(utils.RandomAlphaString(8))
+	resource.RegisterComponent(
+		generic.API,
+		genericModel,
+		resource.Registration[resource.Resource, resource.NoNativeConfig]{Constructor: func(
+			ctx context.Context,
+			deps resource.Dependencies,
+			conf resource.Config,
+			logger logging.Logger,
+		) (resource.Resource, error) {
+			return injectGeneric, nil
+		}})
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Model: sensorModel, Name: "thermometer", API: sensor.API},
+			{Model: genericModel, Name: "controller", API: generic.API},
+		},
+		Jobs: []config.JobConfig{
+			{
+				config.JobConfigData{
+					Name:     "cool down",
+					Schedule: "1h",
+					Steps: []config.JobStep{
+						{Name: "read", Resource: "thermometer", Method: "GetReadings"},
+						{
+							Name:      "fan",
+							Resource:  "controller",
+							Method:    "DoCommand",
+							Arguments: map[string]any{"command": "fan", "temperature": "${read.readings.temperature}"},
+							If:        "${read.readings.temperature} > 30",
+						},
+						{
+							Name:      "heat",
+							Resource:  "controller",
+							Method:    "DoCommand",
+							Arguments: map[string]any{"command": "heat"},
+							If:        "${read.readings.temperature} < 10",
+						},
+						{
+							Name:      "capture",
+							Resource:  "controller",
+							Method:    "DoCommand",
+							Arguments: map[string]any{"command": "capture"},
+							OnError:   config.JobStepErrorContinue,
+						},
+						{
+							Name:      "report",
+							Resource:  "controller",
+							Method:    "DoCommand",
+							Arguments: map[string]any{"command": "report", "message": "capture failed: ${capture.error}"},
+							If:        "${capture.error}",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	lr := setupLocalRobot(t, ctx, cfg, logger)
+	jm := lr.(*localRobot).jobManager
+
+	test.That(t, jm.RunNow("cool down"), test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, len(jm.History("cool down")), test.ShouldEqual, 1)
+	})
+
+	run := jm.History("cool down")[0]
+	test.That(t, run.Error, test.ShouldBeEmpty)
+	test.That(t, run.Result, test.ShouldContainSubstring, `"read":{"readings":{"temperature":31.5}}`)
+
+	commandsMu.Lock()
+	defer commandsMu.Unlock()
+	test.That(t, commands, test.ShouldResemble, []map[string]any{
+		{"command": "fan", "temperature": 31.5},
+		{"command": "capture"},
+		{"command": "report", "message": "capture failed: camera unavailable"},
+	})
+}
@@ -124,6 +124,16 @@ type localRobot struct {
 	configRevision   config.Revision
 	configRevisionMu sync.RWMutex
 
+	// configHistory retains recently applied configs and rolls back to the last-known-good
+	// one if critical resources fail.
+	configHistory configHistoryState
+
+	// healthChecks tracks the periodic health checks of resources.
+	healthChecks healthCheckState
+
+	// resourceGraphFile stores the resource graph on disk for querying by the CLI.
+	resourceGraphFile resourceGraphFileState
+
 	// internal services that are in the graph but we also hold onto
 	webSvc   web.Service
 	frameSvc framesystem.Service
@@ -285,9 +295,10 @@ func (r *localRobot) StopAll(ctx context.Context, extra map[resource.Name]map[st
 		op.Cancel()
 	}
 
-	// Stop all stoppable resources
+	// Stop all stoppable resources. Resources are stopped before the resources they depend
+	// on so that, for example, a base is stopped before its motors.
 	resourceErrs := make(map[string]error)
-	for _, name := range r.ResourceNames() {
+	for _, name := range r.manager.stopOrder(r.ResourceNames()) {
 		res, err := r.ResourceByName(name)
 		if err != nil {
 			resourceErrs[name.Name] = err
@@ -295,7 +306,9 @@ func (r *localRobot) StopAll(ctx context.Context, extra map[resource.Name]map[st
 		}
 
 		if actuator, ok := res.(resource.Actuator); ok {
-			if err := actuator.Stop(ctx, extra[name]); err != nil {
+			if err := stopWithTimeout(ctx, name, func(ctx context.Context) error {
+				return actuator.Stop(ctx, extra[name])
+			}); err != nil {
 				resourceErrs[name.Name] = err
 			}
 		}
@@ -416,6 +429,8 @@ func (r *localRobot) completeConfigWorker() {
 		if anyChanges {
 			r.logger.CDebugw(r.closeContext, "configuration attempt completed with changes", "trigger", trigger)
 		}
+		r.storeResourceGraph(r.closeContext)
+		r.checkConfigRollback(r.closeContext)
 	}
 }
 
@@ -548,6 +563,7 @@ func newWithResources(
 	r.webSvc = web.New(r, logger, rOpts.webOptions...)
 	if r.ftdc != nil {
 		r.ftdc.Add("web", r.webSvc.RequestCounter())
+		r.ftdc.Add("web_access", r.webSvc.AccessController())
 	}
 	r.frameSvc, err = framesystem.New(ctx, resource.Dependencies{}, logger.Sublogger("framesystem"))
 	if err != nil {
@@ -614,6 +630,11 @@ func newWithResources(
 		}, r.activeBackgroundWorkers.Done)
 	}
 
+	r.activeBackgroundWorkers.Add(1)
+	// This goroutine probes resources that declare a health check or implement
+	// resource.HealthChecker and records their health on the resource graph.
+	goutils.ManagedGo(r.healthCheckWorker, r.activeBackgroundWorkers.Done)
+
 	// getResource is passed in to the jobmanager to have access to the resource graph.
 	getResource := func(res string) (resource.Resource, error) {
 		var found bool
@@ -634,7 +655,8 @@ func newWithResources(
 		return r.ResourceByName(match)
 	}
 
-	jobManager, err := jobmanager.New(ctx, logger, getResource, r.webSvc.ModuleAddresses())
+	jobManager, err := jobmanager.New(
+		ctx, logger, getResource, r.webSvc.ModuleAddresses(), config.JobHistoryDirectory(homeDir, partID))
 	if err != nil {
 		r.logger.CErrorw(ctx, "Job manager failed to start", "error", err)
 	}
@@ -1378,6 +1400,13 @@ func (r *localRobot) GetModelsFromModules(ctx context.Context) ([]resource.Modul
 	return r.manager.moduleManager.AllModels(), nil
 }
 
+// addressedRemote is a remote that reports the address it is connected through, such as a
+// *client.RobotClient.
+type addressedRemote interface {
+	Connected() bool
+	ActiveAddress() string
+}
+
 func dialRobotClient(
 	ctx context.Context,
 	config config.Remote,
@@ -1392,6 +1421,9 @@ func dialRobotClient(
 	if config.ReconnectInterval != 0 {
 		rOpts = append(rOpts, client.WithReconnectEvery(config.ReconnectInterval))
 	}
+	if len(config.Addresses) != 0 {
+		rOpts = append(rOpts, client.WithFallbackAddresses(config.Addresses...))
+	}
 
 	// only dial once per reconfiguration cycle, any failures will be retried on a ticker anyway
 	rOpts = append(rOpts, client.WithInitialDialAttempts(1))
@@ -1443,6 +1475,17 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		return
 	}
 
+	toApply := r.configToApply(ctx, newConfig)
+	if toApply == nil {
+		r.logger.CDebugw(ctx, "skipping reconfiguration with config that was rolled back", "revision", newConfig.Revision)
+		return
+	}
+	if toApply != newConfig {
+		r.logger.CWarnw(ctx, "config was rolled back before the robot started; applying the last-known-good config instead",
+			"bad_revision", newConfig.Revision, "revision", toApply.Revision)
+		newConfig = toApply
+	}
+
 	// If reconfigure is allowed, assume we are reconfiguring until this function
 	// returns.
 	r.reconfiguring.Store(true)
@@ -1450,6 +1493,10 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		r.reconfiguring.Store(false)
 	}()
 
+	// Store the resource graph once reconfiguration is done so it can be queried remotely.
+	r.setResourceGraphFile(newConfig)
+	defer r.storeResourceGraph(ctx)
+
 	r.configRevisionMu.Lock()
 	r.configRevision = config.Revision{
 		Revision:    newConfig.Revision,
@@ -1478,6 +1525,15 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		r.reconfigureTracing(ctx, newConfig)
 	}
 
+	auditPartID := localConfigPartID
+	if newConfig.Cloud != nil {
+		auditPartID = newConfig.Cloud.ID
+	}
+	if err := r.webSvc.AuditLog().Reconfigure(newConfig.Audit, config.AuditDirectory(r.homeDir, auditPartID)); err != nil {
+		r.logger.CErrorw(ctx, "failed to reconfigure audit log", "error", err)
+	}
+	r.webSvc.RequestCounter().SetRateLimits(newConfig.RateLimits)
+
 	// Sync Packages before reconfiguring rest of robot and resolving references to any packages
 	// in the config.
 	// TODO(RSDK-1849): Make this non-blocking so other resources that do not require packages can run before package sync finishes.
@@ -1615,6 +1671,11 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 		}
 	}
 
+	// Record the config in the config history once resources have been updated.
+	if existingConfig.Revision != newConfig.Revision || !diff.ResourcesEqual {
+		defer r.recordAppliedConfig(ctx, newConfig)
+	}
+
 	// this check is deferred because it has to happen at the end of reconfigure.
 	// UpdatingJobs depends on the resource graph being populated, which
 	// happens after the "diff.ResourcesEqual" check during startup. However, we also want
@@ -1651,7 +1712,8 @@ func (r *localRobot) reconfigure(ctx context.Context, newConfig *config.Config,
 	// is served by a different module.
 	resourcesToCloseBeforeComplete, _, resourcesToRebuild := r.manager.markRemoved(ctx, diff.Removed)
 
-	// Second we attempt to Close resources.
+	// Second we bring removed resources to a safe state and attempt to Close them.
+	allErrs = multierr.Combine(allErrs, r.manager.safeStopMarked(ctx, nil).Err())
 	alreadyClosed := make(map[resource.Name]struct{}, len(resourcesToCloseBeforeComplete))
 	for _, res := range resourcesToCloseBeforeComplete {
 		allErrs = multierr.Combine(allErrs, r.manager.closeResource(ctx, res))
@@ -1940,6 +2002,14 @@ func (r *localRobot) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// RunJob runs the named job now, outside of its schedule.
+func (r *localRobot) RunJob(name string) error {
+	if r.jobManager == nil {
+		return errors.New("job manager is not running")
+	}
+	return r.jobManager.RunNow(name)
+}
+
 // MachineStatus returns the current status of the robot.
 func (r *localRobot) MachineStatus(ctx context.Context) (robot.MachineStatus, error) {
 	var result robot.MachineStatus
@@ -1976,6 +2046,22 @@ func (r *localRobot) MachineStatus(ctx context.Context) (robot.MachineStatus, er
 		result.State = robot.StateInitializing
 	}
 
+	for _, name := range r.manager.RemoteNames() {
+		remote, ok := r.manager.RemoteByName(name)
+		if !ok {
+			continue
+		}
+		if addressed, ok := remote.(addressedRemote); ok {
+			if result.Remotes == nil {
+				result.Remotes = make(map[string]robot.RemoteStatus)
+			}
+			result.Remotes[name] = robot.RemoteStatus{
+				Connected:     addressed.Connected(),
+				ActiveAddress: addressed.ActiveAddress(),
+			}
+		}
+	}
+
 	if r.jobManager != nil {
 		if n := r.jobManager.NumJobHistories.Load(); n > 0 {
 			if result.JobStatuses == nil {
@@ -79,6 +79,62 @@ type resourceManager struct {
 	logger         logging.Logger
 
 	viz resource.Visualizer
+
+	// configStatsMu guards configStats.
+	configStatsMu sync.Mutex
+	configStats   resourceConfigurationStats
+}
+
+// resourceConfigurationStats are timing statistics about (re)configuring resources in
+// completeConfig. They are reported to FTDC.
+type resourceConfigurationStats struct {
+	// LastRoundMs is how long the most recent completeConfig took to process resources.
+	LastRoundMs int64
+	// LastRoundResources is how many resources were (re)configured in the most recent
+	// completeConfig.
+	LastRoundResources int64
+	// LastRoundSlowestMs is the longest any single resource took to (re)configure in the
+	// most recent completeConfig.
+	LastRoundSlowestMs int64
+	// ResourcesConfigured and ResourcesTimedOut are cumulative over the life of the robot.
+	ResourcesConfigured int64
+	ResourcesTimedOut   int64
+}
+
+// add accounts for a processed resource in the stats of a single completeConfig.
+func (s *resourceConfigurationStats) add(res resourceConfigurationResult) {
+	if !res.attempted {
+		return
+	}
+	s.LastRoundResources++
+	s.LastRoundSlowestMs = max(s.LastRoundSlowestMs, res.duration.Milliseconds())
+	if res.timedOut {
+		s.ResourcesTimedOut++
+	}
+}
+
+// recordConfigurationRound records the stats of a completeConfig that took the given
+// duration. Rounds that did not (re)configure any resources are not recorded so that the
+// stats reflect the last time resources were actually configured.
+func (manager *resourceManager) recordConfigurationRound(round resourceConfigurationStats, d time.Duration) {
+	if round.LastRoundResources == 0 {
+		return
+	}
+	manager.configStatsMu.Lock()
+	defer manager.configStatsMu.Unlock()
+	manager.configStats.LastRoundMs = d.Milliseconds()
+	manager.configStats.LastRoundResources = round.LastRoundResources
+	manager.configStats.LastRoundSlowestMs = round.LastRoundSlowestMs
+	manager.configStats.ResourcesConfigured += round.LastRoundResources
+	manager.configStats.ResourcesTimedOut += round.ResourcesTimedOut
+}
+
+// Stats satisfies the ftdc.Statser interface and returns a copy of the resource
+// configuration stats.
+func (manager *resourceManager) Stats() any {
+	manager.configStatsMu.Lock()
+	defer manager.configStatsMu.Unlock()
+	return manager.configStats
 }
 
 type resourceManagerOptions struct {
@@ -104,11 +160,15 @@ func newResourceManager(
 		resourceGraph = resource.NewGraph(logger)
 	}
 
-	return &resourceManager{
+	manager := &resourceManager{
 		resources: resourceGraph,
 		opts:      opts,
 		logger:    resLogger,
 	}
+	if opts.ftdc != nil {
+		opts.ftdc.Add("resource_manager", manager)
+	}
+	return manager
 }
 
 func fromRemoteNameToRemoteNodeName(name string) resource.Name {
@@ -639,6 +699,9 @@ func (manager *resourceManager) Close(ctx context.Context) error {
 	excludeWebFromClose := map[resource.Name]struct{}{
 		web.InternalServiceName: {},
 	}
+	// bring resources to a safe state before anything is closed, since closing a
+	// dependency may leave its dependents unable to do so.
+	allErrs = multierr.Combine(allErrs, manager.safeStopMarked(ctx, excludeWebFromClose).Err())
 	if err := manager.removeMarkedAndClose(ctx, excludeWebFromClose); err != nil {
 		allErrs = multierr.Combine(allErrs, err)
 	}
@@ -670,10 +733,11 @@ func (manager *resourceManager) Kill() {
 	}
 }
 
-// completeConfig process the tree in reverse order and attempts to build or reconfigure
-// resources that are wrapped in a placeholderResource. this function will attempt to
-// process resources concurrently when they do not depend on each other unless
-// `forceSynce` is set to true.
+// completeConfig processes the graph in dependency order and attempts to build or
+// reconfigure resources that are wrapped in a placeholderResource. resources are scheduled
+// as a work queue over the dependency graph: any resource whose dependencies have been
+// processed starts immediately, up to utils.GetResourceConfigurationConcurrency resources
+// at a time, unless `forceSync` is set to true.
 func (manager *resourceManager) completeConfig(
 	ctx context.Context,
 	lr *localRobot,
@@ -694,193 +758,303 @@ func (manager *resourceManager) completeConfig(
 		manager.logger.CDebugw(ctx, "error resolving dependencies", "error", err)
 	}
 
-	// sort resources into topological "levels" based on their dependencies. resources in
-	// any given level only depend on resources in prior levels. this makes it safe to
-	// process resources within a level concurrently as long as levels are processed in
-	// order.
-	levels := manager.resources.ReverseTopologicalSortInLevels()
-	timeout := rutils.GetResourceConfigurationTimeout(manager.logger)
-	for _, resourceNames := range levels {
-		// At the start of every reconfiguration level, run updateWeakAndOptionalDependents.
-		// value.
-		//
-		// This will make sure that weak and optional dependents are updated before they are
-		// passed into constructors or reconfigure methods.
-		//
-		// Resources that depend on weak or optional dependents should expect that the
-		// weak/optional dependents passed into the constructor or reconfigure method will
-		// only have been reconfigured with all resources constructed before their level.
-		for _, resName := range resourceNames {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
-			gNode, ok := manager.resources.Node(resName)
-			if !ok || !gNode.NeedsReconfigure() {
+	// sort resources so that resources are always considered after their dependencies. the
+	// sort only determines the order in which ready resources are started; a resource
+	// becomes ready as soon as all of its dependencies have been processed.
+	var order []resource.Name
+	for _, level := range manager.resources.ReverseTopologicalSortInLevels() {
+		order = append(order, level...)
+	}
+	inOrder := make(map[resource.Name]struct{}, len(order))
+	for _, resName := range order {
+		inOrder[resName] = struct{}{}
+	}
+	remainingDeps := make(map[resource.Name]int, len(order))
+	dependents := make(map[resource.Name][]resource.Name, len(order))
+	for _, resName := range order {
+		for _, dep := range manager.resources.GetAllParentsOf(resName) {
+			if _, ok := inOrder[dep]; !ok {
 				continue
 			}
-			if !(resName.API.IsComponent() || resName.API.IsService()) {
-				continue
+			remainingDeps[resName]++
+			dependents[dep] = append(dependents[dep], resName)
+		}
+	}
+	var ready []resource.Name
+	for _, resName := range order {
+		if remainingDeps[resName] == 0 {
+			ready = append(ready, resName)
+		}
+	}
+	// processed releases the dependents of a resource once it has been processed. a
+	// resource that failed or timed out still releases its dependents, which will then
+	// fail to find it as a dependency like they did before.
+	processed := func(resName resource.Name) {
+		for _, dependent := range dependents[resName] {
+			remainingDeps[dependent]--
+			if remainingDeps[dependent] == 0 {
+				ready = append(ready, dependent)
 			}
+		}
+	}
 
-			lr.updateWeakAndOptionalDependents(ctx)
-			break
-		}
-		// we use an errgroup here instead of a normal waitgroup to conveniently bubble
-		// up errors in resource processing goroutinues that warrant an early exit.
-		var levelErrG errgroup.Group
-		// Add resources in batches instead of all at once. We've observed this to be more
-		// reliable when there are a large number of resources to add (e.g. hundreds).
-		levelErrG.SetLimit(10)
-		for _, resName := range resourceNames {
-			select {
-			case <-ctx.Done():
+	// Before processing any resources, run updateWeakAndOptionalDependents so that weak
+	// and optional dependents observe resources that were removed or added since they
+	// were last updated.
+	for _, resName := range order {
+		gNode, ok := manager.resources.Node(resName)
+		if !ok || !gNode.NeedsReconfigure() {
+			continue
+		}
+		if !(resName.API.IsComponent() || resName.API.IsService()) {
+			continue
+		}
+		lr.updateWeakAndOptionalDependents(ctx)
+		break
+	}
+
+	roundStart := time.Now()
+	var round resourceConfigurationStats
+	defer func() {
+		manager.recordConfigurationRound(round, time.Since(roundStart))
+	}()
+
+	concurrency := rutils.GetResourceConfigurationConcurrency(manager.logger)
+	// results is buffered so that in-flight resources never block on reporting their result
+	// if we exit early.
+	results := make(chan resourceConfigurationResult, len(order))
+	inFlight := 0
+	for len(ready) > 0 || inFlight > 0 {
+		for len(ready) > 0 && inFlight < concurrency {
+			if ctx.Err() != nil {
 				return
-			default:
 			}
-			// processResource is intended to be run concurrently for each resource
-			// within a topological sort level. if any processResource function returns a
-			// non-nil error then the entire `completeConfig` function will exit early.
+			resName := ready[0]
+			syncRes := forceSync || manager.needsSyncConfiguration(resName)
+			updateWeakDeps := manager.needsWeakAndOptionalDependentsUpdate(lr, resName)
+			// resources that must be processed on their own, or that need weak and optional
+			// dependents to be updated first, wait for all in-flight resources to finish.
+			if (syncRes || updateWeakDeps) && inFlight > 0 {
+				break
+			}
+			ready = ready[1:]
+
+			// This will make sure that weak and optional dependents are updated before they
+			// are passed into constructors or reconfigure methods.
 			//
-			// currently only a top-level context cancellation will result in an early
-			// exist - individual resource processing failures will not.
-			processResource := func() error {
-				resChan := make(chan struct{}, 1)
-				ctxWithTimeout, timeoutCancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
-				defer timeoutCancel()
-
-				stopSlowLogger := rutils.SlowLogger(
-					ctx, "Waiting for resource to complete (re)configuration", "resource", resName.String(), manager.logger)
-
-				lr.reconfigureWorkers.Add(1)
-				goutils.PanicCapturingGo(func() {
-					defer func() {
-						stopSlowLogger()
-						resChan <- struct{}{}
-						lr.reconfigureWorkers.Done()
-					}()
-					gNode, ok := manager.resources.Node(resName)
-					if !ok || !gNode.NeedsReconfigure() {
-						return
-					}
-					if !(resName.API.IsComponent() || resName.API.IsService()) {
-						return
-					}
+			// Resources that depend on weak or optional dependents should expect that the
+			// weak/optional dependents passed into the constructor or reconfigure method will
+			// only have been reconfigured with all resources constructed before them.
+			if updateWeakDeps {
+				lr.updateWeakAndOptionalDependents(ctx)
+			}
 
-					verb := "construct"
-					conf := gNode.Config()
-					if gNode.IsUninitialized() {
-						gNode.InitializeLogger(
-							manager.logger, resName.String(),
-						)
-					} else {
-						verb = "reconfigur"
-					}
-					manager.logger.CInfow(ctx, fmt.Sprintf("Now %ving resource", verb), "resource", resName, "model", conf.Model)
-
-					// The config was already validated, but we must check again before attempting
-					// to add.
-					if _, _, err := conf.Validate("", resName.API.Type.Name); err != nil {
-						gNode.LogAndSetLastError(
-							fmt.Errorf("resource config validation error: %w", err),
-							"resource", conf.ResourceName(),
-							"model", conf.Model)
-						return
-					}
-					if manager.moduleManager.Provides(conf) {
-						if _, _, err := manager.moduleManager.ValidateConfig(ctxWithTimeout, conf); err != nil {
-							gNode.LogAndSetLastError(
-								fmt.Errorf("modular resource config validation error: %w", err),
-								"resource", conf.ResourceName(),
-								"model", conf.Model)
-							return
-						}
-					}
+			if syncRes {
+				res := manager.configureResource(ctx, lr, resName)
+				round.add(res)
+				if res.err != nil {
+					return
+				}
+				processed(resName)
+				continue
+			}
 
-					switch {
-					case resName.API.IsComponent(), resName.API.IsService():
-
-						newRes, newlyBuilt, err := manager.processResource(ctxWithTimeout, conf, gNode, lr)
-						if newlyBuilt || err != nil {
-							if err := manager.markChildrenForUpdate(resName); err != nil {
-								manager.logger.CErrorw(ctx,
-									"failed to mark children of resource for update",
-									"resource", resName,
-									"reason", err)
-							}
-						}
-
-						if err != nil {
-							gNode.LogAndSetLastError(
-								fmt.Errorf("resource build error: %v", err.Error()),
-								"resource", conf.ResourceName(),
-								"model", conf.Model)
-							return
-						}
-
-						// if the ctxWithTimeout fails with DeadlineExceeded, then that means that
-						// resource generation is running async, and we don't currently have good
-						// validation around how this might affect the resource graph. So, we avoid
-						// updating the graph to be safe.
-						if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
-							manager.logger.CErrorw(
-								ctx, "error building resource", "resource", conf.ResourceName(), "model", conf.Model, "error", ctxWithTimeout.Err())
-						} else {
-							gNode.SwapResource(newRes, conf.Model, manager.opts.ftdc)
-							manager.logger.CInfow(ctx, fmt.Sprintf("Successfully %ved resource", verb), "resource", resName, "model", conf.Model)
-						}
-
-					default:
-						err := errors.New("config is not for a component or service")
-						gNode.LogAndSetLastError(err, "resource", resName)
-					}
-				})
+			inFlight++
+			lr.reconfigureWorkers.Add(1)
+			go func() {
+				defer lr.reconfigureWorkers.Done()
+				results <- manager.configureResource(ctx, lr, resName)
+			}()
+		}
+		if inFlight == 0 {
+			continue
+		}
 
-				select {
-				case <-resChan:
-				case <-ctxWithTimeout.Done():
-					// this resource is taking too long to process, so we give up but
-					// continue processing other resources. we do not wait for this
-					// resource to finish processing since it may be running outside code
-					// and have unexpected behavior.
-					if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
-						lr.logger.CWarn(ctx, rutils.NewBuildTimeoutError(resName.String(), lr.logger))
-					}
-				case <-ctx.Done():
-					return ctx.Err()
-				}
-				return nil
+		// currently only a top-level context cancellation will result in an early exit -
+		// individual resource processing failures will not.
+		select {
+		case res := <-results:
+			inFlight--
+			round.add(res)
+			if res.err != nil {
+				return
 			}
+			processed(res.name)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			syncRes := forceSync
-			if !syncRes {
-				// TODO(RSDK-6925): support concurrent processing of resources of
-				// APIs with a maximum instance limit. Currently this limit is
-				// validated later in the resource creation flow and assumes that
-				// each resource is created synchronously to have an accurate
-				// creation count.
-				if c, ok := resource.LookupGenericAPIRegistration(resName.API); ok && c.MaxInstance != 0 {
-					syncRes = true
-				}
+// needsSyncConfiguration returns whether the given resource must be processed while no other
+// resources are being processed.
+func (manager *resourceManager) needsSyncConfiguration(resName resource.Name) bool {
+	// TODO(RSDK-6925): support concurrent processing of resources of
+	// APIs with a maximum instance limit. Currently this limit is
+	// validated later in the resource creation flow and assumes that
+	// each resource is created synchronously to have an accurate
+	// creation count.
+	c, ok := resource.LookupGenericAPIRegistration(resName.API)
+	return ok && c.MaxInstance != 0
+}
+
+// needsWeakAndOptionalDependentsUpdate returns whether weak and optional dependents must be
+// updated before the given resource is processed. This is the case when the resource needs
+// to be (re)configured, one of its dependencies has weak or optional dependencies, and
+// resources were reconfigured since the last update.
+func (manager *resourceManager) needsWeakAndOptionalDependentsUpdate(lr *localRobot, resName resource.Name) bool {
+	if !(resName.API.IsComponent() || resName.API.IsService()) {
+		return false
+	}
+	gNode, ok := manager.resources.Node(resName)
+	if !ok || !gNode.NeedsReconfigure() {
+		return false
+	}
+	if lr.lastWeakAndOptionalDependentsRound.Load() >= manager.resources.CurrLogicalClockValue() {
+		return false
+	}
+	for _, dep := range manager.resources.GetAllParentsOf(resName) {
+		depNode, ok := manager.resources.Node(dep)
+		if !ok {
+			continue
+		}
+		conf := depNode.Config()
+		if len(lr.getWeakDependencyMatchers(conf.API, conf.Model)) > 0 || len(conf.ImplicitOptionalDependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceConfigurationResult is the outcome of processing a single resource in
+// completeConfig.
+type resourceConfigurationResult struct {
+	name resource.Name
+	// attempted is whether the resource needed to be (re)configured.
+	attempted bool
+	timedOut  bool
+	duration  time.Duration
+	// err is only set if processing the remaining resources should stop.
+	err error
+}
+
+// configureResource builds or reconfigures the given resource if it needs to be. The
+// resource is given the timeout from its config, or utils.GetResourceConfigurationTimeout
+// if it does not have one.
+func (manager *resourceManager) configureResource(
+	ctx context.Context,
+	lr *localRobot,
+	resName resource.Name,
+) resourceConfigurationResult {
+	result := resourceConfigurationResult{name: resName}
+	gNode, ok := manager.resources.Node(resName)
+	if !ok || !gNode.NeedsReconfigure() {
+		return result
+	}
+	if !(resName.API.IsComponent() || resName.API.IsService()) {
+		return result
+	}
+	result.attempted = true
+
+	timeout := rutils.GetResourceConfigurationTimeout(manager.logger)
+	if confTimeout := gNode.Config().ConfigurationTimeout; confTimeout > 0 {
+		timeout = confTimeout
+	}
+
+	start := time.Now()
+	resChan := make(chan struct{}, 1)
+	ctxWithTimeout, timeoutCancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer timeoutCancel()
+
+	stopSlowLogger := rutils.SlowLogger(
+		ctx, "Waiting for resource to complete (re)configuration", "resource", resName.String(), manager.logger)
+
+	lr.reconfigureWorkers.Add(1)
+	goutils.PanicCapturingGo(func() {
+		defer func() {
+			gNode.RecordConfigurationDuration(time.Since(start))
+			stopSlowLogger()
+			resChan <- struct{}{}
+			lr.reconfigureWorkers.Done()
+		}()
+
+		verb := "construct"
+		conf := gNode.Config()
+		if gNode.IsUninitialized() {
+			gNode.InitializeLogger(
+				manager.logger, resName.String(),
+			)
+		} else {
+			verb = "reconfigur"
+		}
+		manager.logger.CInfow(ctx, fmt.Sprintf("Now %ving resource", verb), "resource", resName, "model", conf.Model)
+
+		// The config was already validated, but we must check again before attempting
+		// to add.
+		if _, _, err := conf.Validate("", resName.API.Type.Name); err != nil {
+			gNode.LogAndSetLastError(
+				fmt.Errorf("resource config validation error: %w", err),
+				"resource", conf.ResourceName(),
+				"model", conf.Model)
+			return
+		}
+		if manager.moduleManager.Provides(conf) {
+			if _, _, err := manager.moduleManager.ValidateConfig(ctxWithTimeout, conf); err != nil {
+				gNode.LogAndSetLastError(
+					fmt.Errorf("modular resource config validation error: %w", err),
+					"resource", conf.ResourceName(),
+					"model", conf.Model)
+				return
 			}
+		}
 
-			if syncRes {
-				if err := processResource(); err != nil {
-					return
-				}
-			} else {
-				lr.reconfigureWorkers.Add(1)
-				levelErrG.Go(func() error {
-					defer lr.reconfigureWorkers.Done()
-					return processResource()
-				})
+		newRes, newlyBuilt, err := manager.processResource(ctxWithTimeout, conf, gNode, lr)
+		if newlyBuilt || err != nil {
+			if err := manager.markChildrenForUpdate(resName); err != nil {
+				manager.logger.CErrorw(ctx,
+					"failed to mark children of resource for update",
+					"resource", resName,
+					"reason", err)
 			}
-		} // for-each resource name
-		if err := levelErrG.Wait(); err != nil {
+		}
+
+		if err != nil {
+			gNode.LogAndSetLastError(
+				fmt.Errorf("resource build error: %v", err.Error()),
+				"resource", conf.ResourceName(),
+				"model", conf.Model)
 			return
 		}
-	} // for-each level
+
+		// if the ctxWithTimeout fails with DeadlineExceeded, then that means that
+		// resource generation is running async, and we don't currently have good
+		// validation around how this might affect the resource graph. So, we avoid
+		// updating the graph to be safe.
+		if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
+			manager.logger.CErrorw(
+				ctx, "error building resource", "resource", conf.ResourceName(), "model", conf.Model, "error", ctxWithTimeout.Err())
+		} else {
+			gNode.SwapResource(newRes, conf.Model, manager.opts.ftdc)
+			manager.logger.CInfow(ctx, fmt.Sprintf("Successfully %ved resource", verb), "resource", resName, "model", conf.Model)
+		}
+	})
+
+	select {
+	case <-resChan:
+	case <-ctxWithTimeout.Done():
+		// this resource is taking too long to process, so we give up but
+		// continue processing other resources. we do not wait for this
+		// resource to finish processing since it may be running outside code
+		// and have unexpected behavior.
+		if errors.Is(ctxWithTimeout.Err(), context.DeadlineExceeded) {
+			result.timedOut = true
+			lr.logger.CWarn(ctx, rutils.NewBuildTimeoutErrorAfter(resName.String(), timeout))
+		}
+	case <-ctx.Done():
+		result.err = ctx.Err()
+	}
+	result.duration = time.Since(start)
+	return result
 }
 
 func (manager *resourceManager) completeConfigForRemotes(ctx context.Context, lr *localRobot) {
@@ -2266,3 +2266,118 @@ func TestOfflineRemoteResources(t *testing.T) {
 	mainClient.Refresh(ctx)
 	test.That(t, resourceNames, test.ShouldNotContain, mainClient.ResourceNames())
 }
+
+func TestCompleteConfigStartsReadyResources(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	// slow blocks until it is released or times out; fast is constructed immediately.
+	slowModel := resource.DefaultModelFamily.WithModel("slowsensor")
+	fastModel := resource.DefaultModelFamily.WithModel("fastsensor")
+	release := make(chan struct{})
+	var releaseOnce sync.Once
+	defer releaseOnce.Do(func() { close(release) })
+	constructed := make(chan string, 10)
+	resource.RegisterComponent(sensor.API, slowModel, resource.Registration[sensor.Sensor, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (sensor.Sensor, error) {
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			constructed <- conf.Name
+			return inject.NewSensor(conf.Name), nil
+		},
+	})
+	defer resource.Deregister(sensor.API, slowModel)
+	resource.RegisterComponent(sensor.API, fastModel, resource.Registration[sensor.Sensor, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (sensor.Sensor, error) {
+			constructed <- conf.Name
+			return inject.NewSensor(conf.Name), nil
+		},
+	})
+	defer resource.Deregister(sensor.API, fastModel)
+
+	lr := setupLocalRobot(t, ctx, &config.Config{}, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+
+	t.Run("dependents of ready resources do not wait for unrelated resources", func(t *testing.T) {
+		cfg := &config.Config{
+			Components: []resource.Config{
+				{Name: "slow", API: sensor.API, Model: slowModel},
+				{Name: "a", API: sensor.API, Model: fastModel},
+				{Name: "b", API: sensor.API, Model: fastModel, DependsOn: []string{"a"}},
+			},
+		}
+		reconfigured := make(chan struct{})
+		go func() {
+			defer close(reconfigured)
+			r.Reconfigure(ctx, cfg)
+		}()
+
+		// b only depends on a, so it is constructed while slow is still being constructed.
+		var order []string
+		for len(order) < 2 {
+			select {
+			case name := <-constructed:
+				order = append(order, name)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("timed out waiting for resources to be constructed; constructed %v", order)
+			}
+		}
+		test.That(t, order, test.ShouldResemble, []string{"a", "b"})
+
+		releaseOnce.Do(func() { close(release) })
+		<-reconfigured
+		test.That(t, <-constructed, test.ShouldEqual, "slow")
+		for _, name := range []string{"slow", "a", "b"} {
+			_, err := r.ResourceByName(sensor.Named(name))
+			test.That(t, err, test.ShouldBeNil)
+		}
+
+		stats := r.manager.Stats().(resourceConfigurationStats)
+		test.That(t, stats.LastRoundResources, test.ShouldEqual, 3)
+		test.That(t, stats.ResourcesTimedOut, test.ShouldEqual, 0)
+		node, ok := r.manager.resources.Node(sensor.Named("slow"))
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, node.ConfigurationDuration(), test.ShouldBeGreaterThan, 0)
+	})
+
+	t.Run("resources time out after their configuration timeout", func(t *testing.T) {
+		stuck := make(chan struct{})
+		defer close(stuck)
+		stuckModel := resource.DefaultModelFamily.WithModel("stucksensor")
+		resource.RegisterComponent(sensor.API, stuckModel, resource.Registration[sensor.Sensor, resource.NoNativeConfig]{
+			Constructor: func(
+				ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+			) (sensor.Sensor, error) {
+				<-stuck
+				return nil, errors.New("stuck")
+			},
+		})
+		defer resource.Deregister(sensor.API, stuckModel)
+
+		cfg := &config.Config{
+			Components: []resource.Config{
+				{Name: "slow", API: sensor.API, Model: slowModel},
+				{Name: "a", API: sensor.API, Model: fastModel},
+				{Name: "b", API: sensor.API, Model: fastModel, DependsOn: []string{"a"}},
+				{Name: "stuck", API: sensor.API, Model: stuckModel, ConfigurationTimeout: 100 * time.Millisecond},
+			},
+		}
+		start := time.Now()
+		r.Reconfigure(ctx, cfg)
+		test.That(t, time.Since(start), test.ShouldBeLessThan, rutils.DefaultResourceConfigurationTimeout)
+
+		_, err := r.ResourceByName(sensor.Named("stuck"))
+		test.That(t, err, test.ShouldNotBeNil)
+		stats := r.manager.Stats().(resourceConfigurationStats)
+		test.That(t, stats.LastRoundResources, test.ShouldEqual, 1)
+		test.That(t, stats.ResourcesTimedOut, test.ShouldEqual, 1)
+		test.That(t, stats.ResourcesConfigured, test.ShouldEqual, 4)
+	})
+}
@@ -0,0 +1,109 @@
+package robotimpl
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/resource"
+)
+
+// safeStopTimeout is how long a single resource is given to stop or reach a safe state
+// before we give up on it and move on to the next resource.
+var safeStopTimeout = resource.DefaultSafeStopTimeout
+
+// safeStopReport holds the resources that failed to stop or reach a safe state, along with
+// why they failed.
+type safeStopReport map[resource.Name]error
+
+// Err returns an error naming every resource in the report, or nil if the report is empty.
+func (rep safeStopReport) Err() error {
+	if len(rep) == 0 {
+		return nil
+	}
+	names := make([]resource.Name, 0, len(rep))
+	for name := range rep {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	nameStrs := make([]string, 0, len(names))
+	var errs error
+	for _, name := range names {
+		nameStrs = append(nameStrs, name.String())
+		errs = multierr.Combine(errs, errors.Wrapf(rep[name], "%s", name))
+	}
+	return multierr.Combine(
+		errors.Errorf("resources failed to reach a safe state: %s", strings.Join(nameStrs, ", ")),
+		errs)
+}
+
+// stopWithTimeout calls stop for the named resource and waits at most safeStopTimeout for it
+// to return.
+func stopWithTimeout(ctx context.Context, name resource.Name, stop func(ctx context.Context) error) error {
+	return resource.StopWithTimeout(ctx, name, safeStopTimeout, stop)
+}
+
+// stopOrder orders the given resources so that resources come before the resources they
+// depend on. Resources that are not in the graph are put last.
+func (manager *resourceManager) stopOrder(names []resource.Name) []resource.Name {
+	wanted := make(map[resource.Name]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+	ordered := make([]resource.Name, 0, len(names))
+	for _, name := range manager.resources.TopologicalSort() {
+		if _, ok := wanted[name]; ok {
+			ordered = append(ordered, name)
+			delete(wanted, name)
+		}
+	}
+	for _, name := range names {
+		if _, ok := wanted[name]; ok {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// safeStopMarked calls SafeStop on every resource that is marked for removal and implements
+// resource.SafeStopper. Dependents are brought to a safe state before the resources they
+// depend on. Remote resources are skipped since their own machine is responsible for them.
+// The returned report holds the resources that failed to reach a safe state.
+func (manager *resourceManager) safeStopMarked(
+	ctx context.Context,
+	exclude map[resource.Name]struct{},
+) safeStopReport {
+	report := safeStopReport{}
+	for _, name := range manager.resources.TopologicalSort() {
+		if _, ok := exclude[name]; ok || name.ContainsRemoteNames() {
+			continue
+		}
+		gNode, ok := manager.resources.Node(name)
+		if !ok || !gNode.MarkedForRemoval() {
+			continue
+		}
+		// marked nodes are pending removal so their resource must be accessed unsafely.
+		res, err := gNode.UnsafeResource()
+		if err != nil {
+			continue
+		}
+		stopper, ok := res.(resource.SafeStopper)
+		if !ok {
+			continue
+		}
+		manager.logger.CInfow(ctx, "Bringing resource to a safe state", "resource", name)
+		// resource clients are SafeStoppers even when the resource they talk to is not.
+		if err := stopWithTimeout(ctx, name, func(ctx context.Context) error {
+			return stopper.SafeStop(ctx, nil)
+		}); err != nil && !errors.Is(err, resource.ErrSafeStopUnsupported) {
+			report[name] = err
+		}
+	}
+	if err := report.Err(); err != nil {
+		manager.logger.CErrorw(ctx, "some resources failed to reach a safe state", "error", err)
+	}
+	return report
+}
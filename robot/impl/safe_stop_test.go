@@ -0,0 +1,165 @@
+package robotimpl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/sensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+// safeStopSensor is a sensor that records when it is brought to a safe state.
+type safeStopSensor struct {
+	*inject.Sensor
+	safeStop func(ctx context.Context) error
+}
+
+func (s *safeStopSensor) SafeStop(ctx context.Context, extra map[string]interface{}) error {
+	return s.safeStop(ctx)
+}
+
+func TestSafeStop(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	prevTimeout := safeStopTimeout
+	safeStopTimeout = 100 * time.Millisecond
+	defer func() { safeStopTimeout = prevTimeout }()
+
+	var mu sync.Mutex
+	var stopped []string
+	failing := map[string]bool{}
+	hang := make(chan struct{})
+	defer close(hang)
+
+	model := resource.DefaultModelFamily.WithModel("safestopsensor")
+	resource.RegisterComponent(sensor.API, model, resource.Registration[sensor.Sensor, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (sensor.Sensor, error) {
+			name := conf.Name
+			return &safeStopSensor{
+				Sensor: inject.NewSensor(name),
+				safeStop: func(ctx context.Context) error {
+					mu.Lock()
+					stopped = append(stopped, name)
+					fail := failing[name]
+					mu.Unlock()
+					if name == "hangs" {
+						<-hang
+					}
+					if fail {
+						return errors.New("brake did not engage")
+					}
+					return nil
+				},
+			}, nil
+		},
+	})
+	defer resource.Deregister(sensor.API, model)
+
+	takeStopped := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		ret := stopped
+		stopped = nil
+		return ret
+	}
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "motor", API: sensor.API, Model: model},
+			{Name: "base", API: sensor.API, Model: model, DependsOn: []string{"motor"}},
+			{Name: "other", API: sensor.API, Model: model},
+		},
+	}
+	lr := setupLocalRobot(t, ctx, cfg, logger, WithDisableCompleteConfigWorker())
+	r := lr.(*localRobot)
+	test.That(t, takeStopped(), test.ShouldBeEmpty)
+
+	t.Run("removed resources are brought to a safe state before their dependencies", func(t *testing.T) {
+		r.Reconfigure(ctx, &config.Config{
+			Components: []resource.Config{
+				{Name: "other", API: sensor.API, Model: model},
+			},
+		})
+		test.That(t, takeStopped(), test.ShouldResemble, []string{"base", "motor"})
+		_, err := r.ResourceByName(sensor.Named("base"))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("failures are reported on close", func(t *testing.T) {
+		r.Reconfigure(ctx, &config.Config{
+			Components: []resource.Config{
+				{Name: "other", API: sensor.API, Model: model},
+				{Name: "hangs", API: sensor.API, Model: model},
+			},
+		})
+		test.That(t, takeStopped(), test.ShouldBeEmpty)
+
+		mu.Lock()
+		failing["other"] = true
+		mu.Unlock()
+		err := r.Close(ctx)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "resources failed to reach a safe state")
+		test.That(t, err.Error(), test.ShouldContainSubstring, "brake did not engage")
+		test.That(t, err.Error(), test.ShouldContainSubstring, "timed out")
+		test.That(t, takeStopped(), test.ShouldHaveLength, 2)
+	})
+}
+
+func TestStopAllOrder(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var stopped []string
+	model := resource.DefaultModelFamily.WithModel("stopordersensor")
+	resource.RegisterComponent(sensor.API, model, resource.Registration[sensor.Sensor, resource.NoNativeConfig]{
+		Constructor: func(
+			ctx context.Context, deps resource.Dependencies, conf resource.Config, logger logging.Logger,
+		) (sensor.Sensor, error) {
+			return &stoppableSensor{Sensor: inject.NewSensor(conf.Name), stop: func() {
+				mu.Lock()
+				defer mu.Unlock()
+				stopped = append(stopped, conf.Name)
+			}}, nil
+		},
+	})
+	defer resource.Deregister(sensor.API, model)
+
+	cfg := &config.Config{
+		Components: []resource.Config{
+			{Name: "c", API: sensor.API, Model: model, DependsOn: []string{"b"}},
+			{Name: "a", API: sensor.API, Model: model},
+			{Name: "b", API: sensor.API, Model: model, DependsOn: []string{"a"}},
+		},
+	}
+	r := setupLocalRobot(t, ctx, cfg, logger, WithDisableCompleteConfigWorker())
+	test.That(t, r.StopAll(ctx, nil), test.ShouldBeNil)
+	test.That(t, stopped, test.ShouldResemble, []string{"c", "b", "a"})
+}
+
+// stoppableSensor is a sensor that is also an actuator.
+type stoppableSensor struct {
+	*inject.Sensor
+	stop func()
+}
+
+func (s *stoppableSensor) IsMoving(context.Context) (bool, error) {
+	return false, nil
+}
+
+func (s *stoppableSensor) Stop(ctx context.Context, extra map[string]interface{}) error {
+	s.stop()
+	return nil
+}
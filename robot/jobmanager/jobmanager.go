@@ -7,7 +7,10 @@ import (
 	"container/ring"
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -39,6 +42,12 @@ const (
 	// the job manager will be looking for.
 	componentServiceIndex int = 2
 	historyLength         int = 10
+	// persistedRunsLength is how many runs of each job are kept in the persisted run history.
+	persistedRunsLength int = 50
+	// maxResultLen is how many bytes of a job's response are kept in its run history.
+	maxResultLen = 1024
+	// historyFlushInterval is how often changes to the run history are persisted.
+	historyFlushInterval = time.Second
 )
 
 // JobManager keeps track of the currently scheduled jobs and updates the schedule with
@@ -49,14 +58,43 @@ type JobManager struct {
 	getResource   func(resource string) (resource.Resource, error)
 	namesToJobIDs map[string]uuid.UUID
 	ctx           context.Context
+	workers       *utils.StoppableWorkers
 	conn          rpc.ClientConn
 	isClosed      bool
 	closeMutex    sync.Mutex
 
+	// jobs are the currently configured jobs by name, including ones that only run after
+	// another job and are therefore not on the scheduler.
+	jobsMu sync.Mutex
+	jobs   map[string]*job
+
+	// runs is the run history of every job, oldest first. It is persisted to historyPath,
+	// if set.
+	runsMu      sync.Mutex
+	runs        map[string][]config.JobRun
+	runsDirty   bool
+	historyPath string
+
 	NumJobHistories atomic.Int32
 	JobHistories    ssync.Map[string, *JobHistory]
 }
 
+// job is a configured job along with the state needed to apply its concurrency policy.
+type job struct {
+	conf       config.JobConfig
+	logger     logging.Logger
+	policy     config.JobConcurrencyPolicy
+	timeout    time.Duration
+	backoff    time.Duration
+	continuous bool
+
+	// runMu is held for the duration of a run.
+	runMu sync.Mutex
+
+	cancelMu  sync.Mutex
+	cancelRun context.CancelFunc
+}
+
 // JobHistory records historical metadata about a job.
 type JobHistory struct {
 	successTimesMu sync.Mutex
@@ -111,12 +149,15 @@ func (jh *JobHistory) AddFailure(time time.Time) {
 
 // New sets up the context and grpcConn that is used in scheduled jobs. The actual
 // scheduler is initialized and automatically started. Any jobs added to the config will
-// then immediately get scheduled according to their "Schedule" field.
+// then immediately get scheduled according to their "Schedule" field. Run history is
+// persisted within historyDir so that it survives restarts; an empty historyDir keeps it in
+// memory only.
 func New(
 	robotContext context.Context,
 	logger logging.Logger,
 	getResource func(string) (resource.Resource, error),
 	parentAddr config.ParentSockAddrs,
+	historyDir string,
 ) (*JobManager, error) {
 	jobLogger := logger.Sublogger("job_manager")
 
@@ -143,16 +184,25 @@ func New(
 		return nil, err
 	}
 
+	workers := utils.NewStoppableWorkers(robotContext)
 	jm := &JobManager{
 		logger:        jobLogger,
 		scheduler:     scheduler,
 		getResource:   getResource,
 		namesToJobIDs: make(map[string]uuid.UUID),
-		ctx:           robotContext,
+		ctx:           workers.Context(),
+		workers:       workers,
 		conn:          conn,
+		jobs:          make(map[string]*job),
+		runs:          make(map[string][]config.JobRun),
+	}
+	if historyDir != "" {
+		jm.historyPath = filepath.Join(historyDir, config.JobHistoryFileName)
+		jm.loadHistory()
 	}
 
 	jm.scheduler.Start()
+	jm.workers.Add(jm.flushHistoryLoop)
 	return jm, nil
 }
 
@@ -167,8 +217,12 @@ func (jm *JobManager) Close() error {
 	}
 	jm.isClosed = true
 	jm.logger.CInfo(jm.ctx, "JobManager is shutting down.")
+	// stopping the workers interrupts all runs, including those started by the scheduler.
+	jm.workers.Stop()
 	utils.UncheckedError(jm.conn.Close())
-	return jm.scheduler.Shutdown()
+	err := jm.scheduler.Shutdown()
+	jm.flushHistory()
+	return err
 }
 
 // createDescriptorSourceAndgRPCMethod sets up a DescriptorSource for grpc translations
@@ -204,8 +258,8 @@ func (jm *JobManager) createDescriptorSourceAndgRPCMethod(
 	return descSource, grpcService, method, nil
 }
 
-// createJobFunction returns a function that the job scheduler puts on its queue.
-func (jm *JobManager) createJobFunction(jc config.JobConfig, continuous bool) func(ctx context.Context) error {
+// newJob sets up the logger and run settings of a validated job config.
+func (jm *JobManager) newJob(jc config.JobConfig) *job {
 	jobLogger := jm.logger.Sublogger(jc.Name)
 	// Note, if only removing a previously set LogConfiguration, there will be no change (instead of reverting to default).
 	// This matches the existing LogConfiguration behavior throughout the system.
@@ -216,83 +270,244 @@ func (jm *JobManager) createJobFunction(jc config.JobConfig, continuous bool) fu
 	// deduplication for job loggers.
 	jobLogger.NeverDeduplicate()
 
-	// using jm.ctx so we interrupt only if JM is shutting down. When changing schedule, let existing jobs complete instead of interrupting.
-	jobFunc := func(_ context.Context) error {
-		res, err := jm.getResource(jc.Resource)
+	j := &job{
+		conf:       jc,
+		logger:     jobLogger,
+		policy:     jc.ConcurrencyPolicy,
+		backoff:    config.DefaultJobRetryBackoff,
+		continuous: strings.ToLower(jc.Schedule) == "continuous",
+	}
+	// these were checked when the config was validated.
+	if jc.Timeout != "" {
+		j.timeout, _ = time.ParseDuration(jc.Timeout) //nolint:errcheck
+	}
+	if jc.RetryBackoff != "" {
+		j.backoff, _ = time.ParseDuration(jc.RetryBackoff) //nolint:errcheck
+	}
+	if j.policy == "" {
+		// keep the behavior of schedules from before concurrency policies were configurable,
+		// see scheduleJob.
+		j.policy = config.JobConcurrencyQueue
+		if _, err := time.ParseDuration(jc.Schedule); err != nil && jc.Schedule != "" && !j.continuous {
+			j.policy = config.JobConcurrencySkip
+		}
+	}
+	return j
+}
+
+// invokeJob calls the job's method on its resource, or runs its steps, once and returns the
+// response.
+func (jm *JobManager) invokeJob(ctx context.Context, j *job) (map[string]any, error) {
+	if len(j.conf.Steps) != 0 {
+		return jm.runSteps(ctx, j)
+	}
+	return jm.callResource(ctx, j.logger, j.conf.Name, j.conf.Resource, j.conf.Method, j.conf.Command)
+}
+
+// callResource calls a method on the named resource and returns the response. arguments are
+// the command for DoCommand, or the request fields for any other method.
+func (jm *JobManager) callResource(
+	ctx context.Context,
+	jobLogger logging.Logger,
+	name, resourceName, method string,
+	arguments map[string]any,
+) (map[string]any, error) {
+	res, err := jm.getResource(resourceName)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "Could not get resource", "error", err.Error())
+		return nil, err
+	}
+	if method == "DoCommand" {
+		jobLogger.CDebugw(ctx, "Job triggered", "name", name)
+		// unlike below InvokeRPC, if DoCommand panics there is no recover
+		response, err := res.DoCommand(ctx, arguments)
 		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "Could not get resource", "error", err.Error())
-			return err
+			jobLogger.CWarnw(ctx, "Job failed", "error", err.Error())
+			return nil, err
 		}
-		if jc.Method == "DoCommand" {
-			jobLogger.CDebugw(jm.ctx, "Job triggered", "name", jc.Name)
-			// unlike below InvokeRPC, if DoCommand panics there is no recover
-			response, err := res.DoCommand(jm.ctx, jc.Command)
-			if err != nil {
-				jobLogger.CWarnw(jm.ctx, "Job failed", "error", err.Error())
-				return err
-			}
-			jobLogger.CDebugw(jm.ctx, "Job succeeded", "name", jc.Name, "response", response)
+		jobLogger.CDebugw(ctx, "Job succeeded", "name", name, "response", response)
+		return response, nil
+	}
+
+	descSource, grpcService, grpcMethod, err := jm.createDescriptorSourceAndgRPCMethod(res, method)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "grpc setup failed", "error", err)
+		return nil, err
+	}
+
+	gRPCArgument := resource.GetResourceNameOverride(grpcService, grpcMethod)
+	argumentMap := make(map[string]any, len(arguments)+1)
+	for field, value := range arguments {
+		argumentMap[field] = value
+	}
+	argumentMap[gRPCArgument] = resourceName
+	argumentBytes, err := json.Marshal(argumentMap)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "could not serialize gRPC method arguments", "error", err.Error())
+		return nil, err
+	}
+	options := grpcurl.FormatOptions{
+		EmitJSONDefaultFields: true,
+		IncludeTextSeparator:  true,
+		AllowUnknownFields:    true,
+	}
+	rf, formatter, err := grpcurl.RequestParserAndFormatter(
+		grpcurl.Format("json"),
+		descSource,
+		bytes.NewBuffer(argumentBytes),
+		options)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "could not create parser and formatter for grpc requests", "error", err.Error())
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0))
+	h := &grpcurl.DefaultEventHandler{
+		Out:            buffer,
+		Formatter:      formatter,
+		VerbosityLevel: 0,
+	}
+	jobLogger.CDebugw(ctx, "Job triggered", "name", name)
+	grpcMethodCombined := grpcService + "." + grpcMethod
+	err = grpcurl.InvokeRPC(ctx, descSource, jm.conn, grpcMethodCombined, nil, h, rf.Next)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "Job failed", "name", name, "error", err.Error())
+		return nil, err
+	} else if h.Status != nil && h.Status.Err() != nil {
+		// if job panics, it seems to be captured here.
+		jobLogger.CWarnw(ctx, "Job failed", "name", name, "error", h.Status.Err())
+		return nil, h.Status.Err()
+	}
+	response := map[string]any{}
+	err = json.Unmarshal(buffer.Bytes(), &response)
+	if err != nil {
+		jobLogger.CWarnw(ctx, "Unmarshalling grpc response failed with error", "name", name,
+			"error", err.Error())
+		return nil, err
+	}
+	jobLogger.CDebugw(ctx, "Job succeeded", "name", name, "response", response)
+	return response, nil
+}
+
+// runJob runs a job according to its concurrency policy, retrying it as configured, and
+// records the run. If the run succeeds, the jobs configured to run after it are started.
+// chain holds the names of the jobs whose success led to this run, if any.
+func (jm *JobManager) runJob(j *job, trigger string, chain []string) error {
+	switch j.policy {
+	case config.JobConcurrencySkip:
+		if !j.runMu.TryLock() {
+			j.logger.CInfow(jm.ctx, "Job is still running; skipping this run", "name", j.conf.Name, "trigger", trigger)
 			return nil
 		}
-
-		descSource, grpcService, grpcMethod, err := jm.createDescriptorSourceAndgRPCMethod(res, jc.Method)
-		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "grpc setup failed", "error", err)
-			return err
+	case config.JobConcurrencyReplace:
+		j.cancelMu.Lock()
+		if j.cancelRun != nil {
+			j.logger.CInfow(jm.ctx, "Job is still running; cancelling the previous run", "name", j.conf.Name)
+			j.cancelRun()
 		}
+		j.cancelMu.Unlock()
+		j.runMu.Lock()
+	case config.JobConcurrencyQueue:
+		j.runMu.Lock()
+	}
+	defer j.runMu.Unlock()
+	if jm.ctx.Err() != nil {
+		// JM shut down while this run was waiting on the previous one.
+		return jm.ctx.Err()
+	}
+
+	// using jm.ctx so we interrupt only if JM is shutting down. When changing schedule, let
+	// existing jobs complete instead of interrupting.
+	runCtx, cancel := context.WithCancel(jm.ctx)
+	defer cancel()
+	j.cancelMu.Lock()
+	j.cancelRun = cancel
+	j.cancelMu.Unlock()
+	defer func() {
+		j.cancelMu.Lock()
+		j.cancelRun = nil
+		j.cancelMu.Unlock()
+	}()
 
-		gRPCArgument := resource.GetResourceNameOverride(grpcService, grpcMethod)
-		argumentMap := map[string]string{
-			gRPCArgument: jc.Resource,
+	run := config.JobRun{Job: j.conf.Name, Trigger: trigger, Start: time.Now()}
+	var response map[string]any
+	var err error
+	backoff := j.backoff
+	for {
+		run.Attempts++
+		attemptCtx, attemptCancel := runCtx, context.CancelFunc(func() {})
+		if j.timeout > 0 {
+			attemptCtx, attemptCancel = context.WithTimeout(runCtx, j.timeout)
 		}
-		argumentBytes, err := json.Marshal(argumentMap)
-		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "could not serialize gRPC method arguments", "error", err.Error())
-			return err
+		response, err = jm.invokeJob(attemptCtx, j)
+		attemptCancel()
+		if err == nil || run.Attempts > j.conf.Retries || runCtx.Err() != nil {
+			break
 		}
-		options := grpcurl.FormatOptions{
-			EmitJSONDefaultFields: true,
-			IncludeTextSeparator:  true,
-			AllowUnknownFields:    true,
+		j.logger.CInfow(jm.ctx, "Retrying job", "name", j.conf.Name, "attempt", run.Attempts+1, "backoff", backoff)
+		if !utils.SelectContextOrWait(runCtx, backoff) {
+			break
 		}
-		rf, formatter, err := grpcurl.RequestParserAndFormatter(
-			grpcurl.Format("json"),
-			descSource,
-			bytes.NewBuffer(argumentBytes),
-			options)
-		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "could not create parser and formatter for grpc requests", "error", err.Error())
-			return err
+		backoff *= 2
+	}
+	run.End = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+	} else if response != nil {
+		if result, marshalErr := json.Marshal(response); marshalErr == nil {
+			run.Result = string(result)
+			if len(run.Result) > maxResultLen {
+				run.Result = run.Result[:maxResultLen] + "..."
+			}
 		}
+	}
+	jm.recordRun(run)
 
-		buffer := bytes.NewBuffer(make([]byte, 0))
-		h := &grpcurl.DefaultEventHandler{
-			Out:            buffer,
-			Formatter:      formatter,
-			VerbosityLevel: 0,
-		}
-		jobLogger.CDebugw(jm.ctx, "Job triggered", "name", jc.Name)
-		grpcMethodCombined := grpcService + "." + grpcMethod
-		err = grpcurl.InvokeRPC(jm.ctx, descSource, jm.conn, grpcMethodCombined, nil, h, rf.Next)
-		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "Job failed", "name", jc.Name, "error", err.Error())
-			return err
-		} else if h.Status != nil && h.Status.Err() != nil {
-			// if job panics, it seems to be captured here.
-			jobLogger.CWarnw(jm.ctx, "Job failed", "name", jc.Name, "error", h.Status.Err())
-			return h.Status.Err()
+	if err == nil {
+		jm.startDependents(j.conf.Name, append(slices.Clone(chain), j.conf.Name))
+	}
+	return err
+}
+
+// startDependents starts the jobs configured to run after the named job. Jobs that already
+// ran as part of chain are not started again, so that jobs depending on each other in a
+// cycle do not run forever.
+func (jm *JobManager) startDependents(name string, chain []string) {
+	jm.jobsMu.Lock()
+	defer jm.jobsMu.Unlock()
+	for _, dependent := range jm.jobs {
+		if dependent.conf.After != name {
+			continue
 		}
-		response := map[string]any{}
-		err = json.Unmarshal(buffer.Bytes(), &response)
-		if err != nil {
-			jobLogger.CWarnw(jm.ctx, "Unmarshalling grpc response failed with error", "name", jc.Name,
-				"error", err.Error())
-			return err
+		if slices.Contains(chain, dependent.conf.Name) {
+			jm.logger.CWarnw(jm.ctx, "Not running job; jobs depend on each other in a cycle",
+				"name", dependent.conf.Name, "chain", chain)
+			continue
 		}
-		jobLogger.CDebugw(jm.ctx, "Job succeeded", "name", jc.Name, "response", response)
-		return nil
+		jm.workers.Add(func(context.Context) {
+			utils.UncheckedError(jm.runJob(dependent, config.JobTriggerAfter+name, chain))
+		})
 	}
+}
+
+// RunNow starts a run of the named job outside of its schedule. It does not wait for the run
+// to complete.
+func (jm *JobManager) RunNow(name string) error {
+	jm.jobsMu.Lock()
+	j, ok := jm.jobs[name]
+	jm.jobsMu.Unlock()
+	if !ok {
+		return errors.Errorf("no job named %q", name)
+	}
+	jm.logger.CInfow(jm.ctx, "Running job now", "name", name)
+	jm.workers.Add(func(context.Context) {
+		utils.UncheckedError(jm.runJob(j, config.JobTriggerManual, nil))
+	})
+	return nil
+}
 
+// createJobFunction returns a function that the job scheduler puts on its queue.
+func (jm *JobManager) createJobFunction(j *job) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
 		var err error
 		for {
@@ -305,29 +520,122 @@ func (jm *JobManager) createJobFunction(jc config.JobConfig, continuous bool) fu
 				return err
 			default:
 			}
-			err = jobFunc(ctx)
-			now := time.Now()
-			if jh, ok := jm.JobHistories.Load(jc.Name); ok {
-				if err != nil {
-					// this includes captured panics (from InvokeRPC).
-					jh.AddFailure(now)
-				} else {
-					jh.AddSuccess(now)
-				}
-			}
-			if !continuous {
+			err = jm.runJob(j, config.JobTriggerSchedule, nil)
+			if !j.continuous {
 				return err
 			}
 		}
 	}
 }
 
+// recordRun adds a completed run to the history of its job.
+func (jm *JobManager) recordRun(run config.JobRun) {
+	if jh, ok := jm.JobHistories.Load(run.Job); ok {
+		if run.Succeeded() {
+			jh.AddSuccess(run.End)
+		} else {
+			// this includes captured panics (from InvokeRPC).
+			jh.AddFailure(run.End)
+		}
+	}
+
+	jm.runsMu.Lock()
+	defer jm.runsMu.Unlock()
+	runs := append(jm.runs[run.Job], run)
+	if len(runs) > persistedRunsLength {
+		runs = slices.Clone(runs[len(runs)-persistedRunsLength:])
+	}
+	jm.runs[run.Job] = runs
+	jm.runsDirty = true
+}
+
+// History returns the recorded runs of the named job, newest first. Runs from before the
+// machine restarted are included if history is persisted.
+func (jm *JobManager) History(name string) []config.JobRun {
+	jm.runsMu.Lock()
+	runs := slices.Clone(jm.runs[name])
+	jm.runsMu.Unlock()
+	slices.Reverse(runs)
+	return runs
+}
+
+// loadHistory reads the persisted run history, if any.
+func (jm *JobManager) loadHistory() {
+	//nolint:gosec
+	f, err := os.Open(jm.historyPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			jm.logger.Warnw("failed to open job history", "error", err)
+		}
+		return
+	}
+	//nolint:errcheck
+	defer f.Close()
+	runs, err := config.ReadJobHistory(f)
+	if err != nil {
+		jm.logger.Warnw("failed to read job history", "path", jm.historyPath, "error", err)
+		return
+	}
+	jm.runs = runs
+}
+
+func (jm *JobManager) flushHistoryLoop(ctx context.Context) {
+	ticker := time.NewTicker(historyFlushInterval)
+	defer ticker.Stop()
+	for {
+		if !utils.SelectContextOrWaitChan(ctx, ticker.C) {
+			return
+		}
+		jm.flushHistory()
+	}
+}
+
+// flushHistory persists the run history if it changed since it was last persisted.
+func (jm *JobManager) flushHistory() {
+	if jm.historyPath == "" {
+		return
+	}
+	jm.runsMu.Lock()
+	if !jm.runsDirty {
+		jm.runsMu.Unlock()
+		return
+	}
+	data, err := json.Marshal(jm.runs)
+	jm.runsDirty = false
+	jm.runsMu.Unlock()
+	if err != nil {
+		jm.logger.Warnw("failed to marshal job history", "error", err)
+		return
+	}
+
+	// write to a temporary file first so that a crash cannot leave a partial history behind.
+	if err := os.MkdirAll(filepath.Dir(jm.historyPath), 0o700); err != nil {
+		jm.logger.Warnw("failed to create job history directory", "error", err)
+		return
+	}
+	tmpPath := jm.historyPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		jm.logger.Warnw("failed to write job history", "error", err)
+		return
+	}
+	if err := os.Rename(tmpPath, jm.historyPath); err != nil {
+		jm.logger.Warnw("failed to write job history", "error", err)
+	}
+}
+
 // removeJob removes the job from the scheduler and clears the internal map entry.
 func (jm *JobManager) removeJob(name string, verbose bool) {
-	jobID := jm.namesToJobIDs[name]
 	if verbose {
 		jm.logger.CInfow(jm.ctx, "Removing job", "name", name)
 	}
+	jm.jobsMu.Lock()
+	delete(jm.jobs, name)
+	jm.jobsMu.Unlock()
+	jobID, ok := jm.namesToJobIDs[name]
+	if !ok {
+		// jobs that only run after another job are not on the scheduler.
+		return
+	}
 	err := jm.scheduler.RemoveJob(jobID)
 	if err != nil {
 		jm.logger.CWarnw(jm.ctx, "Removing the job failed", "error", err.Error())
@@ -343,11 +651,42 @@ func (jm *JobManager) scheduleJob(jc config.JobConfig, verbose bool) {
 		return
 	}
 
-	var continuous bool
+	j := jm.newJob(jc)
+	jobLogger := j.logger
+
+	if _, ok := jm.JobHistories.Load(jc.Name); !ok {
+		jh := &JobHistory{
+			successTimes: ring.New(historyLength),
+			failureTimes: ring.New(historyLength),
+		}
+		// seed with the runs from before a restart, if any.
+		jm.runsMu.Lock()
+		for _, run := range jm.runs[jc.Name] {
+			if run.Succeeded() {
+				jh.AddSuccess(run.End)
+			} else {
+				jh.AddFailure(run.End)
+			}
+		}
+		jm.runsMu.Unlock()
+		jm.JobHistories.Store(jc.Name, jh)
+		jm.NumJobHistories.Add(1)
+	}
+
+	if jc.Schedule == "" {
+		// the job only runs after the job it depends on succeeds.
+		jm.jobsMu.Lock()
+		jm.jobs[jc.Name] = j
+		jm.jobsMu.Unlock()
+		if verbose {
+			jobLogger.CInfow(jm.ctx, "Job created", "name", jc.Name, "after", jc.After)
+		}
+		return
+	}
+
 	var jobDefinition gocron.JobDefinition
 	var jobOptions []gocron.JobOption
-	if strings.ToLower(jc.Schedule) == "continuous" {
-		continuous = true
+	if j.continuous {
 		// used with WithIntervalFromCompletion: if job unexpectedly exits, try to restart later.
 		// since we capture panics, this is largely unused, but helps reduce scheduler overhead.
 		jobDefinition = gocron.DurationJob(time.Second * 5)
@@ -356,22 +695,18 @@ func (jm *JobManager) scheduleJob(jc config.JobConfig, verbose bool) {
 			gocron.WithSingletonMode(gocron.LimitModeReschedule),
 			gocron.WithIntervalFromCompletion())
 	} else {
-		// Regular gocron-supported modes:
-		//
-		// WithSingletonMode option allows us to perform jobs on the same schedule
-		// sequentially. This will guarantee that there is only one instance of a particular
-		// job running at the same time. If a job reaches its schedule while the previous
-		// iteration is running, the job scheduler will treat them differently based on
-		// jobLimitMode.
-		// If the job is a CRON job, the run will be skipped if a previous
-		// invocation of a job is still running.
-		// If the job is a DURATION job, the new job will run as soon as the previous one
-		// finishes. This has no effect on the schedule (timer) of the job.
+		// Regular gocron-supported modes. Runs of the same job may overlap on the scheduler;
+		// runJob applies the concurrency policy of the job to decide what happens when a job
+		// reaches its schedule while the previous iteration is running:
+		// skip: the run is skipped. This is the default for CRON jobs.
+		// queue: the new run starts as soon as the previous one finishes. This has no effect
+		// on the schedule (timer) of the job. This is the default for DURATION jobs.
+		// replace: the previous run is cancelled and the new run starts once it has stopped.
 
 		// Examples:
 		// CRON job with a */5 * * * * * (every 5 seconds) timer and a 6s sleep as a function
 		// second 0: job starts
-		// second 5: job is asleep, the next iteration would be at second 10. Rescheduled
+		// second 5: job is asleep, the next iteration would be at second 10. Skipped
 		// second 6: job wakes up, finishes
 		// second 10: new iteration (2nd) of the job starts
 
@@ -391,10 +726,8 @@ func (jm *JobManager) scheduleJob(jc config.JobConfig, verbose bool) {
 			// TODO(RSDK-12757): exit if cron job is also invalid. Currently it's stored as an invalid string and validated at NewJob call.
 			withSeconds := len(strings.Split(jc.Schedule, " ")) >= 6
 			jobDefinition = gocron.CronJob(jc.Schedule, withSeconds)
-			jobOptions = append(jobOptions, gocron.WithSingletonMode(gocron.LimitModeReschedule))
 		} else {
 			jobDefinition = gocron.DurationJob(t)
-			jobOptions = append(jobOptions, gocron.WithSingletonMode(gocron.LimitModeWait))
 		}
 	}
 
@@ -402,18 +735,8 @@ func (jm *JobManager) scheduleJob(jc config.JobConfig, verbose bool) {
 		gocron.WithName(jc.Name),
 		gocron.WithContext(jm.ctx))
 
-	jobLogger := jm.logger.Sublogger(jc.Name)
-
-	if _, ok := jm.JobHistories.Load(jc.Name); !ok {
-		jm.JobHistories.Store(jc.Name, &JobHistory{
-			successTimes: ring.New(historyLength),
-			failureTimes: ring.New(historyLength),
-		})
-		jm.NumJobHistories.Add(1)
-	}
-
-	jobFunc := jm.createJobFunction(jc, continuous)
-	j, err := jm.scheduler.NewJob(
+	jobFunc := jm.createJobFunction(j)
+	scheduled, err := jm.scheduler.NewJob(
 		jobDefinition,
 		gocron.NewTask(jobFunc),
 		jobOptions...,
@@ -422,13 +745,16 @@ func (jm *JobManager) scheduleJob(jc config.JobConfig, verbose bool) {
 		jobLogger.CErrorw(jm.ctx, "Failed to create a new job", "name", jc.Name, "error", err.Error())
 		return
 	}
-	jobID := j.ID()
+	jobID := scheduled.ID()
 
 	if verbose {
 		jobLogger.CInfow(jm.ctx, "Job created", "name", jc.Name)
 	}
 
 	jm.namesToJobIDs[jc.Name] = jobID
+	jm.jobsMu.Lock()
+	jm.jobs[jc.Name] = j
+	jm.jobsMu.Unlock()
 }
 
 // UpdateJobs is called when the "jobs" part of the config gets updated. It updates
@@ -0,0 +1,66 @@
+package jobmanager
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
+)
+
+// runSteps runs the steps of a scripted job in order, passing the results of earlier steps
+// on to later ones, and returns the results of every step that ran.
+func (jm *JobManager) runSteps(ctx context.Context, j *job) (map[string]any, error) {
+	results := config.JobStepResults{}
+	for _, step := range j.conf.Steps {
+		if step.If != "" {
+			// conditions were checked when the config was validated.
+			cond, err := config.ParseJobCondition(step.If)
+			if err != nil {
+				return resultsResponse(results), err
+			}
+			if !cond.Evaluate(results) {
+				j.logger.CDebugw(ctx, "Skipping job step; condition does not hold", "step", step.Name, "if", step.If)
+				continue
+			}
+		}
+
+		response, err := jm.runStep(ctx, j, step, results)
+		if err != nil {
+			if step.OnError != config.JobStepErrorContinue || ctx.Err() != nil {
+				return resultsResponse(results), errors.Wrapf(err, "step %q failed", step.Name)
+			}
+			j.logger.CInfow(ctx, "Job step failed; continuing", "step", step.Name, "error", err)
+			results[step.Name] = map[string]any{config.JobStepErrorKey: err.Error()}
+			continue
+		}
+		if response == nil {
+			response = map[string]any{}
+		}
+		results[step.Name] = response
+	}
+	return resultsResponse(results), nil
+}
+
+// runStep resolves the arguments of a step against earlier results and makes its call.
+func (jm *JobManager) runStep(
+	ctx context.Context,
+	j *job,
+	step config.JobStep,
+	results config.JobStepResults,
+) (map[string]any, error) {
+	arguments, err := results.ResolveArguments(step.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	return jm.callResource(ctx, j.logger, j.conf.Name+"."+step.Name, step.Resource, step.Method, arguments)
+}
+
+// resultsResponse converts step results into the response of a scripted job.
+func resultsResponse(results config.JobStepResults) map[string]any {
+	response := make(map[string]any, len(results))
+	for name, result := range results {
+		response[name] = result
+	}
+	return response
+}
@@ -181,6 +181,10 @@ type LocalRobot interface {
 	// DOT reference: https://graphviz.org/doc/info/lang.html
 	ExportResourcesAsDot(index int) (resource.GetSnapshotInfo, error)
 
+	// ResourceGraph returns a structured snapshot of the resource graph that can be
+	// queried for dependencies and rendered as text, DOT or Mermaid.
+	ResourceGraph() resource.GraphInfo
+
 	// RestartAllowed returns whether the robot can safely be restarted.
 	RestartAllowed() bool
 
@@ -352,6 +356,18 @@ type MachineStatus struct {
 	Config      config.Revision
 	State       MachineState
 	JobStatuses map[string]JobStatus
+	// Remotes describe the connections to remote machines, by remote name. The robot API has
+	// no field for them, so over the network they are served by the RemoteService instead and
+	// are not filled in by the MachineStatus of a client.
+	Remotes map[string]RemoteStatus
+}
+
+// RemoteStatus describes the connection to a remote machine.
+type RemoteStatus struct {
+	Connected bool `json:"connected"`
+	// ActiveAddress is the address the remote is connected through, or was last connected
+	// through if it is disconnected.
+	ActiveAddress string `json:"active_address,omitempty"`
 }
 
 // JobStatus encapsulates status information about a single JobManager job.
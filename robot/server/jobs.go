@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
+	"go.viam.com/rdk/robot"
+)
+
+// jobRunner is implemented by robots that run the jobs in their config.
+type jobRunner interface {
+	RunJob(name string) error
+}
+
+type jobServer struct {
+	rdkpb.UnimplementedJobServiceServer
+	robot robot.Robot
+}
+
+// NewJobServer constructs a gRPC service server that runs the jobs of a robot on request.
+func NewJobServer(robot robot.Robot) rdkpb.JobServiceServer {
+	return &jobServer{robot: robot}
+}
+
+// RunJob runs the named job now, outside of its schedule.
+func (s *jobServer) RunJob(ctx context.Context, req *rdkpb.RunJobRequest) (*rdkpb.RunJobResponse, error) {
+	runner, ok := s.robot.(jobRunner)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot does not run jobs")
+	}
+	if err := runner.RunJob(req.GetName()); err != nil {
+		return nil, err
+	}
+	return &rdkpb.RunJobResponse{}, nil
+}
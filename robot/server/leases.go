@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// leaseRevoker is implemented by session managers that lease resources to sessions.
+type leaseRevoker interface {
+	RevokeLease(names ...resource.Name)
+}
+
+type leaseServer struct {
+	rdkpb.UnimplementedLeaseServiceServer
+	robot robot.Robot
+}
+
+// NewLeaseServer constructs a gRPC service server that manages the resource leases of a robot.
+func NewLeaseServer(robot robot.Robot) rdkpb.LeaseServiceServer {
+	return &leaseServer{robot: robot}
+}
+
+// RevokeLease forcibly takes exclusive control of the named resources away from whichever
+// session holds it. Who may call it is up to the robot's roles, like any other method.
+func (s *leaseServer) RevokeLease(ctx context.Context, req *rdkpb.RevokeLeaseRequest) (*rdkpb.RevokeLeaseResponse, error) {
+	revoker, ok := s.robot.SessionManager().(leaseRevoker)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot does not support leases")
+	}
+	names := make([]resource.Name, 0, len(req.GetNames()))
+	for _, nameStr := range req.GetNames() {
+		name, err := resource.NewFromString(nameStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid resource name %q in lease request", nameStr)
+		}
+		names = append(names, name)
+	}
+	revoker.RevokeLease(names...)
+	return &rdkpb.RevokeLeaseResponse{}, nil
+}
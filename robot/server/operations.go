@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.viam.com/rdk/operation"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
+	"go.viam.com/rdk/robot"
+)
+
+type operationServer struct {
+	rdkpb.UnimplementedOperationServiceServer
+	robot robot.Robot
+}
+
+// NewOperationServer constructs a gRPC service server that reports the operations of a robot.
+func NewOperationServer(robot robot.Robot) rdkpb.OperationServiceServer {
+	return &operationServer{robot: robot}
+}
+
+// GetOperationReport returns the running operations, other than the one making this request,
+// and the recently completed operations.
+func (s *operationServer) GetOperationReport(
+	ctx context.Context,
+	req *rdkpb.GetOperationReportRequest,
+) (*rdkpb.GetOperationReportResponse, error) {
+	me := operation.Get(ctx)
+	opMgr := s.robot.OperationManager()
+	report := operation.Report{Running: []operation.Summary{}, History: opMgr.History()}
+	for _, op := range opMgr.All() {
+		if op == me {
+			continue
+		}
+		report.Running = append(report.Running, op.Summary())
+	}
+	sort.Slice(report.Running, func(i, j int) bool {
+		return report.Running[i].Started.Before(report.Running[j].Started)
+	})
+	return OperationReportToProto(report), nil
+}
+
+var operationStatusToProto = map[operation.Status]rdkpb.OperationStatus{
+	operation.StatusSucceeded: rdkpb.OperationStatus_OPERATION_STATUS_SUCCEEDED,
+	operation.StatusFailed:    rdkpb.OperationStatus_OPERATION_STATUS_FAILED,
+	operation.StatusCancelled: rdkpb.OperationStatus_OPERATION_STATUS_CANCELLED,
+}
+
+// OperationReportToProto converts an operation.Report to its proto form.
+func OperationReportToProto(report operation.Report) *rdkpb.GetOperationReportResponse {
+	resp := &rdkpb.GetOperationReportResponse{
+		Running: make([]*rdkpb.RunningOperation, 0, len(report.Running)),
+		History: make([]*rdkpb.CompletedOperation, 0, len(report.History)),
+	}
+	for _, op := range report.Running {
+		resp.Running = append(resp.Running, &rdkpb.RunningOperation{
+			Id:        op.ID.String(),
+			SessionId: sessionIDToProto(op.SessionID),
+			Method:    op.Method,
+			Started:   timestamppb.New(op.Started),
+			Progress:  operationProgressToProto(op.Progress),
+		})
+	}
+	for _, op := range report.History {
+		resp.History = append(resp.History, &rdkpb.CompletedOperation{
+			Id:        op.ID.String(),
+			SessionId: sessionIDToProto(op.SessionID),
+			Method:    op.Method,
+			Started:   timestamppb.New(op.Started),
+			Ended:     timestamppb.New(op.Ended),
+			Status:    operationStatusToProto[op.Status],
+			Error:     op.Error,
+			Progress:  operationProgressToProto(op.Progress),
+		})
+	}
+	return resp
+}
+
+// OperationReportFromProto converts the proto form of an operation report to an
+// operation.Report.
+func OperationReportFromProto(resp *rdkpb.GetOperationReportResponse) (operation.Report, error) {
+	report := operation.Report{
+		Running: make([]operation.Summary, 0, len(resp.GetRunning())),
+		History: make([]operation.CompletedOperation, 0, len(resp.GetHistory())),
+	}
+	for _, op := range resp.GetRunning() {
+		id, sessionID, err := operationIDsFromProto(op.GetId(), op.SessionId)
+		if err != nil {
+			return operation.Report{}, err
+		}
+		report.Running = append(report.Running, operation.Summary{
+			ID:        id,
+			SessionID: sessionID,
+			Method:    op.GetMethod(),
+			Started:   op.GetStarted().AsTime(),
+			Progress:  operationProgressFromProto(op.GetProgress()),
+		})
+	}
+	for _, op := range resp.GetHistory() {
+		id, sessionID, err := operationIDsFromProto(op.GetId(), op.SessionId)
+		if err != nil {
+			return operation.Report{}, err
+		}
+		var status operation.Status
+		for s, pbStatus := range operationStatusToProto {
+			if pbStatus == op.GetStatus() {
+				status = s
+			}
+		}
+		report.History = append(report.History, operation.CompletedOperation{
+			ID:        id,
+			SessionID: sessionID,
+			Method:    op.GetMethod(),
+			Started:   op.GetStarted().AsTime(),
+			Ended:     op.GetEnded().AsTime(),
+			Status:    status,
+			Error:     op.GetError(),
+			Progress:  operationProgressFromProto(op.GetProgress()),
+		})
+	}
+	return report, nil
+}
+
+func operationProgressToProto(progress operation.Progress) *rdkpb.OperationProgress {
+	return &rdkpb.OperationProgress{
+		Current: int64(progress.Current),
+		Total:   int64(progress.Total),
+		Message: progress.Message,
+	}
+}
+
+func operationProgressFromProto(progress *rdkpb.OperationProgress) operation.Progress {
+	return operation.Progress{
+		Current: int(progress.GetCurrent()),
+		Total:   int(progress.GetTotal()),
+		Message: progress.GetMessage(),
+	}
+}
+
+func sessionIDToProto(id uuid.UUID) *string {
+	if id == uuid.Nil {
+		return nil
+	}
+	idStr := id.String()
+	return &idStr
+}
+
+func operationIDsFromProto(idStr string, sessionIDStr *string) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid operation id %q: %w", idStr, err)
+	}
+	if sessionIDStr == nil {
+		return id, uuid.Nil, nil
+	}
+	sessionID, err := uuid.Parse(*sessionIDStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid session id %q: %w", *sessionIDStr, err)
+	}
+	return id, sessionID, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
+	"go.viam.com/rdk/robot"
+)
+
+type remoteServer struct {
+	rdkpb.UnimplementedRemoteServiceServer
+	robot robot.Robot
+}
+
+// NewRemoteServer constructs a gRPC service server that reports the connections of a robot to
+// its remotes.
+func NewRemoteServer(robot robot.Robot) rdkpb.RemoteServiceServer {
+	return &remoteServer{robot: robot}
+}
+
+// GetRemoteStatus returns the status of the connection to each remote of the robot.
+func (s *remoteServer) GetRemoteStatus(
+	ctx context.Context,
+	req *rdkpb.GetRemoteStatusRequest,
+) (*rdkpb.GetRemoteStatusResponse, error) {
+	mStatus, err := s.robot.MachineStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &rdkpb.GetRemoteStatusResponse{Remotes: make(map[string]*rdkpb.RemoteStatus, len(mStatus.Remotes))}
+	for name, remote := range mStatus.Remotes {
+		resp.Remotes[name] = &rdkpb.RemoteStatus{
+			Connected:     remote.Connected,
+			ActiveAddress: remote.ActiveAddress,
+		}
+	}
+	return resp, nil
+}
@@ -540,6 +540,14 @@ func (s *Server) GetMachineStatus(ctx context.Context, _ *pb.GetMachineStatusReq
 			pbResStatus.State = pb.ResourceStatus_STATE_CONFIGURING
 		case resource.NodeStateReady:
 			pbResStatus.State = pb.ResourceStatus_STATE_READY
+			// a ready resource whose health checks are failing is reported as unhealthy
+			// even though it remains available.
+			if resStatus.Health.State == resource.HealthStateUnhealthy {
+				pbResStatus.State = pb.ResourceStatus_STATE_UNHEALTHY
+				if resStatus.Health.Error != nil {
+					pbResStatus.Error = resStatus.Health.Error.Error()
+				}
+			}
 		case resource.NodeStateRemoving:
 			pbResStatus.State = pb.ResourceStatus_STATE_REMOVING
 		case resource.NodeStateUnhealthy:
@@ -18,7 +18,9 @@ import (
 	armpb "go.viam.com/api/component/arm/v1"
 	pb "go.viam.com/api/robot/v1"
 	"go.viam.com/test"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.viam.com/rdk/cloud"
@@ -26,6 +28,7 @@ import (
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/operation"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/protoutils"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
@@ -559,6 +562,67 @@ func TestServer(t *testing.T) {
 		}
 	})
 
+	t.Run("GetOperationReport", func(t *testing.T) {
+		logger := logging.NewTestLogger(t)
+		injectRobot := &inject.Robot{}
+		injectRobot.LoggerFunc = func() logging.Logger {
+			return logger
+		}
+		opServer := server.NewOperationServer(injectRobot)
+		report := func() operation.Report {
+			t.Helper()
+			resp, err := opServer.GetOperationReport(context.Background(), &rdkpb.GetOperationReportRequest{})
+			test.That(t, err, test.ShouldBeNil)
+			report, err := server.OperationReportFromProto(resp)
+			test.That(t, err, test.ShouldBeNil)
+			return report
+		}
+		test.That(t, report().Running, test.ShouldBeEmpty)
+
+		opCtx, cancel := injectRobot.OperationManager().Create(context.Background(), "something", nil)
+		operation.SetProgress(opCtx, operation.Progress{Current: 2, Total: 5, Message: "moving"})
+		running := report().Running
+		test.That(t, running, test.ShouldHaveLength, 1)
+		test.That(t, running[0].ID, test.ShouldEqual, operation.Get(opCtx).ID)
+		test.That(t, running[0].Progress, test.ShouldResemble, operation.Progress{Current: 2, Total: 5, Message: "moving"})
+
+		// operations that reported progress are remembered once they complete.
+		cancel()
+		completed := report()
+		test.That(t, completed.Running, test.ShouldBeEmpty)
+		test.That(t, completed.History, test.ShouldHaveLength, 1)
+		test.That(t, completed.History[0].ID, test.ShouldEqual, running[0].ID)
+		test.That(t, completed.History[0].Progress.Current, test.ShouldEqual, 2)
+		test.That(t, completed.History[0].Status, test.ShouldEqual, operation.StatusSucceeded)
+	})
+
+	t.Run("RevokeLease", func(t *testing.T) {
+		logger := logging.NewTestLogger(t)
+		injectRobot := &inject.Robot{}
+		injectRobot.LoggerFunc = func() logging.Logger {
+			return logger
+		}
+		leaseServer := server.NewLeaseServer(injectRobot)
+		_, err := leaseServer.RevokeLease(context.Background(), &rdkpb.RevokeLeaseRequest{})
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unimplemented)
+
+		sm := robot.NewSessionManager(injectRobot, config.DefaultSessionHeartbeatWindow)
+		defer sm.Close()
+		injectRobot.SessMgr = sm
+		sess, err := sm.Start(context.Background(), "")
+		test.That(t, err, test.ShouldBeNil)
+		arm1 := arm.Named("arm1")
+		test.That(t, sm.AcquireLease(sess.ID(), arm1), test.ShouldBeNil)
+
+		_, err = leaseServer.RevokeLease(context.Background(), &rdkpb.RevokeLeaseRequest{Names: []string{"not a name"}})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, sm.Leases(), test.ShouldHaveLength, 1)
+
+		_, err = leaseServer.RevokeLease(context.Background(), &rdkpb.RevokeLeaseRequest{Names: []string{arm1.String()}})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, sm.Leases(), test.ShouldBeEmpty)
+	})
+
 	t.Run("GetSessions", func(t *testing.T) {
 		sessMgr := &sessionManager{}
 		injectRobot := &inject.Robot{SessMgr: sessMgr}
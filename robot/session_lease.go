@@ -0,0 +1,144 @@
+package robot
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/session"
+)
+
+// AcquireLease gives the session exclusive control of the named resources. While a
+// session holds a lease, safety monitored calls to the resource from any other session,
+// or from no session, fail with a session.LeaseHeldError. Leases are released when the
+// session expires. Either all of the resources are leased or none are.
+func (m *SessionManager) AcquireLease(id uuid.UUID, names ...resource.Name) error {
+	m.sessionResourceMu.Lock()
+	defer m.sessionResourceMu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return session.ErrNoSession
+	}
+	for _, name := range names {
+		if err := m.checkLeaseLocked(id, name); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		m.leases[name] = id
+	}
+	return nil
+}
+
+// ReleaseLease gives up the session's exclusive control of the named resources. Resources
+// not leased by the session are ignored.
+func (m *SessionManager) ReleaseLease(id uuid.UUID, names ...resource.Name) {
+	m.sessionResourceMu.Lock()
+	defer m.sessionResourceMu.Unlock()
+	for _, name := range names {
+		if m.leases[name] == id {
+			delete(m.leases, name)
+		}
+	}
+}
+
+// RevokeLease forcibly takes exclusive control of the named resources away from whichever
+// session holds it.
+func (m *SessionManager) RevokeLease(names ...resource.Name) {
+	m.sessionResourceMu.Lock()
+	defer m.sessionResourceMu.Unlock()
+	for _, name := range names {
+		if id, ok := m.leases[name]; ok {
+			m.logger.Infow("revoked lease", "resource", name, "session_id", id)
+			delete(m.leases, name)
+		}
+	}
+}
+
+// Leases returns the resources currently leased and the sessions that hold them.
+func (m *SessionManager) Leases() map[resource.Name]uuid.UUID {
+	m.sessionResourceMu.RLock()
+	defer m.sessionResourceMu.RUnlock()
+	leases := make(map[resource.Name]uuid.UUID, len(m.leases))
+	for name, id := range m.leases {
+		if _, ok := m.sessions[id]; ok {
+			leases[name] = id
+		}
+	}
+	return leases
+}
+
+// checkLease returns a session.LeaseHeldError if the resource is leased by an active
+// session other than the given one. id may be uuid.Nil for requests without a session.
+func (m *SessionManager) checkLease(id uuid.UUID, name resource.Name) error {
+	m.sessionResourceMu.RLock()
+	defer m.sessionResourceMu.RUnlock()
+	return m.checkLeaseLocked(id, name)
+}
+
+func (m *SessionManager) checkLeaseLocked(id uuid.UUID, name resource.Name) error {
+	holder, ok := m.leases[name]
+	if !ok || holder == id {
+		return nil
+	}
+	// the lease of an expired session is released by the expire loop shortly.
+	if _, active := m.sessions[holder]; !active {
+		return nil
+	}
+	return &session.LeaseHeldError{Name: name, SessionID: holder}
+}
+
+// handleLeaseMetadata acquires and releases leases for the session of an incoming request
+// as requested by its metadata.
+func (m *SessionManager) handleLeaseMetadata(ctx context.Context) error {
+	meta, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	acquire, release := meta.Get(session.LeaseAcquireMetadataKey), meta.Get(session.LeaseReleaseMetadataKey)
+	if len(acquire) == 0 && len(release) == 0 {
+		return nil
+	}
+
+	parseNames := func(values []string) ([]resource.Name, error) {
+		names := make([]resource.Name, 0, len(values))
+		for _, value := range values {
+			name, err := resource.NewFromString(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid resource name %q in lease request", value)
+			}
+			names = append(names, name)
+		}
+		return names, nil
+	}
+
+	sessID, err := sessionFromMetadata(meta)
+	if err != nil {
+		return err
+	}
+	if sessID == uuid.Nil {
+		return errors.New("a session is required to acquire or release a lease")
+	}
+	authEntity, _ := rpc.ContextAuthEntity(ctx)
+	if _, err := m.FindByID(ctx, sessID, authEntity.Entity); err != nil {
+		return err
+	}
+	if len(release) != 0 {
+		names, err := parseNames(release)
+		if err != nil {
+			return err
+		}
+		m.ReleaseLease(sessID, names...)
+	}
+	if len(acquire) != 0 {
+		names, err := parseNames(acquire)
+		if err != nil {
+			return err
+		}
+		return m.AcquireLease(sessID, names...)
+	}
+	return nil
+}
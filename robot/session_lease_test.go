@@ -0,0 +1,149 @@
+package robot_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/session"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestSessionManagerLeases(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	r := &inject.Robot{}
+	r.LoggerFunc = func() logging.Logger {
+		return logger
+	}
+
+	sm := robot.NewSessionManager(r, config.DefaultSessionHeartbeatWindow)
+	defer sm.Close()
+
+	fooSess, err := sm.Start(ctx, "foo")
+	test.That(t, err, test.ShouldBeNil)
+	barSess, err := sm.Start(ctx, "bar")
+	test.That(t, err, test.ShouldBeNil)
+
+	arm1 := arm.Named("arm1")
+	base1 := base.Named("base1")
+
+	// leasing requires an active session.
+	test.That(t, sm.AcquireLease(uuid.New(), arm1), test.ShouldBeError, session.ErrNoSession)
+
+	test.That(t, sm.AcquireLease(fooSess.ID(), arm1), test.ShouldBeNil)
+	// acquiring again is fine.
+	test.That(t, sm.AcquireLease(fooSess.ID(), arm1), test.ShouldBeNil)
+
+	// leasing is all or nothing.
+	err = sm.AcquireLease(barSess.ID(), base1, arm1)
+	test.That(t, err, test.ShouldNotBeNil)
+	var leaseErr *session.LeaseHeldError
+	test.That(t, err, test.ShouldHaveSameTypeAs, leaseErr)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.FailedPrecondition)
+	test.That(t, sm.Leases(), test.ShouldResemble, map[resource.Name]uuid.UUID{arm1: fooSess.ID()})
+
+	// releasing a lease held by another session does nothing.
+	sm.ReleaseLease(barSess.ID(), arm1)
+	test.That(t, sm.Leases(), test.ShouldResemble, map[resource.Name]uuid.UUID{arm1: fooSess.ID()})
+
+	sm.ReleaseLease(fooSess.ID(), arm1)
+	test.That(t, sm.Leases(), test.ShouldBeEmpty)
+
+	test.That(t, sm.AcquireLease(barSess.ID(), base1, arm1), test.ShouldBeNil)
+	sm.RevokeLease(arm1)
+	test.That(t, sm.Leases(), test.ShouldResemble, map[resource.Name]uuid.UUID{base1: barSess.ID()})
+	test.That(t, sm.AcquireLease(fooSess.ID(), arm1), test.ShouldBeNil)
+}
+
+func TestSessionManagerLeaseMetadata(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	r := &inject.Robot{}
+	r.LoggerFunc = func() logging.Logger {
+		return logger
+	}
+	r.ResourceRPCAPIsFunc = func() []resource.RPCAPI {
+		return nil
+	}
+
+	sm := robot.NewSessionManager(r, config.DefaultSessionHeartbeatWindow)
+	defer sm.Close()
+
+	fooSess, err := sm.Start(ctx, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	arm1 := arm.Named("arm1")
+	info := &grpc.UnaryServerInfo{FullMethod: "/viam.robot.v1.RobotService/SendSessionHeartbeat"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	}
+	call := func(kv ...string) error {
+		callCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(kv...))
+		_, err := sm.UnaryServerInterceptor(callCtx, nil, info, handler)
+		return err
+	}
+
+	// acquiring requires a session.
+	test.That(t, call(session.LeaseAcquireMetadataKey, arm1.String()), test.ShouldNotBeNil)
+	test.That(t, call(session.IDMetadataKey, fooSess.ID().String(), session.LeaseAcquireMetadataKey, "not a name"),
+		test.ShouldNotBeNil)
+
+	test.That(t, call(session.IDMetadataKey, fooSess.ID().String(), session.LeaseAcquireMetadataKey, arm1.String()),
+		test.ShouldBeNil)
+	test.That(t, sm.Leases(), test.ShouldResemble, map[resource.Name]uuid.UUID{arm1: fooSess.ID()})
+
+	test.That(t, call(session.IDMetadataKey, fooSess.ID().String(), session.LeaseReleaseMetadataKey, arm1.String()),
+		test.ShouldBeNil)
+	test.That(t, sm.Leases(), test.ShouldBeEmpty)
+
+	// another session cannot release the lease.
+	barSess, err := sm.Start(ctx, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, call(session.IDMetadataKey, fooSess.ID().String(), session.LeaseAcquireMetadataKey, arm1.String()),
+		test.ShouldBeNil)
+	test.That(t, call(session.IDMetadataKey, barSess.ID().String(), session.LeaseReleaseMetadataKey, arm1.String()),
+		test.ShouldBeNil)
+	test.That(t, sm.Leases(), test.ShouldResemble, map[resource.Name]uuid.UUID{arm1: fooSess.ID()})
+}
+
+func TestSessionManagerLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	r := &inject.Robot{}
+	r.LoggerFunc = func() logging.Logger {
+		return logger
+	}
+
+	sm := robot.NewSessionManager(r, 50*time.Millisecond)
+	defer sm.Close()
+
+	fooSess, err := sm.Start(ctx, "foo")
+	test.That(t, err, test.ShouldBeNil)
+	arm1 := arm.Named("arm1")
+	test.That(t, sm.AcquireLease(fooSess.ID(), arm1), test.ShouldBeNil)
+
+	// without heartbeats the session expires and its lease goes with it.
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, sm.Leases(), test.ShouldBeEmpty)
+	})
+
+	barSess, err := sm.Start(ctx, "bar")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, sm.AcquireLease(barSess.ID(), arm1), test.ShouldBeNil)
+}
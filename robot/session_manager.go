@@ -22,6 +22,7 @@ func NewSessionManager(robot Robot, heartbeatWindow time.Duration) *SessionManag
 		logger:            robot.Logger().Sublogger("networking.session_manager"),
 		sessions:          map[uuid.UUID]*session.Session{},
 		resourceToSession: map[resource.Name]uuid.UUID{},
+		leases:            map[resource.Name]uuid.UUID{},
 	}
 	m.workers = utils.NewBackgroundStoppableWorkers(m.expireLoop)
 	return m
@@ -39,6 +40,9 @@ type SessionManager struct {
 
 	resourceToSession map[resource.Name]uuid.UUID
 
+	// leases maps resources to the session that has exclusive control of them.
+	leases map[resource.Name]uuid.UUID
+
 	workers *utils.StoppableWorkers
 }
 
@@ -88,6 +92,11 @@ func (m *SessionManager) expireLoop(ctx context.Context) {
 			for id := range toDelete {
 				delete(m.sessions, id)
 			}
+			for res, id := range m.leases {
+				if _, ok := toDelete[id]; ok {
+					delete(m.leases, res)
+				}
+			}
 
 			if len(toStop) == 0 {
 				return
@@ -115,10 +124,23 @@ func (m *SessionManager) expireLoop(ctx context.Context) {
 						return
 					}
 
-					if actuator, ok := res.(resource.Actuator); ok {
-						if err := actuator.Stop(ctx, nil); err != nil {
-							resourceErrs = append(resourceErrs, err)
+					safeStopper, isSafeStopper := res.(resource.SafeStopper)
+					actuator, isActuator := res.(resource.Actuator)
+					if !isSafeStopper && !isActuator {
+						return
+					}
+					stop := func(ctx context.Context) error {
+						if isSafeStopper {
+							err := safeStopper.SafeStop(ctx, nil)
+							if !isActuator || !errors.Is(err, resource.ErrSafeStopUnsupported) {
+								return err
+							}
 						}
+						return actuator.Stop(ctx, nil)
+					}
+					// a hung resource must not hold up stopping the others or expiring later sessions.
+					if err := resource.StopWithTimeout(ctx, resName, resource.DefaultSafeStopTimeout, stop); err != nil {
+						resourceErrs = append(resourceErrs, err)
 					}
 				}()
 				if serverClosing {
@@ -153,6 +153,9 @@ func (m *SessionManager) UnaryServerInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	if err := m.handleLeaseMetadata(ctx); err != nil {
+		return nil, err
+	}
 	if _, _, isMonitored := m.safetyMonitoredTypeAndMethod(info.FullMethod); !isMonitored {
 		return handler(ctx, req)
 	}
@@ -172,6 +175,9 @@ func (m *SessionManager) StreamServerInterceptor(
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler,
 ) error {
+	if err := m.handleLeaseMetadata(ss.Context()); err != nil {
+		return err
+	}
 	if _, _, isMonitored := m.safetyMonitoredTypeAndMethod(info.FullMethod); !isMonitored {
 		return handler(srv, ss)
 	}
@@ -209,13 +215,17 @@ func associateSession(
 	meta, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		m.logger.CWarnw(ctx, "failed to pull metadata from context", "method", method)
-		return ctx, nil
+		return ctx, m.checkLease(uuid.Nil, safetyMonitoredResourceName)
 	}
 	sessID, err = sessionFromMetadata(meta)
 	if err != nil {
 		m.logger.CWarnw(ctx, "failed to get session id from metadata", "error", err)
 		return ctx, err
 	}
+	// Only the session holding a lease on a resource may make safety monitored calls to it.
+	if err := m.checkLease(sessID, safetyMonitoredResourceName); err != nil {
+		return nil, err
+	}
 	if sessID == uuid.Nil {
 		return ctx, nil
 	}
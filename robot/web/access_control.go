@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.viam.com/utils/rpc"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils/ssync"
+)
+
+// AccessController enforces the roles in config.AuthConfig on incoming requests. Every
+// unary and streaming request from an authenticated entity bound to a role is checked
+// against that role's rules before it is handled. Denied requests are logged and counted.
+type AccessController struct {
+	logger logging.Logger
+
+	// auth is replaced whenever the web server is (re)started.
+	auth atomic.Pointer[config.AuthConfig]
+
+	serviceAPIs serviceAPICache
+
+	// denied maps "<role>.<service short name>/<method>" to how many requests were denied.
+	denied ssync.Map[string, *atomic.Int64]
+}
+
+// setAuth sets the auth config whose roles are enforced.
+func (ac *AccessController) setAuth(auth config.AuthConfig) {
+	ac.auth.Store(&auth)
+}
+
+// PermissionDeniedError is returned when the role of the calling entity does not allow
+// the requested method.
+type PermissionDeniedError struct {
+	role, method, resource string
+}
+
+func (e PermissionDeniedError) Error() string {
+	if e.resource != "" {
+		return fmt.Sprintf("role %q is not allowed to call %v on resource %v", e.role, e.method, e.resource)
+	}
+	return fmt.Sprintf("role %q is not allowed to call %v", e.role, e.method)
+}
+
+// GRPCStatus allows this error to be converted to a [status.Status].
+func (e PermissionDeniedError) GRPCStatus() *status.Status {
+	return status.New(codes.PermissionDenied, e.Error())
+}
+
+// isExemptService returns whether the service is part of connection establishment and must
+// remain callable regardless of role.
+func isExemptService(service string) bool {
+	return strings.HasPrefix(service, "proto.rpc.") || strings.HasPrefix(service, "grpc.")
+}
+
+// serviceAPICache caches which resource API is served by a gRPC service.
+type serviceAPICache struct {
+	serviceToAPI ssync.Map[string, string]
+}
+
+// apiForService returns the resource API served by the given gRPC service, if any.
+func (c *serviceAPICache) apiForService(service string) string {
+	if api, ok := c.serviceToAPI.Load(service); ok {
+		return api
+	}
+	var api string
+	for regAPI, reg := range resource.RegisteredAPIs() {
+		if reg.RPCServiceDesc != nil && reg.RPCServiceDesc.ServiceName == service {
+			api = regAPI.String()
+			break
+		}
+	}
+	// modular APIs may be registered later, so only cache services that were found.
+	if api != "" {
+		c.serviceToAPI.Store(service, api)
+	}
+	return api
+}
+
+// roleFor returns the role of the entity making a request, if any. Requests from entities
+// that are not bound to a role are not restricted.
+func (ac *AccessController) roleFor(ctx context.Context) (*config.RoleConfig, string) {
+	auth := ac.auth.Load()
+	if auth == nil || len(auth.Roles) == 0 {
+		return nil, ""
+	}
+	// without authentication there is no entity to look up a role for.
+	entity, ok := rpc.ContextAuthEntity(ctx)
+	if !ok {
+		return nil, ""
+	}
+	return auth.RoleForEntity(entity.Entity), entity.Entity
+}
+
+// authorize returns an error if the entity making the request is not allowed to call the
+// given method with the given request.
+func (ac *AccessController) authorize(ctx context.Context, fullMethod string, req any) error {
+	split := strings.SplitN(fullMethod, "/", 3)
+	if len(split) != 3 || isExemptService(split[1]) {
+		return nil
+	}
+	service, method := split[1], split[2]
+
+	role, entity := ac.roleFor(ctx)
+	if role == nil {
+		return nil
+	}
+
+	var resourceName string
+	if req != nil {
+		resourceName = resource.GetResourceNameFromRequest(service, method, req)
+	}
+	if role.Allows(ac.serviceAPIs.apiForService(service), service, method, resourceName) {
+		return nil
+	}
+	return ac.deny(ctx, entity, role, service, method, resourceName)
+}
+
+// deny counts and logs a denied request and returns the error to respond with.
+func (ac *AccessController) deny(
+	ctx context.Context,
+	entity string,
+	role *config.RoleConfig,
+	service, method, resourceName string,
+) error {
+	shortPath := service[strings.LastIndexByte(service, byte('.'))+1:] + "/" + method
+	counter, ok := ac.denied.Load(role.Name + "." + shortPath)
+	if !ok {
+		counter, _ = ac.denied.LoadOrStore(role.Name+"."+shortPath, &atomic.Int64{})
+	}
+	counter.Add(1)
+	ac.logger.CWarnw(ctx, "Denied request not allowed by role",
+		"entity", entity, "role", role.Name, "method", "/"+service+"/"+method, "resource", resourceName)
+	return &PermissionDeniedError{role: role.Name, method: shortPath, resource: resourceName}
+}
+
+// UnaryInterceptor returns an incoming server interceptor that rejects requests that the
+// calling entity's role does not allow.
+func (ac *AccessController) UnaryInterceptor(
+	ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+) (any, error) {
+	if err := ac.authorize(ctx, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor returns an incoming server interceptor that rejects streams that the
+// calling entity's role does not allow. Streams are checked when they open unless the role
+// has rules for the method that depend on the targeted resource, which is only known once
+// the first message is received, in which case the stream is checked on its first message.
+func (ac *AccessController) StreamInterceptor(
+	srv any,
+	ss googlegrpc.ServerStream,
+	info *googlegrpc.StreamServerInfo,
+	handler googlegrpc.StreamHandler,
+) error {
+	if !ac.dependsOnResource(ss.Context(), info.FullMethod) {
+		if err := ac.authorize(ss.Context(), info.FullMethod, nil); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+	return handler(srv, &streamWithAccessControl{ServerStream: ss, ac: ac, fullMethod: info.FullMethod})
+}
+
+// dependsOnResource returns whether authorizing a request to the given method requires
+// knowing which resource it targets.
+func (ac *AccessController) dependsOnResource(ctx context.Context, fullMethod string) bool {
+	split := strings.SplitN(fullMethod, "/", 3)
+	if len(split) != 3 || isExemptService(split[1]) {
+		return false
+	}
+	role, _ := ac.roleFor(ctx)
+	if role == nil {
+		return false
+	}
+	return role.DependsOnResource(ac.serviceAPIs.apiForService(split[1]), split[1], split[2])
+}
+
+type streamWithAccessControl struct {
+	googlegrpc.ServerStream
+	ac         *AccessController
+	fullMethod string
+
+	checkOnce sync.Once
+	checkErr  error
+}
+
+// RecvMsg checks the stream against the calling entity's role upon receiving the first
+// message from the client. Once denied, every subsequent message is rejected as well.
+func (s *streamWithAccessControl) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.checkOnce.Do(func() {
+		s.checkErr = s.ac.authorize(s.Context(), s.fullMethod, m)
+	})
+	return s.checkErr
+}
+
+// Stats satisfies the ftdc.Statser interface and will return a copy of the denial counters.
+func (ac *AccessController) Stats() any {
+	ret := make(map[string]int64)
+	for key, counter := range ac.denied.Range {
+		ret[key+".denied"] = counter.Load()
+	}
+	return ret
+}
@@ -0,0 +1,187 @@
+package web
+
+import (
+	"context"
+	"testing"
+
+	armpb "go.viam.com/api/component/arm/v1"
+	robotpb "go.viam.com/api/robot/v1"
+	shellpb "go.viam.com/api/service/shell/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
+)
+
+func TestAccessControllerUnaryInterceptor(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ac := &AccessController{logger: logger}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	call := func(entity string, fullMethod string, req any) error {
+		ctx := context.Background()
+		if entity != "" {
+			ctx = rpc.ContextWithAuthEntity(ctx, rpc.EntityInfo{Entity: entity})
+		}
+		_, err := ac.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+	const (
+		moveToPosition = "/viam.component.arm.v1.ArmService/MoveToPosition"
+		stop           = "/viam.component.arm.v1.ArmService/Stop"
+		resourceNames  = "/viam.robot.v1.RobotService/ResourceNames"
+	)
+
+	// without roles everything is allowed.
+	test.That(t, call("contractor", moveToPosition, &armpb.MoveToPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+
+	ac.setAuth(config.AuthConfig{
+		Roles: []config.RoleConfig{{
+			Name:     "operator",
+			Entities: []string{"contractor"},
+			Allow: []config.RoleRule{
+				{Service: "viam.robot.v1.RobotService", Methods: []string{"ResourceNames"}},
+				{API: "rdk:component:arm", Methods: []string{"Stop"}},
+			},
+		}},
+	})
+
+	test.That(t, call("contractor", stop, &armpb.StopRequest{Name: "arm1"}), test.ShouldBeNil)
+	test.That(t, call("contractor", resourceNames, &robotpb.ResourceNamesRequest{}), test.ShouldBeNil)
+
+	err := call("contractor", moveToPosition, &armpb.MoveToPositionRequest{Name: "arm1"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `role "operator" is not allowed to call ArmService/MoveToPosition`)
+
+	// entities without a role and unauthenticated requests are not restricted.
+	test.That(t, call("admin", moveToPosition, &armpb.MoveToPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+	test.That(t, call("", moveToPosition, &armpb.MoveToPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+	// running jobs must be allowed like any other method.
+	err = call("contractor", "/rdk.robot.v1.JobService/RunJob", &rdkpb.RunJobRequest{Name: "calibrate"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `role "operator" is not allowed to call JobService/RunJob`)
+	// connection establishment is never restricted.
+	test.That(t, call("contractor", "/proto.rpc.v1.AuthService/Authenticate", nil), test.ShouldBeNil)
+
+	test.That(t, ac.Stats(), test.ShouldResemble, map[string]int64{
+		"operator.ArmService/MoveToPosition.denied": 1,
+		"operator.JobService/RunJob.denied":         1,
+	})
+}
+
+func TestAccessControllerRevokeLease(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ac := &AccessController{logger: logger}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	revoke := func(entity string) error {
+		ctx := rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: entity})
+		_, err := ac.UnaryInterceptor(ctx, &rdkpb.RevokeLeaseRequest{Names: []string{"rdk:component:arm/arm1"}},
+			&grpc.UnaryServerInfo{FullMethod: "/rdk.robot.v1.LeaseService/RevokeLease"}, handler)
+		return err
+	}
+
+	// without roles anyone may revoke leases.
+	test.That(t, revoke("guest"), test.ShouldBeNil)
+
+	ac.setAuth(config.AuthConfig{
+		Roles: []config.RoleConfig{
+			{
+				Name:     "operator",
+				Entities: []string{"contractor"},
+				Allow:    []config.RoleRule{{Service: "rdk.robot.v1.LeaseService"}},
+			},
+			{
+				Name:     "viewer",
+				Entities: []string{"guest"},
+				Allow:    []config.RoleRule{{Service: "viam.robot.v1.RobotService"}},
+			},
+		},
+	})
+
+	test.That(t, revoke("contractor"), test.ShouldBeNil)
+	// entities without a role are not restricted.
+	test.That(t, revoke("admin"), test.ShouldBeNil)
+
+	err := revoke("guest")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `role "viewer" is not allowed to call LeaseService/RevokeLease`)
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	msg proto.Message
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.msg)
+	return nil
+}
+
+func TestAccessControllerStreamInterceptor(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	ac := &AccessController{logger: logger}
+	ac.setAuth(config.AuthConfig{
+		Roles: []config.RoleConfig{{
+			Name:     "operator",
+			Entities: []string{"contractor"},
+			Allow: []config.RoleRule{
+				{Service: "viam.robot.v1.RobotService"},
+				{API: "rdk:component:arm", Resources: []string{"left_*"}},
+			},
+		}},
+	})
+
+	stream := func(entity, fullMethod string, msg proto.Message) (bool, error) {
+		ctx := rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: entity})
+		var opened bool
+		handler := func(srv any, ss grpc.ServerStream) error {
+			opened = true
+			return ss.RecvMsg(proto.Clone(msg))
+		}
+		err := ac.StreamInterceptor(nil, &fakeServerStream{ctx: ctx, msg: msg},
+			&grpc.StreamServerInfo{FullMethod: fullMethod}, handler)
+		return opened, err
+	}
+
+	opened, err := stream("contractor", "/viam.robot.v1.RobotService/StreamStatus", &robotpb.StreamStatusRequest{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opened, test.ShouldBeTrue)
+
+	// streams are denied before the handler sees them when the method alone decides.
+	opened, err = stream("contractor", "/viam.service.shell.v1.ShellService/Shell", &shellpb.ShellRequest{Name: "builtin"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `role "operator" is not allowed to call ShellService/Shell`)
+	test.That(t, opened, test.ShouldBeFalse)
+
+	// rules on resources are checked once the targeted resource is known.
+	const moveToPosition = "/viam.component.arm.v1.ArmService/MoveToPosition"
+	opened, err = stream("contractor", moveToPosition, &armpb.MoveToPositionRequest{Name: "left_arm"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opened, test.ShouldBeTrue)
+	opened, err = stream("contractor", moveToPosition, &armpb.MoveToPositionRequest{Name: "right_arm"})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.PermissionDenied)
+	test.That(t, opened, test.ShouldBeTrue)
+
+	test.That(t, ac.Stats(), test.ShouldResemble, map[string]int64{
+		"operator.ShellService/Shell.denied":        1,
+		"operator.ArmService/MoveToPosition.denied": 1,
+	})
+}
@@ -0,0 +1,319 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.viam.com/utils/rpc"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/client"
+	"go.viam.com/rdk/session"
+)
+
+const (
+	// maxAuditArgumentsLen is how many bytes of a request are recorded in an audit entry.
+	maxAuditArgumentsLen = 512
+
+	// auditBackupTimeFormat is the format of the time a rotated audit log file was rotated at,
+	// which is appended to its name.
+	auditBackupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// AuditLog records mutating calls made to the robot's gRPC API to an append-only, rotated
+// log file. It does nothing until it is configured with an audit config.
+type AuditLog struct {
+	logger logging.Logger
+
+	mu     sync.Mutex
+	conf   *config.AuditConfig
+	dir    string
+	writer *auditFile
+
+	serviceAPIs serviceAPICache
+}
+
+// Reconfigure starts, stops or changes the audit log to match the given config. The log is
+// written to config.AuditLogFileName within dir. A nil config disables the audit log.
+func (al *AuditLog) Reconfigure(conf *config.AuditConfig, dir string) error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	if reflect.DeepEqual(conf, al.conf) && dir == al.dir {
+		return nil
+	}
+	if conf == nil || dir != al.dir {
+		if err := al.close(); err != nil {
+			al.logger.Warnw("failed to close audit log", "error", err)
+		}
+	}
+	if conf == nil {
+		return nil
+	}
+	confCopy := *conf
+	if al.writer != nil {
+		// only what is audited or how files are rotated changed; keep writing to the same file.
+		al.conf = &confCopy
+		al.writer.maxSize = int64(conf.MaxSizeMB) * 1024 * 1024
+		al.writer.maxBackups = conf.MaxFiles
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create audit log directory")
+	}
+	al.conf = &confCopy
+	al.dir = dir
+	al.writer = &auditFile{
+		path:       filepath.Join(dir, config.AuditLogFileName),
+		maxSize:    int64(conf.MaxSizeMB) * 1024 * 1024,
+		maxBackups: conf.MaxFiles,
+	}
+	al.logger.Infow("Auditing mutating requests", "dir", dir)
+	return nil
+}
+
+// Close stops the audit log.
+func (al *AuditLog) Close() error {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	return al.close()
+}
+
+func (al *AuditLog) close() error {
+	var err error
+	if al.writer != nil {
+		err = al.writer.Close()
+	}
+	al.conf = nil
+	al.dir = ""
+	al.writer = nil
+	return err
+}
+
+// auditFile is an append-only file that is rotated once it would grow past maxSize bytes,
+// keeping at most maxBackups rotated files next to it. Old files are removed while rotating,
+// so unlike lumberjack it leaves nothing running once it is closed. It is not safe for
+// concurrent use.
+type auditFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// Write appends p to the file, opening or rotating it first if needed.
+func (f *auditFile) Write(p []byte) (int, error) {
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file. It is reopened by the next write.
+func (f *auditFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *auditFile) open() error {
+	//nolint:gosec
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log file")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return multierr.Combine(errors.Wrap(err, "failed to open audit log file"), file.Close())
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate moves the current file aside, named after the time it was rotated at, removes the
+// oldest rotated files beyond maxBackups and opens a new file.
+func (f *auditFile) rotate() error {
+	if err := f.Close(); err != nil {
+		return err
+	}
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext)
+	backup := prefix + "-" + time.Now().UTC().Format(auditBackupTimeFormat) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return errors.Wrap(err, "failed to rotate audit log file")
+	}
+
+	// backup names sort by the time they were rotated at.
+	backups, err := filepath.Glob(prefix + "-*" + ext)
+	if err != nil {
+		return err
+	}
+	sort.Strings(backups)
+	for len(backups) > f.maxBackups {
+		if err := os.Remove(backups[0]); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to remove rotated audit log file")
+		}
+		backups = backups[1:]
+	}
+	return f.open()
+}
+
+// audits returns whether calls to the given method are currently audited.
+func (al *AuditLog) audits(fullMethod string) (service, method string, ok bool) {
+	split := strings.SplitN(fullMethod, "/", 3)
+	if len(split) != 3 {
+		return "", "", false
+	}
+	service, method = split[1], split[2]
+
+	al.mu.Lock()
+	conf := al.conf
+	al.mu.Unlock()
+	if conf == nil {
+		return "", "", false
+	}
+	return service, method, conf.Audits(al.serviceAPIs.apiForService(service), service, method)
+}
+
+// record builds an audit entry for a completed call and appends it to the audit log.
+func (al *AuditLog) record(
+	ctx context.Context,
+	service, method, fullMethod string,
+	req any,
+	start time.Time,
+	callErr error,
+) {
+	entry := config.AuditEntry{
+		Time:       start,
+		Method:     fullMethod,
+		Code:       status.Code(callErr).String(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if callErr != nil {
+		entry.Error = status.Convert(callErr).Message()
+	}
+	if req != nil {
+		entry.Resource = resource.GetResourceNameFromRequest(service, method, req)
+		if msg, ok := req.(proto.Message); ok {
+			if args, err := protojson.Marshal(msg); err == nil {
+				entry.Arguments = string(args)
+				if len(entry.Arguments) > maxAuditArgumentsLen {
+					entry.Arguments = entry.Arguments[:maxAuditArgumentsLen] + "..."
+				}
+			}
+		}
+	}
+	if authEntity, ok := rpc.ContextAuthEntity(ctx); ok {
+		entry.Entity = authEntity.Entity
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		entry.Peer = p.Addr.String()
+	}
+	if clientInfo, ok := client.GetViamClientInfo(ctx); ok {
+		entry.Client = clientInfo
+	}
+	if sess, ok := session.FromContext(ctx); ok {
+		entry.SessionID = sess.ID().String()
+	} else if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(session.IDMetadataKey); len(ids) > 0 {
+			entry.SessionID = ids[0]
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		al.logger.CWarnw(ctx, "failed to marshal audit entry", "method", fullMethod, "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	if al.writer == nil {
+		return
+	}
+	if _, err := al.writer.Write(line); err != nil {
+		al.logger.CWarnw(ctx, "failed to write audit entry", "method", fullMethod, "error", err)
+	}
+}
+
+// UnaryInterceptor returns an incoming server interceptor that records audited calls once
+// they complete.
+func (al *AuditLog) UnaryInterceptor(
+	ctx context.Context, req any, info *googlegrpc.UnaryServerInfo, handler googlegrpc.UnaryHandler,
+) (any, error) {
+	service, method, ok := al.audits(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	al.record(ctx, service, method, info.FullMethod, req, start, err)
+	return resp, err
+}
+
+// StreamInterceptor returns an incoming server interceptor that records audited streams once
+// they complete. The first message received from the client is used as the stream's
+// arguments.
+func (al *AuditLog) StreamInterceptor(
+	srv any,
+	ss googlegrpc.ServerStream,
+	info *googlegrpc.StreamServerInfo,
+	handler googlegrpc.StreamHandler,
+) error {
+	service, method, ok := al.audits(info.FullMethod)
+	if !ok {
+		return handler(srv, ss)
+	}
+	start := time.Now()
+	wrapped := &streamWithAuditLog{ServerStream: ss}
+	err := handler(srv, wrapped)
+	al.record(ss.Context(), service, method, info.FullMethod, wrapped.firstMsg, start, err)
+	return err
+}
+
+type streamWithAuditLog struct {
+	googlegrpc.ServerStream
+	firstMsg any
+}
+
+// RecvMsg keeps the first message received from the client.
+func (s *streamWithAuditLog) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.firstMsg == nil {
+		s.firstMsg = m
+	}
+	return err
+}
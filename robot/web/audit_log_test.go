@@ -0,0 +1,100 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	armpb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+func TestAuditLogUnaryInterceptor(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	al := &AuditLog{logger: logger}
+	dir := t.TempDir()
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		if _, ok := req.(*armpb.StopRequest); ok {
+			return nil, status.Error(codes.Unavailable, "arm is gone")
+		}
+		return "ok", nil
+	}
+	call := func(fullMethod string, req any) {
+		ctx := rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: "operator"})
+		//nolint:errcheck
+		al.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+	}
+	readEntries := func() []config.AuditEntry {
+		f, err := os.Open(filepath.Join(dir, config.AuditLogFileName))
+		test.That(t, err, test.ShouldBeNil)
+		defer f.Close()
+		entries, err := config.ReadAuditLog(f)
+		test.That(t, err, test.ShouldBeNil)
+		return entries
+	}
+
+	// nothing is recorded until the audit log is configured.
+	call("/viam.component.arm.v1.ArmService/MoveToPosition", &armpb.MoveToPositionRequest{Name: "arm1"})
+	_, err := os.Stat(filepath.Join(dir, config.AuditLogFileName))
+	test.That(t, os.IsNotExist(err), test.ShouldBeTrue)
+
+	conf := &config.AuditConfig{APIs: []string{"rdk:component:arm"}}
+	test.That(t, conf.Validate("audit"), test.ShouldBeNil)
+	test.That(t, al.Reconfigure(conf, dir), test.ShouldBeNil)
+
+	call("/viam.component.arm.v1.ArmService/MoveToPosition", &armpb.MoveToPositionRequest{Name: "arm1"})
+	call("/viam.component.arm.v1.ArmService/GetEndPosition", &armpb.GetEndPositionRequest{Name: "arm1"})
+	call("/viam.component.arm.v1.ArmService/Stop", &armpb.StopRequest{Name: "arm2"})
+	call("/viam.robot.v1.RobotService/StopAll", nil)
+
+	entries := readEntries()
+	test.That(t, entries, test.ShouldHaveLength, 2)
+	test.That(t, entries[0].Method, test.ShouldEqual, "/viam.component.arm.v1.ArmService/MoveToPosition")
+	test.That(t, entries[0].Resource, test.ShouldEqual, "arm1")
+	test.That(t, entries[0].Entity, test.ShouldEqual, "operator")
+	test.That(t, entries[0].Arguments, test.ShouldContainSubstring, `"name":"arm1"`)
+	test.That(t, entries[0].Code, test.ShouldEqual, codes.OK.String())
+	test.That(t, entries[1].Resource, test.ShouldEqual, "arm2")
+	test.That(t, entries[1].Code, test.ShouldEqual, codes.Unavailable.String())
+	test.That(t, entries[1].Error, test.ShouldEqual, "arm is gone")
+
+	// disabling the audit log stops recording.
+	test.That(t, al.Reconfigure(nil, dir), test.ShouldBeNil)
+	call("/viam.component.arm.v1.ArmService/MoveToPosition", &armpb.MoveToPositionRequest{Name: "arm1"})
+	test.That(t, readEntries(), test.ShouldHaveLength, 2)
+	test.That(t, al.Close(), test.ShouldBeNil)
+}
+
+func TestAuditFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	f := &auditFile{path: filepath.Join(dir, config.AuditLogFileName), maxSize: 10, maxBackups: 2}
+	defer f.Close()
+
+	for i := range 5 {
+		_, err := f.Write([]byte(fmt.Sprintf("entry %d\n", i)))
+		test.That(t, err, test.ShouldBeNil)
+		// rotated files are named after the millisecond they were rotated at.
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	current, err := os.ReadFile(f.path)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(current), test.ShouldEqual, "entry 4\n")
+	backups, err := filepath.Glob(filepath.Join(dir, "audit-*.jsonl"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, backups, test.ShouldHaveLength, 2)
+	oldest, err := os.ReadFile(backups[0])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, string(oldest), test.ShouldEqual, "entry 2\n")
+}
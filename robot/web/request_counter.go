@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"net"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -13,11 +16,14 @@ import (
 
 	"github.com/viamrobotics/webrtc/v3"
 	"go.viam.com/utils/rpc"
+	"golang.org/x/time/rate"
 	googlegrpc "google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/resource"
@@ -48,6 +54,22 @@ func (e RequestLimitExceededError) GRPCStatus() *status.Status {
 	return status.New(codes.ResourceExhausted, e.Error())
 }
 
+// RateLimitExceededError is an error returned when a request is rejected because the
+// client has exceeded a configured rate limit.
+type RateLimitExceededError struct {
+	method, client    string
+	requestsPerSecond float64
+}
+
+func (e RateLimitExceededError) Error() string {
+	return fmt.Sprintf("client %v exceeded rate limit of %v requests per second for %v", e.client, e.requestsPerSecond, e.method)
+}
+
+// GRPCStatus allows this error to be converted to a [status.Status].
+func (e RateLimitExceededError) GRPCStatus() *status.Status {
+	return status.New(codes.ResourceExhausted, e.Error())
+}
+
 // apiMethod is used to store information about an api path in a denormalized
 // way to avoid repeatedly parsing the same string.
 type apiMethod struct {
@@ -118,6 +140,16 @@ type RequestCounter struct {
 	// connection back to the RDK.
 	pcToClientMetadata ssync.Map[*webrtc.PeerConnection, string]
 
+	// rateLimits holds the configured rate limits and priorities along with each client's token
+	// buckets. It is replaced whenever the configured rate limits change and is nil if none are
+	// configured.
+	rateLimits atomic.Pointer[rateLimitState]
+
+	// rateLimited maps request counter keys to how many requests were rejected by rate limits.
+	rateLimited ssync.Map[string, *atomic.Int64]
+
+	serviceAPIs serviceAPICache
+
 	// beforeLogHook, if set, is called at the start of logRequestLimitExceeded before any
 	// client connection state is inspected. For testing purposes only. The peer connection
 	// associated with the offending client is passed so tests can observe or wait on its
@@ -179,6 +211,10 @@ func (rc *RequestCounter) Stats() any {
 		ret[fmt.Sprintf("%v.inFlightRequests", k)] = v.Load()
 	}
 
+	for k, v := range rc.rateLimited.Range {
+		ret[fmt.Sprintf("%v.rateLimited", k)] = v.Load()
+	}
+
 	return ret
 }
 
@@ -399,8 +435,20 @@ func (rc *RequestCounter) UnaryInterceptor(
 		rc.setClientMetadataForPC(ctx, pc)
 	}
 
+	priority := config.RequestPriorityNormal
+	if apiMethod.shortPath != "" {
+		var resourceName string
+		if req != nil {
+			resourceName = apiMethod.getResourceName(req)
+		}
+		priority = rc.priority(apiMethod, resourceName)
+		if err := rc.checkRateLimits(ctx, apiMethod, resourceName, priority); err != nil {
+			return nil, err
+		}
+	}
+
 	if resource := buildResourceLimitKey(req, apiMethod); resource != "" {
-		if ok := rc.incrInFlight(resource, pc); !ok {
+		if ok := rc.incrInFlight(resource, pc, priority); !ok {
 			numInFlightRequestsForClient := rc.logRequestLimitExceeded(apiMethod.full, resource, pc)
 			return nil, &RequestLimitExceededError{
 				resource:                     resource,
@@ -530,10 +578,19 @@ func (rc *RequestCounter) setClientMetadataForPC(ctx context.Context, pc *webrtc
 
 // incrInFlight attempts to increment the in-flight request counters for a given
 // resource. It returns true if it was successful and false if an additional
-// request would exceed the configured limit.
-func (rc *RequestCounter) incrInFlight(resource string, pc *webrtc.PeerConnection) bool {
+// request would exceed the limit for its priority. Critical requests are never
+// rejected and low priority requests may only use half of the configured limit.
+func (rc *RequestCounter) incrInFlight(resource string, pc *webrtc.PeerConnection, priority config.RequestPriority) bool {
+	limit := rc.inFlightLimit
+	switch priority {
+	case config.RequestPriorityCritical:
+		limit = math.MaxInt64
+	case config.RequestPriorityLow:
+		limit = max(rc.inFlightLimit/2, 1)
+	case config.RequestPriorityNormal:
+	}
 	counter := rc.ensureInFlightCounterForResource(resource)
-	if newCount := counter.Add(1); newCount > rc.inFlightLimit {
+	if newCount := counter.Add(1); newCount > limit {
 		counter.Add(-1)
 		if pc != nil {
 			rc.ensureCounterForResourceForPC(resource, pc, rejectedCounterName).Add(1)
@@ -594,6 +651,16 @@ func (w *wrappedStreamWithRC) RecvMsg(m any) error {
 		// `preRequestIncrement`. Because the message object has not been initialized yet. It's not
 		// clear to me what options we have to pull out the message's `name` field before
 		w.rc.preRequestIncrement(requestKey)
+
+		// Streams are rate limited when they are opened, which is once the first message is
+		// received.
+		if err == nil {
+			resourceName := w.apiMethod.getResourceName(m)
+			priority := w.rc.priority(w.apiMethod, resourceName)
+			if limitErr := w.rc.checkRateLimits(w.Context(), w.apiMethod, resourceName, priority); limitErr != nil {
+				return limitErr
+			}
+		}
 	}
 
 	return err
@@ -671,3 +738,147 @@ func buildResourceLimitKey(clientMsg any, method apiMethod) string {
 	}
 	return ""
 }
+
+// rateLimitBucketSweepInterval is the minimum time between sweeps of token buckets that are
+// no longer needed.
+var rateLimitBucketSweepInterval = 10 * time.Second
+
+// rateLimitState is a set of configured rate limits and the token buckets of the clients
+// they apply to.
+type rateLimitState struct {
+	conf *config.RateLimitConfig
+	// buckets maps "<limit index>.<client>" to the token bucket of a client for that limit.
+	buckets ssync.Map[string, *rateLimitBucket]
+	// lastSweep is when buckets was last swept, in unix nanoseconds.
+	lastSweep atomic.Int64
+}
+
+// rateLimitBucket is the token bucket of a client for a single rate limit.
+type rateLimitBucket struct {
+	limiter *rate.Limiter
+	// pc is the WebRTC connection the client is identified by, if any. Holding on to it keeps
+	// its address, which is part of the bucket's key, from being reused by a new connection
+	// until the bucket is evicted.
+	pc *webrtc.PeerConnection
+}
+
+// evictBuckets removes the buckets of clients whose WebRTC connection has closed and buckets
+// that are full, which are no different from the bucket a new request would create. It does
+// nothing if the buckets were swept less than rateLimitBucketSweepInterval ago.
+func (state *rateLimitState) evictBuckets(now time.Time) {
+	last := state.lastSweep.Load()
+	if now.UnixNano()-last < int64(rateLimitBucketSweepInterval) || !state.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	for key, bucket := range state.buckets.Range {
+		if (bucket.pc != nil && pcIsClosed(bucket.pc)) ||
+			bucket.limiter.TokensAt(now) >= float64(bucket.limiter.Burst()) {
+			state.buckets.CompareAndDelete(key, bucket)
+		}
+	}
+}
+
+// SetRateLimits replaces the rate limits and priorities applied to incoming requests. Token
+// buckets are kept if the config did not change. A nil config removes all rate limits.
+func (rc *RequestCounter) SetRateLimits(conf *config.RateLimitConfig) {
+	current := rc.rateLimits.Load()
+	if current == nil && conf == nil {
+		return
+	}
+	if current != nil && reflect.DeepEqual(current.conf, conf) {
+		return
+	}
+	if conf == nil {
+		rc.rateLimits.Store(nil)
+		return
+	}
+	confCopy := *conf
+	rc.rateLimits.Store(&rateLimitState{conf: &confCopy})
+}
+
+// priority returns the priority class of a request.
+func (rc *RequestCounter) priority(method apiMethod, resourceName string) config.RequestPriority {
+	var (
+		conf *config.RateLimitConfig
+		api  string
+	)
+	if state := rc.rateLimits.Load(); state != nil && len(state.conf.Priorities) != 0 {
+		conf = state.conf
+		api = rc.serviceAPIs.apiForService(method.service)
+	}
+	return conf.PriorityFor(api, method.service, method.name, resourceName)
+}
+
+// checkRateLimits takes a token from every bucket of the calling client that the request is
+// subject to. It returns a RateLimitExceededError if any bucket is empty. Critical requests
+// are never rate limited.
+func (rc *RequestCounter) checkRateLimits(
+	ctx context.Context,
+	method apiMethod,
+	resourceName string,
+	priority config.RequestPriority,
+) error {
+	state := rc.rateLimits.Load()
+	if state == nil || len(state.conf.Limits) == 0 || priority == config.RequestPriorityCritical {
+		return nil
+	}
+
+	var entity string
+	if authEntity, ok := rpc.ContextAuthEntity(ctx); ok {
+		entity = authEntity.Entity
+	}
+	clientInfo, _ := client.GetViamClientInfo(ctx)
+	api := rc.serviceAPIs.apiForService(method.service)
+
+	for idx := range state.conf.Limits {
+		limit := &state.conf.Limits[idx]
+		if !limit.Matches(api, method.service, method.name, resourceName) || !limit.MatchesClient(entity, clientInfo) {
+			continue
+		}
+		id, pc := clientIdentity(ctx, entity)
+		key := fmt.Sprintf("%d.%s", idx, id)
+		bucket, ok := state.buckets.Load(key)
+		if !ok {
+			// new clients are the only way the buckets grow, so sweep out unneeded ones first.
+			state.evictBuckets(time.Now())
+			bucket, _ = state.buckets.LoadOrStore(key, &rateLimitBucket{
+				limiter: rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst),
+				pc:      pc,
+			})
+		}
+		if bucket.limiter.Allow() {
+			continue
+		}
+
+		counterKey := method.shortPath
+		if resourceName != "" {
+			counterKey = resourceName + "." + counterKey
+		}
+		counter, ok := rc.rateLimited.Load(counterKey)
+		if !ok {
+			counter, _ = rc.rateLimited.LoadOrStore(counterKey, &atomic.Int64{})
+		}
+		counter.Add(1)
+		return &RateLimitExceededError{method: method.shortPath, client: id, requestsPerSecond: limit.RequestsPerSecond}
+	}
+	return nil
+}
+
+// clientIdentity returns the identity that token buckets are kept for: the authenticated
+// entity if there is one, otherwise the WebRTC connection or network address of the client.
+// The WebRTC connection is also returned if the client is identified by it.
+func clientIdentity(ctx context.Context, entity string) (string, *webrtc.PeerConnection) {
+	if entity != "" {
+		return entity, nil
+	}
+	if pc, ok := rpc.ContextPeerConnection(ctx); ok {
+		return fmt.Sprintf("webrtc-%p", pc), pc
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host, nil
+		}
+		return p.Addr.String(), nil
+	}
+	return "unknown", nil
+}
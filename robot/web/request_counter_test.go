@@ -0,0 +1,148 @@
+package web
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/viamrobotics/webrtc/v3"
+	armpb "go.viam.com/api/component/arm/v1"
+	"go.viam.com/test"
+	"go.viam.com/utils/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/logging"
+)
+
+func TestRequestCounterRateLimits(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	rc := &RequestCounter{logger: logger}
+	rc.ensureLimit()
+
+	const (
+		getEndPosition = "/viam.component.arm.v1.ArmService/GetEndPosition"
+		stop           = "/viam.component.arm.v1.ArmService/Stop"
+	)
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &armpb.StopResponse{}, nil
+	}
+	call := func(entity, fullMethod string, req any) error {
+		ctx := rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: entity})
+		_, err := rc.UnaryInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+
+	conf := &config.RateLimitConfig{
+		Limits: []config.RateLimitRule{{
+			RoleRule:          config.RoleRule{API: "rdk:component:arm"},
+			Clients:           []string{"dashboard"},
+			RequestsPerSecond: 0.001,
+			Burst:             2,
+		}},
+	}
+	test.That(t, conf.Validate("rate_limits"), test.ShouldBeNil)
+	rc.SetRateLimits(conf)
+
+	for range 2 {
+		test.That(t, call("dashboard", getEndPosition, &armpb.GetEndPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+	}
+	err := call("dashboard", getEndPosition, &armpb.GetEndPositionRequest{Name: "arm1"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, status.Code(err), test.ShouldEqual, codes.ResourceExhausted)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "client dashboard exceeded rate limit")
+
+	// safety calls are never rate limited and other clients have their own buckets.
+	test.That(t, call("dashboard", stop, &armpb.StopRequest{Name: "arm1"}), test.ShouldBeNil)
+	test.That(t, call("teleop", getEndPosition, &armpb.GetEndPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+
+	// an unchanged config keeps the token buckets.
+	rc.SetRateLimits(conf)
+	test.That(t, call("dashboard", getEndPosition, &armpb.GetEndPositionRequest{Name: "arm1"}), test.ShouldNotBeNil)
+	test.That(t, rc.Stats().(map[string]int64)["arm1.ArmService/GetEndPosition.rateLimited"], test.ShouldEqual, 2)
+
+	rc.SetRateLimits(nil)
+	test.That(t, call("dashboard", getEndPosition, &armpb.GetEndPositionRequest{Name: "arm1"}), test.ShouldBeNil)
+}
+
+func TestRequestCounterRateLimitBucketEviction(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	rc := &RequestCounter{logger: logger}
+	rc.ensureLimit()
+	rc.SetRateLimits(&config.RateLimitConfig{
+		Limits: []config.RateLimitRule{{
+			RoleRule:          config.RoleRule{API: "rdk:component:arm"},
+			RequestsPerSecond: 0.001,
+			Burst:             1,
+		}},
+	})
+	state := rc.rateLimits.Load()
+
+	const getEndPosition = "/viam.component.arm.v1.ArmService/GetEndPosition"
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &armpb.GetEndPositionResponse{}, nil
+	}
+	call := func(ctx context.Context) error {
+		_, err := rc.UnaryInterceptor(ctx, &armpb.GetEndPositionRequest{Name: "arm1"},
+			&grpc.UnaryServerInfo{FullMethod: getEndPosition}, handler)
+		return err
+	}
+	numBuckets := func() int {
+		var n int
+		for range state.buckets.Range {
+			n++
+		}
+		return n
+	}
+
+	pc, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	test.That(t, err, test.ShouldBeNil)
+	pcCtx := rpc.ContextWithPeerConnection(context.Background(), pc)
+	test.That(t, call(pcCtx), test.ShouldBeNil)
+	test.That(t, call(pcCtx), test.ShouldNotBeNil)
+	test.That(t, numBuckets(), test.ShouldEqual, 1)
+
+	// the bucket of a connection that is still open is kept even though it is drained.
+	state.lastSweep.Store(0)
+	test.That(t, call(rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: "teleop"})), test.ShouldBeNil)
+	test.That(t, numBuckets(), test.ShouldEqual, 2)
+
+	// buckets of closed connections are evicted once a new client shows up.
+	test.That(t, pc.Close(), test.ShouldBeNil)
+	state.lastSweep.Store(0)
+	test.That(t, call(rpc.ContextWithAuthEntity(context.Background(), rpc.EntityInfo{Entity: "dashboard"})), test.ShouldBeNil)
+	test.That(t, numBuckets(), test.ShouldEqual, 2)
+
+	// full buckets are evicted as well since a new bucket would be no different.
+	state.evictBuckets(time.Now().Add(time.Hour))
+	test.That(t, numBuckets(), test.ShouldEqual, 0)
+}
+
+func TestRequestCounterPriorities(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	rc := &RequestCounter{logger: logger, inFlightLimit: 4}
+	rc.SetRateLimits(&config.RateLimitConfig{
+		Priorities: []config.PriorityRule{{
+			RoleRule: config.RoleRule{Methods: []string{"GetEndPosition"}},
+			Priority: config.RequestPriorityLow,
+		}},
+	})
+
+	const resource = "arm1.viam.component.arm.v1.ArmService"
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityLow), test.ShouldBeTrue)
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityLow), test.ShouldBeTrue)
+	// low priority requests may only use half of the limit.
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityLow), test.ShouldBeFalse)
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityNormal), test.ShouldBeTrue)
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityNormal), test.ShouldBeTrue)
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityNormal), test.ShouldBeFalse)
+	// critical requests are never rejected.
+	test.That(t, rc.incrInFlight(resource, nil, config.RequestPriorityCritical), test.ShouldBeTrue)
+
+	method := extractViamAPI("/viam.component.arm.v1.ArmService/GetEndPosition")
+	test.That(t, rc.priority(method, "arm1"), test.ShouldEqual, config.RequestPriorityLow)
+	method = extractViamAPI("/viam.component.arm.v1.ArmService/Stop")
+	test.That(t, rc.priority(method, "arm1"), test.ShouldEqual, config.RequestPriorityCritical)
+}
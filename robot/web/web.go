@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -43,6 +44,8 @@ import (
 	"go.viam.com/rdk/grpc"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/module"
+	"go.viam.com/rdk/operation"
+	rdkpb "go.viam.com/rdk/proto/api/robot/v1"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/robot/client"
@@ -88,6 +91,10 @@ type Service interface {
 
 	RequestCounter() *RequestCounter
 
+	AccessController() *AccessController
+
+	AuditLog() *AuditLog
+
 	ModPeerConnTracker() *grpc.ModPeerConnTracker
 }
 
@@ -124,6 +131,8 @@ type webService struct {
 	modWorkers   sync.WaitGroup
 
 	requestCounter     RequestCounter
+	accessController   AccessController
+	auditLog           AuditLog
 	modPeerConnTracker *grpc.ModPeerConnTracker
 }
 
@@ -142,6 +151,8 @@ func New(r robot.LocalRobot, logger logging.Logger, opts ...Option) Service {
 		modPeerConnTracker: grpc.NewModPeerConnTracker(),
 		opts:               wOpts,
 		requestCounter:     RequestCounter{logger: logger},
+		accessController:   AccessController{logger: logger},
+		auditLog:           AuditLog{logger: logger},
 	}
 	webSvc.requestCounter.ensureLimit()
 	return webSvc
@@ -420,6 +431,7 @@ func (svc *webService) Close(ctx context.Context) error {
 		utils.UncheckedError(svc.streamServer.Close())
 	}
 	svc.modWorkers.Wait()
+	errs = append(errs, svc.auditLog.Close())
 	return multierr.Combine(errs...)
 }
 
@@ -491,6 +503,38 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 		return err
 	}
 
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx,
+		&rdkpb.JobService_ServiceDesc,
+		grpcserver.NewJobServer(svc.r),
+	); err != nil {
+		return err
+	}
+
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx,
+		&rdkpb.OperationService_ServiceDesc,
+		grpcserver.NewOperationServer(svc.r),
+	); err != nil {
+		return err
+	}
+
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx,
+		&rdkpb.LeaseService_ServiceDesc,
+		grpcserver.NewLeaseServer(svc.r),
+	); err != nil {
+		return err
+	}
+
+	if err := svc.rpcServer.RegisterServiceServer(
+		ctx,
+		&rdkpb.RemoteService_ServiceDesc,
+		grpcserver.NewRemoteServer(svc.r),
+	); err != nil {
+		return err
+	}
+
 	if err := svc.initAPIResourceCollections(ctx, svc.rpcServer); err != nil {
 		return err
 	}
@@ -581,6 +625,16 @@ func (svc *webService) RequestCounter() *RequestCounter {
 	return &svc.requestCounter
 }
 
+// AccessController returns the access controller object.
+func (svc *webService) AccessController() *AccessController {
+	return &svc.accessController
+}
+
+// AuditLog returns the audit log object.
+func (svc *webService) AuditLog() *AuditLog {
+	return &svc.auditLog
+}
+
 // ModPeerConnTracker returns the ModPeerConnTracker object.
 func (svc *webService) ModPeerConnTracker() *grpc.ModPeerConnTracker {
 	return svc.modPeerConnTracker
@@ -626,6 +680,14 @@ func (svc *webService) initRPCOptions(listenerTCPAddr *net.TCPAddr, options webo
 	unaryInterceptors = append(unaryInterceptors, svc.requestCounter.UnaryInterceptor)
 	streamInterceptors = append(streamInterceptors, svc.requestCounter.StreamInterceptor)
 
+	// Audit calls before checking access so that denied calls are recorded too.
+	unaryInterceptors = append(unaryInterceptors, svc.auditLog.UnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.auditLog.StreamInterceptor)
+
+	svc.accessController.setAuth(options.Auth)
+	unaryInterceptors = append(unaryInterceptors, svc.accessController.UnaryInterceptor)
+	streamInterceptors = append(streamInterceptors, svc.accessController.StreamInterceptor)
+
 	unaryInterceptors = append(unaryInterceptors, grpc.ResourceNameTaggingUnaryServerInterceptor)
 
 	if options.Debug {
@@ -813,6 +875,8 @@ func (svc *webService) initMux(options weboptions.Options) *goji.Mux {
 	// TODO: hide behind option
 	// TODO: accept params to display different formats
 	mux.HandleFunc(pat.New("/debug/graph"), svc.handleVisualizeResourceGraph)
+	mux.HandleFunc(pat.New("/debug/resource_graph"), svc.handleResourceGraph)
+	mux.HandleFunc(pat.New("/debug/operations"), svc.handleOperations)
 
 	// serve restart status
 	mux.HandleFunc(pat.New("/restart_status"), svc.handleRestartStatus)
@@ -1064,6 +1128,49 @@ type RestartStatusResponse struct {
 	ModuleServerTCPAddr string `json:"module_server_tcp_addr,omitempty"`
 }
 
+// handleResourceGraph serves the current resource graph. The graph is returned as JSON
+// unless the `format` query parameter is one of "text", "dot" or "mermaid".
+func (svc *webService) handleResourceGraph(w http.ResponseWriter, r *http.Request) {
+	info := svc.r.ResourceGraph()
+	var out string
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		utils.UncheckedError(json.NewEncoder(w).Encode(info))
+		return
+	case "text":
+		out = info.Text()
+	case "dot":
+		out = info.DOT()
+	case "mermaid":
+		out = info.Mermaid()
+	default:
+		http.Error(w, fmt.Sprintf("unknown format %q", format), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	//nolint:errcheck
+	_, _ = w.Write([]byte(out))
+}
+
+// OperationsResponse is the JSON served by the `/debug/operations` endpoint.
+type OperationsResponse = operation.Report
+
+// handleOperations serves the running operations, along with their progress, and the
+// recently completed operations.
+func (svc *webService) handleOperations(w http.ResponseWriter, r *http.Request) {
+	opMgr := svc.r.OperationManager()
+	response := OperationsResponse{Running: []operation.Summary{}, History: opMgr.History()}
+	for _, op := range opMgr.All() {
+		response.Running = append(response.Running, op.Summary())
+	}
+	sort.Slice(response.Running, func(i, j int) bool {
+		return response.Running[i].Started.Before(response.Running[j].Started)
+	})
+	w.Header().Set("Content-Type", "application/json")
+	utils.UncheckedError(json.NewEncoder(w).Encode(response))
+}
+
 // Handles the `/restart_status` endpoint.
 func (svc *webService) handleRestartStatus(w http.ResponseWriter, r *http.Request) {
 	modAddrs := svc.ModuleAddresses()
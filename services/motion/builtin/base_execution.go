@@ -0,0 +1,355 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+const (
+	defaultMaxReplans            = 10
+	defaultPositionPollingFreqHz = 1.
+	// minBaseTurnDegs and minBaseMoveMM are the smallest turns and moves worth making.
+	minBaseTurnDegs = 0.5
+	minBaseMoveMM   = 1.
+)
+
+// baseDeviationError is returned when a base strays too far from its plan.
+type baseDeviationError struct {
+	deviationMM, allowedMM float64
+}
+
+func (e *baseDeviationError) Error() string {
+	return fmt.Sprintf("base deviated %.0fmm from its plan, more than the allowed %.0fmm", e.deviationMM, e.allowedMM)
+}
+
+// baseMove drives a base to a goal on the ground plane. Positions are in the frame of its
+// localizer, in mm, and headings are in degrees counterclockwise from +Y, which is forwards
+// for a base.
+type baseMove struct {
+	componentName string
+	base          base.Base
+	localizer     motion.Localizer
+	goal          r3.Vector
+	// goalTheta is the heading the base should end at, or NaN if any heading will do.
+	goalTheta float64
+	planner   *basePlanner
+
+	mmPerSec     float64
+	degsPerSec   float64
+	deviationMM  float64
+	pollInterval time.Duration
+	// obstaclePollInterval is how often refreshObstacles is called while the base drives, if
+	// both are set. The base replans if its path is blocked by the new obstacles.
+	obstaclePollInterval time.Duration
+	refreshObstacles     func(ctx context.Context) ([]spatialmath.Geometry, error)
+	// detectObstacles, if set, returns what the obstacle detectors of the move currently see,
+	// in the frame of the base.
+	detectObstacles func(ctx context.Context) ([]spatialmath.Geometry, error)
+	// maxReplans is how many times the base may replan after deviating or finding its path
+	// blocked; negative means no limit.
+	maxReplans int
+	// anchor, if set, is where the origin of the localizer's frame is on the globe. Plans are
+	// then reported in GPS coordinates.
+	anchor *geo.Point
+	logger logging.Logger
+}
+
+// baseRadius returns the radius of the disc on the ground plane that the base covers.
+func baseRadius(ctx context.Context, b base.Base) (float64, error) {
+	props, err := b.Properties(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	radius := props.WidthMeters * 1000 / 2
+	geometries, err := b.Geometries(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, geometry := range geometries {
+		bounding, err := spatialmath.BoundingSphere(geometry)
+		if err != nil {
+			return 0, err
+		}
+		offset := bounding.Pose().Point()
+		r := bounding.ToProtobuf().GetSphere().GetRadiusMm() + math.Hypot(offset.X, offset.Y)
+		radius = math.Max(radius, r)
+	}
+	return radius, nil
+}
+
+func (m *baseMove) currentPose(ctx context.Context) (r3.Vector, float64, error) {
+	pif, err := m.localizer.CurrentPosition(ctx)
+	if err != nil {
+		return r3.Vector{}, 0, err
+	}
+	pt := pif.Pose().Point()
+	pt.Z = 0
+	return pt, pif.Pose().Orientation().OrientationVectorDegrees().Theta, nil
+}
+
+// detectedObstacles returns what the obstacle detectors of the move currently see, in the
+// frame of the localizer. They are flattened onto the ground plane as the base can pass
+// neither over nor under them.
+func (m *baseMove) detectedObstacles(ctx context.Context) ([]spatialmath.Geometry, error) {
+	if m.detectObstacles == nil {
+		return nil, nil
+	}
+	detected, err := m.detectObstacles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pos, theta, err := m.currentPose(ctx)
+	if err != nil {
+		return nil, err
+	}
+	basePose := spatialmath.NewPose(pos, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: theta})
+	obstacles := make([]spatialmath.Geometry, 0, len(detected))
+	for _, geometry := range detected {
+		geometry = geometry.Transform(basePose)
+		obstacles = append(obstacles, geometry.Transform(spatialmath.NewPoseFromPoint(r3.Vector{Z: -geometry.Pose().Point().Z})))
+	}
+	return obstacles, nil
+}
+
+// newPlan plans a path from where the base is now to the goal, returning it as a plan along
+// with the waypoints to drive through.
+func (m *baseMove) newPlan(ctx context.Context) (motion.PlanWithMetadata, []r3.Vector, error) {
+	start, theta, err := m.currentPose(ctx)
+	if err != nil {
+		return motion.PlanWithMetadata{}, nil, err
+	}
+	waypoints, err := m.planner.plan(ctx, start, m.goal)
+	if err != nil {
+		return motion.PlanWithMetadata{}, nil, err
+	}
+
+	path := make(motionplan.Path, 0, len(waypoints))
+	traj := make(motionplan.Trajectory, 0, len(waypoints))
+	for i, wp := range waypoints {
+		heading := theta
+		if i > 0 {
+			heading = travelHeading(waypoints[i-1], wp)
+		}
+		if i == len(waypoints)-1 && !math.IsNaN(m.goalTheta) {
+			heading = m.goalTheta
+		}
+		pose := spatialmath.NewPose(wp, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: heading})
+		path = append(path, referenceframe.FrameSystemPoses{
+			m.componentName: referenceframe.NewPoseInFrame(referenceframe.World, pose),
+		})
+		traj = append(traj, referenceframe.FrameSystemInputs{
+			m.componentName: {wp.X, wp.Y, utils.DegToRad(heading)},
+		})
+	}
+
+	var plan motionplan.Plan = motionplan.NewSimplePlan(path, traj)
+	var anchor *spatialmath.GeoPose
+	if m.anchor != nil {
+		plan = motionplan.NewGeoPlan(plan, m.anchor)
+		anchor = spatialmath.NewGeoPose(m.anchor, 0)
+	}
+	return motion.PlanWithMetadata{Plan: plan, AnchorGeoPose: anchor}, waypoints, nil
+}
+
+// run drives the base along its plan, replanning whenever it deviates or its path is blocked,
+// and records the outcome in the store.
+func (m *baseMove) run(ctx context.Context, store *executionStore, exec *execution, waypoints []r3.Vector) {
+	replans := 0
+	for {
+		err := m.follow(ctx, waypoints)
+		if err == nil {
+			store.finish(exec, motion.PlanStateSucceeded, "")
+			return
+		}
+		m.stopBase()
+		if ctx.Err() != nil {
+			store.finish(exec, motion.PlanStateStopped, "")
+			return
+		}
+
+		var deviation *baseDeviationError
+		if !errors.As(err, &deviation) && !errors.Is(err, errBasePathObstructed) {
+			store.finish(exec, motion.PlanStateFailed, err.Error())
+			return
+		}
+		if m.maxReplans >= 0 && replans >= m.maxReplans {
+			store.finish(exec, motion.PlanStateFailed, fmt.Sprintf("exceeded maximum of %d replans: %s", m.maxReplans, err))
+			return
+		}
+		replans++
+		m.logger.CInfow(ctx, "replanning", "component", m.componentName, "reason", err)
+
+		plan, newWaypoints, planErr := m.newPlan(ctx)
+		if planErr != nil {
+			if ctx.Err() != nil {
+				store.finish(exec, motion.PlanStateStopped, "")
+			} else {
+				store.finish(exec, motion.PlanStateFailed, fmt.Sprintf("failed to replan after %s: %s", err, planErr))
+			}
+			return
+		}
+		store.replan(exec, plan, err.Error())
+		waypoints = newWaypoints
+	}
+}
+
+func (m *baseMove) stopBase() {
+	// use a fresh context as the execution's may already be done.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.base.Stop(ctx, nil); err != nil {
+		m.logger.Warnw("failed to stop base", "component", m.componentName, "error", err)
+	}
+}
+
+// follow drives the base through each waypoint in turn and then turns it to the goal
+// heading.
+func (m *baseMove) follow(ctx context.Context, waypoints []r3.Vector) error {
+	for i := 1; i < len(waypoints); i++ {
+		from, to := waypoints[i-1], waypoints[i]
+		pos, theta, err := m.currentPose(ctx)
+		if err != nil {
+			return err
+		}
+		if err := m.turnTo(ctx, theta, travelHeading(pos, to)); err != nil {
+			return err
+		}
+		if err := m.drive(ctx, from, waypoints[i:], pos.Distance(to)); err != nil {
+			return err
+		}
+		pos, _, err = m.currentPose(ctx)
+		if err != nil {
+			return err
+		}
+		if d := pos.Distance(to); d > m.deviationMM {
+			return &baseDeviationError{deviationMM: d, allowedMM: m.deviationMM}
+		}
+	}
+	if math.IsNaN(m.goalTheta) {
+		return nil
+	}
+	_, theta, err := m.currentPose(ctx)
+	if err != nil {
+		return err
+	}
+	return m.turnTo(ctx, theta, m.goalTheta)
+}
+
+func (m *baseMove) turnTo(ctx context.Context, theta, desired float64) error {
+	turn := normalizeDegrees(desired - theta)
+	if math.Abs(turn) < minBaseTurnDegs {
+		return nil
+	}
+	return m.base.Spin(ctx, turn, m.degsPerSec, nil)
+}
+
+// drive moves the base straight for distance towards remaining[0], the next waypoint, watching
+// its position and obstacles while it does so. If it strays more than deviationMM from the
+// segment between from and the waypoint, or the rest of its path becomes blocked, the move is
+// cut short.
+func (m *baseMove) drive(ctx context.Context, from r3.Vector, remaining []r3.Vector, distance float64) error {
+	if distance < minBaseMoveMM {
+		return nil
+	}
+	var positionTicks, obstacleTicks <-chan time.Time
+	if m.pollInterval > 0 {
+		ticker := time.NewTicker(m.pollInterval)
+		defer ticker.Stop()
+		positionTicks = ticker.C
+	}
+	if m.obstaclePollInterval > 0 && m.refreshObstacles != nil {
+		ticker := time.NewTicker(m.obstaclePollInterval)
+		defer ticker.Stop()
+		obstacleTicks = ticker.C
+	}
+	if positionTicks == nil && obstacleTicks == nil {
+		return m.base.MoveStraight(ctx, int(math.Round(distance)), m.mmPerSec, nil)
+	}
+
+	moveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var stopReason error
+	// updated is the planner with the latest obstacles, which is only swapped in once the
+	// move is over as the planner is not safe for concurrent use.
+	var updated *basePlanner
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-moveCtx.Done():
+				return
+			case <-positionTicks:
+				pos, _, err := m.currentPose(moveCtx)
+				if err != nil {
+					continue
+				}
+				if d := spatialmath.DistToLineSegment(from, remaining[0], pos); d > m.deviationMM {
+					stopReason = &baseDeviationError{deviationMM: d, allowedMM: m.deviationMM}
+					cancel()
+					return
+				}
+			case <-obstacleTicks:
+				obstacles, err := m.refreshObstacles(moveCtx)
+				if err != nil {
+					m.logger.CDebugw(moveCtx, "failed to refresh obstacles", "component", m.componentName, "error", err)
+					continue
+				}
+				updated = m.planner.withObstacles(obstacles)
+				pos, _, err := m.currentPose(moveCtx)
+				if err != nil {
+					continue
+				}
+				if !updated.pathValid(append([]r3.Vector{pos}, remaining...)) {
+					stopReason = errBasePathObstructed
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	err := m.base.MoveStraight(moveCtx, int(math.Round(distance)), m.mmPerSec, nil)
+	cancel()
+	wg.Wait()
+	if updated != nil {
+		m.planner = updated
+	}
+	if stopReason != nil {
+		return stopReason
+	}
+	return err
+}
+
+// travelHeading returns the heading of a base driving from a to b.
+func travelHeading(a, b r3.Vector) float64 {
+	d := b.Sub(a)
+	return utils.RadToDeg(math.Atan2(-d.X, d.Y))
+}
+
+// normalizeDegrees returns the equivalent of an angle in (-180, 180].
+func normalizeDegrees(degs float64) float64 {
+	degs = math.Mod(degs, 360)
+	switch {
+	case degs > 180:
+		degs -= 360
+	case degs <= -180:
+		degs += 360
+	}
+	return degs
+}
@@ -0,0 +1,250 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+const (
+	// defaultBasePlanIterations bounds the number of samples the base planner takes before
+	// giving up.
+	defaultBasePlanIterations = 5000
+	// basePlanStepsPerPath is roughly how many extensions of its trees the base planner takes to
+	// cover the distance between start and goal.
+	basePlanStepsPerPath = 20
+)
+
+var (
+	errBaseStartInCollision = errors.New("base is in collision with an obstacle at its current position")
+	errBaseGoalInCollision  = errors.New("destination is in collision with an obstacle")
+	errBaseGoalOutOfBounds  = errors.New("destination is outside of the bounding regions")
+	errBasePlanNotFound     = errors.New("could not find a path to the destination")
+	errBasePathObstructed   = errors.New("an obstacle appeared on the path")
+)
+
+// basePlanner plans collision free paths for a base driving on the ground plane. A base is
+// treated as a disc which can turn in place, so paths are sequences of straight segments
+// between points.
+type basePlanner struct {
+	// obstacles must not come within radius of the base's position.
+	obstacles []spatialmath.Geometry
+	// boundingRegions, if any, must contain the base's position at all times.
+	boundingRegions []spatialmath.Geometry
+	radius          float64
+	iterations      int
+	rand            *rand.Rand
+}
+
+func newBasePlanner(obstacles, boundingRegions []spatialmath.Geometry, radius float64, seed int64) *basePlanner {
+	return &basePlanner{
+		obstacles:       obstacles,
+		boundingRegions: boundingRegions,
+		radius:          radius,
+		iterations:      defaultBasePlanIterations,
+		//nolint:gosec
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// withObstacles returns a copy of the planner which avoids the given obstacles instead.
+func (bp *basePlanner) withObstacles(obstacles []spatialmath.Geometry) *basePlanner {
+	updated := *bp
+	updated.obstacles = obstacles
+	return &updated
+}
+
+// pathValid returns whether the base can drive along a path.
+func (bp *basePlanner) pathValid(path []r3.Vector) bool {
+	for i := 1; i < len(path); i++ {
+		if !bp.segmentValid(path[i-1], path[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// plan returns the points of a path from start to goal, including both. Only X and Y of the
+// points are considered.
+func (bp *basePlanner) plan(ctx context.Context, start, goal r3.Vector) ([]r3.Vector, error) {
+	start.Z, goal.Z = 0, 0
+	if !bp.collisionFree(start) {
+		return nil, errBaseStartInCollision
+	}
+	if !bp.collisionFree(goal) {
+		return nil, errBaseGoalInCollision
+	}
+	if !bp.inBounds(goal) {
+		return nil, errBaseGoalOutOfBounds
+	}
+	if bp.segmentValid(start, goal) {
+		return []r3.Vector{start, goal}, nil
+	}
+
+	path, err := bp.rrtConnect(ctx, start, goal)
+	if err != nil {
+		return nil, err
+	}
+	return bp.shortcut(path), nil
+}
+
+type baseTreeNode struct {
+	point  r3.Vector
+	parent *baseTreeNode
+}
+
+// rrtConnect grows a tree from each of start and goal towards random samples and towards each
+// other until they meet.
+func (bp *basePlanner) rrtConnect(ctx context.Context, start, goal r3.Vector) ([]r3.Vector, error) {
+	dist := start.Distance(goal)
+	stepSize := math.Max(dist/basePlanStepsPerPath, bp.radius)
+
+	// sample within a box around start and goal, grown so that paths can go around obstacles.
+	margin := dist/2 + 4*bp.radius
+	minPt := r3.Vector{X: math.Min(start.X, goal.X) - margin, Y: math.Min(start.Y, goal.Y) - margin}
+	maxPt := r3.Vector{X: math.Max(start.X, goal.X) + margin, Y: math.Max(start.Y, goal.Y) + margin}
+
+	treeA := []*baseTreeNode{{point: start}}
+	treeB := []*baseTreeNode{{point: goal}}
+	aFromStart := true
+	for i := 0; i < bp.iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sample := r3.Vector{
+			X: minPt.X + bp.rand.Float64()*(maxPt.X-minPt.X),
+			Y: minPt.Y + bp.rand.Float64()*(maxPt.Y-minPt.Y),
+		}
+		newA := bp.extend(&treeA, sample, stepSize)
+		if newA != nil {
+			// try to reach the new node from the other tree.
+			var newB *baseTreeNode
+			for {
+				next := bp.extend(&treeB, newA.point, stepSize)
+				if next == nil {
+					break
+				}
+				newB = next
+				if next.point.Distance(newA.point) < 1e-6 {
+					startNode, goalNode := newA, newB
+					if !aFromStart {
+						startNode, goalNode = newB, newA
+					}
+					return joinBaseTrees(startNode, goalNode), nil
+				}
+			}
+		}
+		treeA, treeB = treeB, treeA
+		aFromStart = !aFromStart
+	}
+	return nil, errBasePlanNotFound
+}
+
+// extend grows a tree by at most stepSize from its nearest node towards target, returning the
+// new node or nil if the way is blocked.
+func (bp *basePlanner) extend(tree *[]*baseTreeNode, target r3.Vector, stepSize float64) *baseTreeNode {
+	nearest := (*tree)[0]
+	for _, node := range *tree {
+		if node.point.Distance(target) < nearest.point.Distance(target) {
+			nearest = node
+		}
+	}
+	to := target
+	if d := nearest.point.Distance(target); d > stepSize {
+		to = nearest.point.Add(target.Sub(nearest.point).Mul(stepSize / d))
+	}
+	if to.Distance(nearest.point) < 1e-6 || !bp.segmentValid(nearest.point, to) {
+		return nil
+	}
+	node := &baseTreeNode{point: to, parent: nearest}
+	*tree = append(*tree, node)
+	return node
+}
+
+func joinBaseTrees(startNode, goalNode *baseTreeNode) []r3.Vector {
+	var path []r3.Vector
+	for node := startNode; node != nil; node = node.parent {
+		path = append([]r3.Vector{node.point}, path...)
+	}
+	// the nodes where the trees meet are at the same point.
+	for node := goalNode.parent; node != nil; node = node.parent {
+		path = append(path, node.point)
+	}
+	return path
+}
+
+// shortcut removes points from a path wherever the base can drive straight past them.
+func (bp *basePlanner) shortcut(path []r3.Vector) []r3.Vector {
+	shortened := []r3.Vector{path[0]}
+	for i := 0; i < len(path)-1; {
+		next := i + 1
+		for j := len(path) - 1; j > i+1; j-- {
+			if bp.segmentValid(path[i], path[j]) {
+				next = j
+				break
+			}
+		}
+		shortened = append(shortened, path[next])
+		i = next
+	}
+	return shortened
+}
+
+// segmentValid returns whether the base can drive straight from a to b.
+func (bp *basePlanner) segmentValid(a, b r3.Vector) bool {
+	checkStep := math.Max(bp.radius/2, 1)
+	steps := int(math.Ceil(a.Distance(b) / checkStep))
+	for i := 0; i <= steps; i++ {
+		pt := a
+		if steps > 0 {
+			pt = a.Add(b.Sub(a).Mul(float64(i) / float64(steps)))
+		}
+		if !bp.collisionFree(pt) || !bp.inBounds(pt) {
+			return false
+		}
+	}
+	return true
+}
+
+func (bp *basePlanner) collisionFree(pt r3.Vector) bool {
+	if len(bp.obstacles) == 0 {
+		return true
+	}
+	var footprint spatialmath.Geometry
+	if bp.radius > 0 {
+		var err error
+		footprint, err = spatialmath.NewSphere(spatialmath.NewPoseFromPoint(pt), bp.radius, "")
+		if err != nil {
+			return false
+		}
+	} else {
+		footprint = spatialmath.NewPoint(pt, "")
+	}
+	for _, obstacle := range bp.obstacles {
+		// obstacles may be point cloud maps, which only know how to collide with other geometries.
+		collides, _, err := obstacle.CollidesWith(footprint, 0)
+		if err != nil || collides {
+			return false
+		}
+	}
+	return true
+}
+
+func (bp *basePlanner) inBounds(pt r3.Vector) bool {
+	if len(bp.boundingRegions) == 0 {
+		return true
+	}
+	point := spatialmath.NewPoint(pt, "")
+	for _, region := range bp.boundingRegions {
+		// allow for rounding error in finding the closest point of the region.
+		if inside, _, err := point.CollidesWith(region, 1e-6); err == nil && inside {
+			return true
+		}
+	}
+	return false
+}
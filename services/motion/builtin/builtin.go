@@ -55,6 +55,7 @@ func init() {
 // export keys to be used with DoCommand so they can be referenced by clients.
 const (
 	DoPlan              = "plan"
+	DoPlanTiming        = "plan_timing"
 	DoExecute           = "execute"
 	DoExecuteCheckStart = "executeCheckStart"
 
@@ -62,6 +63,8 @@ const (
 	DoTeleopMove   = "teleop_move"
 	DoTeleopStop   = "teleop_stop"
 	DoTeleopStatus = "teleop_status"
+
+	DoPlanCacheStats = "plan_cache_stats"
 )
 
 const (
@@ -75,6 +78,7 @@ const (
 	defaultGlobePlanDeviationM         = 2.6
 	defaultCollisionBuffer             = 150. // mm
 	defaultExecuteEpsilon              = 0.01 // rad or mm
+	defaultOccupancyThreshold          = 50   // percent
 )
 
 // inputEnabledActuator is an actuator that interacts with the frame system.
@@ -97,6 +101,17 @@ type Config struct {
 
 	// example { "arm" : { "3" : { "min" : 0, "max" : 2 } } }
 	InputRangeOverride map[string]map[string]referenceframe.Limit `json:"input_range_override"`
+
+	// DynamicLimits override the velocity, acceleration and jerk limits of a component's joints,
+	// in radians or mm per second, used to time trajectories for components that follow timed inputs.
+	// example { "arm" : { "0" : { "velocity" : 1, "acceleration" : 2 } } }
+	DynamicLimits map[string]map[string]referenceframe.DynamicLimit `json:"dynamic_limits"`
+
+	// PlanCacheSize is how many plans to remember for reuse by repeated requests. 0 disables caching.
+	PlanCacheSize int `json:"plan_cache_size"`
+	// PlanCacheStartTolerance is how far, in radians or mm, a component may be from where a cached plan
+	// started for the plan to be reused.
+	PlanCacheStartTolerance float64 `json:"plan_cache_start_tolerance"`
 }
 
 func (c *Config) shouldWritePlan(start time.Time, err error) bool {
@@ -126,6 +141,18 @@ func (c *Config) Validate(path string) ([]string, []string, error) {
 		return nil, nil, fmt.Errorf("need a plan_file_path if you sent LogSlowPlanThresholdMS to %v", c.LogSlowPlanThresholdMS)
 	}
 
+	if c.PlanCacheSize < 0 || c.PlanCacheStartTolerance < 0 {
+		return nil, nil, errors.New("plan_cache_size and plan_cache_start_tolerance may not be negative")
+	}
+
+	for name, limits := range c.DynamicLimits {
+		for joint, limit := range limits {
+			if limit.Velocity < 0 || limit.Acceleration < 0 || limit.Jerk < 0 {
+				return nil, nil, fmt.Errorf("dynamic limits of joint %s of %s may not be negative", joint, name)
+			}
+		}
+	}
+
 	return []string{framesystem.InternalServiceName.String()}, nil, nil
 }
 
@@ -140,6 +167,8 @@ type builtIn struct {
 	components              map[string]resource.Resource
 	logger                  logging.Logger
 	configuredDefaultExtras map[string]any
+	executions              *executionStore
+	planCache               *armplanning.PlanCache
 
 	// Teleop pipeline. Protected by teleopMu (separate from mu to simplify lock ordering).
 	teleopMu       sync.RWMutex
@@ -154,6 +183,7 @@ func NewBuiltIn(
 		Named:                   conf.ResourceName().AsNamed(),
 		logger:                  logger,
 		configuredDefaultExtras: make(map[string]any),
+		executions:              newExecutionStore(),
 	}
 
 	if err := ms.Reconfigure(ctx, deps, conf); err != nil {
@@ -176,6 +206,9 @@ func (ms *builtIn) Reconfigure(
 	}
 	ms.teleopMu.Unlock()
 
+	// Stop executions before acquiring write lock as their dependencies may change.
+	ms.executions.stopAll()
+
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
 	config, err := resource.NativeConfig[*Config](conf)
@@ -191,6 +224,10 @@ func (ms *builtIn) Reconfigure(
 	if config.NumThreads > 0 {
 		ms.configuredDefaultExtras["num_threads"] = config.NumThreads
 	}
+	ms.planCache = nil
+	if config.PlanCacheSize > 0 {
+		ms.planCache = armplanning.NewPlanCache(config.PlanCacheSize, config.PlanCacheStartTolerance)
+	}
 
 	movementSensors := make(map[string]movementsensor.MovementSensor)
 	slamServices := make(map[string]slam.Service)
@@ -226,6 +263,7 @@ func (ms *builtIn) Close(ctx context.Context) error {
 	}
 	ms.teleopMu.Unlock()
 
+	ms.executions.stopAll()
 	return nil
 }
 
@@ -235,22 +273,19 @@ func (ms *builtIn) Move(ctx context.Context, req motion.MoveReq) (bool, error) {
 	operation.CancelOtherWithLabel(ctx, builtinOpLabel)
 
 	ms.applyDefaultExtras(req.Extra)
+	operation.SetProgress(ctx, operation.Progress{Message: "planning"})
 	plan, err := ms.plan(ctx, req, ms.logger)
 	if err != nil {
 		return false, err
 	}
-	err = ms.execute(ctx, plan.Trajectory(), math.MaxFloat64)
+	if coordinatedExtra(req.Extra) {
+		err = ms.executeLockstep(ctx, plan)
+	} else {
+		err = ms.execute(ctx, plan.Trajectory(), math.MaxFloat64)
+	}
 	return err == nil, err
 }
 
-func (ms *builtIn) MoveOnMap(ctx context.Context, req motion.MoveOnMapReq) (motion.ExecutionID, error) {
-	return uuid.Nil, fmt.Errorf("MoveOnMap not supported by builtin")
-}
-
-func (ms *builtIn) MoveOnGlobe(ctx context.Context, req motion.MoveOnGlobeReq) (motion.ExecutionID, error) {
-	return uuid.Nil, fmt.Errorf("MoveOnGlobeReqe not supported by builtin")
-}
-
 // GetPose is deprecated.
 func (ms *builtIn) GetPose(
 	ctx context.Context,
@@ -269,32 +304,39 @@ func (ms *builtIn) StopPlan(
 	ctx context.Context,
 	req motion.StopPlanReq,
 ) error {
-	return fmt.Errorf("StopPlan not supported by builtin")
+	ms.executions.stop(req.ComponentName)
+	return nil
 }
 
 func (ms *builtIn) ListPlanStatuses(
 	ctx context.Context,
 	req motion.ListPlanStatusesReq,
 ) ([]motion.PlanStatusWithID, error) {
-	return nil, fmt.Errorf("ListPlanStatuses not supported by builtin")
+	return ms.executions.listPlanStatuses(req.OnlyActivePlans), nil
 }
 
 func (ms *builtIn) PlanHistory(
 	ctx context.Context,
 	req motion.PlanHistoryReq,
 ) ([]motion.PlanWithStatus, error) {
-	return nil, fmt.Errorf("PlanHistory not supported by builtin")
+	return ms.executions.planHistory(req)
 }
 
-// DoCommand supports two commands which are specified through the command map
+// DoCommand supports these commands which are specified through the command map
 //   - DoPlan generates and returns a Trajectory for a given motionpb.MoveRequest without executing it
 //     required key: DoPlan
 //     input value: a motionpb.MoveRequest which will be used to create a Trajectory
 //     output value: a motionplan.Trajectory specified as a map (the mapstructure.Decode function is useful for decoding this)
+//     If the dynamic limits of the components it moves are known, the plan is also returned timed
+//     under DoPlanTiming, as a list of maps of the "inputs" of each component at a "time_s".
 //   - DoExecute takes a Trajectory and executes it
 //     required key: DoExecute
 //     input value: a motionplan.Trajectory
 //     output value: a bool
+//   - DoPlanCacheStats returns how often the plan cache has been hit and missed
+//     required key: DoPlanCacheStats
+//     input value: ignored
+//     output value: a map of hits, misses, invalidations and entries
 func (ms *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
 	// Handle teleop commands first (they manage their own locking).
 	if resp, handled, err := ms.handleTeleopCommand(ctx, cmd); handled {
@@ -358,6 +400,9 @@ func (ms *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (m
 		}
 
 		resp[DoPlan] = plan.Trajectory()
+		if timed, ok := plan.(*motionplan.TimedPlan); ok {
+			resp[DoPlanTiming] = timedPlanSamples(timed.Timing(), defaultPlanTimingInterval)
+		}
 	}
 	if req, ok := cmd[DoExecute]; ok {
 		var trajectory motionplan.Trajectory
@@ -382,6 +427,18 @@ func (ms *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (m
 		}
 		resp[DoExecute] = true
 	}
+	if _, ok := cmd[DoPlanCacheStats]; ok {
+		if ms.planCache == nil {
+			return nil, errors.New("plan caching is not enabled, set plan_cache_size to enable it")
+		}
+		stats := ms.planCache.Stats()
+		resp[DoPlanCacheStats] = map[string]interface{}{
+			"hits":          stats.Hits,
+			"misses":        stats.Misses,
+			"invalidations": stats.Invalidations,
+			"entries":       stats.Entries,
+		}
+	}
 	return resp, nil
 }
 
@@ -440,7 +497,19 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 	if err != nil {
 		return nil, err
 	}
+	plan, err := ms.planInFrameSystem(ctx, req, frameSys, logger)
+	if err != nil {
+		return nil, err
+	}
+	return ms.timedPlan(ctx, frameSys, plan, logger)
+}
 
+func (ms *builtIn) planInFrameSystem(
+	ctx context.Context,
+	req motion.MoveReq,
+	frameSys *referenceframe.FrameSystem,
+	logger logging.Logger,
+) (motionplan.Plan, error) {
 	// build maps of relevant components and inputs from initial inputs
 	fsInputs, err := ms.fsService.CurrentInputs(ctx)
 	if err != nil {
@@ -457,6 +526,9 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 	if err != nil {
 		return nil, err
 	}
+	if primitiveIface, ok := req.Extra["motion_primitive"]; ok {
+		return ms.planPrimitive(ctx, req, frameSys, startState, primitiveIface, logger)
+	}
 	if len(waypoints) == 0 {
 		return nil, errors.New("could not find any waypoints to plan for in MoveRequest. Fill in Destination or goal_state")
 	}
@@ -497,6 +569,25 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 		return nil, err
 	}
 
+	if coordinatedExtra(req.Extra) {
+		if len(worldWaypoints) != 1 {
+			return nil, errors.New("coordinated motion needs a single goal_state giving the goal of each component")
+		}
+		priority, err := priorityExtra(req.Extra)
+		if err != nil {
+			return nil, err
+		}
+		return armplanning.PlanCoordinated(ctx, logger, &armplanning.CoordinatedPlanRequest{
+			FrameSystem:    frameSys,
+			StartState:     startState,
+			Goal:           worldWaypoints[0],
+			Priority:       priority,
+			WorldState:     req.WorldState,
+			Constraints:    req.Constraints,
+			PlannerOptions: planOpts,
+		})
+	}
+
 	// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
 
 	planRequest := &armplanning.PlanRequest{
@@ -509,7 +600,12 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 	}
 
 	start := time.Now()
-	plan, _, err := armplanning.PlanMotion(ctx, logger, planRequest)
+	var plan motionplan.Plan
+	if ms.planCache != nil {
+		plan, _, err = ms.planCache.PlanMotion(ctx, logger, planRequest)
+	} else {
+		plan, _, err = armplanning.PlanMotion(ctx, logger, planRequest)
+	}
 	if ms.conf.shouldWritePlan(start, err) {
 		var traceID string
 		if span := trace.FromContext(ctx); span != nil {
@@ -532,6 +628,47 @@ func (ms *builtIn) plan(ctx context.Context, req motion.MoveReq, logger logging.
 	return plan, err
 }
 
+// planPrimitive plans for the component to follow the motion primitive given in the extras of a
+// request. Unless it is a joint spline, the primitive ends at the request's destination.
+func (ms *builtIn) planPrimitive(
+	ctx context.Context,
+	req motion.MoveReq,
+	frameSys *referenceframe.FrameSystem,
+	startState *armplanning.PlanState,
+	primitiveIface interface{},
+	logger logging.Logger,
+) (motionplan.Plan, error) {
+	primitiveMap, ok := primitiveIface.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("extras motion_primitive could not be interpreted as map[string]interface{}")
+	}
+	primitive, err := armplanning.DeserializeMotionPrimitive(primitiveMap)
+	if err != nil {
+		return nil, err
+	}
+	if primitive.Frame == "" {
+		primitive.Frame = req.ComponentName
+	}
+	if primitive.Type != armplanning.JointSplinePrimitive {
+		if req.Destination == nil {
+			return nil, fmt.Errorf("%s motion primitive needs a destination", primitive.Type)
+		}
+		primitive.Poses = append(primitive.Poses, req.Destination)
+	}
+	planOpts, err := armplanning.NewPlannerOptionsFromExtra(req.Extra)
+	if err != nil {
+		return nil, err
+	}
+	return armplanning.PlanPrimitive(ctx, logger, &armplanning.PrimitiveRequest{
+		FrameSystem:        frameSys,
+		StartConfiguration: startState.Configuration(),
+		Primitive:          primitive,
+		WorldState:         req.WorldState,
+		Constraints:        req.Constraints,
+		PlannerOptions:     planOpts,
+	})
+}
+
 // planTeleop is a low-latency variant of plan() for the teleop pipeline.
 // It uses a pre-built frame system and caller-provided fsInputs (merged from
 // live CurrentInputs + planning head) to avoid per-call overhead.
@@ -675,7 +812,8 @@ func (ms *builtIn) execute(ctx context.Context, trajectory motionplan.Trajectory
 	}
 	combinedSteps = append(combinedSteps, currStep)
 
-	for _, step := range combinedSteps {
+	for i, step := range combinedSteps {
+		operation.SetProgress(ctx, operation.Progress{Current: i + 1, Total: len(combinedSteps), Message: "executing move"})
 		for name, inputs := range step {
 			if len(inputs) == 0 {
 				continue
@@ -688,7 +826,7 @@ func (ms *builtIn) execute(ctx context.Context, trajectory motionplan.Trajectory
 			if err != nil {
 				return err
 			}
-			if err := ie.GoToInputs(ctx, inputs...); err != nil {
+			if err := ms.goToInputs(ctx, name, ie, inputs); err != nil {
 				// If there is an error on GoToInputs, stop the component if possible before returning the error
 				if actuator, ok := r.(inputEnabledActuator); ok {
 					if stopErr := actuator.Stop(ctx, nil); stopErr != nil {
@@ -1,33 +1,45 @@
 package builtin
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image/color"
+	"io"
 	"math"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
 	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils/protoutils"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/movementsensor"
 	_ "go.viam.com/rdk/components/register"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/logging"
 	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/motionplan/armplanning"
+	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot/framesystem"
 	robotimpl "go.viam.com/rdk/robot/impl"
 	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/services/slam"
+	"go.viam.com/rdk/services/vision"
 	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
 )
 
 func setupMotionServiceFromConfig(t *testing.T, configFilename string) (motion.Service, func()) {
@@ -126,6 +138,25 @@ func TestArmMove(t *testing.T) {
 		_, err = ms.Move(context.Background(), moveReq)
 		test.That(t, err, test.ShouldBeNil)
 	})
+
+	t.Run("follows a motion primitive given in extras", func(t *testing.T) {
+		ms, teardown := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
+		defer teardown()
+		grabPose := referenceframe.NewPoseInFrame("pieceArm", spatialmath.NewPoseFromPoint(r3.Vector{X: 0, Y: -30, Z: -50}))
+		_, err = ms.Move(ctx, motion.MoveReq{
+			ComponentName: "pieceArm",
+			Destination:   grabPose,
+			Extra:         map[string]interface{}{"motion_primitive": map[string]interface{}{"type": "linear"}},
+		})
+		test.That(t, err, test.ShouldBeNil)
+
+		_, err = ms.Move(ctx, motion.MoveReq{
+			ComponentName: "pieceArm",
+			Destination:   grabPose,
+			Extra:         map[string]interface{}{"motion_primitive": map[string]interface{}{"type": "arc"}},
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
 }
 
 func TestArmMoveWithObstacles(t *testing.T) {
@@ -750,3 +781,816 @@ func TestWritePlanRequest(t *testing.T) {
 	// Verify the filename contains the custom tag
 	test.That(t, planFile.Name(), test.ShouldContainSubstring, "custom-test-tag")
 }
+
+// simulatedBase is a base on the globe whose position is reported by a movement sensor.
+type simulatedBase struct {
+	mu     sync.Mutex
+	origin *geo.Point
+	// position is in mm east and north of origin and theta is counterclockwise from north.
+	position r3.Vector
+	theta    float64
+	// driftMM is how far the next straight move drifts to the right.
+	driftMM float64
+	visited []r3.Vector
+}
+
+func newSimulatedBase(origin *geo.Point) (*simulatedBase, *inject.Base, *inject.MovementSensor) {
+	sim := &simulatedBase{origin: origin}
+
+	b := inject.NewBase("base")
+	b.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (base.Properties, error) {
+		return base.Properties{WidthMeters: 0.6, TurningRadiusMeters: 0}, nil
+	}
+	b.GeometriesFunc = func(ctx context.Context) ([]spatialmath.Geometry, error) {
+		return nil, nil
+	}
+	b.SpinFunc = func(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.theta = normalizeDegrees(sim.theta + angleDeg)
+		return nil
+	}
+	b.MoveStraightFunc = func(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+		const stepMM = 100.
+		sim.mu.Lock()
+		heading := utils.DegToRad(sim.theta)
+		forward := r3.Vector{X: -math.Sin(heading), Y: math.Cos(heading)}
+		right := r3.Vector{X: forward.Y, Y: -forward.X}
+		steps := int(math.Ceil(float64(distanceMm) / stepMM))
+		drift := sim.driftMM
+		sim.driftMM = 0
+		sim.mu.Unlock()
+
+		for i := 0; i < steps; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			step := math.Min(stepMM, float64(distanceMm)-float64(i)*stepMM)
+			sim.mu.Lock()
+			sim.position = sim.position.Add(forward.Mul(step)).Add(right.Mul(drift / float64(steps)))
+			sim.visited = append(sim.visited, sim.position)
+			sim.mu.Unlock()
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	}
+	b.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		return nil
+	}
+
+	ms := inject.NewMovementSensor("gps")
+	ms.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (*movementsensor.Properties, error) {
+		return &movementsensor.Properties{PositionSupported: true, CompassHeadingSupported: true}, nil
+	}
+	ms.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		bearing := utils.RadToDeg(math.Atan2(sim.position.X, sim.position.Y))
+		return sim.origin.PointAtDistanceAndBearing(sim.position.Norm()*1e-6, bearing), 0, nil
+	}
+	ms.CompassHeadingFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return math.Mod(360-sim.theta, 360), nil
+	}
+	return sim, b, ms
+}
+
+// slamService returns a SLAM service which localizes the base and returns maps from mapFunc.
+func (sim *simulatedBase) slamService(t *testing.T, mapFunc func() []r3.Vector) *inject.SLAMService {
+	t.Helper()
+	svc := inject.NewSLAMService("slam")
+	svc.PositionFunc = func(ctx context.Context) (spatialmath.Pose, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		// SLAM poses point along +X rather than +Y.
+		return spatialmath.NewPose(sim.position, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: sim.theta + 90}), nil
+	}
+	svc.PointCloudMapFunc = func(ctx context.Context, returnEditedMap bool) (func() ([]byte, error), error) {
+		pc := pointcloud.NewBasicPointCloud(0)
+		for _, pt := range mapFunc() {
+			test.That(t, pc.Set(pt, pointcloud.NewBasicData()), test.ShouldBeNil)
+		}
+		var buf bytes.Buffer
+		test.That(t, pointcloud.ToPCD(pc, &buf, pointcloud.PCDBinary), test.ShouldBeNil)
+		return func() ([]byte, error) {
+			if buf.Len() == 0 {
+				return nil, io.EOF
+			}
+			return buf.Next(buf.Len()), nil
+		}, nil
+	}
+	return svc
+}
+
+func (sim *simulatedBase) pose() (r3.Vector, float64, []r3.Vector) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return sim.position, sim.theta, append([]r3.Vector(nil), sim.visited...)
+}
+
+func TestMoveOnGlobe(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	origin := geo.NewPoint(40.7, -73.98)
+	// about 20m north east of origin.
+	destination := origin.PointAtDistanceAndBearing(0.02, 45)
+	goal := spatialmath.GeoPointToPoint(destination, origin)
+	motionCfg := &motion.MotionConfiguration{LinearMPerSec: 1, AngularDegsPerSec: 90}
+
+	setup := func(t *testing.T) (*simulatedBase, motion.Service) {
+		t.Helper()
+		sim, b, movementSensor := newSimulatedBase(origin)
+		deps := resource.Dependencies{b.Name(): b, movementSensor.Name(): movementSensor}
+		ms, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: &Config{}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		t.Cleanup(func() { test.That(t, ms.Close(ctx), test.ShouldBeNil) })
+		return sim, ms
+	}
+
+	waitForPlan := func(t *testing.T, ms motion.Service, executionID motion.ExecutionID) error {
+		t.Helper()
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		return motion.PollHistoryUntilSuccessOrError(timeoutCtx, ms, time.Millisecond*10,
+			motion.PlanHistoryReq{ComponentName: "base", ExecutionID: executionID})
+	}
+
+	t.Run("fails on invalid requests", func(t *testing.T) {
+		_, ms := setup(t)
+		req := motion.MoveOnGlobeReq{ComponentName: "base", MovementSensorName: "gps", Destination: destination}
+
+		badReq := req
+		badReq.Destination = nil
+		_, err := ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, errors.New("destination cannot be nil"))
+
+		badReq = req
+		badReq.ComponentName = "missing"
+		_, err = ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, resource.DependencyNotFoundError(base.Named("missing")))
+
+		badReq = req
+		badReq.MovementSensorName = "missing"
+		_, err = ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, resource.DependencyNotFoundError(movementsensor.Named("missing")))
+
+		badReq = req
+		badReq.MotionCfg = &motion.MotionConfiguration{LinearMPerSec: -1}
+		_, err = ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, errors.New("LinearMPerSec may not be negative"))
+
+		badReq = req
+		badReq.MotionCfg = &motion.MotionConfiguration{
+			ObstacleDetectors: []motion.ObstacleDetectorName{{VisionServiceName: "missing", CameraName: "camera"}},
+		}
+		_, err = ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, resource.DependencyNotFoundError(vision.Named("missing")))
+
+		badReq = req
+		badReq.Destination = origin.PointAtDistanceAndBearing(6000, 0)
+		_, err = ms.MoveOnGlobe(ctx, badReq)
+		test.That(t, err, test.ShouldBeError, errors.New("cannot move more than 5 kilometers"))
+
+		// no execution was started.
+		statuses, err := ms.ListPlanStatuses(ctx, motion.ListPlanStatusesReq{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statuses, test.ShouldBeEmpty)
+		_, err = ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("reaches the destination", func(t *testing.T) {
+		sim, ms := setup(t)
+		executionID, err := ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        destination,
+			Heading:            90,
+			MotionCfg:          motionCfg,
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeNil)
+
+		position, theta, _ := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeLessThan, 10)
+		test.That(t, theta, test.ShouldAlmostEqual, -90)
+
+		history, err := ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history, test.ShouldHaveLength, 1)
+		test.That(t, history[0].Plan.ExecutionID, test.ShouldEqual, executionID)
+		test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateSucceeded)
+		test.That(t, history[0].StatusHistory[1].State, test.ShouldEqual, motion.PlanStateInProgress)
+
+		// the path is reported in GPS coordinates.
+		path := history[0].Plan.Path()
+		last := path[len(path)-1]["base"].Pose().Point()
+		test.That(t, last.Y, test.ShouldAlmostEqual, destination.Lat(), 1e-6)
+		test.That(t, last.X, test.ShouldAlmostEqual, destination.Lng(), 1e-6)
+
+		statuses, err := ms.ListPlanStatuses(ctx, motion.ListPlanStatusesReq{OnlyActivePlans: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statuses, test.ShouldBeEmpty)
+
+		// stopping a finished execution does nothing.
+		test.That(t, ms.StopPlan(ctx, motion.StopPlanReq{ComponentName: "base"}), test.ShouldBeNil)
+		history, err = ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateSucceeded)
+	})
+
+	t.Run("avoids obstacles", func(t *testing.T) {
+		sim, ms := setup(t)
+		// a 4m box half way to the destination.
+		box, err := spatialmath.NewBox(spatialmath.NewZeroPose(), r3.Vector{X: 4000, Y: 4000, Z: 10}, "box")
+		test.That(t, err, test.ShouldBeNil)
+		obstacle := spatialmath.NewGeoGeometry(origin.PointAtDistanceAndBearing(0.01, 45), []spatialmath.Geometry{box})
+		executionID, err := ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        destination,
+			Heading:            math.NaN(),
+			Obstacles:          []*spatialmath.GeoGeometry{obstacle},
+			MotionCfg:          motionCfg,
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeNil)
+
+		position, _, visited := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeLessThan, 10)
+		geometries := spatialmath.GeoGeometriesToGeometries([]*spatialmath.GeoGeometry{obstacle}, origin)
+		for _, pt := range visited {
+			collides, _, err := spatialmath.NewPoint(pt, "").CollidesWith(geometries[0], 0)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, collides, test.ShouldBeFalse)
+		}
+
+		// destinations in collision are rejected.
+		_, err = ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        origin.PointAtDistanceAndBearing(0.01, 45),
+			Obstacles:          []*spatialmath.GeoGeometry{obstacle},
+		})
+		test.That(t, err, test.ShouldBeError, errBaseGoalInCollision)
+	})
+
+	t.Run("replans after deviating", func(t *testing.T) {
+		sim, ms := setup(t)
+		sim.driftMM = 5000
+		pollingFreqHz := 100.
+		executionID, err := ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        destination,
+			Heading:            math.NaN(),
+			MotionCfg: &motion.MotionConfiguration{
+				LinearMPerSec:         1,
+				AngularDegsPerSec:     90,
+				PlanDeviationMM:       1000,
+				PositionPollingFreqHz: &pollingFreqHz,
+			},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeNil)
+
+		position, _, _ := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeLessThan, 10)
+
+		history, err := ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history, test.ShouldHaveLength, 2)
+		test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateSucceeded)
+		test.That(t, history[1].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateFailed)
+		test.That(t, *history[1].StatusHistory[0].Reason, test.ShouldContainSubstring, "deviated")
+		test.That(t, history[0].Plan.ExecutionID, test.ShouldEqual, history[1].Plan.ExecutionID)
+
+		history, err = ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base", LastPlanOnly: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history, test.ShouldHaveLength, 1)
+	})
+
+	t.Run("stops when asked", func(t *testing.T) {
+		sim, ms := setup(t)
+		executionID, err := ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        destination,
+			Heading:            math.NaN(),
+			MotionCfg:          motionCfg,
+		})
+		test.That(t, err, test.ShouldBeNil)
+
+		statuses, err := ms.ListPlanStatuses(ctx, motion.ListPlanStatusesReq{OnlyActivePlans: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statuses, test.ShouldHaveLength, 1)
+		test.That(t, statuses[0].ExecutionID, test.ShouldEqual, executionID)
+
+		test.That(t, ms.StopPlan(ctx, motion.StopPlanReq{ComponentName: "base"}), test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeError, errors.New("plan stopped"))
+		position, _, _ := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeGreaterThan, 10)
+
+		// a new execution is kept separately from the stopped one.
+		secondID, err := ms.MoveOnGlobe(ctx, motion.MoveOnGlobeReq{
+			ComponentName:      "base",
+			MovementSensorName: "gps",
+			Destination:        destination,
+			Heading:            math.NaN(),
+			MotionCfg:          motionCfg,
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, secondID), test.ShouldBeNil)
+		history, err := ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base", ExecutionID: executionID})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateStopped)
+		statuses, err = ms.ListPlanStatuses(ctx, motion.ListPlanStatusesReq{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, statuses, test.ShouldHaveLength, 2)
+	})
+}
+
+// roomMap returns the points of a 10m by 14m room around the origin. If partitioned, a wall
+// with a 3m gap at its east end splits the room in two at Y=5m.
+func roomMap(partitioned bool) []r3.Vector {
+	var pts []r3.Vector
+	for x := -5000.; x <= 5000; x += 100 {
+		pts = append(pts, r3.Vector{X: x, Y: -2000}, r3.Vector{X: x, Y: 12000})
+		if partitioned && x <= 2000 {
+			pts = append(pts, r3.Vector{X: x, Y: 5000})
+		}
+	}
+	for y := -2000.; y <= 12000; y += 100 {
+		pts = append(pts, r3.Vector{X: -5000, Y: y}, r3.Vector{X: 5000, Y: y})
+	}
+	return pts
+}
+
+func TestMoveOnMap(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	// the destination faces +X in the map, which is west for a base.
+	destination := spatialmath.NewPoseFromPoint(r3.Vector{Y: 10000})
+	goal := destination.Point()
+	partition := func(t *testing.T, visited []r3.Vector) {
+		t.Helper()
+		for _, pt := range visited {
+			if pt.X <= 2000 {
+				test.That(t, math.Abs(pt.Y-5000), test.ShouldBeGreaterThan, 300)
+			}
+		}
+	}
+
+	setup := func(t *testing.T, mapFunc func() []r3.Vector) (*simulatedBase, motion.Service) {
+		t.Helper()
+		sim, b, _ := newSimulatedBase(geo.NewPoint(0, 0))
+		slamSvc := sim.slamService(t, mapFunc)
+		deps := resource.Dependencies{b.Name(): b, slamSvc.Name(): slamSvc}
+		ms, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: &Config{}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		t.Cleanup(func() { test.That(t, ms.Close(ctx), test.ShouldBeNil) })
+		return sim, ms
+	}
+
+	waitForPlan := func(t *testing.T, ms motion.Service, executionID motion.ExecutionID) error {
+		t.Helper()
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		return motion.PollHistoryUntilSuccessOrError(timeoutCtx, ms, time.Millisecond*10,
+			motion.PlanHistoryReq{ComponentName: "base", ExecutionID: executionID})
+	}
+
+	t.Run("fails on invalid requests", func(t *testing.T) {
+		_, ms := setup(t, func() []r3.Vector { return roomMap(true) })
+		_, err := ms.MoveOnMap(ctx, motion.MoveOnMapReq{ComponentName: "base", SlamName: "slam"})
+		test.That(t, err, test.ShouldBeError, errors.New("destination cannot be nil"))
+
+		_, err = ms.MoveOnMap(ctx, motion.MoveOnMapReq{ComponentName: "base", SlamName: "missing", Destination: destination})
+		test.That(t, err, test.ShouldBeError, resource.DependencyNotFoundError(slam.Named("missing")))
+
+		// destinations outside of the map are rejected.
+		_, err = ms.MoveOnMap(ctx, motion.MoveOnMapReq{
+			ComponentName: "base",
+			SlamName:      "slam",
+			Destination:   spatialmath.NewPoseFromPoint(r3.Vector{Y: 20000}),
+		})
+		test.That(t, err, test.ShouldBeError, errBaseGoalOutOfBounds)
+
+		// as are destinations on the map's obstacles.
+		_, err = ms.MoveOnMap(ctx, motion.MoveOnMapReq{
+			ComponentName: "base",
+			SlamName:      "slam",
+			Destination:   spatialmath.NewPoseFromPoint(r3.Vector{Y: 5000}),
+		})
+		test.That(t, err, test.ShouldBeError, errBaseGoalInCollision)
+	})
+
+	t.Run("plans around the map", func(t *testing.T) {
+		sim, ms := setup(t, func() []r3.Vector { return roomMap(true) })
+		executionID, err := ms.MoveOnMap(ctx, motion.MoveOnMapReq{
+			ComponentName: "base",
+			SlamName:      "slam",
+			Destination:   destination,
+			MotionCfg:     &motion.MotionConfiguration{LinearMPerSec: 1, AngularDegsPerSec: 90},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeNil)
+
+		position, theta, visited := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeLessThan, 10)
+		test.That(t, theta, test.ShouldAlmostEqual, -90)
+		partition(t, visited)
+
+		history, err := ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, history, test.ShouldHaveLength, 1)
+		test.That(t, len(history[0].Plan.Path()), test.ShouldBeGreaterThan, 2)
+		test.That(t, history[0].Plan.AnchorGeoPose, test.ShouldBeNil)
+	})
+
+	t.Run("replans around new obstacles", func(t *testing.T) {
+		var mu sync.Mutex
+		fetches := 0
+		sim, ms := setup(t, func() []r3.Vector {
+			mu.Lock()
+			defer mu.Unlock()
+			fetches++
+			// the partition is only seen once the base is moving.
+			return roomMap(fetches > 1)
+		})
+		obstaclePollingFreqHz := 100.
+		executionID, err := ms.MoveOnMap(ctx, motion.MoveOnMapReq{
+			ComponentName: "base",
+			SlamName:      "slam",
+			Destination:   destination,
+			MotionCfg: &motion.MotionConfiguration{
+				LinearMPerSec:         1,
+				AngularDegsPerSec:     90,
+				ObstaclePollingFreqHz: &obstaclePollingFreqHz,
+			},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, waitForPlan(t, ms, executionID), test.ShouldBeNil)
+
+		position, _, visited := sim.pose()
+		test.That(t, position.Distance(goal), test.ShouldBeLessThan, 10)
+		partition(t, visited)
+
+		history, err := ms.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "base"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(history), test.ShouldBeGreaterThanOrEqualTo, 2)
+		test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateSucceeded)
+		last := history[len(history)-1]
+		test.That(t, last.StatusHistory[0].State, test.ShouldEqual, motion.PlanStateFailed)
+		test.That(t, *last.StatusHistory[0].Reason, test.ShouldEqual, errBasePathObstructed.Error())
+	})
+}
+
+func TestSlamMapOccupancy(t *testing.T) {
+	ctx := context.Background()
+	slamSvc := inject.NewSLAMService("slam")
+	slamSvc.PointCloudMapFunc = func(ctx context.Context, returnEditedMap bool) (func() ([]byte, error), error) {
+		// SLAM maps carry the probability that a point is occupied in its blue channel.
+		occupied := func(prob uint8) pointcloud.Data {
+			return pointcloud.NewColoredData(color.NRGBA{B: prob, A: 255})
+		}
+		pc := pointcloud.NewBasicPointCloud(0)
+		for y := -1000.; y <= 1000; y += 100 {
+			// a wall SLAM is sure of, with a doubtful point above it, next to an unlikely one.
+			test.That(t, pc.Set(r3.Vector{Y: y}, occupied(90)), test.ShouldBeNil)
+			test.That(t, pc.Set(r3.Vector{Y: y, Z: 500}, occupied(5)), test.ShouldBeNil)
+			test.That(t, pc.Set(r3.Vector{X: 2000, Y: y}, occupied(20)), test.ShouldBeNil)
+		}
+		var buf bytes.Buffer
+		test.That(t, pointcloud.ToPCD(pc, &buf, pointcloud.PCDBinary), test.ShouldBeNil)
+		return func() ([]byte, error) {
+			if buf.Len() == 0 {
+				return nil, io.EOF
+			}
+			return buf.Next(buf.Len()), nil
+		}, nil
+	}
+	collides := func(obstacle spatialmath.Geometry, x float64) bool {
+		t.Helper()
+		box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: x}), r3.Vector{X: 100, Y: 100, Z: 100}, "")
+		test.That(t, err, test.ShouldBeNil)
+		collides, _, err := obstacle.CollidesWith(box, 0)
+		test.That(t, err, test.ShouldBeNil)
+		return collides
+	}
+
+	obstacle, bounds, err := slamMapGeometries(ctx, slamSvc, defaultOccupancyThreshold)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, bounds, test.ShouldNotBeNil)
+	test.That(t, collides(obstacle, 0), test.ShouldBeTrue)
+	test.That(t, collides(obstacle, 2000), test.ShouldBeFalse)
+
+	obstacle, _, err = slamMapGeometries(ctx, slamSvc, 10)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collides(obstacle, 2000), test.ShouldBeTrue)
+
+	// a map without likely obstacles still bounds where the base may go.
+	obstacle, bounds, err = slamMapGeometries(ctx, slamSvc, 95)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacle, test.ShouldBeNil)
+	test.That(t, bounds, test.ShouldNotBeNil)
+}
+
+// timedArm is an arm which follows timed inputs.
+type timedArm struct {
+	*inject.Arm
+	timedInputs [][]referenceframe.TimedInputs
+	// unsupported is set for an arm whose driver turns out not to follow timed inputs.
+	unsupported bool
+}
+
+func (a *timedArm) GoToTimedInputs(ctx context.Context, inputs []referenceframe.TimedInputs) error {
+	if a.unsupported {
+		return framesystem.ErrTimedInputsUnsupported
+	}
+	a.timedInputs = append(a.timedInputs, inputs)
+	return nil
+}
+
+func TestExecuteTimedInputs(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/xarm6.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+
+	setup := func(t *testing.T, limits map[string]map[string]referenceframe.DynamicLimit) (*timedArm, *builtIn, *int) {
+		t.Helper()
+		a := &timedArm{Arm: inject.NewArm("arm")}
+		a.KinematicsFunc = func(ctx context.Context) (referenceframe.Model, error) {
+			return model, nil
+		}
+		a.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+			return make([]referenceframe.Input, 6), nil
+		}
+		a.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			return nil
+		}
+		untimed := 0
+		a.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+			untimed++
+			return nil
+		}
+		deps := resource.Dependencies{a.Name(): a}
+		ms, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: &Config{DynamicLimits: limits}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		t.Cleanup(func() { test.That(t, ms.Close(ctx), test.ShouldBeNil) })
+		return a, ms.(*builtIn), &untimed
+	}
+	trajectory := motionplan.Trajectory{
+		{"arm": {0, 0, 0, 0, 0, 0}},
+		{"arm": {0.5, 0, 0, 0, 0, 0}},
+		{"arm": {0.5, 0.5, 0, 0, 0, 0}},
+	}
+
+	t.Run("falls back to untimed inputs without limits", func(t *testing.T) {
+		a, ms, untimed := setup(t, nil)
+		test.That(t, ms.execute(ctx, trajectory, math.MaxFloat64), test.ShouldBeNil)
+		test.That(t, *untimed, test.ShouldEqual, 1)
+		test.That(t, a.timedInputs, test.ShouldBeEmpty)
+	})
+
+	t.Run("follows timed inputs within configured limits", func(t *testing.T) {
+		limits := map[string]referenceframe.DynamicLimit{}
+		for i := 0; i < 6; i++ {
+			limits[fmt.Sprint(i)] = referenceframe.DynamicLimit{Velocity: 1, Acceleration: 2}
+		}
+		a, ms, untimed := setup(t, map[string]map[string]referenceframe.DynamicLimit{"arm": limits})
+		test.That(t, ms.execute(ctx, trajectory, math.MaxFloat64), test.ShouldBeNil)
+		test.That(t, *untimed, test.ShouldEqual, 0)
+		test.That(t, len(a.timedInputs), test.ShouldEqual, 1)
+
+		samples := a.timedInputs[0]
+		test.That(t, len(samples), test.ShouldBeGreaterThan, 2)
+		test.That(t, samples[0].Inputs, test.ShouldResemble, trajectory[0]["arm"])
+		last := samples[len(samples)-1]
+		for i, input := range trajectory[2]["arm"] {
+			test.That(t, last.Inputs[i], test.ShouldAlmostEqual, input)
+		}
+		for i := 1; i < len(samples); i++ {
+			test.That(t, samples[i].Time, test.ShouldBeGreaterThan, samples[i-1].Time)
+			for _, velocity := range samples[i].Velocities {
+				test.That(t, math.Abs(velocity), test.ShouldBeLessThanOrEqualTo, 1+1e-6)
+			}
+		}
+	})
+
+	t.Run("rejects unknown joints", func(t *testing.T) {
+		_, ms, _ := setup(t, map[string]map[string]referenceframe.DynamicLimit{"arm": {"elbow_nope": {Velocity: 1}}})
+		test.That(t, ms.execute(ctx, trajectory, math.MaxFloat64), test.ShouldNotBeNil)
+	})
+
+	limits := map[string]referenceframe.DynamicLimit{}
+	for i := 0; i < 6; i++ {
+		limits[fmt.Sprint(i)] = referenceframe.DynamicLimit{Velocity: 1, Acceleration: 2}
+	}
+
+	t.Run("falls back to untimed inputs if the arm does not support them after all", func(t *testing.T) {
+		a, ms, untimed := setup(t, map[string]map[string]referenceframe.DynamicLimit{"arm": limits})
+		a.unsupported = true
+		test.That(t, ms.execute(ctx, trajectory, math.MaxFloat64), test.ShouldBeNil)
+		test.That(t, *untimed, test.ShouldEqual, 1)
+	})
+
+	t.Run("times plans", func(t *testing.T) {
+		fs := referenceframe.NewEmptyFrameSystem("test")
+		test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+		plan := motionplan.NewSimplePlan(nil, trajectory)
+
+		_, ms, _ := setup(t, nil)
+		untimedPlan, err := ms.timedPlan(ctx, fs, plan, logger)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, untimedPlan, test.ShouldEqual, plan)
+
+		_, ms, _ = setup(t, map[string]map[string]referenceframe.DynamicLimit{"arm": limits})
+		timedPlan, err := ms.timedPlan(ctx, fs, plan, logger)
+		test.That(t, err, test.ShouldBeNil)
+		timed, ok := timedPlan.(*motionplan.TimedPlan)
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, timed.Trajectory(), test.ShouldResemble, trajectory)
+
+		samples := timedPlanSamples(timed.Timing(), defaultPlanTimingInterval)
+		test.That(t, len(samples), test.ShouldBeGreaterThan, 2)
+		last := samples[len(samples)-1].(map[string]interface{})
+		test.That(t, last["time_s"], test.ShouldEqual, timed.Timing().Duration().Seconds())
+	})
+}
+
+func TestPlanCache(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	t.Run("validates config", func(t *testing.T) {
+		_, _, err := (&Config{PlanCacheSize: -1}).Validate("")
+		test.That(t, err, test.ShouldNotBeNil)
+		_, _, err = (&Config{PlanCacheSize: 10, PlanCacheStartTolerance: 0.01}).Validate("")
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("reports stats only when enabled", func(t *testing.T) {
+		ms, err := NewBuiltIn(ctx, nil, resource.Config{ConvertedAttributes: &Config{}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer test.That(t, ms.Close(ctx), test.ShouldBeNil)
+		_, err = ms.DoCommand(ctx, map[string]interface{}{DoPlanCacheStats: true})
+		test.That(t, err, test.ShouldNotBeNil)
+
+		ms, err = NewBuiltIn(ctx, nil, resource.Config{ConvertedAttributes: &Config{PlanCacheSize: 10}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer test.That(t, ms.Close(ctx), test.ShouldBeNil)
+		resp, err := ms.DoCommand(ctx, map[string]interface{}{DoPlanCacheStats: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[DoPlanCacheStats], test.ShouldResemble, map[string]interface{}{
+			"hits": 0, "misses": 0, "invalidations": 0, "entries": 0,
+		})
+	})
+
+	t.Run("reuses plans for repeated requests", func(t *testing.T) {
+		ms, teardown := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
+		defer teardown()
+		ms.(*builtIn).planCache = armplanning.NewPlanCache(10, 0)
+
+		goalState := armplanning.NewPlanState(nil, referenceframe.FrameSystemInputs{"pieceArm": {0.7, 0.6, 0.5, 0.4, 0.3, 0.2}})
+		moveReq := motion.MoveReq{ComponentName: "pieceGripper", Extra: map[string]interface{}{"goal_state": goalState.Serialize()}}
+		moveReqProto, err := moveReq.ToProto("")
+		test.That(t, err, test.ShouldBeNil)
+		bytes, err := protojson.Marshal(moveReqProto)
+		test.That(t, err, test.ShouldBeNil)
+		first, err := ms.DoCommand(ctx, map[string]interface{}{DoPlan: string(bytes)})
+		test.That(t, err, test.ShouldBeNil)
+		second, err := ms.DoCommand(ctx, map[string]interface{}{DoPlan: string(bytes)})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, second[DoPlan], test.ShouldResemble, first[DoPlan])
+		test.That(t, ms.(*builtIn).planCache.Stats(), test.ShouldResemble, armplanning.PlanCacheStats{Hits: 1, Misses: 1, Entries: 1})
+	})
+}
+
+func TestExecuteLockstep(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	model, err := referenceframe.ParseModelJSONFile(utils.ResolveFile("components/arm/fake/kinematics/xarm6.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	var mu sync.Mutex
+	var events []string
+	stopped := map[string]bool{}
+	newArm := func(name string, fail bool) *inject.Arm {
+		a := inject.NewArm(name)
+		a.KinematicsFunc = func(ctx context.Context) (referenceframe.Model, error) {
+			return model, nil
+		}
+		a.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped[name] = true
+			return nil
+		}
+		a.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+			mu.Lock()
+			events = append(events, fmt.Sprintf("%s start %v", name, inputSteps[0][0]))
+			mu.Unlock()
+			if fail {
+				return errors.New("bad arm")
+			}
+			// the first arm is slower, so the second waits for it at the end of every segment.
+			if name == "a" {
+				time.Sleep(10 * time.Millisecond)
+			}
+			mu.Lock()
+			events = append(events, fmt.Sprintf("%s end %v", name, inputSteps[len(inputSteps)-1][0]))
+			mu.Unlock()
+			return nil
+		}
+		return a
+	}
+	setup := func(t *testing.T, failB bool) *builtIn {
+		t.Helper()
+		events = nil
+		stopped = map[string]bool{}
+		a, b := newArm("a", false), newArm("b", failB)
+		deps := resource.Dependencies{a.Name(): a, b.Name(): b}
+		ms, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: &Config{}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		t.Cleanup(func() { test.That(t, ms.Close(ctx), test.ShouldBeNil) })
+		return ms.(*builtIn)
+	}
+
+	// both arms move for 15 ticks, then b waits 5 ticks while a moves on.
+	trajectory := motionplan.Trajectory{}
+	for i := 0; i <= 20; i++ {
+		step := referenceframe.FrameSystemInputs{
+			"a": {float64(i), 0, 0, 0, 0, 0},
+			"b": {float64(min(i, 15)), 0, 0, 0, 0, 0},
+		}
+		trajectory = append(trajectory, step)
+	}
+
+	t.Run("moves components in lock-step", func(t *testing.T) {
+		ms := setup(t, false)
+		test.That(t, ms.executeLockstep(ctx, motionplan.NewSimplePlan(nil, trajectory)), test.ShouldBeNil)
+		// both arms are sent the first 15 ticks at once, then a alone the last 5 once b has stopped
+		// where a must carry on without it.
+		test.That(t, len(events), test.ShouldEqual, 6)
+		test.That(t, events[:4], test.ShouldContain, "a start 0")
+		test.That(t, events[:4], test.ShouldContain, "b start 0")
+		test.That(t, events[:4], test.ShouldContain, "a end 15")
+		test.That(t, events[:4], test.ShouldContain, "b end 15")
+		test.That(t, events[4:], test.ShouldResemble, []string{"a start 15", "a end 20"})
+	})
+
+	t.Run("stops all components when one fails", func(t *testing.T) {
+		ms := setup(t, true)
+		err := ms.executeLockstep(ctx, motionplan.NewSimplePlan(nil, trajectory))
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "bad arm")
+		test.That(t, stopped, test.ShouldResemble, map[string]bool{"a": true, "b": true})
+		test.That(t, events, test.ShouldNotContain, "a start 15")
+	})
+
+	t.Run("starts timed trajectories together", func(t *testing.T) {
+		a, b := &timedArm{Arm: newArm("a", false)}, &timedArm{Arm: newArm("b", false)}
+		deps := resource.Dependencies{a.Name(): a, b.Name(): b}
+		svc, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: &Config{}}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() { test.That(t, svc.Close(ctx), test.ShouldBeNil) }()
+		ms := svc.(*builtIn)
+
+		limits := make([]referenceframe.DynamicLimit, 6)
+		for i := range limits {
+			limits[i] = referenceframe.DynamicLimit{Velocity: 10, Acceleration: 20}
+		}
+		plan, err := motionplan.NewTimedPlan(motionplan.NewSimplePlan(nil, trajectory), motionplan.TimingOptions{
+			Limits: map[string][]referenceframe.DynamicLimit{"a": limits, "b": limits},
+		})
+		test.That(t, err, test.ShouldBeNil)
+
+		events = nil
+		test.That(t, ms.executeLockstep(ctx, plan), test.ShouldBeNil)
+		test.That(t, events, test.ShouldBeEmpty)
+		// each arm is asked whether it follows timed inputs, then sent its part of the same timing.
+		test.That(t, len(a.timedInputs), test.ShouldEqual, 2)
+		test.That(t, len(b.timedInputs), test.ShouldEqual, 2)
+		samplesA, samplesB := a.timedInputs[1], b.timedInputs[1]
+		test.That(t, len(samplesA), test.ShouldEqual, len(samplesB))
+		for i := range samplesA {
+			test.That(t, samplesA[i].Time, test.ShouldEqual, samplesB[i].Time)
+			if samplesA[i].Inputs[0] <= 15 {
+				test.That(t, samplesB[i].Inputs[0], test.ShouldAlmostEqual, samplesA[i].Inputs[0])
+			}
+		}
+	})
+
+	t.Run("reads coordination extras", func(t *testing.T) {
+		test.That(t, coordinatedExtra(nil), test.ShouldBeFalse)
+		test.That(t, coordinatedExtra(map[string]interface{}{"coordinated": true}), test.ShouldBeTrue)
+		priority, err := priorityExtra(map[string]interface{}{"priority": []interface{}{"b", "a"}})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, priority, test.ShouldResemble, []string{"b", "a"})
+		_, err = priorityExtra(map[string]interface{}{"priority": "b"})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,240 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.viam.com/rdk/services/motion"
+)
+
+// executionTTL is how long the plans of an execution are kept after it ends.
+const executionTTL = 24 * time.Hour
+
+// planRecord is a plan generated during an execution along with its status history, newest
+// first.
+type planRecord struct {
+	plan          motion.PlanWithMetadata
+	statusHistory []motion.PlanStatus
+}
+
+func (pr *planRecord) status() motion.PlanStatus {
+	return pr.statusHistory[0]
+}
+
+func (pr *planRecord) setStatus(state motion.PlanState, reason string) {
+	status := motion.PlanStatus{State: state, Timestamp: time.Now()}
+	if reason != "" {
+		status.Reason = &reason
+	}
+	pr.statusHistory = append([]motion.PlanStatus{status}, pr.statusHistory...)
+}
+
+// execution is a single asynchronous move of a component, such as one MoveOnGlobe call. It
+// may go through several plans as it replans.
+type execution struct {
+	id            motion.ExecutionID
+	componentName string
+	// plans are the plans of the execution, oldest first. Only the last can be in progress.
+	plans  []*planRecord
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (e *execution) active() bool {
+	if len(e.plans) == 0 {
+		return false
+	}
+	_, terminal := motion.TerminalStateSet[e.plans[len(e.plans)-1].status().State]
+	return !terminal
+}
+
+func (e *execution) ended() time.Time {
+	if len(e.plans) == 0 {
+		return time.Time{}
+	}
+	return e.plans[len(e.plans)-1].status().Timestamp
+}
+
+// executionStore keeps track of the executions of the motion service and the history of their
+// plans, for ListPlanStatuses, PlanHistory and StopPlan.
+type executionStore struct {
+	mu sync.Mutex
+	// executions are by component name, oldest first.
+	executions map[string][]*execution
+	workers    sync.WaitGroup
+}
+
+func newExecutionStore() *executionStore {
+	return &executionStore{executions: make(map[string][]*execution)}
+}
+
+// start begins a new execution for a component with its first plan and runs it in the
+// background, stopping any execution already in progress for the component. run must set the
+// final status of the execution's last plan through the store before returning.
+func (s *executionStore) start(
+	componentName string,
+	plan motion.PlanWithMetadata,
+	run func(ctx context.Context, exec *execution),
+) motion.ExecutionID {
+	s.stop(componentName)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exec := &execution{
+		id:            uuid.New(),
+		componentName: componentName,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+	plan.ID = uuid.New()
+	plan.ComponentName = componentName
+	plan.ExecutionID = exec.id
+	record := &planRecord{plan: plan}
+	record.setStatus(motion.PlanStateInProgress, "")
+	exec.plans = append(exec.plans, record)
+
+	s.mu.Lock()
+	s.purgeLocked()
+	s.executions[componentName] = append(s.executions[componentName], exec)
+	s.mu.Unlock()
+
+	s.workers.Add(1)
+	go func() {
+		defer s.workers.Done()
+		defer close(exec.done)
+		defer cancel()
+		run(ctx, exec)
+	}()
+	return exec.id
+}
+
+// replan ends the current plan of an execution with the given reason and makes the given plan
+// the one in progress.
+func (s *executionStore) replan(exec *execution, plan motion.PlanWithMetadata, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	plan.ID = uuid.New()
+	plan.ComponentName = exec.componentName
+	plan.ExecutionID = exec.id
+	exec.plans[len(exec.plans)-1].setStatus(motion.PlanStateFailed, reason)
+	record := &planRecord{plan: plan}
+	record.setStatus(motion.PlanStateInProgress, "")
+	exec.plans = append(exec.plans, record)
+}
+
+// finish sets the final state of the current plan of an execution.
+func (s *executionStore) finish(exec *execution, state motion.PlanState, reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	exec.plans[len(exec.plans)-1].setStatus(state, reason)
+}
+
+// stop stops the execution in progress for a component, if there is one, and waits for it
+// to end.
+func (s *executionStore) stop(componentName string) {
+	s.mu.Lock()
+	var toStop []*execution
+	for _, exec := range s.executions[componentName] {
+		if exec.active() {
+			toStop = append(toStop, exec)
+		}
+	}
+	s.mu.Unlock()
+	for _, exec := range toStop {
+		exec.cancel()
+		<-exec.done
+	}
+}
+
+// stopAll stops every execution in progress and waits for them to end.
+func (s *executionStore) stopAll() {
+	s.mu.Lock()
+	for _, execs := range s.executions {
+		for _, exec := range execs {
+			exec.cancel()
+		}
+	}
+	s.mu.Unlock()
+	s.workers.Wait()
+}
+
+// purgeLocked forgets executions that ended more than executionTTL ago.
+func (s *executionStore) purgeLocked() {
+	for name, execs := range s.executions {
+		kept := execs[:0]
+		for _, exec := range execs {
+			if exec.active() || time.Since(exec.ended()) < executionTTL {
+				kept = append(kept, exec)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.executions, name)
+			continue
+		}
+		s.executions[name] = kept
+	}
+}
+
+func (s *executionStore) listPlanStatuses(onlyActive bool) []motion.PlanStatusWithID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purgeLocked()
+	statuses := []motion.PlanStatusWithID{}
+	for _, execs := range s.executions {
+		for _, exec := range execs {
+			for _, record := range exec.plans {
+				status := record.status()
+				if onlyActive && status.State != motion.PlanStateInProgress {
+					continue
+				}
+				statuses = append(statuses, motion.PlanStatusWithID{
+					PlanID:        record.plan.ID,
+					ComponentName: exec.componentName,
+					ExecutionID:   exec.id,
+					Status:        status,
+				})
+			}
+		}
+	}
+	return statuses
+}
+
+func (s *executionStore) planHistory(req motion.PlanHistoryReq) ([]motion.PlanWithStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	execs := s.executions[req.ComponentName]
+	var exec *execution
+	if req.ExecutionID == uuid.Nil {
+		if len(execs) > 0 {
+			exec = execs[len(execs)-1]
+		}
+	} else {
+		for _, e := range execs {
+			if e.id == req.ExecutionID {
+				exec = e
+			}
+		}
+	}
+	if exec == nil {
+		if req.ExecutionID != uuid.Nil {
+			return nil, fmt.Errorf("no execution %s found for component %s", req.ExecutionID, req.ComponentName)
+		}
+		return nil, fmt.Errorf("no plan history found for component %s", req.ComponentName)
+	}
+
+	history := []motion.PlanWithStatus{}
+	for i := len(exec.plans) - 1; i >= 0; i-- {
+		record := exec.plans[i]
+		history = append(history, motion.PlanWithStatus{
+			Plan:          record.plan,
+			StatusHistory: append([]motion.PlanStatus(nil), record.statusHistory...),
+		})
+		if req.LastPlanOnly {
+			break
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,189 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/operation"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/utils"
+)
+
+// coordinatedExtra returns whether the extras of a request ask for the components in its goal to
+// move at once, each to its own goal.
+func coordinatedExtra(extra map[string]interface{}) bool {
+	coordinated, _ := extra["coordinated"].(bool)
+	return coordinated
+}
+
+// priorityExtra returns the order of right of way given to components in the extras of a request.
+func priorityExtra(extra map[string]interface{}) ([]string, error) {
+	priorityIface, ok := extra["priority"]
+	if !ok {
+		return nil, nil
+	}
+	priorityList, ok := priorityIface.([]interface{})
+	if !ok {
+		return nil, errors.New("extras priority could not be interpreted as a list of component names")
+	}
+	priority := make([]string, 0, len(priorityList))
+	for _, nameIface := range priorityList {
+		name, ok := nameIface.(string)
+		if !ok {
+			return nil, errors.New("extras priority could not be interpreted as a list of component names")
+		}
+		priority = append(priority, name)
+	}
+	return priority, nil
+}
+
+// executeLockstep moves several components through a coordinated plan, in which each step of the
+// trajectory is the same tick of time for all of them. If the plan is timed and every component can
+// follow timed inputs, each is sent its part of the timed trajectory and they all start at once, so
+// that every tick happens at the same time for all of them. Otherwise the trajectory is split where
+// the set of moving components changes, as that is where one must wait for another, and each
+// component is sent its whole part of a segment at once. No segment starts until all components
+// have finished the last, so that none gets too far ahead of where the plan expects it to be
+// relative to the others.
+func (ms *builtIn) executeLockstep(ctx context.Context, plan motionplan.Plan) error {
+	trajectory := plan.Trajectory()
+	components := map[string]framesystem.InputEnabled{}
+	for i := 1; i < len(trajectory); i++ {
+		for _, name := range movingComponents(trajectory[i-1], trajectory[i]) {
+			if _, ok := components[name]; ok {
+				continue
+			}
+			r, ok := ms.components[name]
+			if !ok {
+				return fmt.Errorf("plan had step for resource %s but the motion service is not aware of a component of that name", name)
+			}
+			ie, err := utils.AssertType[framesystem.InputEnabled](r)
+			if err != nil {
+				return err
+			}
+			components[name] = ie
+		}
+	}
+
+	if timedPlan, ok := plan.(*motionplan.TimedPlan); ok {
+		samples, err := lockstepSamples(ctx, timedPlan.Timing(), components)
+		if err != nil {
+			return err
+		}
+		if samples != nil {
+			operation.SetProgress(ctx, operation.Progress{Message: "executing timed coordinated move"})
+			return ms.moveInLockstep(ctx, components, slices.Sorted(maps.Keys(samples)),
+				func(ctx context.Context, name string) error {
+					return components[name].(framesystem.TimedInputEnabled).GoToTimedInputs(ctx, samples[name])
+				})
+		}
+	}
+
+	for start := 0; start < len(trajectory)-1; {
+		moving := movingComponents(trajectory[start], trajectory[start+1])
+		end := start + 1
+		for end < len(trajectory)-1 && slices.Equal(movingComponents(trajectory[end], trajectory[end+1]), moving) {
+			end++
+		}
+		if len(moving) > 0 {
+			operation.SetProgress(ctx, operation.Progress{Current: end, Total: len(trajectory) - 1, Message: "executing coordinated move"})
+			err := ms.moveInLockstep(ctx, components, moving, func(ctx context.Context, name string) error {
+				segment := make([][]referenceframe.Input, 0, end-start+1)
+				for _, step := range trajectory[start : end+1] {
+					segment = append(segment, step[name])
+				}
+				return ms.goToInputs(ctx, name, components[name], segment)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		start = end
+	}
+	return nil
+}
+
+// lockstepSamples returns the timed inputs of each component in a timed trajectory, or nil if any
+// of them cannot follow timed inputs.
+func lockstepSamples(
+	ctx context.Context, timing *motionplan.TimedTrajectory, components map[string]framesystem.InputEnabled,
+) (map[string][]referenceframe.TimedInputs, error) {
+	samples := map[string][]referenceframe.TimedInputs{}
+	for name, ie := range components {
+		timed, ok := ie.(framesystem.TimedInputEnabled)
+		if !ok {
+			return nil, nil
+		}
+		if err := timed.GoToTimedInputs(ctx, nil); err != nil {
+			if errors.Is(err, framesystem.ErrTimedInputsUnsupported) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		componentSamples, err := timing.Sample(name, defaultTimedInputsInterval)
+		if err != nil {
+			return nil, err
+		}
+		samples[name] = componentSamples
+	}
+	return samples, nil
+}
+
+// moveInLockstep moves each of the named components at once and waits for them all to finish. If
+// any fails, the others are canceled and every component is stopped.
+func (ms *builtIn) moveInLockstep(
+	ctx context.Context,
+	components map[string]framesystem.InputEnabled,
+	moving []string,
+	move func(ctx context.Context, name string) error,
+) error {
+	moveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs error
+	for _, name := range moving {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := move(moveCtx, name); err != nil {
+				mu.Lock()
+				errs = multierr.Append(errs, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+				// the others must not carry on without this one.
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	if errs != nil {
+		for _, name := range slices.Sorted(maps.Keys(components)) {
+			if actuator, ok := components[name].(inputEnabledActuator); ok {
+				if stopErr := actuator.Stop(ctx, nil); stopErr != nil {
+					errs = multierr.Append(errs, stopErr)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// movingComponents returns the sorted names of the components whose inputs differ between two steps.
+func movingComponents(from, to referenceframe.FrameSystemInputs) []string {
+	moving := []string{}
+	for name, inputs := range to {
+		if len(inputs) > 0 && !slices.Equal(from[name], inputs) {
+			moving = append(moving, name)
+		}
+	}
+	slices.Sort(moving)
+	return moving
+}
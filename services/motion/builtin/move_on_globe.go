@@ -0,0 +1,262 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func (ms *builtIn) MoveOnGlobe(ctx context.Context, req motion.MoveOnGlobeReq) (motion.ExecutionID, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if req.Destination == nil {
+		return uuid.Nil, errors.New("destination cannot be nil")
+	}
+	if math.IsNaN(req.Destination.Lat()) || math.IsNaN(req.Destination.Lng()) {
+		return uuid.Nil, errors.New("destination may not contain NaN")
+	}
+	b, err := ms.baseComponent(req.ComponentName)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	movementSensor, ok := ms.movementSensors[req.MovementSensorName]
+	if !ok {
+		return uuid.Nil, resource.DependencyNotFoundError(movementsensor.Named(req.MovementSensorName))
+	}
+
+	move, err := ms.newBaseMove(ctx, req.ComponentName, b, req.MotionCfg, req.Extra, defaultGlobePlanDeviationM)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	origin, _, err := movementSensor.Position(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	move.localizer = motion.TwoDLocalizer(motion.NewMovementSensorLocalizer(movementSensor, origin, nil))
+	move.anchor = origin
+	move.goal = spatialmath.GeoPointToPoint(req.Destination, origin)
+	if move.goal.Norm() > maxTravelDistanceMM {
+		return uuid.Nil, fmt.Errorf("cannot move more than %d kilometers", int(maxTravelDistanceMM*1e-6))
+	}
+	if !math.IsNaN(req.Heading) {
+		// headings are left handed, thetas are right handed.
+		move.goalTheta = normalizeDegrees(360 - req.Heading)
+	}
+
+	seed, err := extraInt(req.Extra, "rseed", 0)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	obstacles := spatialmath.GeoGeometriesToGeometries(req.Obstacles, origin)
+	move.planner = newBasePlanner(
+		obstacles,
+		spatialmath.GeoGeometriesToGeometries(req.BoundingRegions, origin),
+		move.planner.radius,
+		int64(seed),
+	)
+	if move.obstaclePollInterval > 0 && move.detectObstacles != nil {
+		move.refreshObstacles = func(ctx context.Context) ([]spatialmath.Geometry, error) {
+			detected, err := move.detectedObstacles(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return append(append([]spatialmath.Geometry{}, obstacles...), detected...), nil
+		}
+	}
+	return ms.startBaseMove(ctx, move)
+}
+
+// baseComponent returns the base with the given name from the service's dependencies.
+func (ms *builtIn) baseComponent(name string) (base.Base, error) {
+	res, ok := ms.components[name]
+	if !ok {
+		return nil, resource.DependencyNotFoundError(base.Named(name))
+	}
+	b, ok := res.(base.Base)
+	if !ok {
+		return nil, fmt.Errorf("cannot move component of type %T because it is not a Base", res)
+	}
+	return b, nil
+}
+
+// newBaseMove returns a move of a base configured by a motion configuration and extras. Its
+// localizer, goal and planner obstacles are left to the caller, though the planner is created
+// with the radius of the base.
+func (ms *builtIn) newBaseMove(
+	ctx context.Context,
+	componentName string,
+	b base.Base,
+	motionCfg *motion.MotionConfiguration,
+	extra map[string]interface{},
+	defaultPlanDeviationM float64,
+) (*baseMove, error) {
+	if motionCfg == nil {
+		motionCfg = &motion.MotionConfiguration{}
+	}
+	if motionCfg.LinearMPerSec < 0 {
+		return nil, errors.New("LinearMPerSec may not be negative")
+	}
+	if motionCfg.AngularDegsPerSec < 0 {
+		return nil, errors.New("AngularDegsPerSec may not be negative")
+	}
+	if motionCfg.PlanDeviationMM < 0 {
+		return nil, errors.New("PlanDeviationMM may not be negative")
+	}
+	if motionCfg.PositionPollingFreqHz != nil && *motionCfg.PositionPollingFreqHz < 0 {
+		return nil, errors.New("PositionPollingFreqHz may not be negative")
+	}
+	if motionCfg.ObstaclePollingFreqHz != nil && *motionCfg.ObstaclePollingFreqHz < 0 {
+		return nil, errors.New("ObstaclePollingFreqHz may not be negative")
+	}
+
+	move := &baseMove{
+		componentName: componentName,
+		base:          b,
+		goalTheta:     math.NaN(),
+		mmPerSec:      defaultLinearMPerSec * 1000,
+		degsPerSec:    defaultAngularDegsPerSec,
+		deviationMM:   defaultPlanDeviationM * 1000,
+		logger:        ms.logger,
+	}
+	if motionCfg.LinearMPerSec > 0 {
+		move.mmPerSec = motionCfg.LinearMPerSec * 1000
+	}
+	if motionCfg.AngularDegsPerSec > 0 {
+		move.degsPerSec = motionCfg.AngularDegsPerSec
+	}
+	if motionCfg.PlanDeviationMM > 0 {
+		move.deviationMM = motionCfg.PlanDeviationMM
+	}
+	pollingFreqHz := defaultPositionPollingFreqHz
+	if motionCfg.PositionPollingFreqHz != nil {
+		pollingFreqHz = *motionCfg.PositionPollingFreqHz
+	}
+	if pollingFreqHz > 0 {
+		move.pollInterval = time.Duration(float64(time.Second) / pollingFreqHz)
+	}
+	if motionCfg.ObstaclePollingFreqHz != nil && *motionCfg.ObstaclePollingFreqHz > 0 {
+		move.obstaclePollInterval = time.Duration(float64(time.Second) / *motionCfg.ObstaclePollingFreqHz)
+	}
+
+	maxReplans, err := extraInt(extra, "max_replans", defaultMaxReplans)
+	if err != nil {
+		return nil, err
+	}
+	move.maxReplans = maxReplans
+	collisionBuffer, err := extraFloat(extra, "collision_buffer_mm", defaultCollisionBuffer)
+	if err != nil {
+		return nil, err
+	}
+	if collisionBuffer < 0 {
+		return nil, errors.New("collision_buffer_mm may not be negative")
+	}
+	radius, err := baseRadius(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	move.planner = newBasePlanner(nil, nil, radius+collisionBuffer, 0)
+	if len(motionCfg.ObstacleDetectors) > 0 {
+		move.detectObstacles, err = ms.obstacleDetectors(componentName, motionCfg.ObstacleDetectors)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return move, nil
+}
+
+// obstacleDetectors returns a function that asks each vision service for the objects its
+// camera sees and returns their geometries in the frame of the component.
+func (ms *builtIn) obstacleDetectors(
+	componentName string,
+	detectors []motion.ObstacleDetectorName,
+) (func(ctx context.Context) ([]spatialmath.Geometry, error), error) {
+	visionServices := make([]vision.Service, 0, len(detectors))
+	for _, detector := range detectors {
+		visionSvc, ok := ms.visionServices[detector.VisionServiceName]
+		if !ok {
+			return nil, resource.DependencyNotFoundError(vision.Named(detector.VisionServiceName))
+		}
+		visionServices = append(visionServices, visionSvc)
+	}
+	if ms.fsService == nil {
+		return nil, errors.New("obstacle detectors require a frame system")
+	}
+	fsService := ms.fsService
+	return func(ctx context.Context) ([]spatialmath.Geometry, error) {
+		var geometries []spatialmath.Geometry
+		for i, detector := range detectors {
+			objects, err := visionServices[i].GetObjectPointClouds(ctx, detector.CameraName, nil)
+			if err != nil {
+				return nil, err
+			}
+			if len(objects) == 0 {
+				continue
+			}
+			cameraPose, err := fsService.GetPose(ctx, detector.CameraName, componentName, nil, nil)
+			if err != nil {
+				return nil, err
+			}
+			for _, object := range objects {
+				if object.Geometry == nil {
+					continue
+				}
+				geometries = append(geometries, object.Geometry.Transform(cameraPose.Pose()))
+			}
+		}
+		return geometries, nil
+	}, nil
+}
+
+// startBaseMove plans the first path of a move and then executes it in the background.
+func (ms *builtIn) startBaseMove(ctx context.Context, move *baseMove) (motion.ExecutionID, error) {
+	plan, waypoints, err := move.newPlan(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return ms.executions.start(move.componentName, plan, func(ctx context.Context, exec *execution) {
+		move.run(ctx, ms.executions, exec, waypoints)
+	}), nil
+}
+
+func extraFloat(extra map[string]interface{}, key string, defaultValue float64) (float64, error) {
+	value, ok := extra[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("extra %s must be a number, got %T", key, value)
+	}
+}
+
+func extraInt(extra map[string]interface{}, key string, defaultValue int) (int, error) {
+	value, err := extraFloat(extra, key, float64(defaultValue))
+	if err != nil {
+		return 0, err
+	}
+	if value != math.Trunc(value) {
+		return 0, fmt.Errorf("extra %s must be an integer, got %v", key, value)
+	}
+	return int(value), nil
+}
@@ -0,0 +1,171 @@
+package builtin
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/geo/r3"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/motion"
+	"go.viam.com/rdk/services/slam"
+	"go.viam.com/rdk/spatialmath"
+)
+
+func (ms *builtIn) MoveOnMap(ctx context.Context, req motion.MoveOnMapReq) (motion.ExecutionID, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if req.Destination == nil {
+		return uuid.Nil, errors.New("destination cannot be nil")
+	}
+	b, err := ms.baseComponent(req.ComponentName)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	slamSvc, ok := ms.slamServices[req.SlamName]
+	if !ok {
+		return uuid.Nil, resource.DependencyNotFoundError(slam.Named(req.SlamName))
+	}
+
+	move, err := ms.newBaseMove(ctx, req.ComponentName, b, req.MotionCfg, req.Extra, defaultSlamPlanDeviationM)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	move.localizer = motion.NewSLAMLocalizer(slamSvc)
+	move.goal = req.Destination.Point()
+	move.goal.Z = 0
+	// destinations are in the frame of the SLAM map, where a theta of 0 points along +X, so
+	// they are adjusted the same way the localizer adjusts positions.
+	move.goalTheta = spatialmath.Compose(req.Destination, motion.SLAMOrientationAdjustment).
+		Orientation().OrientationVectorDegrees().Theta
+
+	// points of the map are obstacles if SLAM is at least this sure, in percent, that they
+	// are occupied.
+	occupancyThreshold, err := extraInt(req.Extra, "occupancy_threshold", defaultOccupancyThreshold)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if occupancyThreshold < 0 || occupancyThreshold > 100 {
+		return uuid.Nil, errors.New("occupancy_threshold must be between 0 and 100")
+	}
+	mapObstacle, bounds, err := slamMapGeometries(ctx, slamSvc, occupancyThreshold)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	obstacles := append([]spatialmath.Geometry{}, req.Obstacles...)
+	if mapObstacle != nil {
+		obstacles = append(obstacles, mapObstacle)
+	}
+	var boundingRegions []spatialmath.Geometry
+	if bounds != nil {
+		boundingRegions = append(boundingRegions, bounds)
+	}
+	seed, err := extraInt(req.Extra, "rseed", 0)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	move.planner = newBasePlanner(obstacles, boundingRegions, move.planner.radius, int64(seed))
+
+	if move.obstaclePollInterval > 0 {
+		// the map is refetched so that the base can replan around whatever SLAM has seen since.
+		move.refreshObstacles = func(ctx context.Context) ([]spatialmath.Geometry, error) {
+			mapObstacle, _, err := slamMapGeometries(ctx, slamSvc, occupancyThreshold)
+			if err != nil {
+				return nil, err
+			}
+			obstacles := append([]spatialmath.Geometry{}, req.Obstacles...)
+			if mapObstacle != nil {
+				obstacles = append(obstacles, mapObstacle)
+			}
+			detected, err := move.detectedObstacles(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return append(obstacles, detected...), nil
+		}
+	}
+	return ms.startBaseMove(ctx, move)
+}
+
+// slamMapGeometries returns the space occupied by a SLAM service's map, flattened onto the
+// ground plane as a base can pass neither over nor under anything in it, along with a box
+// bounding the map. Only points at least occupancyThreshold percent likely to be occupied are
+// obstacles. Either geometry is nil if there is nothing to return.
+func slamMapGeometries(
+	ctx context.Context,
+	slamSvc slam.Service,
+	occupancyThreshold int,
+) (spatialmath.Geometry, spatialmath.Geometry, error) {
+	data, err := slam.PointCloudMapFull(ctx, slamSvc, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	pc, err := pointcloud.ReadPCD(bytes.NewReader(data), "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if pc.Size() == 0 {
+		return nil, nil, nil
+	}
+
+	flattened := pointcloud.NewBasicPointCloud(pc.Size())
+	pc.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		prob := occupancy(d)
+		if prob < occupancyThreshold {
+			return true
+		}
+		// points stacked above each other collapse into one, which is as likely to be
+		// occupied as the most likely of them.
+		flat := r3.Vector{X: p.X, Y: p.Y}
+		if existing, ok := flattened.At(flat.X, flat.Y, 0); ok && occupancy(existing) >= prob {
+			return true
+		}
+		err = flattened.Set(flat, pointcloud.NewValueData(prob))
+		return err == nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var obstacle spatialmath.Geometry
+	if flattened.Size() != 0 {
+		octree, err := pointcloud.ToBasicOctree(flattened, occupancyThreshold)
+		if err != nil {
+			return nil, nil, err
+		}
+		octree.SetLabel("slam map")
+		obstacle = octree
+	}
+
+	meta := pc.MetaData()
+	if meta.MaxX <= meta.MinX || meta.MaxY <= meta.MinY {
+		return obstacle, nil, nil
+	}
+	bounds, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{X: (meta.MinX + meta.MaxX) / 2, Y: (meta.MinY + meta.MaxY) / 2}),
+		r3.Vector{X: meta.MaxX - meta.MinX, Y: meta.MaxY - meta.MinY, Z: 1},
+		"slam map bounds",
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return obstacle, bounds, nil
+}
+
+// occupancy returns how likely, in percent, a point of a SLAM map is to be occupied. SLAM
+// services store it in the blue channel of a point's color, and octrees in its value. Points
+// with neither are taken to be occupied.
+func occupancy(d pointcloud.Data) int {
+	switch {
+	case d != nil && d.HasColor():
+		_, _, b := d.RGB255()
+		return int(b)
+	case d != nil && d.HasValue():
+		return d.Value()
+	default:
+		return 100
+	}
+}
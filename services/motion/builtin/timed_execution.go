@@ -0,0 +1,165 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/robot/framesystem"
+)
+
+const (
+	// defaultTimedBlendDeviation is how far a timed trajectory may cut the corners of a plan so
+	// that the component need not stop at each of its steps.
+	defaultTimedBlendDeviation = 0.01 // rad or mm
+	// defaultTimedInputsInterval is the time between the inputs streamed to a component.
+	defaultTimedInputsInterval = 10 * time.Millisecond
+	// defaultPlanTimingInterval is the time between the samples of a timed plan returned by DoPlan.
+	defaultPlanTimingInterval = 100 * time.Millisecond
+)
+
+// goToInputs moves a component through a batch of inputs. Components that can follow timed inputs
+// are sent a trajectory timed to their dynamic limits, if those are known; others are sent the
+// inputs to move through as they see fit.
+func (ms *builtIn) goToInputs(
+	ctx context.Context,
+	name string,
+	ie framesystem.InputEnabled,
+	inputs [][]referenceframe.Input,
+) error {
+	timed, ok := ie.(framesystem.TimedInputEnabled)
+	if !ok {
+		return ie.GoToInputs(ctx, inputs...)
+	}
+	model, err := ie.Kinematics(ctx)
+	if err != nil {
+		return err
+	}
+	limits, err := ms.dynamicLimits(name, model, nil)
+	if err != nil {
+		return err
+	}
+	if limits == nil {
+		return ie.GoToInputs(ctx, inputs...)
+	}
+
+	traj := make(motionplan.Trajectory, 0, len(inputs))
+	for _, step := range inputs {
+		traj = append(traj, referenceframe.FrameSystemInputs{name: step})
+	}
+	timing, err := motionplan.TimeParameterize(traj, motionplan.TimingOptions{
+		Limits:            map[string][]referenceframe.DynamicLimit{name: limits},
+		MaxBlendDeviation: defaultTimedBlendDeviation,
+	})
+	if err != nil {
+		return err
+	}
+	samples, err := timing.Sample(name, defaultTimedInputsInterval)
+	if err != nil {
+		return err
+	}
+	ms.logger.CDebugw(ctx, "following timed inputs", "component", name, "duration", timing.Duration(), "samples", len(samples))
+	if err := timed.GoToTimedInputs(ctx, samples); !errors.Is(err, framesystem.ErrTimedInputsUnsupported) {
+		return err
+	}
+	return ie.GoToInputs(ctx, inputs...)
+}
+
+// timedPlan times a plan to the dynamic limits of the frames it moves, with any configured overrides
+// applied, so that it can be followed without stopping at each of its steps. The plan is returned
+// as it is if the limits of any of them are not known.
+func (ms *builtIn) timedPlan(
+	ctx context.Context, fs *referenceframe.FrameSystem, plan motionplan.Plan, logger logging.Logger,
+) (motionplan.Plan, error) {
+	traj := plan.Trajectory()
+	if len(traj) == 0 {
+		return plan, nil
+	}
+	limits := motionplan.FrameSystemDynamicLimits(fs)
+	for name := range traj[0] {
+		frame := fs.Frame(name)
+		if frame == nil {
+			continue
+		}
+		frameLimits, err := ms.dynamicLimits(name, frame, limits[name])
+		if err != nil {
+			return nil, err
+		}
+		limits[name] = frameLimits
+	}
+	timed, err := motionplan.NewTimedPlan(plan, motionplan.TimingOptions{
+		Limits:            limits,
+		MaxBlendDeviation: defaultTimedBlendDeviation,
+	})
+	if err != nil {
+		logger.CDebugf(ctx, "not timing plan: %v", err)
+		return plan, nil
+	}
+	return timed, nil
+}
+
+// timedPlanSamples returns the inputs of every frame of a timed plan every interval, including at
+// its end, for DoPlan to return.
+func timedPlanSamples(timing *motionplan.TimedTrajectory, interval time.Duration) []interface{} {
+	var samples []interface{}
+	for t := time.Duration(0); ; t += interval {
+		if t > timing.Duration() {
+			t = timing.Duration()
+		}
+		samples = append(samples, map[string]interface{}{"time_s": t.Seconds(), "inputs": timing.At(t)})
+		if t == timing.Duration() {
+			return samples
+		}
+	}
+}
+
+// dynamicLimits returns the dynamic limits of a frame's joints, from the limits given or else its
+// kinematics, with any configured overrides applied, or nil if any joint has no velocity or
+// acceleration limit.
+func (ms *builtIn) dynamicLimits(
+	name string, frame referenceframe.Frame, frameLimits []referenceframe.DynamicLimit,
+) ([]referenceframe.DynamicLimit, error) {
+	limits := make([]referenceframe.DynamicLimit, len(frame.DoF()))
+	if frameLimits == nil {
+		if limited, ok := frame.(referenceframe.DynamicallyLimited); ok {
+			frameLimits = limited.DynamicLimits()
+		}
+	}
+	copy(limits, frameLimits)
+
+	var moveableNames []string
+	if sm, ok := frame.(*referenceframe.SimpleModel); ok {
+		moveableNames = sm.MoveableFrameNames()
+	}
+	for key, limit := range ms.conf.DynamicLimits[name] {
+		// keys are joint names or indices, as for input_range_override.
+		index := -1
+		for i, jointName := range moveableNames {
+			if key == jointName {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if i, err := strconv.Atoi(key); err == nil {
+				index = i
+			}
+		}
+		if index < 0 || index >= len(limits) {
+			return nil, fmt.Errorf("can't find joint (%s) of %s in dynamic_limits", key, name)
+		}
+		limits[index] = limit
+	}
+
+	for _, limit := range limits {
+		if limit.Velocity <= 0 || limit.Acceleration <= 0 {
+			return nil, nil
+		}
+	}
+	return limits, nil
+}
@@ -3,7 +3,9 @@ package builtin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"slices"
 	"strconv"
@@ -15,6 +17,7 @@ import (
 	geo "github.com/kellydunn/golang-geo"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/components/base"
@@ -49,6 +52,12 @@ var (
 	errBoundingRegionsGeomWithTranslation = errors.New("bounding region " + geomWithTranslation)
 	errObstacleGeomParse                  = errors.New("obstacle unable to be converted from geometry config")
 	errBoundingRegionsGeomParse           = errors.New("bounding regions unable to be converted from geometry config")
+	errNegativeExploreSwathM              = errors.New("explore_swath_m must be non-negative if set")
+	errNegativeExploreRadiusM             = errors.New("explore_radius_m must be non-negative if set")
+	errNegativeExploreSensorRangeM        = errors.New("explore_sensor_range_m must be non-negative if set")
+	errExploreRegionTooSmall              = errors.New("explore_region must have at least 3 points if set")
+	errExploreRegionWithoutGPS            = errors.New("explore_region can only be set for map_type GPS")
+	errRoutesWithoutGPS                   = errors.New("routes can only be set for map_type GPS")
 )
 
 const (
@@ -75,6 +84,25 @@ const (
 
 	// frequency in milliseconds.
 	planHistoryPollFrequency = time.Millisecond * 50
+
+	// how many times to try reaching a point of a route or while exploring before moving on to the next.
+	maxLegAttempts = 3
+	// how many points in a row exploring may fail to reach before giving up.
+	maxFailedExploreLegs = 3
+
+	// distance between the passes made when covering the explore region.
+	defaultExploreSwathM = 2.
+
+	// how far from where it started the robot explores frontiers, and how far it sees while doing so.
+	defaultExploreRadiusM      = 20.
+	defaultExploreSensorRangeM = 5.
+)
+
+// DoCommand keys for managing the routes held in the navigation store.
+const (
+	DoAddRoute    = "add_route"
+	DoRemoveRoute = "remove_route"
+	DoGetRoutes   = "get_routes"
 )
 
 func init() {
@@ -109,6 +137,21 @@ type Config struct {
 	PlanDeviationM             float64                          `json:"plan_deviation_m,omitempty"`
 	ReplanCostFactor           float64                          `json:"replan_cost_factor,omitempty"`
 	LogFilePath                string                           `json:"log_file_path"`
+
+	// ExploreRegion is the polygon, given by its corners, covered in explore mode on a GPS map.
+	// Without one, explore mode explores frontiers around where the robot starts instead.
+	ExploreRegion       []*commonpb.GeoPoint `json:"explore_region,omitempty"`
+	ExploreSwathM       float64              `json:"explore_swath_m,omitempty"`
+	ExploreRadiusM      float64              `json:"explore_radius_m,omitempty"`
+	ExploreSensorRangeM float64              `json:"explore_sensor_range_m,omitempty"`
+
+	// Routes are added to the store, replacing any of the same names, and can be followed by setting
+	// waypoint mode with the name of one as "route" in extra.
+	Routes    []navigation.Route `json:"routes,omitempty"`
+	Geofences []*GeofenceConfig  `json:"geofences,omitempty"`
+	// ActionResources are the resources, besides those in the actions of Routes, which the actions of
+	// routes added later may send commands to.
+	ActionResources []string `json:"action_resources,omitempty"`
 }
 
 type executionWaypoint struct {
@@ -181,6 +224,47 @@ func (conf *Config) Validate(path string) ([]string, []string, error) {
 	if conf.ReplanCostFactor < 0 {
 		return nil, nil, errNegativeReplanCostFactor
 	}
+	if conf.ExploreSwathM < 0 {
+		return nil, nil, errNegativeExploreSwathM
+	}
+	if conf.ExploreRadiusM < 0 {
+		return nil, nil, errNegativeExploreRadiusM
+	}
+	if conf.ExploreSensorRangeM < 0 {
+		return nil, nil, errNegativeExploreSensorRangeM
+	}
+
+	// Ensure the explore region is a polygon on a GPS map
+	if len(conf.ExploreRegion) > 0 {
+		if mapType != navigation.GPSMap {
+			return nil, nil, errExploreRegionWithoutGPS
+		}
+		if len(conf.ExploreRegion) < 3 {
+			return nil, nil, errExploreRegionTooSmall
+		}
+	}
+
+	// Ensure routes and geofences are valid and add the resources actions are sent to as dependencies
+	if len(conf.Routes) > 0 && mapType != navigation.GPSMap {
+		return nil, nil, errRoutesWithoutGPS
+	}
+	actionResources := slices.Clone(conf.ActionResources)
+	for _, route := range conf.Routes {
+		if err := route.Validate(); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+		for _, stop := range route.Stops {
+			if stop.Action != nil && !slices.Contains(actionResources, stop.Action.Resource) {
+				actionResources = append(actionResources, stop.Action.Resource)
+			}
+		}
+	}
+	deps = append(deps, actionResources...)
+	for _, fence := range conf.Geofences {
+		if err := fence.Validate(); err != nil {
+			return nil, nil, resource.NewConfigValidationError(path, err)
+		}
+	}
 
 	// Ensure obstacles have no translation
 	for _, obs := range conf.Obstacles {
@@ -242,6 +326,21 @@ type builtIn struct {
 	motionCfg        *motion.MotionConfiguration
 	replanCostFactor float64
 
+	exploreRegion       []*geo.Point
+	exploreSwathM       float64
+	exploreRadiusM      float64
+	exploreSensorRangeM float64
+	exploreProgress     *navigation.ExploreProgress
+	geofences           []*geofence
+	actionResources     map[string]resource.Resource
+
+	// the geofences as MoveOnGlobe obstacles and bounding regions
+	geofenceObstacles       []*spatialmath.GeoGeometry
+	geofenceBoundingRegions []*spatialmath.GeoGeometry
+
+	// the points of the route or exploration being followed which are still to be reached
+	remainingRoute []*geo.Point
+
 	logger                    logging.Logger
 	wholeServiceCancelFunc    func()
 	currentWaypointCancelFunc func()
@@ -297,6 +396,26 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 	if svcConfig.ReplanCostFactor != 0 {
 		replanCostFactor = svcConfig.ReplanCostFactor
 	}
+	exploreSwathM := defaultExploreSwathM
+	if svcConfig.ExploreSwathM != 0 {
+		exploreSwathM = svcConfig.ExploreSwathM
+	}
+	exploreRadiusM := defaultExploreRadiusM
+	if svcConfig.ExploreRadiusM != 0 {
+		exploreRadiusM = svcConfig.ExploreRadiusM
+	}
+	exploreSensorRangeM := defaultExploreSensorRangeM
+	if svcConfig.ExploreSensorRangeM != 0 {
+		exploreSensorRangeM = svcConfig.ExploreSensorRangeM
+	}
+	exploreRegion := make([]*geo.Point, 0, len(svcConfig.ExploreRegion))
+	for _, pt := range svcConfig.ExploreRegion {
+		exploreRegion = append(exploreRegion, geo.NewPoint(pt.GetLatitude(), pt.GetLongitude()))
+	}
+	geofences := make([]*geofence, 0, len(svcConfig.Geofences))
+	for _, fence := range svcConfig.Geofences {
+		geofences = append(geofences, newGeofence(fence))
+	}
 
 	motionServiceName := resource.DefaultServiceName
 	if svcConfig.MotionServiceName != "" {
@@ -356,8 +475,8 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 		visionServicesByName[visionSvc.Name().Name] = visionSvc
 	}
 
-	// Parse movement sensor from the configuration if map type is GPS
-	if mapType == navigation.GPSMap {
+	// Parse movement sensor from the configuration if map type is GPS, or if one is given for exploring without a map
+	if mapType == navigation.GPSMap || svcConfig.MovementSensorName != "" {
 		movementSensor, err := movementsensor.FromProvider(deps, svcConfig.MovementSensorName)
 		if err != nil {
 			return err
@@ -365,6 +484,28 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 		svc.movementSensor = movementSensor
 	}
 
+	// Find the resources route actions may send commands to
+	actionResources := map[string]resource.Resource{}
+	actionResourceNames := slices.Clone(svcConfig.ActionResources)
+	for _, route := range svcConfig.Routes {
+		for _, stop := range route.Stops {
+			if stop.Action != nil {
+				actionResourceNames = append(actionResourceNames, stop.Action.Resource)
+			}
+		}
+	}
+	for _, name := range actionResourceNames {
+		for depName, dep := range deps {
+			if depName.String() == name || depName.ShortName() == name {
+				actionResources[name] = dep
+				break
+			}
+		}
+		if _, ok := actionResources[name]; !ok {
+			return errors.Errorf("resource %q for route actions not found in dependencies", name)
+		}
+	}
+
 	// Reconfigure the store if necessary
 	if svc.storeType != string(storeCfg.Type) {
 		newStore, err := navigation.NewStoreFromConfig(ctx, svcConfig.Store)
@@ -375,6 +516,19 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 		svc.storeType = string(storeCfg.Type)
 	}
 
+	// Add the configured routes to the store
+	if len(svcConfig.Routes) > 0 {
+		routeStore, ok := svc.store.(navigation.RouteStore)
+		if !ok {
+			return errors.New("navigation store does not hold routes")
+		}
+		for _, route := range svcConfig.Routes {
+			if err := routeStore.AddRoute(ctx, route); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Parse obstacles from the configuration
 	newObstacles, err := spatialmath.GeoGeometriesFromConfigs(svcConfig.Obstacles)
 	if err != nil {
@@ -395,6 +549,13 @@ func (svc *builtIn) Reconfigure(ctx context.Context, deps resource.Dependencies,
 	svc.boundingRegions = newBoundingRegions
 	svc.replanCostFactor = replanCostFactor
 	svc.visionServicesByName = visionServicesByName
+	svc.exploreRegion = exploreRegion
+	svc.exploreSwathM = exploreSwathM
+	svc.exploreRadiusM = exploreRadiusM
+	svc.exploreSensorRangeM = exploreSensorRangeM
+	svc.geofences = geofences
+	svc.geofenceObstacles, svc.geofenceBoundingRegions = geofenceGeometries(geofences)
+	svc.actionResources = actionResources
 	svc.motionCfg = &motion.MotionConfiguration{
 		ObstacleDetectors:     obstacleDetectorNamePairs,
 		LinearMPerSec:         metersPerSec,
@@ -440,10 +601,12 @@ func (svc *builtIn) SetMode(ctx context.Context, mode navigation.Mode, extra map
 	}
 
 	switch svc.mode {
-	case navigation.ModeManual, navigation.ModeExplore:
+	case navigation.ModeManual:
 		// do nothing
 	case navigation.ModeWaypoint:
 		svc.startWaypointMode(cancelCtx, extra)
+	case navigation.ModeExplore:
+		svc.startExploreMode(cancelCtx, extra)
 	}
 
 	return nil
@@ -543,14 +706,48 @@ func (svc *builtIn) Close(ctx context.Context) error {
 }
 
 func (svc *builtIn) moveToWaypoint(ctx context.Context, wp navigation.Waypoint, extra map[string]interface{}) error {
+	if err := svc.moveOnGlobeSync(ctx, wp, math.NaN(), extra); err != nil {
+		return err
+	}
+	return svc.waypointReached(ctx)
+}
+
+// moveToPoint moves the base to a point which is not a stored waypoint, trying again a few times if it
+// fails to get there.
+func (svc *builtIn) moveToPoint(ctx context.Context, pt *geo.Point, heading float64, extra map[string]interface{}) error {
+	var err error
+	for attempt := 0; attempt < maxLegAttempts; attempt++ {
+		err = svc.moveOnGlobeSync(ctx, navigation.Waypoint{Lat: pt.Lat(), Long: pt.Lng()}, heading, extra)
+		if err == nil || ctx.Err() != nil || errors.Is(err, errBreaksGeofence) {
+			return err
+		}
+		svc.logger.CWarnf(ctx, "retrying navigation to %v since it errored out: %s", *pt, err)
+	}
+	return err
+}
+
+// moveOnGlobeSync moves the base to a waypoint with MoveOnGlobe and waits for it to get there. The
+// geofences are passed on for MoveOnGlobe to plan around, though keep in geofences only bound the plan
+// when no bounding regions are configured, since a plan need only stay inside one of those. If the
+// waypoint or the base, on the way there, is ever on the wrong side of a geofence anyway it is stopped.
+func (svc *builtIn) moveOnGlobeSync(
+	ctx context.Context, wp navigation.Waypoint, heading float64, extra map[string]interface{},
+) error {
+	if err := checkGeofences(svc.geofences, wp.ToPoint()); err != nil {
+		return err
+	}
+	boundingRegions := svc.boundingRegions
+	if len(boundingRegions) == 0 {
+		boundingRegions = svc.geofenceBoundingRegions
+	}
 	req := motion.MoveOnGlobeReq{
 		ComponentName:      svc.base.Name().Name,
 		Destination:        wp.ToPoint(),
-		Heading:            math.NaN(),
+		Heading:            heading,
 		MovementSensorName: svc.movementSensor.Name().Name,
-		Obstacles:          svc.obstacles,
+		Obstacles:          append(slices.Clone(svc.obstacles), svc.geofenceObstacles...),
 		MotionCfg:          svc.motionCfg,
-		BoundingRegions:    svc.boundingRegions,
+		BoundingRegions:    boundingRegions,
 		Extra:              extra,
 	}
 	cancelCtx, cancelFn := context.WithCancel(ctx)
@@ -585,6 +782,7 @@ func (svc *builtIn) moveToWaypoint(ctx context.Context, wp navigation.Waypoint,
 		}
 	}()
 
+	waitForGeofences := svc.watchGeofences(cancelCtx, cancelFn)
 	err = motion.PollHistoryUntilSuccessOrError(cancelCtx, svc.motionService, planHistoryPollFrequency,
 		motion.PlanHistoryReq{
 			ComponentName: req.ComponentName,
@@ -592,13 +790,15 @@ func (svc *builtIn) moveToWaypoint(ctx context.Context, wp navigation.Waypoint,
 			LastPlanOnly:  true,
 		},
 	)
-	if err != nil {
-		return err
+	cancelFn()
+	if breach := waitForGeofences(); breach != nil {
+		return breach
 	}
-
-	return svc.waypointReached(cancelCtx)
+	return err
 }
 
+// startWaypointMode visits the waypoints in the store in turn, or follows a route instead if one is
+// named as "route" in extra.
 func (svc *builtIn) startWaypointMode(ctx context.Context, extra map[string]interface{}) {
 	if extra == nil {
 		extra = map[string]interface{}{}
@@ -606,6 +806,13 @@ func (svc *builtIn) startWaypointMode(ctx context.Context, extra map[string]inte
 
 	extra["motion_profile"] = "position_only"
 
+	if route, ok := extra["route"].(string); ok {
+		extra = maps.Clone(extra)
+		delete(extra, "route")
+		svc.startRouteMode(ctx, route, extra)
+		return
+	}
+
 	svc.activeBackgroundWorkers.Add(1)
 	utils.ManagedGo(func() {
 		// do not exit loop - even if there are no waypoints remaining
@@ -631,6 +838,14 @@ func (svc *builtIn) startWaypointMode(ctx context.Context, extra map[string]inte
 					svc.logger.CInfof(ctx, "skipping waypoint %+v since it was deleted", wp)
 					continue
 				}
+				if errors.Is(err, errBreaksGeofence) && ctx.Err() == nil {
+					// trying again would only break the geofence again
+					svc.logger.CWarnf(ctx, "skipping waypoint %+v since it %s", wp, err)
+					if err := svc.store.WaypointVisited(ctx, wp.ID); err != nil {
+						svc.logger.CWarnf(ctx, "failed to skip waypoint %+v: %s", wp, err)
+					}
+					continue
+				}
 				svc.logger.CWarnf(ctx, "retrying navigation to waypoint %+v since it errored out: %s", wp, err)
 				continue
 			}
@@ -639,6 +854,12 @@ func (svc *builtIn) startWaypointMode(ctx context.Context, extra map[string]inte
 	}, svc.activeBackgroundWorkers.Done)
 }
 
+func (svc *builtIn) setRemainingRoute(route []*geo.Point) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.remainingRoute = route
+}
+
 func (svc *builtIn) stopActiveMode() {
 	if svc.wholeServiceCancelFunc != nil {
 		svc.wholeServiceCancelFunc()
@@ -810,40 +1031,49 @@ func (svc *builtIn) Paths(ctx context.Context, extra map[string]interface{}) ([]
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
 
+	paths := []*navigation.Path{}
 	rawExecutionWaypoint := svc.activeExecutionWaypoint.Load()
-	// If there is no execution, return empty paths
-	if rawExecutionWaypoint == nil || rawExecutionWaypoint == emptyExecutionWaypoint {
-		return []*navigation.Path{}, nil
-	}
+	// If there is an execution, return the path it plans to follow
+	if rawExecutionWaypoint != nil && rawExecutionWaypoint != emptyExecutionWaypoint {
+		ewp, ok := rawExecutionWaypoint.(executionWaypoint)
+		if !ok {
+			return nil, errors.New("execution corrupt")
+		}
 
-	ewp, ok := rawExecutionWaypoint.(executionWaypoint)
-	if !ok {
-		return nil, errors.New("execution corrupt")
-	}
+		ph, err := svc.motionService.PlanHistory(ctx, motion.PlanHistoryReq{
+			ComponentName: svc.base.Name().Name,
+			ExecutionID:   ewp.executionID,
+			LastPlanOnly:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
 
-	ph, err := svc.motionService.PlanHistory(ctx, motion.PlanHistoryReq{
-		ComponentName: svc.base.Name().Name,
-		ExecutionID:   ewp.executionID,
-		LastPlanOnly:  true,
-	})
-	if err != nil {
-		return nil, err
+		path := ph[0].Plan.Path()
+		geoPoints := make([]*geo.Point, 0, len(path))
+		poses, err := path.GetFramePoses(svc.base.Name().ShortName())
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range poses {
+			geoPoints = append(geoPoints, geo.NewPoint(p.Point().Y, p.Point().X))
+		}
+		navPath, err := navigation.NewPath(ewp.waypoint.ID, geoPoints)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, navPath)
 	}
 
-	path := ph[0].Plan.Path()
-	geoPoints := make([]*geo.Point, 0, len(path))
-	poses, err := path.GetFramePoses(svc.base.Name().ShortName())
-	if err != nil {
-		return nil, err
-	}
-	for _, p := range poses {
-		geoPoints = append(geoPoints, geo.NewPoint(p.Point().Y, p.Point().X))
-	}
-	navPath, err := navigation.NewPath(ewp.waypoint.ID, geoPoints)
-	if err != nil {
-		return nil, err
+	// While following a route or exploring, the rest of the route follows
+	if len(svc.remainingRoute) > 0 {
+		routePath, err := navigation.NewPath(primitive.NilObjectID, slices.Clone(svc.remainingRoute))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, routePath)
 	}
-	return []*navigation.Path{navPath}, nil
+	return paths, nil
 }
 
 func (svc *builtIn) Properties(ctx context.Context) (navigation.Properties, error) {
@@ -853,5 +1083,74 @@ func (svc *builtIn) Properties(ctx context.Context) (navigation.Properties, erro
 	prop := navigation.Properties{
 		MapType: svc.mapType,
 	}
+	if svc.mode == navigation.ModeExplore && svc.exploreProgress != nil {
+		progress := *svc.exploreProgress
+		prop.ExploreProgress = &progress
+	}
 	return prop, nil
 }
+
+// DoCommand manages the routes held in the navigation store.
+//
+//   - DoAddRoute adds the route given as its value, replacing any route of the same name
+//   - DoRemoveRoute removes the route named by its value
+//   - DoGetRoutes returns all the routes in the store under the same key
+func (svc *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	store, ok := svc.store.(navigation.RouteStore)
+	if !ok {
+		return nil, resource.ErrDoUnimplemented
+	}
+
+	resp := map[string]interface{}{}
+	if routeIface, ok := cmd[DoAddRoute]; ok {
+		var route navigation.Route
+		if err := convertJSON(routeIface, &route); err != nil {
+			return nil, errors.Wrap(err, "could not interpret route")
+		}
+		for _, stop := range route.Stops {
+			if stop.Action == nil {
+				continue
+			}
+			if _, ok := svc.actionResources[stop.Action.Resource]; !ok {
+				return nil, errors.Errorf("resource %q is not one of the navigation service's action_resources", stop.Action.Resource)
+			}
+		}
+		if err := store.AddRoute(ctx, route); err != nil {
+			return nil, err
+		}
+		resp[DoAddRoute] = true
+	}
+	if nameIface, ok := cmd[DoRemoveRoute]; ok {
+		name, err := rdkutils.AssertType[string](nameIface)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.RemoveRoute(ctx, name); err != nil {
+			return nil, err
+		}
+		resp[DoRemoveRoute] = true
+	}
+	if _, ok := cmd[DoGetRoutes]; ok {
+		routes, err := store.Routes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var routesResp []interface{}
+		if err := convertJSON(routes, &routesResp); err != nil {
+			return nil, err
+		}
+		resp[DoGetRoutes] = routesResp
+	}
+	return resp, nil
+}
+
+// convertJSON converts between types through their JSON representations.
+func convertJSON(from, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -12,8 +13,10 @@ import (
 	"github.com/google/uuid"
 	geo "github.com/kellydunn/golang-geo"
 	"go.uber.org/atomic"
+	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
 	"go.viam.com/utils"
+	"go.viam.com/utils/testutils"
 
 	"go.viam.com/rdk/components/base"
 	baseFake "go.viam.com/rdk/components/base/fake"
@@ -30,13 +33,14 @@ import (
 	"go.viam.com/rdk/robot/framesystem"
 	robotimpl "go.viam.com/rdk/robot/impl"
 	"go.viam.com/rdk/services/motion"
-	_ "go.viam.com/rdk/services/motion/builtin"
+	motionBuiltin "go.viam.com/rdk/services/motion/builtin"
 	"go.viam.com/rdk/services/navigation"
 	"go.viam.com/rdk/services/vision"
 	_ "go.viam.com/rdk/services/vision/colordetector"
 	"go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/testutils/inject"
 	injectmotion "go.viam.com/rdk/testutils/inject/motion"
+	rdkutils "go.viam.com/rdk/utils"
 	viz "go.viam.com/rdk/vision"
 )
 
@@ -180,6 +184,99 @@ func TestValidateConfig(t *testing.T) {
 			numDeps:     0,
 			expectedErr: errNegativeReplanCostFactor,
 		},
+		{
+			description: "invalid config negative explore_swath_m",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				ExploreSwathM:      -1,
+			},
+			numDeps:     0,
+			expectedErr: errNegativeExploreSwathM,
+		},
+		{
+			description: "valid config with explore_region",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				ExploreRegion:      []*commonpb.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 2}, {Latitude: 2, Longitude: 1}},
+			},
+			numDeps:     4,
+			expectedErr: nil,
+		},
+		{
+			description: "invalid config explore_region with too few points",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				ExploreRegion:      []*commonpb.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 2}, {Latitude: 2, Longitude: 1}}[:2],
+			},
+			numDeps:     0,
+			expectedErr: errExploreRegionTooSmall,
+		},
+		{
+			description: "invalid config explore_region for map_type none",
+			cfg: Config{
+				BaseName:      "base",
+				MapType:       "None",
+				ExploreRegion: []*commonpb.GeoPoint{{Latitude: 1, Longitude: 1}, {Latitude: 1, Longitude: 2}, {Latitude: 2, Longitude: 1}},
+			},
+			numDeps:     0,
+			expectedErr: errExploreRegionWithoutGPS,
+		},
+		{
+			description: "valid config with routes, geofences and action resources",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				Routes: []navigation.Route{{Name: "patrol", Stops: []navigation.RouteStop{
+					{Lat: 1, Long: 1, Action: &navigation.WaypointAction{Resource: "camera"}},
+					{Lat: 1, Long: 2, Action: &navigation.WaypointAction{Resource: "arm"}},
+				}}},
+				Geofences: []*GeofenceConfig{{
+					Name:   "yard",
+					Type:   geofenceKeepIn,
+					Points: []*commonpb.GeoPoint{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 3}, {Latitude: 3, Longitude: 0}},
+				}},
+				ActionResources: []string{"arm", "gripper"},
+			},
+			numDeps:     7,
+			expectedErr: nil,
+		},
+		{
+			description: "invalid config routes for map_type none",
+			cfg: Config{
+				BaseName: "base",
+				MapType:  "None",
+				Routes:   []navigation.Route{{Name: "patrol", Stops: []navigation.RouteStop{{Lat: 1, Long: 1}}}},
+			},
+			numDeps:     0,
+			expectedErr: errRoutesWithoutGPS,
+		},
+		{
+			description: "invalid config route without waypoints",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				Routes:             []navigation.Route{{Name: "patrol"}},
+			},
+			numDeps:     0,
+			expectedErr: errors.New(`route "patrol" must have at least one waypoint`),
+		},
+		{
+			description: "invalid config geofence with unknown type",
+			cfg: Config{
+				BaseName:           "base",
+				MovementSensorName: "localizer",
+				Geofences: []*GeofenceConfig{{
+					Name:   "yard",
+					Type:   "keep_near",
+					Points: []*commonpb.GeoPoint{{Latitude: 0, Longitude: 0}, {Latitude: 0, Longitude: 3}, {Latitude: 3, Longitude: 0}},
+				}},
+			},
+			numDeps:     0,
+			expectedErr: errors.New(`geofence "yard" must have a type of keep_in or keep_out`),
+		},
 	}
 
 	for _, tt := range cases {
@@ -241,7 +338,8 @@ func TestNew(t *testing.T) {
 		test.That(t, svcStruct.mapType, test.ShouldEqual, navigation.NoMap)
 		test.That(t, svcStruct.base.Name().Name, test.ShouldEqual, "new_base")
 		test.That(t, svcStruct.motionService.Name().Name, test.ShouldEqual, "new_motion")
-		test.That(t, svcStruct.movementSensor, test.ShouldBeNil)
+		// kept for exploring without a map
+		test.That(t, svcStruct.movementSensor.Name().Name, test.ShouldEqual, "movement_sensor")
 	})
 
 	t.Run("setting parameters for GPS map_type", func(t *testing.T) {
@@ -662,6 +760,10 @@ func setupStartWaypointExplore(ctx context.Context, t *testing.T, logger logging
 	test.That(t, err, test.ShouldBeNil)
 
 	injectMovementSensor := inject.NewMovementSensor("test_movement")
+	// without a position explore mode stops straight away, leaving only the motion of the mode before it
+	injectMovementSensor.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+		return nil, 0, errors.New("no position")
+	}
 	visionService := inject.NewVisionService("vision")
 	camera := inject.NewCamera("camera")
 	config := resource.Config{
@@ -1910,3 +2012,722 @@ func createFrameSystemService(
 
 	return fsSvc, nil
 }
+
+// setupExplore returns a navigation service whose motion service moves the base straight to
+// wherever it is sent, as reported by its movement sensor.
+func setupExplore(
+	ctx context.Context, t *testing.T, logger logging.Logger, conf *Config, extraDeps ...resource.Resource,
+) *startWaypointState {
+	t.Helper()
+	fakeBase, err := baseFake.NewBase(ctx, nil, resource.Config{
+		Name:  "test_base",
+		API:   base.API,
+		Frame: &referenceframe.LinkConfig{Geometry: &spatialmath.GeometryConfig{R: 100}},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	conf.Store = navigation.StoreConfig{Type: navigation.StoreTypeMemory}
+	conf.BaseName = "test_base"
+	conf.MotionServiceName = "test_motion"
+	injectMS := injectmotion.NewMotionService("test_motion")
+	deps := resource.Dependencies{
+		injectMS.Name(): injectMS,
+		fakeBase.Name(): fakeBase,
+	}
+	for _, dep := range extraDeps {
+		deps[dep.Name()] = dep
+	}
+	s := &startWaypointState{injectMS: injectMS, base: fakeBase}
+
+	position := geo.NewPoint(40, -74)
+	if conf.MovementSensorName != "" {
+		s.movementSensor = inject.NewMovementSensor(conf.MovementSensorName)
+		s.movementSensor.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+			s.RLock()
+			defer s.RUnlock()
+			return position, 0, nil
+		}
+		deps[s.movementSensor.Name()] = s.movementSensor
+	}
+	injectMS.MoveOnGlobeFunc = func(ctx context.Context, req motion.MoveOnGlobeReq) (motion.ExecutionID, error) {
+		s.Lock()
+		defer s.Unlock()
+		executionID := uuid.New()
+		s.mogrs = append(s.mogrs, req)
+		s.pws = []motion.PlanWithStatus{{
+			Plan:          motion.PlanWithMetadata{ExecutionID: executionID},
+			StatusHistory: []motion.PlanStatus{{State: motion.PlanStateSucceeded}},
+		}}
+		position = req.Destination
+		return executionID, nil
+	}
+	injectMS.PlanHistoryFunc = func(ctx context.Context, req motion.PlanHistoryReq) ([]motion.PlanWithStatus, error) {
+		s.RLock()
+		defer s.RUnlock()
+		return s.pws, nil
+	}
+	injectMS.StopPlanFunc = func(ctx context.Context, req motion.StopPlanReq) error {
+		return nil
+	}
+
+	ns, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: conf}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	s.ns = ns
+	s.closeFunc = func() { test.That(t, ns.Close(context.Background()), test.ShouldBeNil) }
+	return s
+}
+
+// simulatedBase is a base on the globe whose position and heading are reported by a movement
+// sensor.
+type simulatedBase struct {
+	mu     sync.Mutex
+	origin *geo.Point
+	// position is in mm east and north of origin and theta is counterclockwise from north.
+	position r3.Vector
+	theta    float64
+	visited  []r3.Vector
+	// stuck makes the base fail to move straight.
+	stuck bool
+}
+
+func (sim *simulatedBase) pose() (r3.Vector, float64, []r3.Vector) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	return sim.position, sim.theta, append([]r3.Vector(nil), sim.visited...)
+}
+
+// setupWithBuiltinMotion returns a navigation service which drives a simulated base through the
+// builtin motion service. The base starts at origin facing north. The frame system holds the
+// base and fsParts.
+func setupWithBuiltinMotion(
+	ctx context.Context,
+	t *testing.T,
+	logger logging.Logger,
+	origin *geo.Point,
+	conf *Config,
+	fsParts []*referenceframe.FrameSystemPart,
+	extraDeps ...resource.Resource,
+) (*simulatedBase, navigation.Service, motion.Service) {
+	t.Helper()
+	sim := &simulatedBase{origin: origin}
+
+	b := inject.NewBase("test_base")
+	b.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (base.Properties, error) {
+		return base.Properties{WidthMeters: 0.6}, nil
+	}
+	b.GeometriesFunc = func(ctx context.Context) ([]spatialmath.Geometry, error) {
+		return nil, nil
+	}
+	b.SpinFunc = func(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.theta = math.Mod(sim.theta+angleDeg, 360)
+		return nil
+	}
+	b.MoveStraightFunc = func(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+		const stepMM = 100.
+		sim.mu.Lock()
+		heading := rdkutils.DegToRad(sim.theta)
+		stuck := sim.stuck
+		sim.mu.Unlock()
+		if stuck {
+			return errors.New("base is stuck")
+		}
+		forward := r3.Vector{X: -math.Sin(heading), Y: math.Cos(heading)}
+		for moved := 0.; moved < float64(distanceMm); moved += stepMM {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			sim.mu.Lock()
+			sim.position = sim.position.Add(forward.Mul(math.Min(stepMM, float64(distanceMm)-moved)))
+			sim.visited = append(sim.visited, sim.position)
+			sim.mu.Unlock()
+			time.Sleep(time.Millisecond)
+		}
+		return nil
+	}
+	b.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		return nil
+	}
+
+	movementSensor := inject.NewMovementSensor("test_movement")
+	movementSensor.PropertiesFunc = func(ctx context.Context, extra map[string]interface{}) (*movementsensor.Properties, error) {
+		return &movementsensor.Properties{PositionSupported: true, CompassHeadingSupported: true}, nil
+	}
+	movementSensor.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (*geo.Point, float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return geoPoint(origin, sim.position.Mul(1e-3)), 0, nil
+	}
+	movementSensor.CompassHeadingFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return math.Mod(360-sim.theta, 360), nil
+	}
+
+	deps := resource.Dependencies{b.Name(): b, movementSensor.Name(): movementSensor}
+	for _, dep := range extraDeps {
+		deps[dep.Name()] = dep
+	}
+	fsParts = append([]*referenceframe.FrameSystemPart{
+		{FrameConfig: referenceframe.NewLinkInFrame(referenceframe.World, spatialmath.NewZeroPose(), "test_base", nil)},
+		{FrameConfig: referenceframe.NewLinkInFrame("test_base", spatialmath.NewZeroPose(), "test_movement", nil)},
+	}, fsParts...)
+	_, err := createFrameSystemService(ctx, deps, fsParts, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	motionSvc, err := motionBuiltin.NewBuiltIn(ctx, deps, resource.Config{
+		Name:                "test_motion",
+		API:                 motion.API,
+		ConvertedAttributes: &motionBuiltin.Config{},
+	}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	t.Cleanup(func() { test.That(t, motionSvc.Close(context.Background()), test.ShouldBeNil) })
+	deps[motionSvc.Name()] = motionSvc
+
+	conf.Store = navigation.StoreConfig{Type: navigation.StoreTypeMemory}
+	conf.BaseName = "test_base"
+	conf.MovementSensorName = "test_movement"
+	conf.MotionServiceName = "test_motion"
+	ns, err := NewBuiltIn(ctx, deps, resource.Config{ConvertedAttributes: conf}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	t.Cleanup(func() { test.That(t, ns.Close(context.Background()), test.ShouldBeNil) })
+	return sim, ns, motionSvc
+}
+
+func TestObstacleDetectorsWithBuiltinMotion(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	origin := geo.NewPoint(40, -74)
+
+	// a box 10m north of the start, in the way of a waypoint 20m north, which the camera can
+	// only see from within 6m.
+	obstacle, err := spatialmath.NewBox(
+		spatialmath.NewPoseFromPoint(r3.Vector{Y: 10000, Z: 500}),
+		r3.Vector{X: 2000, Y: 1000, Z: 1000},
+		"obstacle",
+	)
+	test.That(t, err, test.ShouldBeNil)
+	// the camera is mounted 200mm in front of the center of the base.
+	cameraOffset := spatialmath.NewPoseFromPoint(r3.Vector{Y: 200})
+
+	var sim *simulatedBase
+	var detections atomic.Int64
+	visionService := inject.NewVisionService("vision")
+	visionService.GetObjectPointCloudsFunc = func(
+		ctx context.Context, cameraName string, extra map[string]interface{},
+	) ([]*viz.Object, error) {
+		test.That(t, cameraName, test.ShouldEqual, "camera")
+		position, theta, _ := sim.pose()
+		if position.Distance(obstacle.Pose().Point()) > 6000 {
+			return nil, nil
+		}
+		detections.Inc()
+		basePose := spatialmath.NewPose(position, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: theta})
+		cameraPose := spatialmath.Compose(basePose, cameraOffset)
+		return []*viz.Object{{Geometry: obstacle.Transform(spatialmath.PoseInverse(cameraPose))}}, nil
+	}
+	cam := inject.NewCamera("camera")
+
+	sim, ns, motionSvc := setupWithBuiltinMotion(ctx, t, logger, origin, &Config{
+		MetersPerSec:               1,
+		DegPerSec:                  90,
+		PositionPollingFrequencyHz: 100,
+		ObstaclePollingFrequencyHz: 100,
+		ObstacleDetectors:          []*ObstacleDetectorNameConfig{{VisionServiceName: "vision", CameraName: "camera"}},
+	}, []*referenceframe.FrameSystemPart{
+		{FrameConfig: referenceframe.NewLinkInFrame("test_base", cameraOffset, "camera", nil)},
+	}, visionService, cam)
+
+	destination := geoPoint(origin, r3.Vector{Y: 20})
+	test.That(t, ns.AddWaypoint(ctx, destination, nil), test.ShouldBeNil)
+	test.That(t, ns.SetMode(ctx, navigation.ModeWaypoint, nil), test.ShouldBeNil)
+	testutils.WaitForAssertionWithSleep(t, 10*time.Millisecond, 1000, func(tb testing.TB) {
+		tb.Helper()
+		waypoints, err := ns.Waypoints(ctx, nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, waypoints, test.ShouldBeEmpty)
+	})
+
+	position, _, visited := sim.pose()
+	test.That(t, position.Distance(r3.Vector{Y: 20000}), test.ShouldBeLessThan, 100)
+	test.That(t, detections.Load(), test.ShouldBeGreaterThan, 0)
+	for _, pt := range visited {
+		collides, _, err := spatialmath.NewPoint(r3.Vector{X: pt.X, Y: pt.Y, Z: 500}, "").CollidesWith(obstacle, 0)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, collides, test.ShouldBeFalse)
+	}
+
+	// the base replanned around the obstacle once it was seen.
+	history, err := motionSvc.PlanHistory(ctx, motion.PlanHistoryReq{ComponentName: "test_base"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(history), test.ShouldBeGreaterThan, 1)
+	test.That(t, history[0].StatusHistory[0].State, test.ShouldEqual, motion.PlanStateSucceeded)
+	replanned := history[len(history)-1].StatusHistory[0]
+	test.That(t, replanned.State, test.ShouldEqual, motion.PlanStateFailed)
+	test.That(t, *replanned.Reason, test.ShouldContainSubstring, "an obstacle appeared on the path")
+}
+
+// waitForExplore waits for exploring to finish and returns how it went.
+func waitForExplore(ctx context.Context, t *testing.T, ns navigation.Service) *navigation.ExploreProgress {
+	t.Helper()
+	timeoutCtx, cancelFn := context.WithTimeout(ctx, time.Second*10)
+	defer cancelFn()
+	for {
+		if timeoutCtx.Err() != nil {
+			t.Fatal("test timed out")
+		}
+		prop, err := ns.Properties(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, prop.ExploreProgress, test.ShouldNotBeNil)
+		if prop.ExploreProgress.Done {
+			return prop.ExploreProgress
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestExplore(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+
+	t.Run("covers the explore region on a GPS map", func(t *testing.T) {
+		origin := geo.NewPoint(40, -74)
+		region := []*commonpb.GeoPoint{}
+		for _, corner := range []r3.Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 6}, {X: 0, Y: 6}} {
+			pt := geoPoint(origin, corner)
+			region = append(region, &commonpb.GeoPoint{Latitude: pt.Lat(), Longitude: pt.Lng()})
+		}
+		s := setupExplore(ctx, t, logger, &Config{MovementSensorName: "test_movement", ExploreRegion: region})
+		defer s.closeFunc()
+
+		prop, err := s.ns.Properties(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, prop.ExploreProgress, test.ShouldBeNil)
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeExplore, nil), test.ShouldBeNil)
+		progress := waitForExplore(ctx, t, s.ns)
+		test.That(t, progress.Strategy, test.ShouldEqual, navigation.ExploreCoverage)
+		test.That(t, progress.Err, test.ShouldBeNil)
+		test.That(t, progress.Covered, test.ShouldEqual, 1)
+
+		s.RLock()
+		defer s.RUnlock()
+		// three passes 2m apart along the 10m edges, starting from the corner the robot is at
+		expected := []r3.Vector{{X: 0, Y: 1}, {X: 10, Y: 1}, {X: 10, Y: 3}, {X: 0, Y: 3}, {X: 0, Y: 5}, {X: 10, Y: 5}}
+		test.That(t, len(s.mogrs), test.ShouldEqual, len(expected))
+		for i, req := range s.mogrs {
+			pt := localPoint(origin, req.Destination)
+			test.That(t, pt.X, test.ShouldAlmostEqual, expected[i].X, 1e-3)
+			test.That(t, pt.Y, test.ShouldAlmostEqual, expected[i].Y, 1e-3)
+			test.That(t, req.Extra, test.ShouldResemble, map[string]interface{}{"motion_profile": "position_only"})
+		}
+
+		paths, err := s.ns.Paths(ctx, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, paths, test.ShouldBeEmpty)
+	})
+
+	t.Run("explores frontiers without a map", func(t *testing.T) {
+		s := setupExplore(ctx, t, logger, &Config{
+			MapType:             "None",
+			MovementSensorName:  "test_movement",
+			ExploreRadiusM:      4,
+			ExploreSensorRangeM: 2,
+		})
+		defer s.closeFunc()
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeExplore, nil), test.ShouldBeNil)
+		progress := waitForExplore(ctx, t, s.ns)
+		test.That(t, progress.Strategy, test.ShouldEqual, navigation.ExploreFrontier)
+		test.That(t, progress.Err, test.ShouldBeNil)
+		test.That(t, progress.Covered, test.ShouldEqual, 1)
+
+		s.RLock()
+		defer s.RUnlock()
+		test.That(t, len(s.mogrs), test.ShouldBeGreaterThan, 1)
+		for _, req := range s.mogrs {
+			test.That(t, localPoint(geo.NewPoint(40, -74), req.Destination).Norm(), test.ShouldBeLessThanOrEqualTo, 4+1e-3)
+		}
+	})
+
+	t.Run("exploring frontiers requires a movement sensor", func(t *testing.T) {
+		s := setupExplore(ctx, t, logger, &Config{MapType: "None"})
+		defer s.closeFunc()
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeExplore, nil), test.ShouldBeNil)
+		progress := waitForExplore(ctx, t, s.ns)
+		test.That(t, progress.Err, test.ShouldNotBeNil)
+		test.That(t, s.mogrs, test.ShouldBeEmpty)
+	})
+}
+
+func TestExploreWithBuiltinMotion(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	origin := geo.NewPoint(40, -74)
+	conf := func() *Config {
+		return &Config{
+			MapType:                    "None",
+			MetersPerSec:               1,
+			DegPerSec:                  90,
+			PositionPollingFrequencyHz: 100,
+			ExploreRadiusM:             4,
+			ExploreSensorRangeM:        2,
+		}
+	}
+
+	t.Run("explores frontiers without a map", func(t *testing.T) {
+		sim, ns, _ := setupWithBuiltinMotion(ctx, t, logger, origin, conf(), nil)
+
+		test.That(t, ns.SetMode(ctx, navigation.ModeExplore, nil), test.ShouldBeNil)
+		progress := waitForExplore(ctx, t, ns)
+		test.That(t, progress.Strategy, test.ShouldEqual, navigation.ExploreFrontier)
+		test.That(t, progress.Err, test.ShouldBeNil)
+		test.That(t, progress.Covered, test.ShouldEqual, 1)
+
+		_, _, visited := sim.pose()
+		test.That(t, visited, test.ShouldNotBeEmpty)
+		for _, pt := range visited {
+			test.That(t, pt.Norm(), test.ShouldBeLessThanOrEqualTo, 4100)
+		}
+	})
+
+	t.Run("gives up once several points in a row cannot be reached", func(t *testing.T) {
+		sim, ns, _ := setupWithBuiltinMotion(ctx, t, logger, origin, conf(), nil)
+		sim.mu.Lock()
+		sim.stuck = true
+		sim.mu.Unlock()
+
+		test.That(t, ns.SetMode(ctx, navigation.ModeExplore, nil), test.ShouldBeNil)
+		progress := waitForExplore(ctx, t, ns)
+		test.That(t, progress.Err, test.ShouldNotBeNil)
+		test.That(t, progress.Err.Error(), test.ShouldContainSubstring, "points in a row")
+		test.That(t, progress.Covered, test.ShouldBeLessThan, 1)
+
+		position, _, _ := sim.pose()
+		test.That(t, position.Norm(), test.ShouldEqual, 0)
+	})
+}
+
+func TestCoverageRoute(t *testing.T) {
+	origin := geo.NewPoint(40, -74)
+	region := func(corners ...r3.Vector) []*geo.Point {
+		pts := []*geo.Point{}
+		for _, corner := range corners {
+			pts = append(pts, geoPoint(origin, corner))
+		}
+		return pts
+	}
+
+	t.Run("splits passes which leave the region", func(t *testing.T) {
+		// a U shape open to the north, whose upper passes cross its gap
+		route, err := coverageRoute(region(
+			r3.Vector{X: 0, Y: 0}, r3.Vector{X: 12, Y: 0}, r3.Vector{X: 12, Y: 4}, r3.Vector{X: 8, Y: 4},
+			r3.Vector{X: 8, Y: 2}, r3.Vector{X: 4, Y: 2}, r3.Vector{X: 4, Y: 4}, r3.Vector{X: 0, Y: 4},
+		), 2)
+		test.That(t, err, test.ShouldBeNil)
+		expected := []r3.Vector{{X: 0, Y: 1}, {X: 12, Y: 1}, {X: 12, Y: 3}, {X: 8, Y: 3}, {X: 4, Y: 3}, {X: 0, Y: 3}}
+		test.That(t, len(route), test.ShouldEqual, len(expected))
+		for i, pt := range route {
+			test.That(t, localPoint(origin, pt).X, test.ShouldAlmostEqual, expected[i].X, 1e-3)
+			test.That(t, localPoint(origin, pt).Y, test.ShouldAlmostEqual, expected[i].Y, 1e-3)
+		}
+	})
+
+	t.Run("makes one pass through a narrow region", func(t *testing.T) {
+		route, err := coverageRoute(region(r3.Vector{X: 0, Y: 0}, r3.Vector{X: 0, Y: 10}, r3.Vector{X: 0.5, Y: 10}), 2)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(route), test.ShouldEqual, 2)
+	})
+
+	t.Run("fails for degenerate regions", func(t *testing.T) {
+		_, err := coverageRoute(region(r3.Vector{X: 0, Y: 0}, r3.Vector{X: 10, Y: 0}), 2)
+		test.That(t, err, test.ShouldBeError, errExploreRegionTooSmall)
+		_, err = coverageRoute(region(r3.Vector{X: 0, Y: 0}, r3.Vector{X: 10, Y: 0}, r3.Vector{X: 0, Y: 10}), 0)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestOccupancyGrid(t *testing.T) {
+	grid := newOccupancyGrid(3, 0.5)
+	// a wall 1.5m east of the centre
+	wall, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: 1500}), r3.Vector{X: 400, Y: 2000, Z: 1000}, "")
+	test.That(t, err, test.ShouldBeNil)
+	grid.observe(r3.Vector{}, 2.5, []spatialmath.Geometry{wall})
+
+	test.That(t, grid.state(gridCell{0, 0}), test.ShouldEqual, cellFree)
+	test.That(t, grid.state(gridCell{-4, 0}), test.ShouldEqual, cellFree)
+	test.That(t, grid.state(gridCell{3, 0}), test.ShouldEqual, cellOccupied)
+	// hidden behind the wall
+	test.That(t, grid.state(gridCell{4, 0}), test.ShouldEqual, cellUnknown)
+	// out of sensor range
+	test.That(t, grid.state(gridCell{-6, 0}), test.ShouldEqual, cellUnknown)
+	test.That(t, grid.known(), test.ShouldBeBetween, 0, 1)
+
+	frontier, ok := grid.nearestFrontier(r3.Vector{}, nil)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, grid.frontier(frontier), test.ShouldBeTrue)
+	test.That(t, grid.center(frontier).Norm(), test.ShouldBeLessThanOrEqualTo, 2.5)
+
+	// once everything reachable has been seen there are no frontiers left
+	for _, pos := range []r3.Vector{{X: -2}, {Y: 2}, {Y: -2}, {X: 1, Y: 2}, {X: 1, Y: -2}, {X: 2.5, Y: 1.5}, {X: 2.5, Y: -1.5}} {
+		grid.observe(pos, 2.5, []spatialmath.Geometry{wall})
+	}
+	_, ok = grid.nearestFrontier(r3.Vector{}, nil)
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+// waitForMoveOnGlobe waits for MoveOnGlobe to have been called n times.
+func waitForMoveOnGlobe(ctx context.Context, t *testing.T, s *startWaypointState, n int) {
+	t.Helper()
+	timeoutCtx, cancelFn := context.WithTimeout(ctx, time.Second*10)
+	defer cancelFn()
+	for {
+		if timeoutCtx.Err() != nil {
+			t.Fatal("test timed out")
+		}
+		s.RLock()
+		called := len(s.mogrs)
+		s.RUnlock()
+		if called >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	origin := geo.NewPoint(40, -74)
+	stopAt := func(x, y float64) navigation.RouteStop {
+		pt := geoPoint(origin, r3.Vector{X: x, Y: y})
+		return navigation.RouteStop{Lat: pt.Lat(), Long: pt.Lng()}
+	}
+	pointAt := func(x, y float64) *geo.Point {
+		stop := stopAt(x, y)
+		return stop.ToPoint()
+	}
+
+	t.Run("follows a route with headings, dwell times and actions", func(t *testing.T) {
+		var commands []map[string]interface{}
+		camera := inject.NewGenericComponent("camera")
+		camera.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			commands = append(commands, cmd)
+			return nil, nil
+		}
+		heading := 90.
+		first, second := stopAt(0, 5), stopAt(5, 5)
+		first.HeadingDegs = &heading
+		second.DwellSec = 0.01
+		second.Action = &navigation.WaypointAction{Resource: "camera", Command: map[string]interface{}{"snap": true}}
+		s := setupExplore(ctx, t, logger, &Config{
+			MovementSensorName: "test_movement",
+			Routes:             []navigation.Route{{Name: "patrol", Stops: []navigation.RouteStop{first, second}}},
+		}, camera)
+		defer s.closeFunc()
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeWaypoint, map[string]interface{}{"route": "patrol"}), test.ShouldBeNil)
+		waitForMoveOnGlobe(ctx, t, s, 2)
+		timeoutCtx, cancelFn := context.WithTimeout(ctx, time.Second*10)
+		defer cancelFn()
+		for {
+			paths, err := s.ns.Paths(ctx, nil)
+			test.That(t, err, test.ShouldBeNil)
+			if len(paths) == 0 {
+				break
+			}
+			if timeoutCtx.Err() != nil {
+				t.Fatal("test timed out")
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		s.RLock()
+		defer s.RUnlock()
+		test.That(t, len(s.mogrs), test.ShouldEqual, 2)
+		test.That(t, s.mogrs[0].Destination, test.ShouldResemble, first.ToPoint())
+		test.That(t, s.mogrs[0].Heading, test.ShouldEqual, 90)
+		test.That(t, s.mogrs[0].Extra, test.ShouldBeEmpty)
+		test.That(t, s.mogrs[1].Destination, test.ShouldResemble, second.ToPoint())
+		test.That(t, math.IsNaN(s.mogrs[1].Heading), test.ShouldBeTrue)
+		test.That(t, s.mogrs[1].Extra, test.ShouldResemble, map[string]interface{}{"motion_profile": "position_only"})
+		test.That(t, commands, test.ShouldResemble, []map[string]interface{}{{"snap": true}})
+	})
+
+	t.Run("loops until the mode changes", func(t *testing.T) {
+		s := setupExplore(ctx, t, logger, &Config{
+			MovementSensorName: "test_movement",
+			Routes: []navigation.Route{{
+				Name:  "patrol",
+				Stops: []navigation.RouteStop{stopAt(0, 5), stopAt(5, 5)},
+				Loop:  true,
+			}},
+		})
+		defer s.closeFunc()
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeWaypoint, map[string]interface{}{"route": "patrol"}), test.ShouldBeNil)
+		waitForMoveOnGlobe(ctx, t, s, 5)
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeManual, nil), test.ShouldBeNil)
+
+		s.RLock()
+		defer s.RUnlock()
+		for i, req := range s.mogrs {
+			test.That(t, req.Destination, test.ShouldResemble, []*geo.Point{pointAt(0, 5), pointAt(5, 5)}[i%2])
+		}
+	})
+
+	t.Run("skips stops outside of geofences", func(t *testing.T) {
+		fence := []*commonpb.GeoPoint{}
+		for _, corner := range []r3.Vector{{X: -1, Y: -1}, {X: 3, Y: -1}, {X: 3, Y: 10}, {X: -1, Y: 10}} {
+			pt := geoPoint(origin, corner)
+			fence = append(fence, &commonpb.GeoPoint{Latitude: pt.Lat(), Longitude: pt.Lng()})
+		}
+		s := setupExplore(ctx, t, logger, &Config{
+			MovementSensorName: "test_movement",
+			Routes: []navigation.Route{{
+				Name:  "patrol",
+				Stops: []navigation.RouteStop{stopAt(5, 5), stopAt(0, 5)},
+			}},
+			Geofences: []*GeofenceConfig{{Name: "yard", Type: geofenceKeepIn, Points: fence}},
+		})
+		defer s.closeFunc()
+
+		test.That(t, s.ns.SetMode(ctx, navigation.ModeWaypoint, map[string]interface{}{"route": "patrol"}), test.ShouldBeNil)
+		waitForMoveOnGlobe(ctx, t, s, 1)
+
+		s.RLock()
+		defer s.RUnlock()
+		test.That(t, len(s.mogrs), test.ShouldEqual, 1)
+		test.That(t, s.mogrs[0].Destination, test.ShouldResemble, pointAt(0, 5))
+	})
+
+	t.Run("DoCommand adds, lists and removes routes", func(t *testing.T) {
+		s := setupExplore(ctx, t, logger, &Config{MovementSensorName: "test_movement"})
+		defer s.closeFunc()
+
+		route := map[string]interface{}{
+			"name":      "patrol",
+			"ordering":  "shortest",
+			"waypoints": []interface{}{map[string]interface{}{"latitude": 40, "longitude": -74}},
+		}
+		resp, err := s.ns.DoCommand(ctx, map[string]interface{}{DoAddRoute: route})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[DoAddRoute], test.ShouldBeTrue)
+
+		resp, err = s.ns.DoCommand(ctx, map[string]interface{}{DoGetRoutes: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[DoGetRoutes], test.ShouldResemble, []interface{}{map[string]interface{}{
+			"name":      "patrol",
+			"ordering":  "shortest",
+			"waypoints": []interface{}{map[string]interface{}{"latitude": 40., "longitude": -74.}},
+		}})
+
+		route["waypoints"] = []interface{}{map[string]interface{}{
+			"latitude": 40, "longitude": -74, "action": map[string]interface{}{"resource": "camera"},
+		}}
+		_, err = s.ns.DoCommand(ctx, map[string]interface{}{DoAddRoute: route})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "action_resources")
+
+		resp, err = s.ns.DoCommand(ctx, map[string]interface{}{DoRemoveRoute: "patrol"})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[DoRemoveRoute], test.ShouldBeTrue)
+		resp, err = s.ns.DoCommand(ctx, map[string]interface{}{DoGetRoutes: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[DoGetRoutes], test.ShouldBeEmpty)
+	})
+}
+
+func TestOrderStops(t *testing.T) {
+	origin := geo.NewPoint(40, -74)
+	stops := []navigation.RouteStop{}
+	for _, pt := range []r3.Vector{{X: 7, Y: 1}, {X: 8, Y: -5}, {X: 2, Y: 9}, {X: 10, Y: 2}} {
+		geoPt := geoPoint(origin, pt)
+		stops = append(stops, navigation.RouteStop{Lat: geoPt.Lat(), Long: geoPt.Lng()})
+	}
+	order := func(ordered []navigation.RouteStop) []int {
+		indices := []int{}
+		for _, stop := range ordered {
+			indices = append(indices, slices.Index(stops, stop))
+		}
+		return indices
+	}
+
+	test.That(t, order(orderStops(stops, origin, navigation.RouteOrderGiven, false)), test.ShouldResemble, []int{0, 1, 2, 3})
+	// the nearest neighbour goes south and then has to come all the way back north for the last stop
+	test.That(t, order(orderStops(stops, origin, navigation.RouteOrderNearestNeighbor, false)),
+		test.ShouldResemble, []int{0, 3, 1, 2})
+	test.That(t, order(orderStops(stops, origin, navigation.RouteOrderShortest, false)), test.ShouldResemble, []int{2, 3, 0, 1})
+	// a loop starts from the stop nearest the start and visits each stop once
+	loop := order(orderStops(stops, origin, navigation.RouteOrderShortest, true))
+	test.That(t, loop[0], test.ShouldEqual, 0)
+	test.That(t, len(loop), test.ShouldEqual, len(stops))
+}
+
+func TestGeofences(t *testing.T) {
+	origin := geo.NewPoint(40, -74)
+	points := []*commonpb.GeoPoint{}
+	for _, corner := range []r3.Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}} {
+		pt := geoPoint(origin, corner)
+		points = append(points, &commonpb.GeoPoint{Latitude: pt.Lat(), Longitude: pt.Lng()})
+	}
+	keepIn := newGeofence(&GeofenceConfig{Name: "yard", Type: geofenceKeepIn, Points: points})
+	keepOut := newGeofence(&GeofenceConfig{Name: "pond", Type: geofenceKeepOut, Points: points})
+	inside, outside := geoPoint(origin, r3.Vector{X: 5, Y: 5}), geoPoint(origin, r3.Vector{X: 15, Y: 5})
+
+	test.That(t, keepIn.contains(inside), test.ShouldBeTrue)
+	test.That(t, keepIn.contains(outside), test.ShouldBeFalse)
+
+	test.That(t, checkGeofences([]*geofence{keepIn}, inside), test.ShouldBeNil)
+	err := checkGeofences([]*geofence{keepIn}, outside)
+	test.That(t, errors.Is(err, errBreaksGeofence), test.ShouldBeTrue)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `keep in geofence "yard"`)
+
+	test.That(t, checkGeofences([]*geofence{keepOut}, outside), test.ShouldBeNil)
+	err = checkGeofences([]*geofence{keepOut}, inside)
+	test.That(t, errors.Is(err, errBreaksGeofence), test.ShouldBeTrue)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `keep out geofence "pond"`)
+}
+
+func TestGeofenceGeometries(t *testing.T) {
+	origin := geo.NewPoint(40, -74)
+	fence := func(name, fenceType string, corners ...r3.Vector) *geofence {
+		points := []*commonpb.GeoPoint{}
+		for _, corner := range corners {
+			pt := geoPoint(origin, corner)
+			points = append(points, &commonpb.GeoPoint{Latitude: pt.Lat(), Longitude: pt.Lng()})
+		}
+		return newGeofence(&GeofenceConfig{Name: name, Type: fenceType, Points: points})
+	}
+	// an L shaped pond, given clockwise, and two overlapping yards
+	pond := fence("pond", geofenceKeepOut,
+		r3.Vector{X: 0, Y: 0}, r3.Vector{X: 0, Y: 10}, r3.Vector{X: 5, Y: 10}, r3.Vector{X: 5, Y: 5},
+		r3.Vector{X: 10, Y: 5}, r3.Vector{X: 10, Y: 0})
+	yard := fence("yard", geofenceKeepIn, r3.Vector{X: 0, Y: 0}, r3.Vector{X: 20, Y: 0}, r3.Vector{X: 20, Y: 20}, r3.Vector{X: 0, Y: 20})
+	field := fence("field", geofenceKeepIn,
+		r3.Vector{X: 10, Y: 10}, r3.Vector{X: 30, Y: 10}, r3.Vector{X: 30, Y: 30}, r3.Vector{X: 10, Y: 30})
+
+	obstacles, boundingRegions := geofenceGeometries([]*geofence{pond, yard, field})
+	test.That(t, len(obstacles), test.ShouldEqual, 1)
+	test.That(t, len(boundingRegions), test.ShouldEqual, 1)
+
+	covers := func(geoGeometries []*spatialmath.GeoGeometry, x, y float64) bool {
+		pt := spatialmath.NewPoint(spatialmath.GeoPointToPoint(geoPoint(origin, r3.Vector{X: x, Y: y}), origin), "")
+		for _, geom := range spatialmath.GeoGeometriesToGeometries(geoGeometries, origin) {
+			if collides, _, err := pt.CollidesWith(geom, 1e-6); err == nil && collides {
+				return true
+			}
+		}
+		return false
+	}
+	test.That(t, covers(obstacles, 2, 8), test.ShouldBeTrue)
+	test.That(t, covers(obstacles, 8, 2), test.ShouldBeTrue)
+	test.That(t, covers(obstacles, 8, 8), test.ShouldBeFalse)
+
+	// only where the yard and field overlap is inside both
+	test.That(t, covers(boundingRegions, 15, 15), test.ShouldBeTrue)
+	test.That(t, covers(boundingRegions, 5, 5), test.ShouldBeFalse)
+	test.That(t, covers(boundingRegions, 25, 25), test.ShouldBeFalse)
+}
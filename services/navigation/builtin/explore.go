@@ -0,0 +1,255 @@
+package builtin
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/services/navigation"
+	"go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// size of the cells of the occupancy map built while exploring frontiers.
+const exploreGridResolutionM = 0.5
+
+// startExploreMode explores in the background until there is nothing left to explore or ctx is
+// cancelled. A configured explore region is covered in passes, otherwise frontiers are explored.
+// It expects svc.mu to be held.
+func (svc *builtIn) startExploreMode(ctx context.Context, extra map[string]interface{}) {
+	if extra == nil {
+		extra = map[string]interface{}{}
+	}
+
+	extra["motion_profile"] = "position_only"
+
+	strategy := navigation.ExploreFrontier
+	if len(svc.exploreRegion) > 0 {
+		strategy = navigation.ExploreCoverage
+	}
+	svc.exploreProgress = &navigation.ExploreProgress{Strategy: strategy}
+	svc.remainingRoute = nil
+
+	svc.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		var err error
+		switch strategy {
+		case navigation.ExploreCoverage:
+			err = svc.exploreCoverage(ctx, extra)
+		case navigation.ExploreFrontier:
+			err = svc.exploreFrontiers(ctx, extra)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			svc.logger.CWarnf(ctx, "stopped exploring: %s", err)
+		} else {
+			svc.logger.CInfo(ctx, "finished exploring")
+		}
+		svc.mu.Lock()
+		defer svc.mu.Unlock()
+		svc.exploreProgress.Done = true
+		svc.exploreProgress.Err = err
+		svc.remainingRoute = nil
+	}, svc.activeBackgroundWorkers.Done)
+}
+
+// exploreCoverage drives back and forth across the explore region.
+func (svc *builtIn) exploreCoverage(ctx context.Context, extra map[string]interface{}) error {
+	svc.mu.RLock()
+	region, swathM := svc.exploreRegion, svc.exploreSwathM
+	svc.mu.RUnlock()
+
+	route, err := coverageRoute(region, swathM)
+	if err != nil {
+		return err
+	}
+	// start from whichever end of the route is closer
+	current, _, err := svc.movementSensor.Position(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if current.GreatCircleDistance(route[len(route)-1]) < current.GreatCircleDistance(route[0]) {
+		slices.Reverse(route)
+	}
+
+	total := routeLengthM(route)
+	failed := 0
+	for i, pt := range route {
+		svc.setExploreProgress(routeLengthM(route[:i])/total, route[i:])
+		svc.logger.CDebugf(ctx, "covering point %d of %d of the explore region: %v", i+1, len(route), *pt)
+		if err := svc.exploreLeg(ctx, pt, extra, &failed); err != nil {
+			return err
+		}
+	}
+	svc.setExploreProgress(1, nil)
+	return nil
+}
+
+// exploreFrontiers drives to the nearest frontier of what has been seen around where the robot
+// started until none are left within the explore radius.
+func (svc *builtIn) exploreFrontiers(ctx context.Context, extra map[string]interface{}) error {
+	if svc.movementSensor == nil {
+		return errors.New("exploring frontiers requires a movement_sensor to be configured")
+	}
+	origin, _, err := svc.movementSensor.Position(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	svc.mu.RLock()
+	radiusM, sensorRangeM := svc.exploreRadiusM, svc.exploreSensorRangeM
+	svc.mu.RUnlock()
+
+	grid := newOccupancyGrid(radiusM, exploreGridResolutionM)
+	// cells already visited or given up on are never explored again
+	visited := map[gridCell]bool{}
+	failed := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		current, _, err := svc.movementSensor.Position(ctx, nil)
+		if err != nil {
+			return err
+		}
+		obstacles, err := svc.Obstacles(ctx, nil)
+		if err != nil {
+			return err
+		}
+		pos := localPoint(origin, current)
+		grid.observe(pos, sensorRangeM, spatialmath.GeoGeometriesToGeometries(obstacles, origin))
+		visited[grid.cellAt(pos)] = true
+
+		frontier, ok := grid.nearestFrontier(pos, visited)
+		svc.setExploreProgress(grid.known(), nil)
+		if !ok {
+			return nil
+		}
+		visited[frontier] = true
+
+		dest := geoPoint(origin, grid.center(frontier))
+		svc.logger.CDebugf(ctx, "exploring frontier at %v, %.0f%% explored", *dest, 100*grid.known())
+		if err := svc.exploreLeg(ctx, dest, extra, &failed); err != nil {
+			return err
+		}
+	}
+}
+
+// exploreLeg moves to a point while exploring. A point which cannot be reached is skipped, unless
+// failed, the number of points in a row which could not be reached, gets to maxFailedExploreLegs,
+// as then the robot is most likely stuck.
+func (svc *builtIn) exploreLeg(ctx context.Context, pt *geo.Point, extra map[string]interface{}, failed *int) error {
+	err := svc.moveToPoint(ctx, pt, math.NaN(), extra)
+	if err == nil {
+		*failed = 0
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	*failed++
+	if *failed >= maxFailedExploreLegs {
+		return errors.Wrapf(err, "could not reach %d points in a row while exploring", *failed)
+	}
+	svc.logger.CWarnf(ctx, "skipping %v since it could not be reached: %s", *pt, err)
+	return nil
+}
+
+func (svc *builtIn) setExploreProgress(covered float64, route []*geo.Point) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.exploreProgress.Covered = covered
+	svc.remainingRoute = route
+}
+
+// coverageRoute returns the points to drive through, in order, to cover a polygon in passes swathM
+// apart. Passes run along the longest edge of the polygon, alternating direction, and are split
+// where they cross out of the polygon.
+func coverageRoute(region []*geo.Point, swathM float64) ([]*geo.Point, error) {
+	if len(region) < 3 {
+		return nil, errExploreRegionTooSmall
+	}
+	if swathM <= 0 {
+		return nil, errors.New("cannot cover a region with passes which are not a positive distance apart")
+	}
+
+	// work in the frame of the longest edge, so that passes run along the x axis
+	origin := region[0]
+	corners := make([]r3.Vector, 0, len(region))
+	for _, pt := range region {
+		corners = append(corners, localPoint(origin, pt))
+	}
+	var longest r3.Vector
+	for i, corner := range corners {
+		if edge := corners[(i+1)%len(corners)].Sub(corner); edge.Norm() > longest.Norm() {
+			longest = edge
+		}
+	}
+	angle := math.Atan2(longest.Y, longest.X)
+	for i, corner := range corners {
+		corners[i] = rotate(corner, -angle)
+	}
+	minY, maxY := corners[0].Y, corners[0].Y
+	for _, corner := range corners {
+		minY = math.Min(minY, corner.Y)
+		maxY = math.Max(maxY, corner.Y)
+	}
+
+	route := []*geo.Point{}
+	for pass, y := 0, minY+swathM/2; y < maxY || pass == 0; pass, y = pass+1, y+swathM {
+		if y >= maxY {
+			// the region is narrower than one pass
+			y = (minY + maxY) / 2
+		}
+		crossings := []float64{}
+		for i, a := range corners {
+			b := corners[(i+1)%len(corners)]
+			if (a.Y <= y) != (b.Y <= y) {
+				crossings = append(crossings, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+			}
+		}
+		slices.Sort(crossings)
+		if pass%2 == 1 {
+			slices.Reverse(crossings)
+		}
+		for i := 0; i+1 < len(crossings); i += 2 {
+			for _, x := range crossings[i : i+2] {
+				route = append(route, geoPoint(origin, rotate(r3.Vector{X: x, Y: y}, angle)))
+			}
+		}
+	}
+	if len(route) == 0 {
+		return nil, errors.New("explore region has no area to cover")
+	}
+	return route, nil
+}
+
+func routeLengthM(route []*geo.Point) float64 {
+	length := 0.
+	for i := 1; i < len(route); i++ {
+		length += 1e3 * route[i-1].GreatCircleDistance(route[i])
+	}
+	return length
+}
+
+func rotate(v r3.Vector, angle float64) r3.Vector {
+	sin, cos := math.Sincos(angle)
+	return r3.Vector{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
+
+// localPoint returns the position of a point relative to origin in meters, with x east and y north.
+func localPoint(origin, pt *geo.Point) r3.Vector {
+	return spatialmath.GeoPointToPoint(pt, origin).Mul(1e-3)
+}
+
+// geoPoint is the inverse of localPoint.
+func geoPoint(origin *geo.Point, v r3.Vector) *geo.Point {
+	return origin.PointAtDistanceAndBearing(1e-3*math.Hypot(v.X, v.Y), rdkutils.RadToDeg(math.Atan2(v.X, v.Y)))
+}
@@ -0,0 +1,252 @@
+package builtin
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+const (
+	geofenceKeepIn  = "keep_in"
+	geofenceKeepOut = "keep_out"
+)
+
+var errBreaksGeofence = errors.New("breaks geofence")
+
+// GeofenceConfig describes a polygon, given by its corners, which the base must stay inside of
+// (keep_in) or outside of (keep_out).
+type GeofenceConfig struct {
+	Name   string               `json:"name"`
+	Type   string               `json:"type"`
+	Points []*commonpb.GeoPoint `json:"points"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (conf *GeofenceConfig) Validate() error {
+	if conf.Type != geofenceKeepIn && conf.Type != geofenceKeepOut {
+		return errors.Errorf("geofence %q must have a type of %s or %s", conf.Name, geofenceKeepIn, geofenceKeepOut)
+	}
+	if len(conf.Points) < 3 {
+		return errors.Errorf("geofence %q must have at least 3 points", conf.Name)
+	}
+	return nil
+}
+
+type geofence struct {
+	name    string
+	keepIn  bool
+	origin  *geo.Point
+	points  []*geo.Point
+	corners []r3.Vector
+}
+
+func newGeofence(conf *GeofenceConfig) *geofence {
+	origin := geo.NewPoint(conf.Points[0].GetLatitude(), conf.Points[0].GetLongitude())
+	fence := &geofence{name: conf.Name, keepIn: conf.Type == geofenceKeepIn, origin: origin}
+	for _, pt := range conf.Points {
+		point := geo.NewPoint(pt.GetLatitude(), pt.GetLongitude())
+		fence.points = append(fence.points, point)
+		fence.corners = append(fence.corners, localPoint(origin, point))
+	}
+	return fence
+}
+
+// contains returns whether a point lies inside the geofence's polygon.
+func (fence *geofence) contains(pt *geo.Point) bool {
+	p := localPoint(fence.origin, pt)
+	inside := false
+	for i, a := range fence.corners {
+		b := fence.corners[(i+1)%len(fence.corners)]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// polygon returns the corners of the geofence in millimeters from origin.
+func (fence *geofence) polygon(origin *geo.Point) []r3.Vector {
+	polygon := make([]r3.Vector, 0, len(fence.points))
+	for _, pt := range fence.points {
+		polygon = append(polygon, spatialmath.GeoPointToPoint(pt, origin))
+	}
+	return polygon
+}
+
+// checkGeofences returns an error naming the first of the geofences a point is on the wrong side of.
+func checkGeofences(fences []*geofence, pt *geo.Point) error {
+	for _, fence := range fences {
+		switch inside := fence.contains(pt); {
+		case fence.keepIn && !inside:
+			return errors.Wrapf(errBreaksGeofence, "%v is outside keep in geofence %q", *pt, fence.name)
+		case !fence.keepIn && inside:
+			return errors.Wrapf(errBreaksGeofence, "%v is inside keep out geofence %q", *pt, fence.name)
+		}
+	}
+	return nil
+}
+
+// geofenceGeometries returns the keep out geofences as obstacles, and the area inside all of the keep
+// in geofences as a bounding region, for MoveOnGlobe to plan around. They are flat meshes on the ground,
+// which is where the base's footprint is checked against them.
+func geofenceGeometries(fences []*geofence) (obstacles, boundingRegions []*spatialmath.GeoGeometry) {
+	var keepInOrigin *geo.Point
+	var keepIn [][3]r3.Vector
+	for _, fence := range fences {
+		switch {
+		case !fence.keepIn:
+			if triangles := triangulate(fence.polygon(fence.origin)); len(triangles) > 0 {
+				obstacles = append(obstacles, spatialmath.NewGeoGeometry(fence.origin,
+					[]spatialmath.Geometry{groundMesh(triangles, fence.name)}))
+			}
+		case keepInOrigin == nil:
+			keepInOrigin = fence.origin
+			keepIn = triangulate(fence.polygon(keepInOrigin))
+		default:
+			keepIn = intersectTriangles(keepIn, triangulate(fence.polygon(keepInOrigin)))
+		}
+	}
+	if len(keepIn) > 0 {
+		boundingRegions = append(boundingRegions, spatialmath.NewGeoGeometry(keepInOrigin,
+			[]spatialmath.Geometry{groundMesh(keepIn, geofenceKeepIn)}))
+	}
+	return obstacles, boundingRegions
+}
+
+func groundMesh(triangles [][3]r3.Vector, label string) *spatialmath.Mesh {
+	meshTriangles := make([]*spatialmath.Triangle, 0, len(triangles))
+	for _, tri := range triangles {
+		meshTriangles = append(meshTriangles, spatialmath.NewTriangle(tri[0], tri[1], tri[2]))
+	}
+	return spatialmath.NewMesh(spatialmath.NewZeroPose(), meshTriangles, label)
+}
+
+// turn returns twice the signed area of the triangle abc, which is positive if it turns left.
+func turn(a, b, c r3.Vector) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// triangulate splits a simple polygon into counterclockwise triangles by cutting off its ears in turn.
+func triangulate(polygon []r3.Vector) [][3]r3.Vector {
+	pts := slices.Clone(polygon)
+	area := 0.
+	for i, a := range pts {
+		area += turn(r3.Vector{}, a, pts[(i+1)%len(pts)])
+	}
+	if area < 0 {
+		slices.Reverse(pts)
+	}
+
+	var triangles [][3]r3.Vector
+	for len(pts) > 3 {
+		cut := false
+		for i := range pts {
+			a, b, c := pts[(i+len(pts)-1)%len(pts)], pts[i], pts[(i+1)%len(pts)]
+			if turn(a, b, c) <= 0 {
+				continue
+			}
+			ear := true
+			for _, p := range pts {
+				if p != a && p != b && p != c && turn(a, b, p) >= 0 && turn(b, c, p) >= 0 && turn(c, a, p) >= 0 {
+					ear = false
+					break
+				}
+			}
+			if ear {
+				triangles = append(triangles, [3]r3.Vector{a, b, c})
+				pts = slices.Delete(pts, i, i+1)
+				cut = true
+				break
+			}
+		}
+		if !cut {
+			// what is left of the polygon is degenerate, e.g. because it crosses itself.
+			return triangles
+		}
+	}
+	if len(pts) == 3 && turn(pts[0], pts[1], pts[2]) > 0 {
+		triangles = append(triangles, [3]r3.Vector{pts[0], pts[1], pts[2]})
+	}
+	return triangles
+}
+
+// intersectTriangles returns triangles covering the area covered by both sets of counterclockwise
+// triangles.
+func intersectTriangles(a, b [][3]r3.Vector) [][3]r3.Vector {
+	var triangles [][3]r3.Vector
+	for _, triA := range a {
+		for _, triB := range b {
+			clipped := triA[:]
+			for i, edgeStart := range triB {
+				clipped = clipPolygon(clipped, edgeStart, triB[(i+1)%3])
+			}
+			for i := 1; i+1 < len(clipped); i++ {
+				if turn(clipped[0], clipped[i], clipped[i+1]) > 0 {
+					triangles = append(triangles, [3]r3.Vector{clipped[0], clipped[i], clipped[i+1]})
+				}
+			}
+		}
+	}
+	return triangles
+}
+
+// clipPolygon returns the part of a convex polygon to the left of the line through a and b.
+func clipPolygon(polygon []r3.Vector, a, b r3.Vector) []r3.Vector {
+	var clipped []r3.Vector
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		turnP, turnQ := turn(a, b, p), turn(a, b, q)
+		if turnP >= 0 {
+			clipped = append(clipped, p)
+		}
+		if (turnP >= 0) != (turnQ >= 0) {
+			clipped = append(clipped, p.Add(q.Sub(p).Mul(turnP/(turnP-turnQ))))
+		}
+	}
+	return clipped
+}
+
+// watchGeofences polls the position of the movement sensor until ctx is done, calling cancel if it
+// is ever on the wrong side of a geofence. The returned function waits for the watch to end and
+// returns the geofence broken, if any.
+func (svc *builtIn) watchGeofences(ctx context.Context, cancel func()) func() error {
+	var wg sync.WaitGroup
+	var breach error
+	if len(svc.geofences) == 0 || svc.movementSensor == nil {
+		return func() error { return nil }
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(planHistoryPollFrequency)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			pt, _, err := svc.movementSensor.Position(ctx, nil)
+			if err != nil {
+				continue
+			}
+			if err := checkGeofences(svc.geofences, pt); err != nil {
+				breach = err
+				cancel()
+				return
+			}
+		}
+	}()
+	return func() error {
+		wg.Wait()
+		return breach
+	}
+}
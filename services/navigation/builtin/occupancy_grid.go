@@ -0,0 +1,190 @@
+package builtin
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+type cellState uint8
+
+const (
+	cellUnknown = cellState(iota)
+	cellFree
+	cellOccupied
+)
+
+// gridCell indexes an occupancyGrid by the number of cells east and north of its centre.
+type gridCell struct {
+	x, y int
+}
+
+var gridNeighbors = []gridCell{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// occupancyGrid maps which of the cells within a radius of its centre are free, occupied or not
+// yet seen. Positions are in meters relative to the centre, with x east and y north.
+type occupancyGrid struct {
+	radiusM     float64
+	resolutionM float64
+	halfWidth   int
+	cells       []cellState
+}
+
+func newOccupancyGrid(radiusM, resolutionM float64) *occupancyGrid {
+	halfWidth := int(math.Ceil(radiusM / resolutionM))
+	return &occupancyGrid{
+		radiusM:     radiusM,
+		resolutionM: resolutionM,
+		halfWidth:   halfWidth,
+		cells:       make([]cellState, (2*halfWidth+1)*(2*halfWidth+1)),
+	}
+}
+
+func (g *occupancyGrid) cellAt(pos r3.Vector) gridCell {
+	return gridCell{int(math.Round(pos.X / g.resolutionM)), int(math.Round(pos.Y / g.resolutionM))}
+}
+
+func (g *occupancyGrid) center(c gridCell) r3.Vector {
+	return r3.Vector{X: float64(c.x) * g.resolutionM, Y: float64(c.y) * g.resolutionM}
+}
+
+// contains returns whether a cell is part of the map.
+func (g *occupancyGrid) contains(c gridCell) bool {
+	if c.x < -g.halfWidth || c.x > g.halfWidth || c.y < -g.halfWidth || c.y > g.halfWidth {
+		return false
+	}
+	return g.center(c).Norm() <= g.radiusM
+}
+
+func (g *occupancyGrid) index(c gridCell) int {
+	return (c.y+g.halfWidth)*(2*g.halfWidth+1) + c.x + g.halfWidth
+}
+
+func (g *occupancyGrid) state(c gridCell) cellState {
+	if !g.contains(c) {
+		return cellUnknown
+	}
+	return g.cells[g.index(c)]
+}
+
+func (g *occupancyGrid) setState(c gridCell, state cellState) {
+	if g.contains(c) {
+		g.cells[g.index(c)] = state
+	}
+}
+
+// observe updates the map with what can be seen from pos: cells within sensorRangeM are occupied
+// if they fall within an obstacle, given in mm, and free if there is a clear line of sight to them.
+// Obstacles are checked at their own height, so that those detected above the ground still block
+// the cells beneath them.
+func (g *occupancyGrid) observe(pos r3.Vector, sensorRangeM float64, obstacles []spatialmath.Geometry) {
+	from := g.cellAt(pos)
+	reach := int(math.Ceil(sensorRangeM / g.resolutionM))
+	inRange := []gridCell{}
+	for dy := -reach; dy <= reach; dy++ {
+		for dx := -reach; dx <= reach; dx++ {
+			c := gridCell{from.x + dx, from.y + dy}
+			if !g.contains(c) || g.center(c).Sub(pos).Norm() > sensorRangeM {
+				continue
+			}
+			if g.occupied(c, obstacles) {
+				g.setState(c, cellOccupied)
+				continue
+			}
+			// an obstacle which has gone is not known to have left free space until it is seen
+			if g.state(c) == cellOccupied {
+				g.setState(c, cellUnknown)
+			}
+			inRange = append(inRange, c)
+		}
+	}
+	for _, c := range inRange {
+		if g.visible(pos, c) {
+			g.setState(c, cellFree)
+		}
+	}
+	g.setState(from, cellFree)
+}
+
+func (g *occupancyGrid) occupied(c gridCell, obstacles []spatialmath.Geometry) bool {
+	centerMM := g.center(c).Mul(1e3)
+	for _, obstacle := range obstacles {
+		pt := spatialmath.NewPoint(r3.Vector{X: centerMM.X, Y: centerMM.Y, Z: obstacle.Pose().Point().Z}, "")
+		if collides, _, err := obstacle.CollidesWith(pt, 0); err == nil && collides {
+			return true
+		}
+	}
+	return false
+}
+
+// visible returns whether no occupied cell lies between pos and a cell.
+func (g *occupancyGrid) visible(pos r3.Vector, to gridCell) bool {
+	ray := g.center(to).Sub(pos)
+	steps := int(math.Ceil(2 * ray.Norm() / g.resolutionM))
+	for i := 1; i < steps; i++ {
+		c := g.cellAt(pos.Add(ray.Mul(float64(i) / float64(steps))))
+		if c != to && g.state(c) == cellOccupied {
+			return false
+		}
+	}
+	return true
+}
+
+// nearestFrontier returns the free cell bordering unseen space which is fewest cells away from pos
+// across free space, leaving out those to skip.
+func (g *occupancyGrid) nearestFrontier(pos r3.Vector, skip map[gridCell]bool) (gridCell, bool) {
+	start := g.cellAt(pos)
+	seen := map[gridCell]bool{start: true}
+	queue := []gridCell{start}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if !skip[c] && g.frontier(c) {
+			return c, true
+		}
+		for _, n := range gridNeighbors {
+			next := gridCell{c.x + n.x, c.y + n.y}
+			if !seen[next] && g.contains(next) && g.state(next) == cellFree {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return gridCell{}, false
+}
+
+func (g *occupancyGrid) frontier(c gridCell) bool {
+	if g.state(c) != cellFree {
+		return false
+	}
+	for _, n := range gridNeighbors {
+		next := gridCell{c.x + n.x, c.y + n.y}
+		if g.contains(next) && g.state(next) == cellUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// known returns the fraction of the map which has been seen.
+func (g *occupancyGrid) known() float64 {
+	known, total := 0, 0
+	for y := -g.halfWidth; y <= g.halfWidth; y++ {
+		for x := -g.halfWidth; x <= g.halfWidth; x++ {
+			c := gridCell{x, y}
+			if !g.contains(c) {
+				continue
+			}
+			total++
+			if g.state(c) != cellUnknown {
+				known++
+			}
+		}
+	}
+	if total == 0 {
+		return 1
+	}
+	return float64(known) / float64(total)
+}
@@ -0,0 +1,231 @@
+package builtin
+
+import (
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+// how many passes of improvements to make to the order of a route's stops before settling for it.
+const maxRouteOrderingPasses = 100
+
+// startRouteMode follows the named route in the background until it is done or ctx is cancelled.
+func (svc *builtIn) startRouteMode(ctx context.Context, name string, extra map[string]interface{}) {
+	svc.remainingRoute = nil
+
+	svc.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		err := svc.followRoute(ctx, name, extra)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			svc.logger.CWarnf(ctx, "stopped following route %q: %s", name, err)
+		} else {
+			svc.logger.CInfof(ctx, "finished route %q", name)
+		}
+		svc.setRemainingRoute(nil)
+	}, svc.activeBackgroundWorkers.Done)
+}
+
+func (svc *builtIn) followRoute(ctx context.Context, name string, extra map[string]interface{}) error {
+	store, ok := svc.store.(navigation.RouteStore)
+	if !ok {
+		return errors.New("navigation store does not hold routes")
+	}
+	route, err := store.Route(ctx, name)
+	if err != nil {
+		return err
+	}
+	if svc.movementSensor == nil {
+		return errors.New("following a route requires a movement_sensor to be configured")
+	}
+	current, _, err := svc.movementSensor.Position(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stops := orderStops(route.Stops, current, route.Ordering, route.Loop)
+
+	for {
+		reached := 0
+		for i, stop := range stops {
+			remaining := make([]*geo.Point, 0, len(stops)-i)
+			for _, s := range stops[i:] {
+				remaining = append(remaining, s.ToPoint())
+			}
+			svc.setRemainingRoute(remaining)
+
+			svc.logger.CInfof(ctx, "navigating to waypoint %d of route %q: %+v", i+1, name, stop)
+			heading, legExtra := math.NaN(), extra
+			if stop.HeadingDegs != nil {
+				// the heading is ignored by the default motion profile
+				heading, legExtra = *stop.HeadingDegs, maps.Clone(extra)
+				delete(legExtra, "motion_profile")
+			}
+			if err := svc.moveToPoint(ctx, stop.ToPoint(), heading, legExtra); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				svc.logger.CWarnf(ctx, "skipping waypoint %d of route %q since it could not be reached: %s", i+1, name, err)
+				continue
+			}
+			reached++
+			if err := svc.arriveAtStop(ctx, stop); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				svc.logger.CWarnf(ctx, "action at waypoint %d of route %q failed: %s", i+1, name, err)
+			}
+		}
+		if !route.Loop {
+			return nil
+		}
+		if reached == 0 {
+			return errors.Errorf("no waypoint of looping route %q could be reached", name)
+		}
+	}
+}
+
+// arriveAtStop runs the action of a stop and then waits there for its dwell time.
+func (svc *builtIn) arriveAtStop(ctx context.Context, stop navigation.RouteStop) error {
+	if stop.Action != nil {
+		svc.mu.RLock()
+		res, ok := svc.actionResources[stop.Action.Resource]
+		svc.mu.RUnlock()
+		if !ok {
+			return errors.Errorf("resource %q is not one of the navigation service's action_resources", stop.Action.Resource)
+		}
+		if _, err := res.DoCommand(ctx, stop.Action.Command); err != nil {
+			return err
+		}
+	}
+	if !utils.SelectContextOrWait(ctx, time.Duration(stop.DwellSec*float64(time.Second))) {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// orderStops returns the stops of a route in the order they should be visited from start.
+func orderStops(
+	stops []navigation.RouteStop, start *geo.Point, ordering navigation.RouteOrdering, loop bool,
+) []navigation.RouteStop {
+	if len(stops) < 2 {
+		return stops
+	}
+	switch ordering {
+	case navigation.RouteOrderNearestNeighbor, navigation.RouteOrderShortest:
+	default:
+		return stops
+	}
+
+	// index 0 is the start, stop i is index i+1
+	points := []*geo.Point{start}
+	for _, stop := range stops {
+		points = append(points, stop.ToPoint())
+	}
+	dist := make([][]float64, len(points))
+	for i := range points {
+		dist[i] = make([]float64, len(points))
+		for j := range points {
+			dist[i][j] = points[i].GreatCircleDistance(points[j])
+		}
+	}
+
+	order := make([]int, 0, len(stops))
+	visited := make([]bool, len(points))
+	for last := 0; len(order) < len(stops); {
+		next := -1
+		for i := 1; i < len(points); i++ {
+			if !visited[i] && (next == -1 || dist[last][i] < dist[last][next]) {
+				next = i
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		last = next
+	}
+
+	if ordering == navigation.RouteOrderShortest {
+		if loop {
+			twoOptCycle(order, dist)
+			// start the cycle from the stop closest to the start
+			first := 0
+			for i, stop := range order {
+				if dist[0][stop] < dist[0][order[first]] {
+					first = i
+				}
+			}
+			order = append(order[first:], order[:first]...)
+		} else {
+			twoOptPath(order, dist)
+		}
+	}
+
+	ordered := make([]navigation.RouteStop, 0, len(stops))
+	for _, i := range order {
+		ordered = append(ordered, stops[i-1])
+	}
+	return ordered
+}
+
+// twoOptPath shortens a path from point 0 through the points of order by reversing any stretch of it
+// which would be shorter the other way around.
+func twoOptPath(order []int, dist [][]float64) {
+	at := func(i int) int {
+		if i < 0 {
+			return 0
+		}
+		return order[i]
+	}
+	for pass := 0; pass < maxRouteOrderingPasses; pass++ {
+		improved := false
+		for i := 0; i < len(order)-1; i++ {
+			for j := i + 1; j < len(order); j++ {
+				delta := dist[at(i-1)][order[j]] - dist[at(i-1)][order[i]]
+				if j+1 < len(order) {
+					delta += dist[order[i]][order[j+1]] - dist[order[j]][order[j+1]]
+				}
+				if delta < -1e-12 {
+					slices.Reverse(order[i : j+1])
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+// twoOptCycle shortens a cycle through the points of order by reversing any stretch of it which
+// would be shorter the other way around.
+func twoOptCycle(order []int, dist [][]float64) {
+	n := len(order)
+	for pass := 0; pass < maxRouteOrderingPasses; pass++ {
+		improved := false
+		for i := 0; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				if i == 0 && j == n-1 {
+					continue
+				}
+				prev, next := order[(i+n-1)%n], order[(j+1)%n]
+				delta := dist[prev][order[j]] + dist[order[i]][next] - dist[prev][order[i]] - dist[order[j]][next]
+				if delta < -1e-12 {
+					slices.Reverse(order[i : j+1])
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
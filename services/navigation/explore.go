@@ -0,0 +1,25 @@
+package navigation
+
+// ExploreStrategy describes how the service explores while in ModeExplore.
+type ExploreStrategy string
+
+// The set of known explore strategies.
+const (
+	// ExploreFrontier drives to the nearest edge between seen and unseen space of an occupancy map
+	// built from the service's obstacle detectors, until there are no such edges left.
+	ExploreFrontier = ExploreStrategy("frontier")
+	// ExploreCoverage drives back and forth across a configured region in evenly spaced passes.
+	ExploreCoverage = ExploreStrategy("coverage")
+)
+
+// ExploreProgress describes how far the service has got exploring while in ModeExplore.
+type ExploreProgress struct {
+	Strategy ExploreStrategy
+	// Covered is the fraction, from 0 to 1, of the area being explored which has been seen when
+	// exploring frontiers or driven over when covering a region.
+	Covered float64
+	// Done is true once exploring has stopped, either because there is nothing left to explore or
+	// because of Err.
+	Done bool
+	Err  error
+}
@@ -87,6 +87,9 @@ func StringToMapType(mapTypeName string) (MapType, error) {
 // Properties returns information about the MapType that the configured navigation service is using.
 type Properties struct {
 	MapType MapType
+	// ExploreProgress is set while the service is in ModeExplore. It is not part of the navigation
+	// API's messages, so it is only reported by services called in process.
+	ExploreProgress *ExploreProgress
 }
 
 // A Service controls the navigation for a robot.
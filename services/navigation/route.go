@@ -0,0 +1,97 @@
+package navigation
+
+import (
+	"context"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+)
+
+var errRouteNotFound = errors.New("route not found")
+
+// RouteOrdering describes the order in which the stops of a Route are visited.
+type RouteOrdering string
+
+// The set of known route orderings.
+const (
+	// RouteOrderGiven visits stops in the order they are listed.
+	RouteOrderGiven = RouteOrdering("given")
+	// RouteOrderNearestNeighbor visits whichever unvisited stop is closest next, starting from
+	// wherever the robot is.
+	RouteOrderNearestNeighbor = RouteOrdering("nearest_neighbor")
+	// RouteOrderShortest improves on RouteOrderNearestNeighbor to find a short path through all stops,
+	// or a short cycle through them for routes which loop.
+	RouteOrderShortest = RouteOrdering("shortest")
+)
+
+// A Route is a named list of stops to visit in turn.
+type Route struct {
+	Name     string        `json:"name" bson:"_id"`
+	Stops    []RouteStop   `json:"waypoints" bson:"waypoints"`
+	Ordering RouteOrdering `json:"ordering,omitempty" bson:"ordering,omitempty"`
+	// Loop routes start again from their first stop once the last has been visited, until the
+	// navigation service leaves waypoint mode.
+	Loop bool `json:"loop,omitempty" bson:"loop,omitempty"`
+}
+
+// A RouteStop is a location on a route along with what to do there.
+type RouteStop struct {
+	Lat  float64 `json:"latitude" bson:"latitude"`
+	Long float64 `json:"longitude" bson:"longitude"`
+	// HeadingDegs is the compass heading to arrive at the stop with, if it matters.
+	HeadingDegs *float64 `json:"heading_degs,omitempty" bson:"heading_degs,omitempty"`
+	// DwellSec is how long to wait at the stop, after running its action, before moving on.
+	DwellSec float64         `json:"dwell_sec,omitempty" bson:"dwell_sec,omitempty"`
+	Action   *WaypointAction `json:"action,omitempty" bson:"action,omitempty"`
+}
+
+// A WaypointAction is a DoCommand to send to a resource on arriving at a stop.
+type WaypointAction struct {
+	Resource string                 `json:"resource" bson:"resource"`
+	Command  map[string]interface{} `json:"command" bson:"command"`
+}
+
+// ToPoint converts the stop to a geo.Point.
+func (stop *RouteStop) ToPoint() *geo.Point {
+	return geo.NewPoint(stop.Lat, stop.Long)
+}
+
+// Validate ensures all parts of the route are valid.
+func (route *Route) Validate() error {
+	if route.Name == "" {
+		return errors.New("route must have a name")
+	}
+	if len(route.Stops) == 0 {
+		return errors.Errorf("route %q must have at least one waypoint", route.Name)
+	}
+	switch route.Ordering {
+	case "", RouteOrderGiven, RouteOrderNearestNeighbor, RouteOrderShortest:
+	default:
+		return errors.Errorf("route %q has unknown ordering %q", route.Name, route.Ordering)
+	}
+	for i, stop := range route.Stops {
+		if stop.Lat < -90 || stop.Lat > 90 || stop.Long < -180 || stop.Long > 180 {
+			return errors.Errorf("waypoint %d of route %q is not a valid location", i, route.Name)
+		}
+		if stop.HeadingDegs != nil && (*stop.HeadingDegs < 0 || *stop.HeadingDegs >= 360) {
+			return errors.Errorf("waypoint %d of route %q must have a heading_degs in [0, 360) if set", i, route.Name)
+		}
+		if stop.DwellSec < 0 {
+			return errors.Errorf("waypoint %d of route %q must have a non-negative dwell_sec if set", i, route.Name)
+		}
+		if stop.Action != nil && stop.Action.Resource == "" {
+			return errors.Errorf("the action at waypoint %d of route %q must name a resource", i, route.Name)
+		}
+	}
+	return nil
+}
+
+// RouteStore is a NavStore which also holds named routes.
+type RouteStore interface {
+	NavStore
+	Routes(ctx context.Context) ([]Route, error)
+	Route(ctx context.Context, name string) (Route, error)
+	// AddRoute adds a route, replacing any with the same name.
+	AddRoute(ctx context.Context, route Route) error
+	RemoveRoute(ctx context.Context, name string) error
+}
@@ -2,7 +2,12 @@ package navigation
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
 	"math"
+	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,6 +20,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.uber.org/multierr"
 	mongoutils "go.viam.com/utils/mongo"
+
+	"go.viam.com/rdk/resource"
 )
 
 var errNoMoreWaypoints = errors.New("no more waypoints")
@@ -38,6 +45,8 @@ const (
 	StoreTypeMemory = "memory"
 	// StoreTypeMongoDB is the constant for the mongodb store type.
 	StoreTypeMongoDB = "mongodb"
+	// StoreTypeFile is the constant for the file store type.
+	StoreTypeFile = "file"
 )
 
 // StoreConfig describes how to configure data storage.
@@ -50,6 +59,10 @@ type StoreConfig struct {
 func (config *StoreConfig) Validate(path string) error {
 	switch config.Type {
 	case StoreTypeMemory, StoreTypeMongoDB, StoreTypeUnset:
+	case StoreTypeFile:
+		if filePath, ok := config.Config["path"].(string); !ok || filePath == "" {
+			return resource.NewConfigValidationFieldRequiredError(path, "store.config.path")
+		}
 	default:
 		return errors.Errorf("unknown store type %q", config.Type)
 	}
@@ -63,6 +76,8 @@ func NewStoreFromConfig(ctx context.Context, conf StoreConfig) (NavStore, error)
 		return NewMemoryNavigationStore(), nil
 	case StoreTypeMongoDB:
 		return NewMongoDBNavigationStore(ctx, conf.Config)
+	case StoreTypeFile:
+		return NewFileNavigationStore(conf.Config)
 	default:
 		return nil, errors.Errorf("unknown store type %q", conf.Type)
 	}
@@ -70,11 +85,11 @@ func NewStoreFromConfig(ctx context.Context, conf StoreConfig) (NavStore, error)
 
 // A Waypoint designates a location within a path to navigate to.
 type Waypoint struct {
-	ID      primitive.ObjectID `bson:"_id"`
-	Visited bool               `bson:"visited"`
-	Order   int                `bson:"order"`
-	Lat     float64            `bson:"latitude"`
-	Long    float64            `bson:"longitude"`
+	ID      primitive.ObjectID `bson:"_id" json:"id"`
+	Visited bool               `bson:"visited" json:"visited"`
+	Order   int                `bson:"order" json:"order"`
+	Lat     float64            `bson:"latitude" json:"latitude"`
+	Long    float64            `bson:"longitude" json:"longitude"`
 }
 
 // ToPoint converts the waypoint to a geo.Point.
@@ -93,10 +108,11 @@ func NewMemoryNavigationStore() *MemoryNavigationStore {
 	return &MemoryNavigationStore{}
 }
 
-// MemoryNavigationStore holds the waypoints for the navigation service.
+// MemoryNavigationStore holds the waypoints and routes for the navigation service.
 type MemoryNavigationStore struct {
 	mu        sync.RWMutex
 	waypoints []*Waypoint
+	routes    map[string]Route
 }
 
 // Waypoints returns a copy of all of the waypoints in the MemoryNavigationStore.
@@ -185,16 +201,78 @@ func (store *MemoryNavigationStore) WaypointVisited(ctx context.Context, id prim
 	return nil
 }
 
+// Routes returns a copy of all of the routes in the MemoryNavigationStore, sorted by name.
+func (store *MemoryNavigationStore) Routes(ctx context.Context) ([]Route, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	routes := make([]Route, 0, len(store.routes))
+	for _, name := range slices.Sorted(maps.Keys(store.routes)) {
+		routes = append(routes, copyRoute(store.routes[name]))
+	}
+	return routes, nil
+}
+
+// Route returns a copy of the route with the given name.
+func (store *MemoryNavigationStore) Route(ctx context.Context, name string) (Route, error) {
+	if ctx.Err() != nil {
+		return Route{}, ctx.Err()
+	}
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	route, ok := store.routes[name]
+	if !ok {
+		return Route{}, errors.Wrap(errRouteNotFound, name)
+	}
+	return copyRoute(route), nil
+}
+
+// AddRoute adds a route to the MemoryNavigationStore, replacing any with the same name.
+func (store *MemoryNavigationStore) AddRoute(ctx context.Context, route Route) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := route.Validate(); err != nil {
+		return err
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.routes == nil {
+		store.routes = map[string]Route{}
+	}
+	store.routes[route.Name] = copyRoute(route)
+	return nil
+}
+
+// RemoveRoute removes a route from the MemoryNavigationStore.
+func (store *MemoryNavigationStore) RemoveRoute(ctx context.Context, name string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.routes, name)
+	return nil
+}
+
 // Close does nothing.
 func (store *MemoryNavigationStore) Close(ctx context.Context) error {
 	return nil
 }
 
+func copyRoute(route Route) Route {
+	route.Stops = slices.Clone(route.Stops)
+	return route
+}
+
 // Database and collection names used by the MongoDBNavigationStore.
 var (
 	defaultMongoDBURI                = "mongodb://127.0.0.1:27017"
 	MongoDBNavStoreDBName            = "navigation"
 	MongoDBNavStoreWaypointsCollName = "waypoints"
+	MongoDBNavStoreRoutesCollName    = "routes"
 	mongoDBNavStoreIndexes           = []mongo.IndexModel{
 		{
 			Keys: bson.D{
@@ -231,13 +309,15 @@ func NewMongoDBNavigationStore(ctx context.Context, config map[string]interface{
 	return &MongoDBNavigationStore{
 		mongoClient:   mongoClient,
 		waypointsColl: waypoints,
+		routesColl:    mongoClient.Database(MongoDBNavStoreDBName).Collection(MongoDBNavStoreRoutesCollName),
 	}, nil
 }
 
-// MongoDBNavigationStore holds the mongodb client and waypoints collection.
+// MongoDBNavigationStore holds the mongodb client and the waypoints and routes collections.
 type MongoDBNavigationStore struct {
 	mongoClient   *mongo.Client
 	waypointsColl *mongo.Collection
+	routesColl    *mongo.Collection
 }
 
 // Close closes the connection with the mongodb client.
@@ -307,3 +387,172 @@ func (store *MongoDBNavigationStore) WaypointVisited(ctx context.Context, id pri
 	_, err := store.waypointsColl.UpdateOne(ctx, bson.D{{"_id", id}}, bson.D{{"$set", bson.D{{"visited", true}}}})
 	return err
 }
+
+// Routes returns all the routes in the MongoDBNavigationStore, sorted by name.
+func (store *MongoDBNavigationStore) Routes(ctx context.Context) ([]Route, error) {
+	cursor, err := store.routesColl.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	all := []Route{}
+	if err := cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Route returns the route with the given name.
+func (store *MongoDBNavigationStore) Route(ctx context.Context, name string) (Route, error) {
+	var route Route
+	if err := store.routesColl.FindOne(ctx, bson.D{{"_id", name}}).Decode(&route); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return Route{}, errors.Wrap(errRouteNotFound, name)
+		}
+		return Route{}, err
+	}
+	return route, nil
+}
+
+// AddRoute adds a route to the MongoDBNavigationStore, replacing any with the same name.
+func (store *MongoDBNavigationStore) AddRoute(ctx context.Context, route Route) error {
+	if err := route.Validate(); err != nil {
+		return err
+	}
+	_, err := store.routesColl.ReplaceOne(ctx, bson.D{{"_id", route.Name}}, route, options.Replace().SetUpsert(true))
+	return err
+}
+
+// RemoveRoute removes a route from the MongoDBNavigationStore.
+func (store *MongoDBNavigationStore) RemoveRoute(ctx context.Context, name string) error {
+	_, err := store.routesColl.DeleteOne(ctx, bson.D{{"_id", name}})
+	return err
+}
+
+// fileNavStoreContents is the format of the file backing a FileNavigationStore.
+type fileNavStoreContents struct {
+	Waypoints []*Waypoint `json:"waypoints"`
+	Routes    []Route     `json:"routes"`
+}
+
+// NewFileNavigationStore creates a new navigation store from the JSON file at the "path" of the
+// config, which need not exist yet. Changes to the store are written back to the file unless
+// "read_only" is set, in which case they only last until the store is closed.
+func NewFileNavigationStore(config map[string]interface{}) (*FileNavigationStore, error) {
+	path, ok := config["path"].(string)
+	if !ok || path == "" {
+		return nil, errors.New("file navigation store requires a path")
+	}
+	readOnly, _ := config["read_only"].(bool)
+
+	store := &FileNavigationStore{
+		MemoryNavigationStore: NewMemoryNavigationStore(),
+		path:                  path,
+		readOnly:              readOnly,
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return store, nil
+	case err != nil:
+		return nil, err
+	}
+	var contents fileNavStoreContents
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return nil, errors.Wrapf(err, "reading navigation store file %s", path)
+	}
+	for _, wp := range contents.Waypoints {
+		if wp.ID.IsZero() {
+			wp.ID = primitive.NewObjectID()
+		}
+	}
+	store.waypoints = contents.Waypoints
+	for _, route := range contents.Routes {
+		if err := route.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "reading navigation store file %s", path)
+		}
+		if store.routes == nil {
+			store.routes = map[string]Route{}
+		}
+		store.routes[route.Name] = route
+	}
+	return store, nil
+}
+
+// FileNavigationStore holds the waypoints and routes for the navigation service in memory, backed by
+// a JSON file.
+type FileNavigationStore struct {
+	*MemoryNavigationStore
+	path     string
+	readOnly bool
+}
+
+// AddWaypoint adds a waypoint to the FileNavigationStore.
+func (store *FileNavigationStore) AddWaypoint(ctx context.Context, point *geo.Point) (Waypoint, error) {
+	wp, err := store.MemoryNavigationStore.AddWaypoint(ctx, point)
+	if err != nil {
+		return Waypoint{}, err
+	}
+	return wp, store.save()
+}
+
+// RemoveWaypoint removes a waypoint from the FileNavigationStore.
+func (store *FileNavigationStore) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error {
+	if err := store.MemoryNavigationStore.RemoveWaypoint(ctx, id); err != nil {
+		return err
+	}
+	return store.save()
+}
+
+// WaypointVisited sets that a waypoint has been visited.
+func (store *FileNavigationStore) WaypointVisited(ctx context.Context, id primitive.ObjectID) error {
+	if err := store.MemoryNavigationStore.WaypointVisited(ctx, id); err != nil {
+		return err
+	}
+	return store.save()
+}
+
+// AddRoute adds a route to the FileNavigationStore, replacing any with the same name.
+func (store *FileNavigationStore) AddRoute(ctx context.Context, route Route) error {
+	if err := store.MemoryNavigationStore.AddRoute(ctx, route); err != nil {
+		return err
+	}
+	return store.save()
+}
+
+// RemoveRoute removes a route from the FileNavigationStore.
+func (store *FileNavigationStore) RemoveRoute(ctx context.Context, name string) error {
+	if err := store.MemoryNavigationStore.RemoveRoute(ctx, name); err != nil {
+		return err
+	}
+	return store.save()
+}
+
+// save writes the store to its file, replacing the file whole so that it is never left half written.
+func (store *FileNavigationStore) save() error {
+	if store.readOnly {
+		return nil
+	}
+	store.mu.RLock()
+	contents := fileNavStoreContents{Waypoints: store.waypoints, Routes: []Route{}}
+	for _, name := range slices.Sorted(maps.Keys(store.routes)) {
+		contents.Routes = append(contents.Routes, store.routes[name])
+	}
+	data, err := json.MarshalIndent(contents, "", "  ")
+	store.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return multierr.Combine(err, tmp.Close(), os.Remove(tmp.Name()))
+	}
+	if err := tmp.Close(); err != nil {
+		return multierr.Combine(err, os.Remove(tmp.Name()))
+	}
+	return os.Rename(tmp.Name(), store.path)
+}
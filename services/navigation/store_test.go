@@ -0,0 +1,84 @@
+package navigation_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+func TestFileNavigationStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires a path", func(t *testing.T) {
+		cfg := navigation.StoreConfig{Type: navigation.StoreTypeFile}
+		err := cfg.Validate("")
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "store.config.path")
+	})
+
+	t.Run("persists waypoints and routes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nav.json")
+		cfg := navigation.StoreConfig{Type: navigation.StoreTypeFile, Config: map[string]interface{}{"path": path}}
+
+		store, err := navigation.NewStoreFromConfig(ctx, cfg)
+		test.That(t, err, test.ShouldBeNil)
+		wp, err := store.AddWaypoint(ctx, geo.NewPoint(1, 2))
+		test.That(t, err, test.ShouldBeNil)
+		routeStore, ok := store.(navigation.RouteStore)
+		test.That(t, ok, test.ShouldBeTrue)
+		route := navigation.Route{
+			Name:     "patrol",
+			Stops:    []navigation.RouteStop{{Lat: 1, Long: 2, DwellSec: 5}, {Lat: 3, Long: 4}},
+			Ordering: navigation.RouteOrderShortest,
+			Loop:     true,
+		}
+		test.That(t, routeStore.AddRoute(ctx, route), test.ShouldBeNil)
+		test.That(t, routeStore.AddRoute(ctx, navigation.Route{Name: "bad"}), test.ShouldNotBeNil)
+
+		reopened, err := navigation.NewStoreFromConfig(ctx, cfg)
+		test.That(t, err, test.ShouldBeNil)
+		wps, err := reopened.Waypoints(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, wps, test.ShouldResemble, []navigation.Waypoint{wp})
+		routes, err := reopened.(navigation.RouteStore).Routes(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, routes, test.ShouldResemble, []navigation.Route{route})
+
+		test.That(t, routeStore.RemoveRoute(ctx, "patrol"), test.ShouldBeNil)
+		_, err = routeStore.Route(ctx, "patrol")
+		test.That(t, err, test.ShouldNotBeNil)
+		reopened, err = navigation.NewStoreFromConfig(ctx, cfg)
+		test.That(t, err, test.ShouldBeNil)
+		routes, err = reopened.(navigation.RouteStore).Routes(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, routes, test.ShouldBeEmpty)
+	})
+
+	t.Run("read only stores leave the file alone", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nav.json")
+		contents := `{"waypoints": [{"latitude": 1, "longitude": 2}], "routes": []}`
+		test.That(t, os.WriteFile(path, []byte(contents), 0o600), test.ShouldBeNil)
+
+		store, err := navigation.NewStoreFromConfig(ctx, navigation.StoreConfig{
+			Type:   navigation.StoreTypeFile,
+			Config: map[string]interface{}{"path": path, "read_only": true},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		wp, err := store.NextWaypoint(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, wp.ID.IsZero(), test.ShouldBeFalse)
+		test.That(t, wp.ToPoint(), test.ShouldResemble, geo.NewPoint(1, 2))
+
+		_, err = store.AddWaypoint(ctx, geo.NewPoint(3, 4))
+		test.That(t, err, test.ShouldBeNil)
+		data, err := os.ReadFile(path)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, string(data), test.ShouldEqual, contents)
+	})
+}
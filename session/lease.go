@@ -0,0 +1,37 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.viam.com/rdk/resource"
+)
+
+const (
+	// LeaseAcquireMetadataKey is the gRPC metadata key to use when requesting exclusive
+	// control of resources for the session of a request. Each value is a resource name.
+	LeaseAcquireMetadataKey = "viam-lease-acquire"
+
+	// LeaseReleaseMetadataKey is the gRPC metadata key to use when giving up exclusive
+	// control of resources held by the session of a request. Each value is a resource name.
+	LeaseReleaseMetadataKey = "viam-lease-release"
+)
+
+// LeaseHeldError is returned when a resource is leased for exclusive control by a
+// session other than the one making a request.
+type LeaseHeldError struct {
+	Name      resource.Name
+	SessionID uuid.UUID
+}
+
+func (e *LeaseHeldError) Error() string {
+	return fmt.Sprintf("resource %q is leased for exclusive control by session %s", e.Name, e.SessionID)
+}
+
+// GRPCStatus allows this error to be converted to a [status.Status].
+func (e *LeaseHeldError) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
@@ -23,6 +23,15 @@ const (
 	// that resources are allowed to (re)configure.
 	ResourceConfigurationTimeoutEnvVar = "VIAM_RESOURCE_CONFIGURATION_TIMEOUT"
 
+	// DefaultResourceConfigurationConcurrency is the default number of resources
+	// that may be (re)configured at the same time.
+	DefaultResourceConfigurationConcurrency = 10
+
+	// ResourceConfigurationConcurrencyEnvVar is the environment variable that can
+	// be set to override DefaultResourceConfigurationConcurrency as the number of
+	// resources that may be (re)configured at the same time.
+	ResourceConfigurationConcurrencyEnvVar = "VIAM_RESOURCE_CONFIGURATION_CONCURRENCY"
+
 	// DefaultModuleStartupTimeout is the default module startup timeout.
 	DefaultModuleStartupTimeout = 5 * time.Minute
 
@@ -119,6 +128,23 @@ func GetResourceConfigurationTimeout(logger logging.Logger) time.Duration {
 	return timeout
 }
 
+// GetResourceConfigurationConcurrency returns the number of resources that may be
+// (re)configured at the same time (env variable value if set,
+// DefaultResourceConfigurationConcurrency otherwise).
+func GetResourceConfigurationConcurrency(logger logging.Logger) int {
+	val := os.Getenv(ResourceConfigurationConcurrencyEnvVar)
+	if val == "" {
+		return DefaultResourceConfigurationConcurrency
+	}
+	concurrency, err := strconv.Atoi(val)
+	if err != nil || concurrency < 1 {
+		logger.Warnf("Failed to parse %s env var, falling back to default concurrency of %d",
+			ResourceConfigurationConcurrencyEnvVar, DefaultResourceConfigurationConcurrency)
+		return DefaultResourceConfigurationConcurrency
+	}
+	return concurrency
+}
+
 // GetModuleStartupTimeout calculates the module startup timeout
 // (env variable value if set, DefaultModuleStartupTimeout otherwise).
 func GetModuleStartupTimeout(logger logging.Logger) time.Duration {
@@ -44,6 +44,14 @@ func NewBuildTimeoutError(name string, logger logging.Logger) error {
 	return timeoutErrorHelper(id, timeout, timeoutMsg)
 }
 
+// NewBuildTimeoutErrorAfter is used when a resource times out during construction or
+// reconfiguration after a timeout configured for that resource.
+func NewBuildTimeoutErrorAfter(name string, timeout time.Duration) error {
+	id := fmt.Sprintf("resource %s", name)
+	timeoutMsg := "reconfigure"
+	return timeoutErrorHelper(id, timeout, timeoutMsg)
+}
+
 // NewModuleStartUpTimeoutError is used when a module times out during startup.
 func NewModuleStartUpTimeoutError(name string, logger logging.Logger) error {
 	timeout := GetModuleStartupTimeout(logger)